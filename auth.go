@@ -0,0 +1,330 @@
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// identity is the authenticated caller of an API request.
+type identity struct {
+	user  string
+	teams []string
+}
+
+// key is the same for all identities of the same teams, which may see the same objectives.
+func (id *identity) key() string {
+	if id == nil {
+		return ""
+	}
+	teams := slices.Clone(id.teams)
+	slices.Sort(teams)
+	return strings.Join(teams, ",")
+}
+
+type identityKeyType string
+
+const identityKey identityKeyType = "identity"
+
+func contextSetIdentity(ctx context.Context, id *identity) context.Context {
+	return context.WithValue(ctx, identityKey, id)
+}
+
+// contextGetIdentity returns the caller of the request or nil if the API doesn't authenticate requests.
+func contextGetIdentity(ctx context.Context) *identity {
+	id, _ := ctx.Value(identityKey).(*identity)
+	return id
+}
+
+// errNoCredentials is returned by authenticators if the request doesn't carry their kind of credentials.
+var errNoCredentials = errors.New("no credentials")
+
+type authenticator interface {
+	authenticate(r *http.Request) (*identity, error)
+}
+
+// authenticate passes on the identity of the first authenticator with credentials in the request's context.
+// Requests without credentials or with invalid ones are rejected.
+func authenticate(logger log.Logger, authenticators []authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, a := range authenticators {
+				id, err := a.authenticate(r)
+				if errors.Is(err, errNoCredentials) {
+					continue
+				}
+				if err != nil {
+					level.Debug(logger).Log("msg", "failed to authenticate request", "path", r.URL.Path, "err", err)
+					http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+					return
+				}
+				next.ServeHTTP(w, r.WithContext(contextSetIdentity(r.Context(), id)))
+				return
+			}
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		})
+	}
+}
+
+// proxyAuthenticator trusts the user and teams a proxy in front of the API sets as headers, like oauth2-proxy does.
+type proxyAuthenticator struct {
+	userHeader  string
+	teamsHeader string
+}
+
+func (a *proxyAuthenticator) authenticate(r *http.Request) (*identity, error) {
+	user := r.Header.Get(a.userHeader)
+	if user == "" {
+		return nil, errNoCredentials
+	}
+
+	id := &identity{user: user}
+	if a.teamsHeader != "" {
+		for _, value := range r.Header.Values(a.teamsHeader) {
+			for team := range strings.SplitSeq(value, ",") {
+				if team = strings.TrimSpace(team); team != "" {
+					id.teams = append(id.teams, team)
+				}
+			}
+		}
+	}
+	return id, nil
+}
+
+// jwksReloadInterval limits how often tokens signed by unknown keys cause the JWKS file to be read again.
+const jwksReloadInterval = time.Minute
+
+// oidcAuthenticator validates OIDC bearer tokens against the keys of a local JWKS file.
+// The file is read again when tokens are signed by unknown keys, so keys can be rotated by updating it.
+type oidcAuthenticator struct {
+	jwksFile   string
+	teamsClaim string
+	parser     *jwt.Parser
+
+	mu     sync.Mutex
+	keys   map[string]crypto.PublicKey
+	loaded time.Time
+}
+
+func newOIDCAuthenticator(jwksFile, issuer, audience, teamsClaim string) (*oidcAuthenticator, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}),
+		jwt.WithExpirationRequired(),
+	}
+	if issuer != "" {
+		opts = append(opts, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		opts = append(opts, jwt.WithAudience(audience))
+	}
+
+	a := &oidcAuthenticator{
+		jwksFile:   jwksFile,
+		teamsClaim: teamsClaim,
+		parser:     jwt.NewParser(opts...),
+	}
+	if err := a.load(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *oidcAuthenticator) load() error {
+	data, err := os.ReadFile(a.jwksFile)
+	if err != nil {
+		return err
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", a.jwksFile, err)
+	}
+	a.keys = keys
+	a.loaded = time.Now()
+	return nil
+}
+
+func (a *oidcAuthenticator) key(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	lookup := func() (crypto.PublicKey, bool) {
+		if kid == "" && len(a.keys) == 1 {
+			for _, key := range a.keys {
+				return key, true
+			}
+		}
+		key, ok := a.keys[kid]
+		return key, ok
+	}
+
+	key, ok := lookup()
+	if !ok && time.Since(a.loaded) > jwksReloadInterval {
+		if err := a.load(); err != nil {
+			return nil, err
+		}
+		key, ok = lookup()
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	return key, nil
+}
+
+func (a *oidcAuthenticator) authenticate(r *http.Request) (*identity, error) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return nil, errNoCredentials
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := a.parser.ParseWithClaims(token, claims, a.key); err != nil {
+		return nil, err
+	}
+
+	user, _ := claims.GetSubject()
+	id := &identity{user: user}
+	switch teams := claims[a.teamsClaim].(type) {
+	case string:
+		id.teams = []string{teams}
+	case []any:
+		for _, team := range teams {
+			if team, ok := team.(string); ok {
+				id.teams = append(id.teams, team)
+			}
+		}
+	}
+	return id, nil
+}
+
+// jwk is a JSON Web Key as defined by RFC 7517.
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS returns the public signing keys of a JSON Web Key Set by their key ID.
+// Symmetric keys are skipped, as tokens signed with them could be forged by anyone reading the file.
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		var (
+			key crypto.PublicKey
+			err error
+		)
+		switch k.Kty {
+		case "RSA":
+			key, err = k.rsa()
+		case "EC":
+			key, err = k.ecdsa()
+		case "OKP":
+			key, err = k.ed25519()
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no signing keys")
+	}
+	return keys, nil
+}
+
+func (k jwk) rsa() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %w", err)
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 2 || exponent.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("invalid RSA key")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
+
+func (k jwk) ecdsa() (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch k.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+	}
+
+	x, err := base64.RawURLEncoding.DecodeString(k.X)
+	if err != nil {
+		return nil, fmt.Errorf("invalid x coordinate: %w", err)
+	}
+	y, err := base64.RawURLEncoding.DecodeString(k.Y)
+	if err != nil {
+		return nil, fmt.Errorf("invalid y coordinate: %w", err)
+	}
+
+	// Coordinates are encoded with the curve's full size, like in the uncompressed point format.
+	size := (curve.Params().BitSize + 7) / 8
+	if len(x) != size || len(y) != size {
+		return nil, fmt.Errorf("invalid %s key", k.Crv)
+	}
+	return ecdsa.ParseUncompressedPublicKey(curve, slices.Concat([]byte{4}, x, y))
+}
+
+func (k jwk) ed25519() (ed25519.PublicKey, error) {
+	if k.Crv != "Ed25519" {
+		return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+	}
+	x, err := base64.RawURLEncoding.DecodeString(k.X)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	if len(x) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid Ed25519 key")
+	}
+	return ed25519.PublicKey(x), nil
+}
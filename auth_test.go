@@ -0,0 +1,229 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func ecdsaJWK(t *testing.T, kid string, key *ecdsa.PrivateKey) map[string]string {
+	t.Helper()
+	point, err := key.PublicKey.Bytes()
+	require.NoError(t, err)
+	size := (len(point) - 1) / 2
+	return map[string]string{
+		"kid": kid,
+		"kty": "EC",
+		"crv": key.Curve.Params().Name,
+		"x":   b64(point[1 : 1+size]),
+		"y":   b64(point[1+size:]),
+	}
+}
+
+func writeJWKS(t *testing.T, path string, keys ...map[string]string) {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{"keys": keys})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+func TestParseJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(map[string]any{"keys": []map[string]string{
+		{"kid": "rsa", "kty": "RSA", "use": "sig", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+		ecdsaJWK(t, "ec", ecKey),
+		{"kid": "ed", "kty": "OKP", "crv": "Ed25519", "x": b64(edKey)},
+		{"kid": "enc", "kty": "RSA", "use": "enc", "n": b64(rsaKey.N.Bytes()), "e": "AQAB"},
+		{"kid": "hmac", "kty": "oct", "k": b64([]byte("secret"))},
+	}})
+	require.NoError(t, err)
+
+	keys, err := parseJWKS(data)
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+	require.True(t, rsaKey.PublicKey.Equal(keys["rsa"]))
+	require.True(t, ecKey.PublicKey.Equal(keys["ec"]))
+	require.True(t, edKey.Equal(keys["ed"]))
+
+	for name, data := range map[string]string{
+		"empty":        `{"keys":[]}`,
+		"onlyHMAC":     `{"keys":[{"kty":"oct","k":"c2VjcmV0"}]}`,
+		"invalidCurve": `{"keys":[{"kty":"EC","crv":"P-224","x":"AA","y":"AA"}]}`,
+		"offCurve":     `{"keys":[{"kty":"EC","crv":"P-256","x":"` + b64(make([]byte, 32)) + `","y":"` + b64(make([]byte, 32)) + `"}]}`,
+		"shortEd25519": `{"keys":[{"kty":"OKP","crv":"Ed25519","x":"AAAA"}]}`,
+		"invalidJSON":  `{"keys":`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseJWKS([]byte(data))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestOIDCAuthenticator(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, ecdsaJWK(t, "first", key))
+
+	a, err := newOIDCAuthenticator(path, "https://issuer.example.com", "pyrra", "groups")
+	require.NoError(t, err)
+
+	sign := func(t *testing.T, kid string, key *ecdsa.PrivateKey, claims jwt.MapClaims) *http.Request {
+		t.Helper()
+		token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Authorization", "Bearer "+signed)
+		return r
+	}
+	claims := func(overrides jwt.MapClaims) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"sub":    "jane",
+			"iss":    "https://issuer.example.com",
+			"aud":    "pyrra",
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"groups": []string{"payments", "checkout"},
+		}
+		for k, v := range overrides {
+			c[k] = v
+		}
+		return c
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		id, err := a.authenticate(sign(t, "first", key, claims(nil)))
+		require.NoError(t, err)
+		require.Equal(t, &identity{user: "jane", teams: []string{"payments", "checkout"}}, id)
+	})
+	t.Run("singleTeam", func(t *testing.T) {
+		id, err := a.authenticate(sign(t, "first", key, claims(jwt.MapClaims{"groups": "payments"})))
+		require.NoError(t, err)
+		require.Equal(t, []string{"payments"}, id.teams)
+	})
+	t.Run("withoutKeyID", func(t *testing.T) {
+		id, err := a.authenticate(sign(t, "", key, claims(nil)))
+		require.NoError(t, err)
+		require.Equal(t, "jane", id.user)
+	})
+	t.Run("noCredentials", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		_, err := a.authenticate(r)
+		require.ErrorIs(t, err, errNoCredentials)
+
+		r.Header.Set("Authorization", "Basic amFuZTpzZWNyZXQ=")
+		_, err = a.authenticate(r)
+		require.ErrorIs(t, err, errNoCredentials)
+	})
+	for name, overrides := range map[string]jwt.MapClaims{
+		"expired":       {"exp": time.Now().Add(-time.Minute).Unix()},
+		"noExpiration":  {"exp": nil},
+		"otherIssuer":   {"iss": "https://other.example.com"},
+		"otherAudience": {"aud": "grafana"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := claims(overrides)
+			for k, v := range c {
+				if v == nil {
+					delete(c, k)
+				}
+			}
+			_, err := a.authenticate(sign(t, "first", key, c))
+			require.Error(t, err)
+			require.NotErrorIs(t, err, errNoCredentials)
+		})
+	}
+	t.Run("otherKey", func(t *testing.T) {
+		other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		_, err = a.authenticate(sign(t, "first", other, claims(nil)))
+		require.Error(t, err)
+	})
+	t.Run("unsigned", func(t *testing.T) {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims(nil)).SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Authorization", "Bearer "+token)
+		_, err = a.authenticate(r)
+		require.Error(t, err)
+	})
+	t.Run("rotated", func(t *testing.T) {
+		second, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		writeJWKS(t, path, ecdsaJWK(t, "first", key), ecdsaJWK(t, "second", second))
+
+		// The file isn't read again right after it was loaded.
+		_, err = a.authenticate(sign(t, "second", second, claims(nil)))
+		require.ErrorContains(t, err, `unknown key "second"`)
+
+		a.loaded = a.loaded.Add(-jwksReloadInterval)
+		id, err := a.authenticate(sign(t, "second", second, claims(nil)))
+		require.NoError(t, err)
+		require.Equal(t, "jane", id.user)
+	})
+}
+
+func TestProxyAuthenticator(t *testing.T) {
+	a := &proxyAuthenticator{userHeader: "X-Forwarded-User", teamsHeader: "X-Forwarded-Groups"}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	_, err := a.authenticate(r)
+	require.ErrorIs(t, err, errNoCredentials)
+
+	r.Header.Set("X-Forwarded-User", "jane")
+	r.Header.Add("X-Forwarded-Groups", "payments, checkout")
+	r.Header.Add("X-Forwarded-Groups", "search,")
+	id, err := a.authenticate(r)
+	require.NoError(t, err)
+	require.Equal(t, &identity{user: "jane", teams: []string{"payments", "checkout", "search"}}, id)
+}
+
+func TestAuthenticate(t *testing.T) {
+	handler := authenticate(log.NewNopLogger(), []authenticator{
+		&proxyAuthenticator{userHeader: "X-Forwarded-User", teamsHeader: "X-Forwarded-Groups"},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := contextGetIdentity(r.Context())
+		_, _ = w.Write([]byte(id.user + ":" + id.key()))
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/objectives.v1alpha1.ObjectiveService/List", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	r.Header.Set("X-Forwarded-User", "jane")
+	r.Header.Set("X-Forwarded-Groups", "search,payments")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "jane:payments,search", w.Body.String())
+}
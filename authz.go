@@ -0,0 +1,295 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	connect "connectrpc.com/connect"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	objectivesv1alpha1 "github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1"
+	"github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1/objectivesv1alpha1connect"
+	"github.com/pyrra-dev/pyrra/slo"
+)
+
+// teamAuthorizer lets callers only see the objectives whose team label is one of their teams.
+// Callers of the admin teams see all objectives.
+type teamAuthorizer struct {
+	label      string
+	adminTeams map[string]struct{}
+}
+
+func newTeamAuthorizer(label string, adminTeams []string) *teamAuthorizer {
+	a := &teamAuthorizer{label: label, adminTeams: make(map[string]struct{}, len(adminTeams))}
+	for _, team := range adminTeams {
+		a.adminTeams[team] = struct{}{}
+	}
+	return a
+}
+
+// allowed returns whether the caller of the request may see an objective with the given labels,
+// or nil if the caller may see all objectives. Requests without a caller may see none.
+func (a *teamAuthorizer) allowed(ctx context.Context) func(map[string]string) bool {
+	teams := map[string]struct{}{}
+	if id := contextGetIdentity(ctx); id != nil {
+		for _, team := range id.teams {
+			if _, ok := a.adminTeams[team]; ok {
+				return nil
+			}
+			teams[team] = struct{}{}
+		}
+	}
+
+	return func(labels map[string]string) bool {
+		team, ok := labels[a.label]
+		if !ok {
+			return false
+		}
+		_, ok = teams[team]
+		return ok
+	}
+}
+
+// authorizedBackendClient only lists the objectives the caller of the request may see.
+type authorizedBackendClient struct {
+	client     objectivesv1alpha1connect.ObjectiveBackendServiceClient
+	authorizer *teamAuthorizer
+}
+
+// List lists all matching objectives and paginates the ones the caller may see,
+// as pages of the backend would be missing the objectives filtered out of them.
+func (c *authorizedBackendClient) List(ctx context.Context, req *connect.Request[objectivesv1alpha1.ListRequest]) (*connect.Response[objectivesv1alpha1.ListResponse], error) {
+	allowed := c.authorizer.allowed(ctx)
+	if allowed == nil {
+		return c.client.List(ctx, req)
+	}
+
+	resp, err := c.client.List(ctx, connect.NewRequest(&objectivesv1alpha1.ListRequest{
+		Expr:     req.Msg.Expr,
+		Grouping: req.Msg.Grouping,
+		Search:   req.Msg.Search,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	objectives := make([]*objectivesv1alpha1.Objective, 0, len(resp.Msg.Objectives))
+	for _, o := range resp.Msg.Objectives {
+		if allowed(o.Labels) {
+			objectives = append(objectives, o)
+		}
+	}
+
+	page, err := objectivesv1alpha1.ListPage(req.Msg, objectives)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	page.Warnings = resp.Msg.Warnings
+
+	return connect.NewResponse(page), nil
+}
+
+// queryAuthorizer restricts callers to querying the series of the objectives they may see.
+// Queries aren't restricted if the authorizer is nil.
+type queryAuthorizer struct {
+	// client lists all objectives, including the ones the caller may not see.
+	client     objectivesv1alpha1connect.ObjectiveBackendServiceClient
+	authorizer *teamAuthorizer
+}
+
+// objectives returns the objectives of the datasource the caller may see and the ones they may not see.
+// It returns false if the caller may query all series.
+func (a *queryAuthorizer) objectives(ctx context.Context, datasource string) (visible, hidden []slo.Objective, restricted bool, err error) {
+	if a == nil || a.authorizer == nil {
+		return nil, nil, false, nil
+	}
+	allowed := a.authorizer.allowed(ctx)
+	if allowed == nil {
+		return nil, nil, false, nil
+	}
+
+	resp, err := a.client.List(ctx, connect.NewRequest(&objectivesv1alpha1.ListRequest{}))
+	if err != nil && connect.CodeOf(err) != connect.CodeNotFound {
+		return nil, nil, false, err
+	}
+	if resp == nil {
+		return nil, nil, true, nil
+	}
+
+	for _, o := range resp.Msg.Objectives {
+		// Series of other datasources aren't the objective's, even if they are selected alike.
+		if o.GetDatasource() != datasource {
+			continue
+		}
+		objective, err := objectivesv1alpha1.ToInternal(o)
+		if err != nil {
+			return nil, nil, false, connect.NewError(connect.CodeInternal, err)
+		}
+		if allowed(o.Labels) {
+			visible = append(visible, objective)
+		} else {
+			hidden = append(hidden, objective)
+		}
+	}
+	return visible, hidden, true, nil
+}
+
+// authorizeQuery returns an error if the query selects series other than the ones of objectives the caller may see in the datasource.
+func (a *queryAuthorizer) authorizeQuery(ctx context.Context, query, datasource string) error {
+	visible, hidden, restricted, err := a.objectives(ctx, datasource)
+	if err != nil || !restricted {
+		return err
+	}
+	return authorizeQuery(query, visible, hidden)
+}
+
+// authorizeObjective returns an error if the objective's indicator selects series other than the ones of objectives the caller may see,
+// like an objective that is passed along with a request instead of being looked up.
+func (a *queryAuthorizer) authorizeObjective(ctx context.Context, objective slo.Objective) error {
+	visible, hidden, restricted, err := a.objectives(ctx, objective.Datasource)
+	if err != nil || !restricted {
+		return err
+	}
+	for _, selector := range indicatorSelectors(objective) {
+		if !selectorAllowed(selector, visible, hidden) {
+			vs := &parser.VectorSelector{LabelMatchers: selector}
+			return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("%s doesn't select series of objectives you may see", vs.String()))
+		}
+	}
+	return nil
+}
+
+// authorizeQuery returns an error unless all series the query selects belong to one of the visible objectives.
+// Series belong to an objective if they carry its slo label, like its recording rules and alerts,
+// or if they are selected at least as narrowly as by one of the objective's indicator selectors.
+// Names are only unique per namespace and backend, so the slo label of a visible objective
+// needs more matchers if a hidden objective has the same name, like the hidden one's propagated labels.
+func authorizeQuery(query string, visible, hidden []slo.Objective) error {
+	expr, err := parser.ParseExpr(query)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	var denied error
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		vs, ok := node.(*parser.VectorSelector)
+		if !ok || selectorAllowed(vs.LabelMatchers, visible, hidden) {
+			return nil
+		}
+		denied = connect.NewError(connect.CodePermissionDenied, fmt.Errorf("%s doesn't select series of objectives you may see", vs.String()))
+		return denied
+	})
+	return denied
+}
+
+func selectorAllowed(matchers []*labels.Matcher, visible, hidden []slo.Objective) bool {
+	for _, m := range matchers {
+		if m.Name == "slo" && m.Type == labels.MatchEqual && sloAllowed(matchers, m.Value, visible, hidden) {
+			return true
+		}
+	}
+	for _, o := range visible {
+		for _, selector := range indicatorSelectors(o) {
+			if len(selector) > 0 && narrows(matchers, selector) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sloAllowed returns whether an objective with the name is visible and the matchers can't select
+// the recording rules and alerts of any hidden objective with the same name.
+func sloAllowed(matchers []*labels.Matcher, name string, visible, hidden []slo.Objective) bool {
+	if !slices.ContainsFunc(visible, func(o slo.Objective) bool { return o.Name() == name }) {
+		return false
+	}
+	for _, o := range hidden {
+		if o.Name() == name && selectsRules(matchers, ruleLabels(o)) {
+			return false
+		}
+	}
+	return true
+}
+
+// selectsRules returns whether the matchers may select series with the rule labels.
+// Labels other than the rule labels are unknown and may match.
+func selectsRules(matchers []*labels.Matcher, ruleLabels map[string]string) bool {
+	for _, m := range matchers {
+		if value, ok := ruleLabels[m.Name]; ok && !m.Matches(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// ruleLabels returns the labels all recording rules and alerts of the objective have.
+func ruleLabels(o slo.Objective) map[string]string {
+	lset := map[string]string{"slo": o.Name()}
+	o.Labels.Range(func(l labels.Label) {
+		if strings.HasPrefix(l.Name, slo.PropagationLabelsPrefix) {
+			lset[strings.TrimPrefix(l.Name, slo.PropagationLabelsPrefix)] = l.Value
+		}
+	})
+	return lset
+}
+
+// narrows returns whether the matchers only select series the selector selects too.
+// Every matcher of the selector has to be part of them or be narrowed to a single value,
+// like the matchers of an objective's grouping are.
+func narrows(matchers, selector []*labels.Matcher) bool {
+Selector:
+	for _, sm := range selector {
+		for _, m := range matchers {
+			if m.Name != sm.Name {
+				continue
+			}
+			if m.Type == sm.Type && m.Value == sm.Value {
+				continue Selector
+			}
+			if m.Type == labels.MatchEqual && sm.Matches(m.Value) {
+				continue Selector
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// indicatorSelectors returns the matchers of every selector of the objective's indicator.
+func indicatorSelectors(o slo.Objective) [][]*labels.Matcher {
+	var selectors [][]*labels.Matcher
+	switch o.IndicatorType() {
+	case slo.Ratio:
+		selectors = append(selectors, o.Indicator.Ratio.Errors.LabelMatchers, o.Indicator.Ratio.Total.LabelMatchers)
+	case slo.Latency:
+		selectors = append(selectors, o.Indicator.Latency.Success.LabelMatchers, o.Indicator.Latency.Total.LabelMatchers)
+	case slo.LatencyNative:
+		selectors = append(selectors, o.Indicator.LatencyNative.Total.LabelMatchers)
+	case slo.BoolGauge:
+		selectors = append(selectors, o.Indicator.BoolGauge.LabelMatchers)
+	case slo.Composite:
+		for _, component := range o.Indicator.Composite.Components {
+			for _, objective := range component.Objectives {
+				selectors = append(selectors, indicatorSelectors(objective)...)
+			}
+		}
+	case slo.Raw:
+		for _, query := range []string{o.Indicator.Raw.Good, o.Indicator.Raw.Total} {
+			expr, err := parser.ParseExpr(query)
+			if err != nil {
+				continue
+			}
+			parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+				if vs, ok := node.(*parser.VectorSelector); ok {
+					selectors = append(selectors, vs.LabelMatchers)
+				}
+				return nil
+			})
+		}
+	}
+	return selectors
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"github.com/go-kit/log"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+
+	objectivesv1alpha1 "github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1"
+	prometheusv1 "github.com/pyrra-dev/pyrra/proto/prometheus/v1"
+	"github.com/pyrra-dev/pyrra/slo"
+)
+
+func teamObjective(name, team, job string) slo.Objective {
+	o := batchObjective(name, "default", job, 28*24*time.Hour)
+	if team != "" {
+		o.Labels = labels.NewBuilder(o.Labels).Set("team", team).Labels()
+	}
+	return o
+}
+
+func withTeams(teams ...string) context.Context {
+	return contextSetIdentity(context.Background(), &identity{user: "jane", teams: teams})
+}
+
+func TestAuthorizedBackendClient(t *testing.T) {
+	client := &authorizedBackendClient{
+		client: staticObjectives{
+			teamObjective("a", "payments", "checkout"),
+			teamObjective("b", "search", "search"),
+			teamObjective("c", "payments", "payments"),
+			teamObjective("d", "", "legacy"),
+		},
+		authorizer: newTeamAuthorizer("team", []string{"sre"}),
+	}
+
+	list := func(t *testing.T, ctx context.Context, req *objectivesv1alpha1.ListRequest) []string {
+		resp, err := client.List(ctx, connect.NewRequest(req))
+		require.NoError(t, err)
+		names := []string{}
+		for _, o := range resp.Msg.Objectives {
+			names = append(names, o.Labels["__name__"])
+		}
+		return names
+	}
+
+	require.Equal(t, []string{"a", "c"}, list(t, withTeams("payments"), &objectivesv1alpha1.ListRequest{}))
+	require.Equal(t, []string{"a", "b", "c"}, list(t, withTeams("search", "payments"), &objectivesv1alpha1.ListRequest{}))
+	require.Equal(t, []string{"a", "b", "c", "d"}, list(t, withTeams("search", "sre"), &objectivesv1alpha1.ListRequest{}))
+	require.Equal(t, []string{}, list(t, withTeams(), &objectivesv1alpha1.ListRequest{}))
+	require.Equal(t, []string{}, list(t, context.Background(), &objectivesv1alpha1.ListRequest{}))
+
+	// Pages only hold the objectives the caller may see.
+	resp, err := client.List(withTeams("payments"), connect.NewRequest(&objectivesv1alpha1.ListRequest{PageSize: 1, OrderBy: objectivesv1alpha1.OrderByName}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Objectives, 1)
+	require.Equal(t, "a", resp.Msg.Objectives[0].Labels["__name__"])
+	require.Equal(t, int32(2), resp.Msg.TotalSize)
+
+	resp, err = client.List(withTeams("payments"), connect.NewRequest(&objectivesv1alpha1.ListRequest{PageSize: 1, OrderBy: objectivesv1alpha1.OrderByName, PageToken: resp.Msg.NextPageToken}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Objectives, 1)
+	require.Equal(t, "c", resp.Msg.Objectives[0].Labels["__name__"])
+	require.Empty(t, resp.Msg.NextPageToken)
+
+	// Objectives of other teams can't be looked up.
+	s := &objectiveServer{client: client}
+	_, err = s.getObjective(withTeams("marketing"), `{__name__="a"}`)
+	require.Error(t, err)
+}
+
+func TestAuthorizeQuery(t *testing.T) {
+	latency := slo.Objective{
+		Labels: labels.FromStrings(labels.MetricName, "api-latency"),
+		Indicator: slo.Indicator{Latency: &slo.LatencyIndicator{
+			Success: slo.Metric{Name: "http_request_duration_seconds_bucket", LabelMatchers: []*labels.Matcher{
+				labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "http_request_duration_seconds_bucket"),
+				labels.MustNewMatcher(labels.MatchRegexp, "job", "api|api-canary"),
+				labels.MustNewMatcher(labels.MatchEqual, "le", "0.1"),
+			}},
+			Total: slo.Metric{Name: "http_request_duration_seconds_count", LabelMatchers: []*labels.Matcher{
+				labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "http_request_duration_seconds_count"),
+				labels.MustNewMatcher(labels.MatchRegexp, "job", "api|api-canary"),
+			}},
+		}},
+	}
+	raw := slo.Objective{
+		Labels: labels.FromStrings(labels.MetricName, "raw"),
+		Indicator: slo.Indicator{Raw: &slo.RawIndicator{
+			Good:  `sum(probe_success{job="blackbox"})`,
+			Total: `count(probe_success{job="blackbox"})`,
+		}},
+	}
+	objectives := []slo.Objective{teamObjective("api-errors", "payments", "api"), latency, raw}
+
+	for _, query := range []string{
+		`sum(increase(http_requests_total{job="api"}[4w]))`,
+		`sum by (code) (rate(http_requests_total{job="api",code=~"5.."}[5m]))`,
+		`sum(rate(http_requests_total{job="api",handler="/pay"}[5m]))`,
+		`sum(increase(http_requests_total{job="api",code="500"}[4w]))`,
+		`http_requests:burnrate5m{slo="api-errors"}`,
+		`ALERTS{slo="api-latency"}`,
+		`sum(rate(http_request_duration_seconds_count{job="api-canary"}[5m]))`,
+		`http_request_duration_seconds_bucket{job=~"api|api-canary",le="0.1"}`,
+		`probe_success{job="blackbox",instance="a"}`,
+		`vector(1)`,
+	} {
+		require.NoError(t, authorizeQuery(query, objectives, nil), query)
+	}
+
+	for _, query := range []string{
+		`http_requests_total`,
+		`http_requests_total{job="checkout"}`,
+		`http_requests_total{job=~"api|checkout"}`,
+		`sum(rate(http_requests_total{job="api"}[5m])) / sum(rate(http_requests_total{job="checkout"}[5m]))`,
+		`http_request_duration_seconds_bucket{job=~"api|api-canary"}`,
+		`http_request_duration_seconds_count{job="checkout"}`,
+		`{job="api"}`,
+		`ALERTS{slo="checkout-errors"}`,
+		`ALERTS{slo=~"api-errors|checkout-errors"}`,
+	} {
+		err := authorizeQuery(query, objectives, nil)
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), query)
+	}
+
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(authorizeQuery(`sum(`, objectives, nil)))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(authorizeQuery(`up`, nil, nil)))
+}
+
+func TestAuthorizeQuerySameName(t *testing.T) {
+	propagated := func(o slo.Objective, team string) slo.Objective {
+		o.Labels = labels.NewBuilder(o.Labels).Set(slo.PropagationLabelsPrefix+"team", team).Labels()
+		return o
+	}
+	visible := []slo.Objective{
+		propagated(teamObjective("errors", "payments", "checkout"), "payments"),
+		teamObjective("latency", "payments", "checkout"),
+	}
+	hidden := []slo.Objective{
+		propagated(teamObjective("errors", "search", "search"), "search"),
+		teamObjective("latency", "search", "search"),
+		teamObjective("availability", "search", "search"),
+	}
+
+	for _, query := range []string{
+		`ALERTS{slo="errors",team="payments"}`,
+		`http_requests:burnrate5m{slo="errors",team="payments"}`,
+		`http_requests_total{job="checkout"}`,
+	} {
+		require.NoError(t, authorizeQuery(query, visible, hidden), query)
+	}
+
+	for _, query := range []string{
+		// The recording rules and alerts of the hidden objectives have the same slo label.
+		`ALERTS{slo="errors"}`,
+		`ALERTS{slo="errors",team="search"}`,
+		`ALERTS{slo="errors",team=~"payments|search"}`,
+		// Without propagated labels the hidden objective's series can't be told apart.
+		`ALERTS{slo="latency",team="payments"}`,
+		`ALERTS{slo="availability"}`,
+		`http_requests_total{job="search"}`,
+	} {
+		err := authorizeQuery(query, visible, hidden)
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), query)
+	}
+}
+
+func TestPrometheusServerAuthorize(t *testing.T) {
+	other := teamObjective("c", "payments", "other")
+	other.Datasource = "other"
+	ps := &prometheusServer{
+		promAPI: &promCache{api: reportPrometheus{}},
+		queries: &queryAuthorizer{
+			client: staticObjectives{
+				teamObjective("a", "payments", "checkout"),
+				teamObjective("b", "search", "search"),
+				other,
+			},
+			authorizer: newTeamAuthorizer("team", nil),
+		},
+	}
+
+	_, err := ps.Query(withTeams("payments"), connect.NewRequest(&prometheusv1.QueryRequest{Query: `http_requests_total{job="checkout"}`}))
+	require.NoError(t, err)
+
+	_, err = ps.Query(withTeams("payments"), connect.NewRequest(&prometheusv1.QueryRequest{Query: `http_requests_total{job="search"}`}))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	_, err = ps.QueryRange(withTeams("payments"), connect.NewRequest(&prometheusv1.QueryRangeRequest{Query: `http_requests_total{job="search"}`}))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	// Series are only the objective's in the objective's datasource.
+	_, err = ps.Query(withTeams("payments"), connect.NewRequest(&prometheusv1.QueryRequest{Query: `http_requests_total{job="checkout"}`, Datasource: "other"}))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	_, err = ps.Query(withTeams("payments"), connect.NewRequest(&prometheusv1.QueryRequest{Query: `ALERTS{slo="c"}`, Datasource: "other"}))
+	require.NoError(t, err)
+	_, err = ps.QueryRange(withTeams("payments"), connect.NewRequest(&prometheusv1.QueryRangeRequest{Query: `ALERTS{slo="c"}`}))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	// Without authorizer all queries are allowed.
+	ps.queries = nil
+	_, err = ps.Query(context.Background(), connect.NewRequest(&prometheusv1.QueryRequest{Query: `http_requests_total{job="search"}`}))
+	require.NoError(t, err)
+}
+
+func TestBacktestAuthorized(t *testing.T) {
+	s := &objectiveServer{
+		logger:  log.NewNopLogger(),
+		promAPI: &promCache{api: backtestPrometheus{}},
+		queries: &queryAuthorizer{
+			client: staticObjectives{
+				teamObjective("a", "payments", "checkout"),
+				teamObjective("b", "search", "search"),
+			},
+			authorizer: newTeamAuthorizer("team", []string{"sre"}),
+		},
+	}
+	backtest := func(ctx context.Context, objective slo.Objective) error {
+		_, err := s.Backtest(ctx, connect.NewRequest(&objectivesv1alpha1.BacktestRequest{
+			Objective: objectivesv1alpha1.FromInternal(objective),
+		}))
+		return err
+	}
+
+	require.NoError(t, backtest(withTeams("payments"), teamObjective("new", "payments", "checkout")))
+
+	// Objectives passed along with the backtest can't select the series of other teams' objectives.
+	err := backtest(withTeams("payments"), teamObjective("new", "payments", "search"))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	err = backtest(withTeams("payments"), teamObjective("new", "payments", "unknown"))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	// Nor the series of the same job in another datasource.
+	other := teamObjective("new", "payments", "checkout")
+	other.Datasource = "other"
+	err = backtest(withTeams("payments"), other)
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	require.NoError(t, backtest(withTeams("sre"), teamObjective("new", "payments", "search")))
+}
+
+// identityBackend records the identity of the requests listing its objectives.
+type identityBackend struct {
+	staticObjectives
+	identity *identity
+}
+
+func (b *identityBackend) List(ctx context.Context, req *connect.Request[objectivesv1alpha1.ListRequest]) (*connect.Response[objectivesv1alpha1.ListResponse], error) {
+	b.identity = contextGetIdentity(ctx)
+	return b.staticObjectives.List(ctx, req)
+}
+
+func TestMCPAuthenticated(t *testing.T) {
+	backend := &identityBackend{}
+	objectives := &objectiveServer{
+		promAPI: &promCache{api: reportPrometheus{}},
+		client:  backend,
+	}
+	handler := authenticate(log.NewNopLogger(), []authenticator{
+		&proxyAuthenticator{userHeader: "X-Forwarded-User", teamsHeader: "X-Forwarded-Groups"},
+	})(newMCPHandler(objectives, log.NewNopLogger()))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Set("X-Forwarded-User", "jane")
+		r.Header.Set("X-Forwarded-Groups", "payments")
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := context.Background()
+	client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test", Version: "v0"}, nil)
+	session, err := client.Connect(ctx, &mcpsdk.StreamableClientTransport{Endpoint: srv.URL}, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+
+	_, err = session.CallTool(ctx, &mcpsdk.CallToolParams{Name: "list_objectives"})
+	require.NoError(t, err)
+	require.Equal(t, &identity{user: "jane", teams: []string{"payments"}}, backend.identity)
+}
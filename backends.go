@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	connect "connectrpc.com/connect"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	objectivesv1alpha1 "github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1"
+	"github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1/objectivesv1alpha1connect"
+)
+
+// multiBackendClient lists the objectives of several backends, like the operators of different clusters, as one.
+// Every objective gets a label with the name of its backend.
+// Requests matching that label only list the objectives of the matching backends.
+type multiBackendClient struct {
+	label    string
+	names    []string
+	backends map[string]objectivesv1alpha1connect.ObjectiveBackendServiceClient
+}
+
+func newMultiBackendClient(label string, backends map[string]objectivesv1alpha1connect.ObjectiveBackendServiceClient) *multiBackendClient {
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return &multiBackendClient{label: label, names: names, backends: backends}
+}
+
+// List lists the objectives of all backends and merges them before they are sorted and paginated.
+// Backends failing to list their objectives are skipped with a warning, unless all of them fail.
+func (c *multiBackendClient) List(ctx context.Context, req *connect.Request[objectivesv1alpha1.ListRequest]) (*connect.Response[objectivesv1alpha1.ListResponse], error) {
+	names := c.names
+	expr := req.Msg.Expr
+	if expr != "" {
+		matchers, err := parser.ParseMetricSelector(expr)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("failed to parse expr: %w", err))
+		}
+		names, expr = c.matchBackends(matchers)
+	}
+
+	responses := make([]*objectivesv1alpha1.ListResponse, len(names))
+	errs := make([]error, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := c.backends[name].List(ctx, connect.NewRequest(&objectivesv1alpha1.ListRequest{
+				Expr:     expr,
+				Grouping: req.Msg.Grouping,
+				Search:   req.Msg.Search,
+			}))
+			if err != nil {
+				errs[i] = fmt.Errorf("backend %s: %w", name, err)
+				return
+			}
+			responses[i] = resp.Msg
+		}()
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	if failed > 0 && failed == len(names) {
+		return nil, connect.NewError(connect.CodeUnavailable, errors.Join(errs...))
+	}
+
+	var (
+		objectives []*objectivesv1alpha1.Objective
+		warnings   []string
+	)
+	for i, name := range names {
+		if errs[i] != nil {
+			warnings = append(warnings, errs[i].Error())
+			continue
+		}
+		for _, o := range responses[i].Objectives {
+			if o.Labels == nil {
+				o.Labels = map[string]string{}
+			}
+			o.Labels[c.label] = name
+			objectives = append(objectives, o)
+		}
+		warnings = append(warnings, responses[i].Warnings...)
+	}
+
+	resp, err := objectivesv1alpha1.ListPage(req.Msg, objectives)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	resp.Warnings = warnings
+
+	return connect.NewResponse(resp), nil
+}
+
+// matchBackends returns the backends whose name matches the matchers of the backend label
+// and the expr of the other matchers to forward to them.
+func (c *multiBackendClient) matchBackends(matchers []*labels.Matcher) ([]string, string) {
+	var backendMatchers, others []*labels.Matcher
+	for _, m := range matchers {
+		if m.Name == c.label {
+			backendMatchers = append(backendMatchers, m)
+		} else {
+			others = append(others, m)
+		}
+	}
+
+	names := make([]string, 0, len(c.names))
+Names:
+	for _, name := range c.names {
+		for _, m := range backendMatchers {
+			if !m.Matches(name) {
+				continue Names
+			}
+		}
+		names = append(names, name)
+	}
+
+	if len(others) == 0 {
+		return names, ""
+	}
+	return names, (&parser.VectorSelector{LabelMatchers: others}).String()
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+
+	objectivesv1alpha1 "github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1"
+	"github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1/objectivesv1alpha1connect"
+)
+
+// failingBackend fails to list any objectives.
+type failingBackend struct{}
+
+func (failingBackend) List(context.Context, *connect.Request[objectivesv1alpha1.ListRequest]) (*connect.Response[objectivesv1alpha1.ListResponse], error) {
+	return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("connection refused"))
+}
+
+// exprBackend records the expr of the last request.
+type exprBackend struct {
+	staticObjectives
+	expr string
+}
+
+func (b *exprBackend) List(ctx context.Context, req *connect.Request[objectivesv1alpha1.ListRequest]) (*connect.Response[objectivesv1alpha1.ListResponse], error) {
+	b.expr = req.Msg.Expr
+	return b.staticObjectives.List(ctx, req)
+}
+
+func TestMultiBackendClient(t *testing.T) {
+	window := 28 * 24 * time.Hour
+	eu := &exprBackend{staticObjectives: staticObjectives{
+		batchObjective("b", "default", "api", window),
+		batchObjective("a", "monitoring", "api", window),
+	}}
+	us := &exprBackend{staticObjectives: staticObjectives{
+		batchObjective("a", "default", "api", window),
+	}}
+
+	client := newMultiBackendClient("cluster", map[string]objectivesv1alpha1connect.ObjectiveBackendServiceClient{
+		"eu": eu,
+		"us": us,
+	})
+
+	list := func(t *testing.T, req *objectivesv1alpha1.ListRequest) *objectivesv1alpha1.ListResponse {
+		resp, err := client.List(context.Background(), connect.NewRequest(req))
+		require.NoError(t, err)
+		return resp.Msg
+	}
+	clusters := func(objectives []*objectivesv1alpha1.Objective) []string {
+		c := make([]string, 0, len(objectives))
+		for _, o := range objectives {
+			c = append(c, o.Labels["__name__"]+"/"+o.Labels["cluster"])
+		}
+		return c
+	}
+
+	t.Run("all", func(t *testing.T) {
+		resp := list(t, &objectivesv1alpha1.ListRequest{})
+		require.Equal(t, []string{"b/eu", "a/eu", "a/us"}, clusters(resp.Objectives))
+		require.Equal(t, int32(3), resp.TotalSize)
+		require.Empty(t, resp.Warnings)
+		require.Empty(t, eu.expr)
+	})
+
+	t.Run("orderedPage", func(t *testing.T) {
+		resp := list(t, &objectivesv1alpha1.ListRequest{OrderBy: objectivesv1alpha1.OrderByName, PageSize: 2})
+		require.Equal(t, []string{"a/us", "a/eu"}, clusters(resp.Objectives))
+		require.NotEmpty(t, resp.NextPageToken)
+		require.Equal(t, int32(3), resp.TotalSize)
+	})
+
+	t.Run("cluster", func(t *testing.T) {
+		eu.expr, us.expr = "unset", "unset"
+		resp := list(t, &objectivesv1alpha1.ListRequest{Expr: `{__name__="a",cluster="us",namespace="default"}`})
+		require.Equal(t, []string{"a/us"}, clusters(resp.Objectives))
+		require.Equal(t, `{__name__="a",namespace="default"}`, us.expr)
+		require.Equal(t, "unset", eu.expr)
+	})
+
+	t.Run("clusterRegex", func(t *testing.T) {
+		resp := list(t, &objectivesv1alpha1.ListRequest{Expr: `{cluster=~"e.*"}`})
+		require.Equal(t, []string{"b/eu", "a/eu"}, clusters(resp.Objectives))
+		require.Empty(t, eu.expr)
+	})
+
+	t.Run("unknownCluster", func(t *testing.T) {
+		resp := list(t, &objectivesv1alpha1.ListRequest{Expr: `{cluster="ap"}`})
+		require.Empty(t, resp.Objectives)
+	})
+
+	t.Run("invalidExpr", func(t *testing.T) {
+		_, err := client.List(context.Background(), connect.NewRequest(&objectivesv1alpha1.ListRequest{Expr: `{cluster=}`}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+}
+
+func TestMultiBackendClientFailures(t *testing.T) {
+	client := newMultiBackendClient("source", map[string]objectivesv1alpha1connect.ObjectiveBackendServiceClient{
+		"eu":         failingBackend{},
+		"filesystem": staticObjectives{batchObjective("a", "default", "api", time.Hour)},
+	})
+
+	resp, err := client.List(context.Background(), connect.NewRequest(&objectivesv1alpha1.ListRequest{}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Objectives, 1)
+	require.Equal(t, "filesystem", resp.Msg.Objectives[0].Labels["source"])
+	require.Equal(t, []string{"backend eu: unavailable: connection refused"}, resp.Msg.Warnings)
+
+	// The objective of the working backend can be looked up as usual.
+	s := &objectiveServer{client: client}
+	objective, err := s.getObjective(context.Background(), `{__name__="a",source="filesystem"}`)
+	require.NoError(t, err)
+	require.Equal(t, "a", objective.Name())
+
+	// Partial responses aren't cached.
+	cache := newBackendClientCache(client).(*backendClientCache)
+	_, err = cache.List(context.Background(), connect.NewRequest(&objectivesv1alpha1.ListRequest{}))
+	require.NoError(t, err)
+	cache.cache.Wait()
+	_, found := cache.cache.Get("\x00\x000\x00\x00\x00")
+	require.False(t, found)
+
+	client = newMultiBackendClient("source", map[string]objectivesv1alpha1connect.ObjectiveBackendServiceClient{
+		"eu": failingBackend{},
+		"us": failingBackend{},
+	})
+	_, err = client.List(context.Background(), connect.NewRequest(&objectivesv1alpha1.ListRequest{}))
+	require.Equal(t, connect.CodeUnavailable, connect.CodeOf(err))
+	require.ErrorContains(t, err, "backend eu")
+	require.ErrorContains(t, err, "backend us")
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/api"
+	prometheusapiv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	objectivesv1alpha1 "github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1"
+	"github.com/pyrra-dev/pyrra/slo"
+)
+
+// backtestMinStep is the evaluation interval of the generated alerting rules.
+// Backtesting with a smaller step doesn't get any more precise.
+const backtestMinStep = 30 * time.Second
+
+func cmdBacktest(
+	logger log.Logger,
+	promClient api.Client,
+	file string,
+	start, end time.Time,
+	step time.Duration,
+	prometheus3Migration bool,
+) int {
+	_, objective, err := objectiveFromFile(file)
+	if err != nil {
+		level.Error(logger).Log("msg", "reading objective", "file", file, "err", err)
+		return 1
+	}
+
+	if end.IsZero() {
+		end = time.Now()
+	}
+	if start.IsZero() {
+		start = end.Add(-time.Duration(objective.Window))
+	}
+
+	alerts, err := backtestAlerts(
+		context.Background(),
+		prometheusapiv1.NewAPI(promClient),
+		objective,
+		start, end, step,
+		slo.GenerationOptions{EnablePrometheus3Migration: prometheus3Migration},
+	)
+	if err != nil {
+		level.Error(logger).Log("msg", "backtesting objective", "file", file, "err", err)
+		return 1
+	}
+
+	for _, a := range alerts {
+		w := a.Window
+		firing := 0
+		for _, i := range a.Intervals {
+			if i.State == objectivesv1alpha1.Alert_firing {
+				firing++
+			}
+		}
+		fmt.Printf("%s severity=%s short=%s long=%s factor=%g for=%s: fired %d times\n",
+			objective.AlertName(),
+			w.Severity,
+			model.Duration(w.Short.AsDuration()),
+			model.Duration(w.Long.AsDuration()),
+			w.Factor,
+			model.Duration(w.For.AsDuration()),
+			firing,
+		)
+		for _, i := range a.Intervals {
+			fmt.Printf("  %-7s %s - %s %s\n",
+				i.State,
+				i.Start.AsTime().Format(time.RFC3339),
+				i.End.AsTime().Format(time.RFC3339),
+				labelSet(i.Labels),
+			)
+		}
+	}
+
+	return 0
+}
+
+func labelSet(lset map[string]string) model.LabelSet {
+	ls := make(model.LabelSet, len(lset))
+	for n, v := range lset {
+		ls[model.LabelName(n)] = model.LabelValue(v)
+	}
+	return ls
+}
+
+func (s *objectiveServer) Backtest(ctx context.Context, req *connect.Request[objectivesv1alpha1.BacktestRequest]) (*connect.Response[objectivesv1alpha1.BacktestResponse], error) {
+	var objective slo.Objective
+	if req.Msg.Objective != nil {
+		var err error
+		objective, err = objectivesv1alpha1.ToInternal(req.Msg.Objective)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		// Objectives that aren't looked up could select any series.
+		if err := s.queries.authorizeObjective(ctx, objective); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		objective, err = s.getObjective(ctx, req.Msg.Expr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	end := time.Now()
+	if req.Msg.End != nil {
+		end = req.Msg.End.AsTime()
+	}
+	start := end.Add(-time.Duration(objective.Window))
+	if req.Msg.Start != nil {
+		start = req.Msg.Start.AsTime()
+	}
+	if !start.Before(end) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("start %s needs to be before end %s", start, end))
+	}
+
+	// The range queries over weeks of data aren't cached, it's unlikely that the exact same backtest runs twice.
+	alerts, err := backtestAlerts(ctx, s.promAPI.api, objective, start, end, req.Msg.Step.AsDuration(), s.opts)
+	if err != nil {
+		level.Warn(s.logger).Log("msg", "failed to backtest alerts", "err", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&objectivesv1alpha1.BacktestResponse{Alerts: alerts}), nil
+}
+
+// backtestAlerts replays the multi burn rate alerts of the objective from start to end.
+// Instead of the recording rules, which might not exist yet for a new objective,
+// the burn rates are calculated from the raw series with one range query per alert.
+// If step is 0, it defaults to 1/1000 of the time range, but not less than the rules' evaluation interval.
+func backtestAlerts(ctx context.Context, promAPI prometheusAPI, objective slo.Objective, start, end time.Time, step time.Duration, opts slo.GenerationOptions) ([]*objectivesv1alpha1.BacktestAlert, error) {
+	ctx = contextSetDatasource(ctx, objective.Datasource)
+
+	if step <= 0 {
+		step = max(end.Sub(start)/1000, backtestMinStep)
+	}
+
+	mbras, err := objective.Alerts()
+	if err != nil {
+		return nil, err
+	}
+
+	target := strconv.FormatFloat(objective.Target, 'f', -1, 64)
+
+	alerts := make([]*objectivesv1alpha1.BacktestAlert, 0, len(mbras))
+	for _, a := range mbras {
+		// The thresholds are formatted the same way as in the generated alerting rules.
+		factor := strconv.FormatFloat(a.Factor, 'f', -1, 64)
+		query := fmt.Sprintf("(%s) > (%s * (1-%s)) and (%s) > (%s * (1-%s))",
+			objective.Burnrate(a.Short, opts), factor, target,
+			objective.Burnrate(a.Long, opts), factor, target,
+		)
+
+		value, _, err := promAPI.QueryRange(ctx, query, prometheusapiv1.Range{
+			Start: start,
+			End:   end,
+			Step:  step,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("querying %s burn rate alert: %w", a.Severity, err)
+		}
+		matrix, ok := value.(model.Matrix)
+		if !ok {
+			return nil, fmt.Errorf("querying %s burn rate alert: expected matrix, got %s", a.Severity, value.Type())
+		}
+
+		alerts = append(alerts, &objectivesv1alpha1.BacktestAlert{
+			Window: &objectivesv1alpha1.BurnRateWindow{
+				Severity: a.Severity,
+				For:      durationpb.New(a.For),
+				Factor:   a.Factor,
+				Short:    durationpb.New(a.Short),
+				Long:     durationpb.New(a.Long),
+			},
+			Query:     query,
+			Intervals: backtestIntervals(matrix, step, a.For),
+		})
+	}
+
+	return alerts, nil
+}
+
+// backtestIntervals turns the samples of an alert's query into the intervals the alert would have been pending and firing.
+// Consecutive samples are one active alert, just like consecutive rule evaluations returning a result are.
+// The alert is pending until it has been active for the for duration and firing afterward.
+func backtestIntervals(matrix model.Matrix, step, holdDuration time.Duration) []*objectivesv1alpha1.BacktestInterval {
+	var intervals []*objectivesv1alpha1.BacktestInterval
+
+	for _, series := range matrix {
+		lset := make(map[string]string, len(series.Metric))
+		for n, v := range series.Metric {
+			lset[string(n)] = string(v)
+		}
+
+		add := func(state objectivesv1alpha1.Alert_State, start, end time.Time) {
+			intervals = append(intervals, &objectivesv1alpha1.BacktestInterval{
+				Labels: lset,
+				State:  state,
+				Start:  timestamppb.New(start),
+				End:    timestamppb.New(end),
+			})
+		}
+
+		for i := 0; i < len(series.Values); {
+			activeAt := series.Values[i].Timestamp.Time()
+
+			j := i
+			for j+1 < len(series.Values) && series.Values[j+1].Timestamp.Sub(series.Values[j].Timestamp) <= step {
+				j++
+			}
+			lastActiveAt := series.Values[j].Timestamp.Time()
+
+			firingAt := activeAt.Add(holdDuration)
+			// Rules are only evaluated every step, the alert fires at the first evaluation after the for duration.
+			if offset := holdDuration % step; offset != 0 {
+				firingAt = firingAt.Add(step - offset)
+			}
+
+			if holdDuration > 0 {
+				add(objectivesv1alpha1.Alert_pending, activeAt, minTime(firingAt, lastActiveAt))
+			}
+			if !firingAt.After(lastActiveAt) {
+				add(objectivesv1alpha1.Alert_firing, firingAt, lastActiveAt)
+			}
+
+			i = j + 1
+		}
+	}
+
+	sort.SliceStable(intervals, func(i, j int) bool {
+		return intervals[i].Start.AsTime().Before(intervals[j].Start.AsTime())
+	})
+
+	return intervals
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	prometheusapiv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	objectivesv1alpha1 "github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1"
+	"github.com/pyrra-dev/pyrra/slo"
+)
+
+// backtestPrometheus returns the matrix of a range query and an empty matrix for unknown queries.
+type backtestPrometheus map[string]model.Matrix
+
+func (p backtestPrometheus) Query(_ context.Context, query string, _ time.Time, _ ...prometheusapiv1.Option) (model.Value, prometheusapiv1.Warnings, error) {
+	return nil, nil, fmt.Errorf("unexpected instant query %q", query)
+}
+
+func (p backtestPrometheus) QueryRange(_ context.Context, query string, _ prometheusapiv1.Range, _ ...prometheusapiv1.Option) (model.Value, prometheusapiv1.Warnings, error) {
+	if matrix, ok := p[query]; ok {
+		return matrix, nil, nil
+	}
+	return model.Matrix{}, nil, nil
+}
+
+// activeSamples returns a sample every step from start until end, as if a rule evaluation returned a result.
+func activeSamples(start, end time.Time, step time.Duration) []model.SamplePair {
+	var samples []model.SamplePair
+	for ts := start; !ts.After(end); ts = ts.Add(step) {
+		samples = append(samples, model.SamplePair{Timestamp: model.TimeFromUnixNano(ts.UnixNano()), Value: 1})
+	}
+	return samples
+}
+
+func TestBacktestAlerts(t *testing.T) {
+	objective := slo.Objective{
+		Labels: labels.FromStrings(labels.MetricName, "api"),
+		Target: 0.99,
+		Window: model.Duration(28 * 24 * time.Hour),
+		Indicator: slo.Indicator{
+			Ratio: &slo.RatioIndicator{
+				Errors: slo.Metric{
+					Name: "http_requests_total",
+					LabelMatchers: []*labels.Matcher{
+						labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "http_requests_total"),
+						labels.MustNewMatcher(labels.MatchRegexp, "code", "5.."),
+					},
+				},
+				Total: slo.Metric{
+					Name: "http_requests_total",
+					LabelMatchers: []*labels.Matcher{
+						labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "http_requests_total"),
+					},
+				},
+				Grouping: []string{"handler"},
+			},
+		},
+		BurnRatePolicy: []slo.Window{{
+			Severity: "critical",
+			For:      2 * time.Minute,
+			Factor:   14,
+			Short:    5 * time.Minute,
+			Long:     time.Hour,
+		}, {
+			Severity: "warning",
+			For:      0,
+			Factor:   1,
+			Short:    6 * time.Hour,
+			Long:     3 * 24 * time.Hour,
+		}},
+	}
+
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	criticalQuery := `(sum by (handler) (rate(http_requests_total{code=~"5.."}[5m])) / sum by (handler) (rate(http_requests_total[5m]))) > (14 * (1-0.99)) and ` +
+		`(sum by (handler) (rate(http_requests_total{code=~"5.."}[1h])) / sum by (handler) (rate(http_requests_total[1h]))) > (14 * (1-0.99))`
+
+	prometheus := backtestPrometheus{
+		criticalQuery: {{
+			Metric: model.Metric{"handler": "/"},
+			Values: append(
+				// Active for 1m only, the alert never fires.
+				activeSamples(start.Add(time.Hour), start.Add(time.Hour+time.Minute), time.Minute),
+				// Active for 30m, firing after 2m.
+				activeSamples(start.Add(3*time.Hour), start.Add(3*time.Hour+30*time.Minute), time.Minute)...,
+			),
+		}},
+	}
+
+	alerts, err := backtestAlerts(context.Background(), prometheus, objective, start, end, time.Minute, slo.GenerationOptions{})
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+
+	critical := alerts[0]
+	require.Equal(t, "critical", critical.Window.Severity)
+	require.Equal(t, criticalQuery, critical.Query)
+	require.Equal(t, []*objectivesv1alpha1.BacktestInterval{{
+		Labels: map[string]string{"handler": "/"},
+		State:  objectivesv1alpha1.Alert_pending,
+		Start:  timestamppb.New(start.Add(time.Hour)),
+		End:    timestamppb.New(start.Add(time.Hour + time.Minute)),
+	}, {
+		Labels: map[string]string{"handler": "/"},
+		State:  objectivesv1alpha1.Alert_pending,
+		Start:  timestamppb.New(start.Add(3 * time.Hour)),
+		End:    timestamppb.New(start.Add(3*time.Hour + 2*time.Minute)),
+	}, {
+		Labels: map[string]string{"handler": "/"},
+		State:  objectivesv1alpha1.Alert_firing,
+		Start:  timestamppb.New(start.Add(3*time.Hour + 2*time.Minute)),
+		End:    timestamppb.New(start.Add(3*time.Hour + 30*time.Minute)),
+	}}, critical.Intervals)
+
+	warning := alerts[1]
+	require.Equal(t, "warning", warning.Window.Severity)
+	require.Empty(t, warning.Intervals)
+}
+
+func TestBacktestAlertsFractionalFactor(t *testing.T) {
+	objective := slo.Objective{
+		Labels: labels.FromStrings(labels.MetricName, "api"),
+		Target: 0.99,
+		Window: model.Duration(28 * 24 * time.Hour),
+		Indicator: slo.Indicator{
+			Ratio: &slo.RatioIndicator{
+				Errors: slo.Metric{
+					Name: "http_requests_total",
+					LabelMatchers: []*labels.Matcher{
+						labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "http_requests_total"),
+						labels.MustNewMatcher(labels.MatchRegexp, "code", "5.."),
+					},
+				},
+				Total: slo.Metric{
+					Name: "http_requests_total",
+					LabelMatchers: []*labels.Matcher{
+						labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "http_requests_total"),
+					},
+				},
+			},
+		},
+		Alerting: slo.Alerting{Burnrates: true},
+		BurnRatePolicy: []slo.Window{{
+			Severity: "ticket",
+			Factor:   1.5,
+			Short:    6 * time.Hour,
+			Long:     3 * 24 * time.Hour,
+		}},
+	}
+
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	alerts, err := backtestAlerts(context.Background(), backtestPrometheus{}, objective, start, start.Add(24*time.Hour), time.Minute, slo.GenerationOptions{})
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	require.Equal(t,
+		`(sum(rate(http_requests_total{code=~"5.."}[6h])) / sum(rate(http_requests_total[6h]))) > (1.5 * (1-0.99)) and `+
+			`(sum(rate(http_requests_total{code=~"5.."}[3d])) / sum(rate(http_requests_total[3d]))) > (1.5 * (1-0.99))`,
+		alerts[0].Query,
+	)
+
+	// The thresholds match the ones of the generated alerting rule.
+	group, err := objective.Burnrates(slo.GenerationOptions{})
+	require.NoError(t, err)
+	var rules int
+	for _, r := range group.Rules {
+		if r.Alert != "ErrorBudgetBurn" {
+			continue
+		}
+		rules++
+		require.Equal(t, 2, strings.Count(r.Expr.String(), " > (1.5 * (1-0.99))"))
+	}
+	require.Equal(t, 1, rules)
+}
+
+func TestBacktestIntervals(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	matrix := model.Matrix{{
+		Metric: model.Metric{},
+		Values: activeSamples(start, start.Add(10*time.Minute), time.Minute),
+	}}
+
+	// Without a for duration alerts fire right away.
+	intervals := backtestIntervals(matrix, time.Minute, 0)
+	require.Len(t, intervals, 1)
+	require.Equal(t, objectivesv1alpha1.Alert_firing, intervals[0].State)
+	require.Equal(t, start, intervals[0].Start.AsTime())
+
+	// With a step larger than the for duration alerts fire at the next evaluation.
+	matrix[0].Values = activeSamples(start, start.Add(10*time.Minute), 5*time.Minute)
+	intervals = backtestIntervals(matrix, 5*time.Minute, 2*time.Minute)
+	require.Len(t, intervals, 2)
+	require.Equal(t, start.Add(5*time.Minute), intervals[0].End.AsTime())
+	require.Equal(t, start.Add(5*time.Minute), intervals[1].Start.AsTime())
+}
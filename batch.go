@@ -0,0 +1,306 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	objectivesv1alpha1 "github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1"
+	"github.com/pyrra-dev/pyrra/slo"
+)
+
+// maxBatchObjectives limits how many objectives are queried in a single query,
+// so that queries stay well below the URL length limits of proxies in front of Prometheus.
+const maxBatchObjectives = 50
+
+// statusBatch are objectives whose status is queried together.
+type statusBatch struct {
+	datasource string
+	objectives []int
+	total      []parser.Expr
+	errors     []parser.Expr
+}
+
+func (s *objectiveServer) BatchGetStatus(ctx context.Context, req *connect.Request[objectivesv1alpha1.BatchGetStatusRequest]) (*connect.Response[objectivesv1alpha1.BatchGetStatusResponse], error) {
+	resp, err := s.client.List(ctx, connect.NewRequest(&objectivesv1alpha1.ListRequest{
+		Expr: req.Msg.Expr,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	ts := time.Now()
+	if req.Msg.Time != nil {
+		ts = req.Msg.Time.AsTime()
+	} else {
+		// The cache doesn't tell queries at different times apart, so only the current status is cached.
+		ctx = contextSetPromCache(ctx, 15*time.Second)
+	}
+
+	objectives := make([]slo.Objective, 0, len(resp.Msg.Objectives))
+	for _, o := range resp.Msg.Objectives {
+		objective, err := objectivesv1alpha1.ToInternal(o)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		objectives = append(objectives, objective)
+	}
+
+	batches, err := s.statusBatches(objectives, ts)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	statuses := make([][]*objectivesv1alpha1.ObjectiveStatus, len(objectives))
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	setErr := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	for i, o := range objectives {
+		if o.IndicatorType() != slo.Composite {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status, err := s.getCompositeStatus(ctx, o, ts)
+			if err != nil {
+				setErr(err)
+				return
+			}
+			statuses[i] = status
+		}()
+	}
+
+	for _, batch := range batches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.queryStatusBatch(ctx, objectives, batch, ts, statuses); err != nil {
+				setErr(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	result := make([]*objectivesv1alpha1.BatchStatus, 0, len(objectives))
+	for i, o := range objectives {
+		status := statuses[i]
+		if status == nil {
+			status = []*objectivesv1alpha1.ObjectiveStatus{}
+		}
+		result = append(result, &objectivesv1alpha1.BatchStatus{
+			Labels: o.Labels.Map(),
+			Status: status,
+		})
+	}
+
+	return connect.NewResponse(&objectivesv1alpha1.BatchGetStatusResponse{
+		Objectives: result,
+	}), nil
+}
+
+// statusBatches groups the objectives by their datasource, indicator type and window, as those have queries of the same shape.
+// Objectives with the same name, like ones in different namespaces, are put into different batches,
+// because their results can only be told apart by the slo label.
+func (s *objectiveServer) statusBatches(objectives []slo.Objective, ts time.Time) ([]*statusBatch, error) {
+	var batches []*statusBatch
+	open := map[string][]*statusBatch{}
+	names := map[*statusBatch]map[string]struct{}{}
+
+	for i, o := range objectives {
+		if o.IndicatorType() == slo.Composite {
+			continue
+		}
+
+		total, err := o.QueryCalendar(o.QueryTotal(o.Window, s.opts), ts, ts)
+		if err != nil {
+			return nil, err
+		}
+		totalExpr, err := batchExpr(total)
+		if err != nil {
+			return nil, fmt.Errorf("objective %s: %w", o.Name(), err)
+		}
+		errs, err := o.QueryCalendar(o.QueryErrors(o.Window, s.opts), ts, ts)
+		if err != nil {
+			return nil, err
+		}
+		errorsExpr, err := batchExpr(errs)
+		if err != nil {
+			return nil, fmt.Errorf("objective %s: %w", o.Name(), err)
+		}
+
+		key := fmt.Sprintf("%s;%d;%s", o.Datasource, o.IndicatorType(), o.Window)
+		var batch *statusBatch
+		for _, b := range open[key] {
+			if _, ok := names[b][o.Name()]; !ok && len(b.objectives) < maxBatchObjectives {
+				batch = b
+				break
+			}
+		}
+		if batch == nil {
+			batch = &statusBatch{datasource: o.Datasource}
+			batches = append(batches, batch)
+			open[key] = append(open[key], batch)
+			names[batch] = map[string]struct{}{}
+		}
+
+		names[batch][o.Name()] = struct{}{}
+		batch.objectives = append(batch.objectives, i)
+		batch.total = append(batch.total, totalExpr)
+		batch.errors = append(batch.errors, errorsExpr)
+	}
+
+	return batches, nil
+}
+
+// queryStatusBatch queries the total and errors of all objectives in the batch
+// and writes their statuses to the objectives' index in statuses.
+func (s *objectiveServer) queryStatusBatch(ctx context.Context, objectives []slo.Objective, batch *statusBatch, ts time.Time, statuses [][]*objectivesv1alpha1.ObjectiveStatus) error {
+	ctx = contextSetDatasource(ctx, batch.datasource)
+
+	queryTotal := mergeBatchExprs(batch.total)
+	total, _, err := s.promAPI.Query(ctx, queryTotal, ts)
+	if err != nil {
+		level.Warn(s.logger).Log("msg", "failed to query batched total", "query", queryTotal, "err", err)
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	queryErrors := mergeBatchExprs(batch.errors)
+	errs, _, err := s.promAPI.Query(ctx, queryErrors, ts)
+	if err != nil {
+		level.Warn(s.logger).Log("msg", "failed to query batched errors", "query", queryErrors, "err", err)
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	totalVector, ok := total.(model.Vector)
+	if !ok {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("unexpected batched total result %s", total.Type()))
+	}
+	errorsVector, ok := errs.(model.Vector)
+	if !ok {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("unexpected batched errors result %s", errs.Type()))
+	}
+
+	totalByName := splitBySLO(totalVector)
+	errorsByName := splitBySLO(errorsVector)
+	for _, i := range batch.objectives {
+		name := objectives[i].Name()
+		statuses[i] = objectiveStatus(objectives[i], totalByName[name], errorsByName[name])
+	}
+	return nil
+}
+
+// batchExpr parses an objective's query and adds the slo label to all of its aggregations,
+// so that its results can be told apart from the other objectives' in the batch.
+func batchExpr(query string) (parser.Expr, error) {
+	expr, err := parser.ParseExpr(query)
+	if err != nil {
+		return nil, err
+	}
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		if agg, ok := node.(*parser.AggregateExpr); ok && !agg.Without && !slices.Contains(agg.Grouping, "slo") {
+			agg.Grouping = append(agg.Grouping, "slo")
+		}
+		return nil
+	})
+	return expr, nil
+}
+
+// mergeBatchExprs returns a single query for all expressions.
+// Expressions that only differ by the slo matcher are merged into one selecting all of their objectives' names,
+// the others are combined with or.
+func mergeBatchExprs(exprs []parser.Expr) string {
+	var keys []string
+	names := map[string][]string{}
+	merged := map[string]parser.Expr{}
+
+	for _, expr := range exprs {
+		var name string
+		parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+			if vs, ok := node.(*parser.VectorSelector); ok {
+				for _, m := range vs.LabelMatchers {
+					if m.Name == "slo" && m.Type == labels.MatchEqual {
+						name = m.Value
+					}
+				}
+			}
+			return nil
+		})
+
+		key := strings.ReplaceAll(expr.String(), fmt.Sprintf(`slo=%q`, name), `slo=""`)
+		if _, ok := merged[key]; !ok {
+			keys = append(keys, key)
+			merged[key] = expr
+		}
+		names[key] = append(names[key], name)
+	}
+
+	queries := make([]string, 0, len(keys))
+	for _, key := range keys {
+		expr := merged[key]
+		if len(names[key]) > 1 {
+			quoted := make([]string, 0, len(names[key]))
+			for _, name := range names[key] {
+				quoted = append(quoted, regexp.QuoteMeta(name))
+			}
+			matcher := labels.MustNewMatcher(labels.MatchRegexp, "slo", strings.Join(quoted, "|"))
+
+			parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+				if vs, ok := node.(*parser.VectorSelector); ok {
+					for i, m := range vs.LabelMatchers {
+						if m.Name == "slo" && m.Type == labels.MatchEqual {
+							vs.LabelMatchers[i] = matcher
+						}
+					}
+				}
+				return nil
+			})
+		}
+		queries = append(queries, expr.String())
+	}
+
+	if len(queries) == 1 {
+		return queries[0]
+	}
+	return "(" + strings.Join(queries, ") or (") + ")"
+}
+
+// splitBySLO splits the results of a batch by their objective and removes the slo label again.
+func splitBySLO(vector model.Vector) map[string]model.Vector {
+	split := map[string]model.Vector{}
+	for _, sample := range vector {
+		name := string(sample.Metric["slo"])
+		metric := sample.Metric.Clone()
+		delete(metric, "slo")
+		split[name] = append(split[name], &model.Sample{
+			Metric:    metric,
+			Value:     sample.Value,
+			Timestamp: sample.Timestamp,
+		})
+	}
+	return split
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"github.com/go-kit/log"
+	prometheusapiv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	objectivesv1alpha1 "github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1"
+	"github.com/pyrra-dev/pyrra/slo"
+)
+
+func batchObjective(name, namespace, job string, window time.Duration, grouping ...string) slo.Objective {
+	return slo.Objective{
+		Labels: labels.FromStrings(labels.MetricName, name, "namespace", namespace),
+		Target: 0.99,
+		Window: model.Duration(window),
+		Indicator: slo.Indicator{
+			Ratio: &slo.RatioIndicator{
+				Errors: slo.Metric{
+					Name: "http_requests_total",
+					LabelMatchers: []*labels.Matcher{
+						labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "http_requests_total"),
+						labels.MustNewMatcher(labels.MatchEqual, "job", job),
+						labels.MustNewMatcher(labels.MatchRegexp, "code", "5.."),
+					},
+				},
+				Total: slo.Metric{
+					Name: "http_requests_total",
+					LabelMatchers: []*labels.Matcher{
+						labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "http_requests_total"),
+						labels.MustNewMatcher(labels.MatchEqual, "job", job),
+					},
+				},
+				Grouping: grouping,
+			},
+		},
+	}
+}
+
+func TestStatusBatches(t *testing.T) {
+	fourWeeks := 28 * 24 * time.Hour
+	objectives := []slo.Objective{
+		batchObjective("a", "default", "api", fourWeeks),
+		batchObjective("b", "default", "api", fourWeeks),
+		batchObjective("c", "default", "web", fourWeeks, "handler"),
+		// Same name in another namespace.
+		batchObjective("a", "monitoring", "api", fourWeeks),
+		// Another window.
+		batchObjective("d", "default", "api", 7*24*time.Hour),
+	}
+
+	s := &objectiveServer{}
+	batches, err := s.statusBatches(objectives, time.Now())
+	require.NoError(t, err)
+	require.Len(t, batches, 3)
+
+	require.Equal(t, []int{0, 1, 2}, batches[0].objectives)
+	require.Equal(t,
+		`(sum by (slo) (http_requests:increase4w{job="api",slo=~"a|b"})) or (sum by (handler, slo) (http_requests:increase4w{job="web",slo="c"}))`,
+		mergeBatchExprs(batches[0].total),
+	)
+	require.Equal(t,
+		`(sum by (slo) (http_requests:increase4w{code=~"5..",job="api",slo=~"a|b"})) or (sum by (handler, slo) (http_requests:increase4w{code=~"5..",job="web",slo="c"}))`,
+		mergeBatchExprs(batches[0].errors),
+	)
+
+	require.Equal(t, []int{3}, batches[1].objectives)
+	require.Equal(t, []int{4}, batches[2].objectives)
+	require.Equal(t, `sum by (slo) (http_requests:increase1w{job="api",slo="d"})`, mergeBatchExprs(batches[2].total))
+}
+
+func TestBatchGetStatus(t *testing.T) {
+	fourWeeks := 28 * 24 * time.Hour
+	objectives := []slo.Objective{
+		batchObjective("a", "default", "api", fourWeeks),
+		batchObjective("b", "default", "api", fourWeeks),
+		batchObjective("c", "default", "web", fourWeeks, "handler"),
+	}
+
+	prometheus := reportPrometheus{
+		`(sum by (slo) (http_requests:increase4w{job="api",slo=~"a|b"})) or (sum by (handler, slo) (http_requests:increase4w{job="web",slo="c"}))`: {
+			{Metric: model.Metric{"slo": "a"}, Value: 1000},
+			{Metric: model.Metric{"slo": "b"}, Value: 0},
+			{Metric: model.Metric{"slo": "c", "handler": "/"}, Value: 100},
+			{Metric: model.Metric{"slo": "c", "handler": "/api"}, Value: 200},
+		},
+		`(sum by (slo) (http_requests:increase4w{code=~"5..",job="api",slo=~"a|b"})) or (sum by (handler, slo) (http_requests:increase4w{code=~"5..",job="web",slo="c"}))`: {
+			{Metric: model.Metric{"slo": "a"}, Value: 5},
+			{Metric: model.Metric{"slo": "c", "handler": "/api"}, Value: 4},
+		},
+	}
+
+	s := &objectiveServer{
+		logger:  log.NewNopLogger(),
+		promAPI: &promCache{api: prometheus},
+		client:  staticObjectives(objectives),
+	}
+
+	resp, err := s.BatchGetStatus(context.Background(), connect.NewRequest(&objectivesv1alpha1.BatchGetStatusRequest{
+		Time: timestamppb.New(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Objectives, 3)
+
+	a := resp.Msg.Objectives[0]
+	require.Equal(t, map[string]string{"__name__": "a", "namespace": "default"}, a.Labels)
+	require.Len(t, a.Status, 1)
+	require.Equal(t, map[string]string{}, a.Status[0].Labels)
+	require.InDelta(t, 0.995, a.Status[0].Availability.Percentage, 1e-9)
+	require.InDelta(t, 0.5, a.Status[0].Budget.Remaining, 1e-9)
+
+	// Objectives without requests have no status, like in GetStatus.
+	require.Equal(t, map[string]string{"__name__": "b", "namespace": "default"}, resp.Msg.Objectives[1].Labels)
+	require.Empty(t, resp.Msg.Objectives[1].Status)
+
+	c := resp.Msg.Objectives[2]
+	require.Len(t, c.Status, 2)
+	byHandler := map[string]*objectivesv1alpha1.ObjectiveStatus{}
+	for _, status := range c.Status {
+		byHandler[status.Labels["handler"]] = status
+	}
+	require.Equal(t, map[string]string{"handler": "/"}, byHandler["/"].Labels)
+	require.Equal(t, 1.0, byHandler["/"].Availability.Percentage)
+	require.InDelta(t, 0.98, byHandler["/api"].Availability.Percentage, 1e-9)
+}
+
+// scalarPrometheus returns a scalar for every query, like a misbehaving proxy might.
+type scalarPrometheus struct{}
+
+func (scalarPrometheus) Query(_ context.Context, _ string, ts time.Time, _ ...prometheusapiv1.Option) (model.Value, prometheusapiv1.Warnings, error) {
+	return &model.Scalar{Value: 1, Timestamp: model.TimeFromUnixNano(ts.UnixNano())}, nil, nil
+}
+
+func (scalarPrometheus) QueryRange(_ context.Context, query string, _ prometheusapiv1.Range, _ ...prometheusapiv1.Option) (model.Value, prometheusapiv1.Warnings, error) {
+	return nil, nil, fmt.Errorf("unexpected range query %q", query)
+}
+
+func TestBatchGetStatusUnexpectedResult(t *testing.T) {
+	s := &objectiveServer{
+		logger:  log.NewNopLogger(),
+		promAPI: &promCache{api: scalarPrometheus{}},
+		client:  staticObjectives([]slo.Objective{batchObjective("a", "default", "api", 28*24*time.Hour)}),
+	}
+
+	_, err := s.BatchGetStatus(context.Background(), connect.NewRequest(&objectivesv1alpha1.BatchGetStatusRequest{}))
+	require.Equal(t, connect.CodeInternal, connect.CodeOf(err))
+	require.ErrorContains(t, err, "unexpected batched total result scalar")
+}
+
+// invalidObjectives lists an objective whose calendar can't be converted, like a corrupted backend might.
+type invalidObjectives struct{}
+
+func (invalidObjectives) List(_ context.Context, _ *connect.Request[objectivesv1alpha1.ListRequest]) (*connect.Response[objectivesv1alpha1.ListResponse], error) {
+	o := objectivesv1alpha1.FromInternal(batchObjective("a", "default", "api", 28*24*time.Hour))
+	o.Calendar = &objectivesv1alpha1.Calendar{Period: "fortnight", TimeZone: "UTC"}
+	return connect.NewResponse(&objectivesv1alpha1.ListResponse{Objectives: []*objectivesv1alpha1.Objective{o}}), nil
+}
+
+func TestInvalidCalendar(t *testing.T) {
+	s := &objectiveServer{
+		logger:  log.NewNopLogger(),
+		promAPI: &promCache{api: scalarPrometheus{}},
+		client:  invalidObjectives{},
+	}
+	ctx := context.Background()
+
+	_, err := s.BatchGetStatus(ctx, connect.NewRequest(&objectivesv1alpha1.BatchGetStatusRequest{}))
+	require.Equal(t, connect.CodeInternal, connect.CodeOf(err))
+	require.ErrorContains(t, err, "invalid calendar")
+
+	_, err = s.List(ctx, connect.NewRequest(&objectivesv1alpha1.ListRequest{}))
+	require.Equal(t, connect.CodeInternal, connect.CodeOf(err))
+
+	_, err = s.GetStatus(ctx, connect.NewRequest(&objectivesv1alpha1.GetStatusRequest{Expr: `{__name__="a"}`}))
+	require.Equal(t, connect.CodeInternal, connect.CodeOf(err))
+}
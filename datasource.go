@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/prometheus/client_golang/api"
+	prometheusapiv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	promconfig "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v3"
+)
+
+type datasourceKeyType string
+
+const datasourceKey datasourceKeyType = "datasource"
+
+// contextSetDatasource sends the Prometheus queries made with the context to the named datasource.
+// Queries without a datasource go to the default Prometheus.
+func contextSetDatasource(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, datasourceKey, name)
+}
+
+func contextGetDatasource(ctx context.Context) string {
+	name, _ := ctx.Value(datasourceKey).(string)
+	return name
+}
+
+// datasourcesConfig is the file passed with --datasources-file.
+type datasourcesConfig struct {
+	Datasources []datasourceConfig `yaml:"datasources"`
+}
+
+// datasourceConfig is a named Prometheus to query.
+// It authenticates with the same HTTP client options Prometheus uses for its scrape configs.
+type datasourceConfig struct {
+	Name             string                      `yaml:"name"`
+	URL              string                      `yaml:"url"`
+	HTTPClientConfig promconfig.HTTPClientConfig `yaml:",inline"`
+}
+
+func (c *datasourceConfig) UnmarshalYAML(value *yaml.Node) error {
+	*c = datasourceConfig{HTTPClientConfig: promconfig.DefaultHTTPClientConfig}
+
+	// Decoding the node directly doesn't reject unknown fields, so the node is decoded by its own decoder.
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	type plain datasourceConfig
+	return decoder.Decode((*plain)(c))
+}
+
+// loadDatasources reads the datasources file and creates a client for each of its datasources.
+func loadDatasources(path string) (map[string]api.Client, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config datasourcesConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	clients := make(map[string]api.Client, len(config.Datasources))
+	for _, ds := range config.Datasources {
+		if ds.Name == "" {
+			return nil, fmt.Errorf("datasource without name")
+		}
+		if _, ok := clients[ds.Name]; ok {
+			return nil, fmt.Errorf("datasource %s: name is not unique", ds.Name)
+		}
+		if _, err := url.ParseRequestURI(ds.URL); err != nil {
+			return nil, fmt.Errorf("datasource %s: invalid url: %w", ds.Name, err)
+		}
+
+		ds.HTTPClientConfig.SetDirectory(filepath.Dir(path))
+		if err := ds.HTTPClientConfig.Validate(); err != nil {
+			return nil, fmt.Errorf("datasource %s: %w", ds.Name, err)
+		}
+
+		roundTripper, err := promconfig.NewRoundTripperFromConfig(ds.HTTPClientConfig, "prometheus-"+ds.Name)
+		if err != nil {
+			return nil, fmt.Errorf("datasource %s: %w", ds.Name, err)
+		}
+		client, err := api.NewClient(api.Config{
+			Address:      ds.URL,
+			RoundTripper: roundTripper,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("datasource %s: %w", ds.Name, err)
+		}
+		clients[ds.Name] = newThanosClient(client)
+	}
+
+	return clients, nil
+}
+
+// datasourceRouter sends queries to the datasource set in their context.
+type datasourceRouter struct {
+	defaultAPI  prometheusAPI
+	datasources map[string]prometheusAPI
+}
+
+func (r *datasourceRouter) api(ctx context.Context) (prometheusAPI, error) {
+	name := contextGetDatasource(ctx)
+	if name == "" {
+		return r.defaultAPI, nil
+	}
+	promAPI, ok := r.datasources[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown datasource %q", name)
+	}
+	return promAPI, nil
+}
+
+func (r *datasourceRouter) Query(ctx context.Context, query string, ts time.Time, opts ...prometheusapiv1.Option) (model.Value, prometheusapiv1.Warnings, error) {
+	promAPI, err := r.api(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return promAPI.Query(ctx, query, ts, opts...)
+}
+
+func (r *datasourceRouter) QueryRange(ctx context.Context, query string, rng prometheusapiv1.Range, opts ...prometheusapiv1.Option) (model.Value, prometheusapiv1.Warnings, error) {
+	promAPI, err := r.api(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return promAPI.QueryRange(ctx, query, rng, opts...)
+}
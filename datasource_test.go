@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/go-kit/log"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	objectivesv1alpha1 "github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1"
+)
+
+func TestLoadDatasources(t *testing.T) {
+	testcases := []struct {
+		name    string
+		config  string
+		names   []string
+		invalid bool
+	}{{
+		name: "valid",
+		config: `datasources:
+- name: eu
+  url: http://prometheus-eu:9090
+  basic_auth:
+    username: pyrra
+    password: secret
+- name: us
+  url: http://thanos-us:9090
+  http_headers:
+    X-Scope-OrgID:
+      values: [tenant]
+`,
+		names: []string{"eu", "us"},
+	}, {
+		name:   "empty",
+		config: `datasources: []`,
+		names:  []string{},
+	}, {
+		name: "duplicate",
+		config: `datasources:
+- name: eu
+  url: http://prometheus-eu:9090
+- name: eu
+  url: http://prometheus-eu-2:9090
+`,
+		invalid: true,
+	}, {
+		name: "missingName",
+		config: `datasources:
+- url: http://prometheus-eu:9090
+`,
+		invalid: true,
+	}, {
+		name: "missingURL",
+		config: `datasources:
+- name: eu
+`,
+		invalid: true,
+	}, {
+		name: "unknownField",
+		config: `datasources:
+- name: eu
+  url: http://prometheus-eu:9090
+  tenant: foo
+`,
+		invalid: true,
+	}, {
+		name: "invalidAuth",
+		config: `datasources:
+- name: eu
+  url: http://prometheus-eu:9090
+  bearer_token: secret
+  basic_auth:
+    username: pyrra
+`,
+		invalid: true,
+	}}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "datasources.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.config), 0o644))
+
+			clients, err := loadDatasources(path)
+			if tc.invalid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(clients))
+			for name := range clients {
+				names = append(names, name)
+			}
+			require.ElementsMatch(t, tc.names, names)
+		})
+	}
+}
+
+func TestPromCacheDatasources(t *testing.T) {
+	cache, err := ristretto.NewCache(&ristretto.Config[string, any]{
+		NumCounters: 100,
+		MaxCost:     1 << 20,
+		BufferItems: 64,
+	})
+	require.NoError(t, err)
+	defer cache.Close()
+
+	query := `up{job="api"}`
+	promAPI := &promCache{
+		api: &datasourceRouter{
+			defaultAPI: reportPrometheus{query: {{Value: 1}}},
+			datasources: map[string]prometheusAPI{
+				"eu": reportPrometheus{query: {{Value: 2}}},
+			},
+		},
+		cache: cache,
+	}
+
+	ctx := contextSetPromCache(context.Background(), time.Minute)
+	for i := 0; i < 2; i++ {
+		value, _, err := promAPI.Query(ctx, query, time.Now())
+		require.NoError(t, err)
+		require.Equal(t, model.SampleValue(1), value.(model.Vector)[0].Value)
+
+		value, _, err = promAPI.Query(contextSetDatasource(ctx, "eu"), query, time.Now())
+		require.NoError(t, err)
+		require.Equal(t, model.SampleValue(2), value.(model.Vector)[0].Value)
+
+		// Make the first values visible in the cache for the second iteration.
+		cache.Wait()
+	}
+
+	_, _, err = promAPI.Query(contextSetDatasource(ctx, "us"), query, time.Now())
+	require.EqualError(t, err, `prometheus query: unknown datasource "us"`)
+}
+
+func TestObjectiveServer_GetStatusDatasource(t *testing.T) {
+	objective := batchObjective("a", "default", "api", 28*24*time.Hour)
+	objective.Datasource = "eu"
+
+	s := &objectiveServer{
+		logger: log.NewNopLogger(),
+		promAPI: &promCache{api: &datasourceRouter{
+			defaultAPI: reportPrometheus{},
+			datasources: map[string]prometheusAPI{
+				"eu": reportPrometheus{
+					`sum(http_requests:increase4w{job="api",slo="a"})`:             {{Value: 1000}},
+					`sum(http_requests:increase4w{code=~"5..",job="api",slo="a"})`: {{Value: 5}},
+				},
+			},
+		}},
+		client: staticObjectives{objective},
+	}
+
+	resp, err := s.GetStatus(context.Background(), connect.NewRequest(&objectivesv1alpha1.GetStatusRequest{}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Status, 1)
+	require.InDelta(t, 0.995, resp.Msg.Status[0].Availability.Percentage, 1e-9)
+
+	objective.Datasource = "us"
+	s.client = staticObjectives{objective}
+	_, err = s.GetStatus(context.Background(), connect.NewRequest(&objectivesv1alpha1.GetStatusRequest{}))
+	require.Error(t, err)
+}
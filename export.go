@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pyrra-dev/pyrra/openslo"
+)
+
+// cmdExportOpenSLO writes the config files as OpenSLO SLOs.
+// Objectives with fields OpenSLO can't represent are reported and skipped, instead of exporting a different objective.
+func cmdExportOpenSLO(logger log.Logger, configFiles, outputFolder string) int {
+	filenames, err := configFilenames(configFiles)
+	if err != nil {
+		level.Error(logger).Log("msg", "getting file names", "err", err)
+		return 1
+	}
+
+	root := configRoot(configFiles)
+
+	var failed, total int
+	for _, file := range filenames {
+		objectives, err := objectivesFromFile(file)
+		if err != nil {
+			level.Error(logger).Log("msg", "reading objectives", "file", file, "err", err)
+			failed++
+			total++
+			continue
+		}
+
+		// The SLOs of files with multiple objectives are written as documents of a single file.
+		var docs []byte
+		for _, o := range objectives {
+			total++
+			s, err := openslo.FromServiceLevelObjective(o.kube)
+			if err != nil {
+				level.Error(logger).Log("msg", "exporting objective", "file", file, "objective", o.kube.GetName(), "err", err)
+				failed++
+				continue
+			}
+
+			bytes, err := yaml.Marshal(s)
+			if err != nil {
+				level.Error(logger).Log("msg", "marshaling OpenSLO", "file", file, "err", err)
+				return 1
+			}
+			if len(docs) > 0 || outputFolder == "" {
+				docs = append(docs, "---\n"...)
+			}
+			docs = append(docs, bytes...)
+		}
+		if len(docs) == 0 {
+			continue
+		}
+
+		if outputFolder == "" {
+			fmt.Printf("%s", docs)
+			continue
+		}
+
+		path := filepath.Join(outputFolder, configName(root, file))
+		if err := os.WriteFile(path, docs, 0o644); err != nil {
+			level.Error(logger).Log("msg", "writing OpenSLO", "file", path, "err", err)
+			return 1
+		}
+	}
+
+	if failed > 0 {
+		level.Error(logger).Log("msg", "some objectives couldn't be exported", "failed", failed, "total", total)
+		return 1
+	}
+	return 0
+}
@@ -1,12 +1,20 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -16,26 +24,34 @@ import (
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/oklog/run"
+	"github.com/pmezard/go-difflib/difflib"
 	connectprometheus "github.com/polarsignals/connect-go-prometheus"
 	"github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/prometheus/client_golang/api"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/rulefmt"
 	"github.com/prometheus/prometheus/promql/parser"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/yaml"
 
 	"github.com/pyrra-dev/pyrra/kubernetes/api/v1alpha1"
+	"github.com/pyrra-dev/pyrra/openslo"
 	objectivesv1alpha1 "github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1"
 	"github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1/objectivesv1alpha1connect"
+	"github.com/pyrra-dev/pyrra/ruler"
 	"github.com/pyrra-dev/pyrra/slo"
 )
 
 type Objectives struct {
 	mu         sync.RWMutex
 	objectives map[string]slo.Objective
+	// errors are the errors of the config files whose objectives or rules are invalid.
+	errors map[string]string
 }
 
 func (os *Objectives) Set(o slo.Objective) {
@@ -44,6 +60,38 @@ func (os *Objectives) Set(o slo.Objective) {
 	os.mu.Unlock()
 }
 
+func (os *Objectives) Delete(o slo.Objective) {
+	os.mu.Lock()
+	delete(os.objectives, o.Labels.String())
+	os.mu.Unlock()
+}
+
+// SetError sets the error of a config file, or removes it if err is nil.
+func (os *Objectives) SetError(file string, err error) {
+	os.mu.Lock()
+	defer os.mu.Unlock()
+	if err == nil {
+		delete(os.errors, file)
+		return
+	}
+	if os.errors == nil {
+		os.errors = map[string]string{}
+	}
+	os.errors[file] = err.Error()
+}
+
+// Errors returns the errors of all invalid config files sorted by file.
+func (os *Objectives) Errors() []string {
+	os.mu.RLock()
+	defer os.mu.RUnlock()
+	errs := make([]string, 0, len(os.errors))
+	for file, err := range os.errors {
+		errs = append(errs, fmt.Sprintf("%s: %s", file, err))
+	}
+	sort.Strings(errs)
+	return errs
+}
+
 func (os *Objectives) Match(ms []*labels.Matcher) []slo.Objective {
 	if len(ms) == 0 {
 		os.mu.RLock()
@@ -89,7 +137,7 @@ Objectives:
 	return objectives
 }
 
-func cmdFilesystem(logger log.Logger, reg *prometheus.Registry, promClient api.Client, configFiles, prometheusFolder string, genericRules, enablePrometheus3Migration bool, pyrraExternalURL *url.URL) int {
+func cmdFilesystem(logger log.Logger, reg *prometheus.Registry, promClient api.Client, configFiles, prometheusFolder string, genericRules, enablePrometheus3Migration bool, pyrraExternalURL *url.URL, resyncInterval time.Duration, rulerClient ruler.Client) int {
 	reconcilesTotal := prometheus.NewCounter(prometheus.CounterOpts{
 		Name: "pyrra_filesystem_reconciles_total",
 		Help: "The total amount of reconciles.",
@@ -98,37 +146,30 @@ func cmdFilesystem(logger log.Logger, reg *prometheus.Registry, promClient api.C
 		Name: "pyrra_filesystem_reconciles_errors_total",
 		Help: "The total amount of errors during reconciles.",
 	})
+	fileValid := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pyrra_filesystem_file_valid",
+		Help: "Whether the objectives and generated rules of a config file are valid (1) or not (0). Invalid files keep their last valid rules.",
+	}, []string{"file"})
 
 	reg.MustRegister(
 		reconcilesTotal,
 		reconcilesErrors,
+		fileValid,
 	)
 
 	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 	objectives := &Objectives{objectives: map[string]slo.Objective{}}
 	files := make(chan string, 16)
 	reload := make(chan struct{}, 16)
 
 	var gr run.Group
 	{
-		gr.Add(func() error {
-			// Initially read all files and send them to be processed and added to the in memory store.
-			filenames, err := filepath.Glob(configFiles)
-			if err != nil {
-				return fmt.Errorf("getting files names: %w", err)
-			}
-			for _, f := range filenames {
-				files <- f
-			}
-			<-ctx.Done()
-			return nil
-		}, func(_ error) {
-			cancel()
-		})
-	}
-	{
-		dir := filepath.Dir(configFiles)
-		level.Info(logger).Log("msg", "watching directory for changes", "directory", dir)
+		dirs, err := configDirs(configFiles)
+		if err != nil {
+			level.Error(logger).Log("msg", "failed to get directories of config files", "err", err)
+			return 1
+		}
 
 		watcher, err := fsnotify.NewWatcher()
 		if err != nil {
@@ -136,12 +177,16 @@ func cmdFilesystem(logger log.Logger, reg *prometheus.Registry, promClient api.C
 			return 1
 		}
 
-		err = watcher.Add(dir)
-		if err != nil {
-			level.Error(logger).Log("msg", "failed to add directory to file watcher", "directory", dir, "err", err)
-			return 1
+		for _, dir := range dirs {
+			level.Info(logger).Log("msg", "watching directory for changes", "directory", dir)
+			if err := watcher.Add(dir); err != nil {
+				level.Error(logger).Log("msg", "failed to add directory to file watcher", "directory", dir, "err", err)
+				return 1
+			}
 		}
 
+		_, name, recursive := recursivePattern(configFiles)
+
 		gr.Add(func() error {
 			for {
 				select {
@@ -151,7 +196,18 @@ func cmdFilesystem(logger log.Logger, reg *prometheus.Registry, promClient api.C
 					if !ok {
 						continue
 					}
-					if event.Op&fsnotify.Write == fsnotify.Write {
+					if recursive && event.Has(fsnotify.Create) {
+						if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+							// Directories created in the tree are watched too, unless they are hidden.
+							// Their files are sent right away, as they might have been created before the directories were watched.
+							if !strings.HasPrefix(info.Name(), ".") {
+								watchDir(logger, watcher, filepath.Join(event.Name, "**", name), files)
+							}
+							continue
+						}
+					}
+					// Files being removed or renamed are sent too, so their objectives and rules are removed.
+					if event.Op&(fsnotify.Create|fsnotify.Write|fsnotify.Remove|fsnotify.Rename) != 0 {
 						files <- event.Name
 					}
 				case err := <-watcher.Errors:
@@ -164,40 +220,58 @@ func cmdFilesystem(logger log.Logger, reg *prometheus.Registry, promClient api.C
 		})
 	}
 	{
+		pyrraURL := ""
+		if pyrraExternalURL != nil {
+			pyrraURL = pyrraExternalURL.String()
+		}
+
+		reconciler := &fileReconciler{
+			logger:                     logger,
+			objectives:                 objectives,
+			configRoot:                 configRoot(configFiles),
+			prometheusFolder:           prometheusFolder,
+			genericRules:               genericRules,
+			enablePrometheus3Migration: enablePrometheus3Migration,
+			externalURL:                pyrraURL,
+			reconcilesTotal:            reconcilesTotal,
+			reconcilesErrors:           reconcilesErrors,
+			fileValid:                  fileValid,
+			rulerClient:                rulerClient,
+			sources:                    map[string]*fileSource{},
+			composites:                 map[string]struct{}{},
+		}
+
 		gr.Add(func() error {
+			// Initially read all files and add them to the in memory store.
+			changed, err := reconciler.resync(configFiles)
+			if err != nil {
+				return fmt.Errorf("getting files names: %w", err)
+			}
+			if changed {
+				reload <- struct{}{} // Trigger a Prometheus reload
+			}
+
+			// Watchers miss events, like the ones of files swapped by ConfigMap symlinks,
+			// so all files are compared with the store periodically too.
+			ticker := time.NewTicker(resyncInterval)
+			defer ticker.Stop()
+
 			for {
 				select {
 				case <-ctx.Done():
 					return nil
 				case f := <-files:
-					// We only care about watching for files with a valid yaml extension
-					if filepath.Ext(f) != ".yaml" && filepath.Ext(f) != ".yml" {
-						level.Warn(logger).Log("msg", "ignoring non YAML file", "file", f)
-						continue
+					if reconciler.reconcile(f) {
+						reload <- struct{}{} // Trigger a Prometheus reload
 					}
-
-					level.Debug(logger).Log("msg", "processing", "file", f)
-					reconcilesTotal.Inc()
-
-					pyrraURL := ""
-					if pyrraExternalURL != nil {
-						pyrraURL = pyrraExternalURL.String()
-					}
-
-					err := writeRuleFile(logger, f, prometheusFolder, genericRules, false, enablePrometheus3Migration, pyrraURL)
+				case <-ticker.C:
+					changed, err := reconciler.resync(configFiles)
 					if err != nil {
-						reconcilesErrors.Inc()
-						level.Error(logger).Log("msg", "error creating rule file", "file", f, "err", err)
+						level.Warn(logger).Log("msg", "failed to resync files", "err", err)
 					}
-
-					_, objective, err := objectiveFromFile(f)
-					if err != nil {
-						reconcilesErrors.Inc()
-						level.Error(logger).Log("msg", "failed to get objective from file", "file", f, "err", err)
+					if changed {
+						reload <- struct{}{} // Trigger a Prometheus reload
 					}
-					objectives.Set(objective)
-
-					reload <- struct{}{} // Trigger a Prometheus reload
 				}
 			}
 		}, func(_ error) {
@@ -212,6 +286,9 @@ func cmdFilesystem(logger log.Logger, reg *prometheus.Registry, promClient api.C
 				case <-ctx.Done():
 					return nil
 				case <-reload:
+					if rulerClient != nil {
+						continue // The rules are pushed to the ruler instead.
+					}
 					timeout := time.After(5 * time.Second)
 					for {
 						select {
@@ -278,6 +355,417 @@ func cmdFilesystem(logger log.Logger, reg *prometheus.Registry, promClient api.C
 	return 0
 }
 
+// configDirs returns the directories of the config files to watch.
+// Patterns of directory trees return all directories of the tree.
+func configDirs(pattern string) ([]string, error) {
+	root, _, ok := recursivePattern(pattern)
+	if !ok {
+		return []string{filepath.Dir(pattern)}, nil
+	}
+
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		dirs = append(dirs, path)
+		return nil
+	})
+	return dirs, err
+}
+
+// watchDir adds the directories of a new directory tree to the watcher and sends its config files.
+func watchDir(logger log.Logger, watcher *fsnotify.Watcher, pattern string, files chan<- string) {
+	dirs, err := configDirs(pattern)
+	if err != nil {
+		level.Warn(logger).Log("msg", "failed to get directories of new directory", "err", err)
+		return
+	}
+	for _, dir := range dirs {
+		level.Info(logger).Log("msg", "watching directory for changes", "directory", dir)
+		if err := watcher.Add(dir); err != nil {
+			level.Warn(logger).Log("msg", "failed to add directory to file watcher", "directory", dir, "err", err)
+		}
+	}
+
+	filenames, err := configFilenames(pattern)
+	if err != nil {
+		level.Warn(logger).Log("msg", "failed to get files of new directory", "err", err)
+		return
+	}
+	for _, f := range filenames {
+		files <- f
+	}
+}
+
+// fileSource is a config file and what was generated from it.
+type fileSource struct {
+	hash       [sha256.Size]byte
+	objectives []configObjective
+	// ruleNames are the names of the rule files of the objectives.
+	ruleNames []string
+	ruleFiles []string
+}
+
+// fileReconciler keeps the objectives in the store and their rule files in sync with the config files.
+// It is only used by a single goroutine.
+type fileReconciler struct {
+	logger                     log.Logger
+	objectives                 *Objectives
+	configRoot                 string
+	prometheusFolder           string
+	genericRules               bool
+	enablePrometheus3Migration bool
+	externalURL                string
+
+	reconcilesTotal  prometheus.Counter
+	reconcilesErrors prometheus.Counter
+	fileValid        *prometheus.GaugeVec
+
+	// rulerClient receives the rules of the rule files, if set.
+	rulerClient ruler.Client
+	// pushed are the contents of the rule files last pushed to the ruler.
+	pushed map[string][]byte
+
+	sources map[string]*fileSource
+	// Composite objectives are regenerated whenever another objective changes,
+	// as their rules depend on the objectives they select.
+	composites map[string]struct{}
+}
+
+// reconcile updates the objectives and rules of the file, or removes them if the file is gone.
+// It returns whether any rule files changed, as Prometheus only needs to be reloaded then.
+func (r *fileReconciler) reconcile(file string) bool {
+	// We only care about watching for files with a valid yaml extension
+	if filepath.Ext(file) != ".yaml" && filepath.Ext(file) != ".yml" {
+		// Directories being removed or renamed don't send events for the files in them.
+		if r.removeDir(file) {
+			return true
+		}
+		level.Warn(r.logger).Log("msg", "ignoring non YAML file", "file", file)
+		return false
+	}
+	if isTestFile(file) {
+		level.Debug(r.logger).Log("msg", "ignoring unit test file", "file", file)
+		return false
+	}
+
+	bytes, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return r.remove(file)
+	}
+
+	source, ok := r.sources[file]
+	if !ok {
+		source = &fileSource{}
+		r.sources[file] = source
+	}
+	if err == nil {
+		hash := sha256.Sum256(bytes)
+		if hash == source.hash {
+			return false
+		}
+		source.hash = hash
+	}
+
+	level.Debug(r.logger).Log("msg", "processing", "file", file)
+	r.reconcilesTotal.Inc()
+
+	if err != nil {
+		r.reconcilesErrors.Inc()
+		r.setError(file, err)
+		level.Error(r.logger).Log("msg", "failed to read file", "file", file, "err", err)
+		return false
+	}
+
+	// Invalid files keep the objectives and rules of their last valid version.
+	objectives, err := objectivesFromFile(file)
+	if err != nil {
+		r.reconcilesErrors.Inc()
+		r.setError(file, err)
+		level.Error(r.logger).Log("msg", "failed to get objectives from file", "file", file, "err", err)
+		return false
+	}
+
+	ruleNames := make([]string, 0, len(objectives))
+	var ruleFiles []string
+	for _, o := range objectives {
+		name := ruleFileName(r.configRoot, file, o.kube, len(objectives) > 1)
+		ruleNames = append(ruleNames, name)
+		ruleFiles = append(ruleFiles, ruleFilenames(name, o.objective, r.prometheusFolder)...)
+	}
+	if err := r.conflicts(file, ruleFiles); err != nil {
+		// The file is reconciled again by the next resync, as the conflicting file might have changed by then.
+		source.hash = [sha256.Size]byte{}
+		r.reconcilesErrors.Inc()
+		r.setError(file, err)
+		level.Error(r.logger).Log("msg", "conflicting rule files", "file", file, "err", err)
+		return false
+	}
+
+	for _, previous := range source.objectives {
+		if !slices.ContainsFunc(objectives, func(o configObjective) bool {
+			return labels.Equal(o.objective.Labels, previous.objective.Labels)
+		}) {
+			r.deleteObjective(file, previous.objective)
+		}
+	}
+	var composite, components bool
+	for _, o := range objectives {
+		r.objectives.Set(o.objective)
+		if o.objective.Indicator.Composite != nil {
+			composite = true
+		} else {
+			components = true
+		}
+	}
+	source.objectives = objectives
+	source.ruleNames = ruleNames
+
+	w := r.ruleWriter()
+	all := r.objectives.Match(nil)
+	var errs []error
+	for i, o := range objectives {
+		if err := writeRuleFile(r.logger, w, ruleNames[i], o.kube, o.objective, all, r.genericRules, false, r.enablePrometheus3Migration, r.externalURL); err != nil {
+			r.reconcilesErrors.Inc()
+			level.Error(r.logger).Log("msg", "error creating rule file", "file", file, "err", err)
+			errs = append(errs, err)
+		}
+	}
+	r.setError(file, errors.Join(errs...))
+	changed := len(w.changed) > 0
+
+	if len(errs) > 0 {
+		// The file is reconciled again by the next resync, as the errors might be temporary, like an unavailable ruler.
+		source.hash = [sha256.Size]byte{}
+		// Rules that failed to generate keep their last valid rule files, which are removed once the file is fixed.
+		for _, f := range ruleFiles {
+			if !slices.Contains(source.ruleFiles, f) {
+				source.ruleFiles = append(source.ruleFiles, f)
+			}
+		}
+	} else {
+		// Rule files aren't generated under the same names anymore if performanceOverAccuracy was toggled
+		// or objectives were added to or removed from the file.
+		var failed []string
+		for _, f := range source.ruleFiles {
+			if slices.Contains(ruleFiles, f) {
+				continue
+			}
+			if r.removeRuleFile(f) {
+				changed = true
+			} else {
+				failed = append(failed, f)
+			}
+		}
+		source.ruleFiles = append(ruleFiles, failed...)
+		if len(failed) > 0 {
+			// The file is reconciled again by the next resync, which retries removing the rule files.
+			source.hash = [sha256.Size]byte{}
+		}
+	}
+
+	if composite {
+		r.composites[file] = struct{}{}
+	} else {
+		delete(r.composites, file)
+	}
+	if components && r.writeComposites() {
+		changed = true
+	}
+
+	return changed
+}
+
+// conflicts returns an error if any of the rule files are already generated for the objectives of another file.
+func (r *fileReconciler) conflicts(file string, ruleFiles []string) error {
+	for i, f := range ruleFiles {
+		if slices.Contains(ruleFiles[:i], f) {
+			return fmt.Errorf("multiple objectives of the file generate the rule file %q", f)
+		}
+	}
+	for other, source := range r.sources {
+		if other == file {
+			continue
+		}
+		for _, f := range ruleFiles {
+			if slices.Contains(source.ruleFiles, f) {
+				return fmt.Errorf("rule file %q is generated for %q already", f, other)
+			}
+		}
+	}
+	return nil
+}
+
+// remove removes the objectives and rule files of a config file that is gone.
+func (r *fileReconciler) remove(file string) bool {
+	source, ok := r.sources[file]
+	if !ok {
+		return false
+	}
+
+	level.Info(r.logger).Log("msg", "removing objectives of removed file", "file", file)
+	r.reconcilesTotal.Inc()
+
+	delete(r.composites, file)
+	for _, o := range source.objectives {
+		r.deleteObjective(file, o.objective)
+	}
+	source.hash = [sha256.Size]byte{}
+	source.objectives = nil
+	source.ruleNames = nil
+
+	var failed []string
+	for _, f := range source.ruleFiles {
+		if !r.removeRuleFile(f) {
+			failed = append(failed, f)
+		}
+	}
+	_ = r.writeComposites()
+
+	if len(failed) > 0 {
+		// The source is kept with the rule files that failed to be removed,
+		// so that the next resync removes the file again and retries removing them.
+		source.ruleFiles = failed
+		return true
+	}
+	delete(r.sources, file)
+	r.fileValid.DeleteLabelValues(file)
+	r.objectives.SetError(file, nil)
+
+	return true
+}
+
+// removeDir removes the objectives and rule files of all config files in a directory that is gone.
+func (r *fileReconciler) removeDir(dir string) bool {
+	if _, err := os.Stat(dir); !errors.Is(err, fs.ErrNotExist) {
+		return false
+	}
+
+	var changed bool
+	prefix := filepath.Clean(dir) + string(filepath.Separator)
+	for f := range r.sources {
+		if strings.HasPrefix(f, prefix) && r.remove(f) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// resync reconciles all config files and removes the ones gone without an event.
+// It returns whether any rule files changed.
+func (r *fileReconciler) resync(configFiles string) (bool, error) {
+	filenames, err := configFilenames(configFiles)
+	if err != nil {
+		return false, err
+	}
+
+	var changed bool
+	found := make(map[string]struct{}, len(filenames))
+	for _, f := range filenames {
+		if filepath.Ext(f) != ".yaml" && filepath.Ext(f) != ".yml" {
+			continue
+		}
+		found[f] = struct{}{}
+	}
+	// Files that are gone are removed first, so that their rule files can be generated for other files.
+	for f := range r.sources {
+		if _, ok := found[f]; !ok && r.remove(f) {
+			changed = true
+		}
+	}
+	for _, f := range filenames {
+		if _, ok := found[f]; ok && r.reconcile(f) {
+			changed = true
+		}
+	}
+
+	return changed, nil
+}
+
+// deleteObjective deletes the objective of file from the store, unless another file defines the same objective.
+func (r *fileReconciler) deleteObjective(file string, objective slo.Objective) {
+	for f, source := range r.sources {
+		if f == file {
+			continue
+		}
+		for _, o := range source.objectives {
+			if labels.Equal(o.objective.Labels, objective.Labels) {
+				return
+			}
+		}
+	}
+	r.objectives.Delete(objective)
+}
+
+// ruleWriter returns a writer for the rule files, which pushes them to the ruler too if there is one.
+func (r *fileReconciler) ruleWriter() *ruleWriter {
+	if r.pushed == nil {
+		r.pushed = map[string][]byte{}
+	}
+	return &ruleWriter{folder: r.prometheusFolder, rulerClient: r.rulerClient, pushed: r.pushed}
+}
+
+// removeRuleFile removes the rule file and deletes its namespace from the ruler, if there is one.
+// It returns false if either failed, so that the caller keeps the rule file to retry removing it.
+func (r *fileReconciler) removeRuleFile(file string) bool {
+	if r.rulerClient != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), rulerTimeout)
+		defer cancel()
+		if err := r.rulerClient.DeleteNamespace(ctx, rulerNamespace(file)); err != nil {
+			r.reconcilesErrors.Inc()
+			level.Error(r.logger).Log("msg", "failed to delete rule file from ruler", "file", file, "err", err)
+			return false
+		}
+		delete(r.pushed, file)
+	}
+	if err := os.Remove(file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		r.reconcilesErrors.Inc()
+		level.Error(r.logger).Log("msg", "failed to remove rule file", "file", file, "err", err)
+		return false
+	}
+	level.Debug(r.logger).Log("msg", "removed rule file", "file", file)
+	return true
+}
+
+// setError updates the validity of a config file.
+func (r *fileReconciler) setError(file string, err error) {
+	valid := 1.0
+	if err != nil {
+		valid = 0
+	}
+	r.fileValid.WithLabelValues(file).Set(valid)
+	r.objectives.SetError(file, err)
+}
+
+// writeComposites regenerates the rules of all composite objectives and returns whether any of them changed.
+func (r *fileReconciler) writeComposites() bool {
+	w := r.ruleWriter()
+	all := r.objectives.Match(nil)
+	for file := range r.composites {
+		source := r.sources[file]
+		var errs []error
+		for i, o := range source.objectives {
+			if o.objective.Indicator.Composite == nil {
+				continue
+			}
+			if err := writeRuleFile(r.logger, w, source.ruleNames[i], o.kube, o.objective, all, r.genericRules, false, r.enablePrometheus3Migration, r.externalURL); err != nil {
+				r.reconcilesErrors.Inc()
+				level.Error(r.logger).Log("msg", "error creating rule file", "file", file, "err", err)
+				errs = append(errs, err)
+			}
+		}
+		r.setError(file, errors.Join(errs...))
+	}
+	return len(w.changed) > 0
+}
+
 type FilesystemObjectiveServer struct {
 	objectives *Objectives
 }
@@ -295,36 +783,55 @@ func (s *FilesystemObjectiveServer) List(_ context.Context, req *connect.Request
 	matchingObjectives := s.objectives.Match(matchers)
 	objectives := make([]*objectivesv1alpha1.Objective, 0, len(matchingObjectives))
 	for _, o := range matchingObjectives {
+		if o.Indicator.Composite != nil {
+			resolved, err := o.ResolveComposite(s.objectives.Match(nil))
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			o = resolved
+		}
 		objectives = append(objectives, objectivesv1alpha1.FromInternal(o))
 	}
 
+	// Invalid config files are reported, as their objectives are missing or outdated.
+	errs := s.objectives.Errors()
 	if len(objectives) == 0 {
+		if len(errs) > 0 {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("no objectives found, invalid config files: %s", strings.Join(errs, "; ")))
+		}
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("no objectives found"))
 	}
 
-	return connect.NewResponse(&objectivesv1alpha1.ListResponse{
-		Objectives: objectives,
-	}), nil
-}
-
-func writeRuleFile(logger log.Logger, file, prometheusFolder string, genericRules, operatorRule, enablePrometheus3Migration bool, externalURL string) error {
-	kubeObjective, objective, err := objectiveFromFile(file)
+	page, err := objectivesv1alpha1.ListPage(req.Msg, objectives)
 	if err != nil {
-		return fmt.Errorf("failed to get objective: %w", err)
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	for _, err := range errs {
+		page.Warnings = append(page.Warnings, "invalid config file "+err)
 	}
+	return connect.NewResponse(page), nil
+}
 
+// writeRuleFile generates the rules of the objective to ruleFile with the writer.
+// The objectives are the ones composite objectives select their components from.
+func writeRuleFile(logger log.Logger, w *ruleWriter, ruleFile string, kubeObjective v1alpha1.ServiceLevelObjective, objective slo.Objective, objectives []slo.Objective, genericRules, operatorRule, enablePrometheus3Migration bool, externalURL string) error {
 	warn, err := kubeObjective.ValidateCreate(context.Background(), &kubeObjective)
 	if len(warn) > 0 {
 		for _, w := range warn {
 			level.Warn(logger).Log(
 				"msg", "validation warning",
-				"file", file,
+				"objective", kubeObjective.GetName(),
 				"warning", w,
 			)
 		}
 	}
 	if err != nil {
-		return fmt.Errorf("invalid objective: %s - %w", file, err)
+		return fmt.Errorf("invalid objective: %s - %w", kubeObjective.GetName(), err)
+	}
+
+	objective, err = objective.ResolveComposite(objectives)
+	if err != nil {
+		return fmt.Errorf("failed to resolve composite objective: %s - %w", kubeObjective.GetName(), err)
 	}
 
 	opts := slo.GenerationOptions{
@@ -333,7 +840,7 @@ func writeRuleFile(logger log.Logger, file, prometheusFolder string, genericRule
 	}
 
 	if objective.PerformanceOverAccuracy {
-		return writeRuleFileSplit(logger, kubeObjective, objective, file, prometheusFolder, genericRules, operatorRule, opts)
+		return writeRuleFileSplit(logger, w, kubeObjective, objective, ruleFile, genericRules, operatorRule, opts)
 	}
 
 	increases, err := objective.IncreaseRules(opts)
@@ -365,10 +872,58 @@ func writeRuleFile(logger log.Logger, file, prometheusFolder string, genericRule
 		}
 	}
 
-	return writeRuleSpec(logger, kubeObjective, rule, file, prometheusFolder, operatorRule)
+	if len(objective.BudgetPolicy) > 0 {
+		policy, err := objective.BudgetPolicyRules(opts)
+		if err != nil {
+			return fmt.Errorf("failed to get error budget policy rules: %w", err)
+		}
+		rule.Groups = append(rule.Groups, policy)
+	}
+
+	return writeRuleSpec(logger, w, kubeObjective, rule, ruleFile, operatorRule)
+}
+
+// configName returns the name of a config file relative to the root of the config files,
+// with the directories prepended using dashes, so that files of different directories get unique names in a flat folder.
+func configName(root, file string) string {
+	rel, err := filepath.Rel(root, file)
+	if err != nil || root == "" || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.Base(file)
+	}
+	return strings.ReplaceAll(filepath.ToSlash(rel), "/", "-")
+}
+
+// ruleFileName returns the name of the rule file of an objective of a config file.
+// Files with multiple objectives get a rule file per objective, named after the objective's namespace and name.
+func ruleFileName(root, file string, kubeObjective v1alpha1.ServiceLevelObjective, multiple bool) string {
+	name := configName(root, file)
+	if !multiple {
+		return name
+	}
+	ext := filepath.Ext(name)
+	parts := []string{strings.TrimSuffix(name, ext)}
+	if ns := kubeObjective.GetNamespace(); ns != "" {
+		parts = append(parts, ns)
+	}
+	parts = append(parts, kubeObjective.GetName())
+	return strings.Join(parts, "-") + ext
+}
+
+// ruleFilenames returns the paths of the rule files writeRuleFile writes for the objective to ruleFile.
+func ruleFilenames(ruleFile string, objective slo.Objective, prometheusFolder string) []string {
+	_, f := filepath.Split(ruleFile)
+	if objective.PerformanceOverAccuracy {
+		ext := filepath.Ext(f)
+		base := strings.TrimSuffix(f, ext)
+		return []string{
+			filepath.Join(prometheusFolder, base+"-short"+ext),
+			filepath.Join(prometheusFolder, base+"-long"+ext),
+		}
+	}
+	return []string{filepath.Join(prometheusFolder, f)}
 }
 
-func writeRuleFileSplit(logger log.Logger, kubeObjective v1alpha1.ServiceLevelObjective, objective slo.Objective, file, prometheusFolder string, genericRules, operatorRule bool, opts slo.GenerationOptions) error {
+func writeRuleFileSplit(logger log.Logger, w *ruleWriter, kubeObjective v1alpha1.ServiceLevelObjective, objective slo.Objective, ruleFile string, genericRules, operatorRule bool, opts slo.GenerationOptions) error {
 	shortGroup, longGroup, err := objective.SplitIncreaseRules(opts)
 	if err != nil {
 		return fmt.Errorf("failed to get split increase rules: %w", err)
@@ -379,7 +934,7 @@ func writeRuleFileSplit(logger log.Logger, kubeObjective v1alpha1.ServiceLevelOb
 		return fmt.Errorf("failed to get burn rate rules: %w", err)
 	}
 
-	_, f := filepath.Split(file)
+	_, f := filepath.Split(ruleFile)
 	ext := filepath.Ext(f)
 	base := strings.TrimSuffix(f, ext)
 
@@ -404,7 +959,7 @@ func writeRuleFileSplit(logger log.Logger, kubeObjective v1alpha1.ServiceLevelOb
 	}
 
 	shortFile := base + "-short" + ext
-	if err := writeRuleSpec(logger, kubeObjective, shortSpec, shortFile, prometheusFolder, operatorRule); err != nil {
+	if err := writeRuleSpec(logger, w, kubeObjective, shortSpec, shortFile, operatorRule); err != nil {
 		return fmt.Errorf("failed to write short rules: %w", err)
 	}
 
@@ -413,16 +968,30 @@ func writeRuleFileSplit(logger log.Logger, kubeObjective v1alpha1.ServiceLevelOb
 		Groups: []monitoringv1.RuleGroup{longGroup},
 	}
 
+	if len(objective.BudgetPolicy) > 0 {
+		policy, err := objective.BudgetPolicyRules(opts)
+		if err != nil {
+			return fmt.Errorf("failed to get error budget policy rules: %w", err)
+		}
+		longSpec.Groups = append(longSpec.Groups, policy)
+	}
+
 	longFile := base + "-long" + ext
-	return writeRuleSpec(logger, kubeObjective, longSpec, longFile, prometheusFolder, operatorRule)
+	return writeRuleSpec(logger, w, kubeObjective, longSpec, longFile, operatorRule)
 }
 
-func writeRuleSpec(_ log.Logger, kubeObjective v1alpha1.ServiceLevelObjective, rule monitoringv1.PrometheusRuleSpec, file, prometheusFolder string, operatorRule bool) error {
+func writeRuleSpec(_ log.Logger, w *ruleWriter, kubeObjective v1alpha1.ServiceLevelObjective, rule monitoringv1.PrometheusRuleSpec, file string, operatorRule bool) error {
 	bytes, err := yaml.Marshal(rule)
 	if err != nil {
 		return fmt.Errorf("failed to marshal rules: %w", err)
 	}
 
+	// Rules are validated like Prometheus does when loading rule files, including parsing their PromQL expressions,
+	// as Prometheus rejects reloads with any invalid rule file. Invalid rules aren't written, keeping the last valid ones.
+	if _, errs := rulefmt.Parse(bytes, false, model.UTF8Validation); len(errs) > 0 {
+		return fmt.Errorf("invalid rules for %q: %w", file, errors.Join(errs...))
+	}
+
 	if operatorRule {
 		monv1rule := &monitoringv1.PrometheusRule{
 			TypeMeta: metav1.TypeMeta{
@@ -443,30 +1012,263 @@ func writeRuleSpec(_ log.Logger, kubeObjective v1alpha1.ServiceLevelObjective, r
 		}
 	}
 
+	return w.write(file, bytes)
+}
+
+// ruleWriter writes rule files to a folder.
+// Files are replaced atomically, so that Prometheus never reads partially written rules,
+// and files whose content didn't change aren't written at all.
+type ruleWriter struct {
+	folder string
+	// dryRun skips writing the files.
+	dryRun bool
+	// diff receives a unified diff of every file that changes, if set.
+	diff io.Writer
+	// rulerClient receives the rule groups of every file that changes, if set.
+	// The rule groups of a file are kept in a namespace of the ruler named after the file.
+	rulerClient ruler.Client
+	// pushed are the contents of the files last pushed to the ruler.
+	pushed map[string][]byte
+
+	// changed are the paths of the files that changed.
+	changed []string
+}
+
+func (w *ruleWriter) write(file string, content []byte) error {
 	_, f := filepath.Split(file)
-	path := filepath.Join(prometheusFolder, f)
+	path := filepath.Join(w.folder, f)
+
+	current, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read file %q: %w", path, err)
+	}
+
+	// Files are pushed before they are written, so that files failing to push are retried.
+	if w.rulerClient != nil && !w.dryRun {
+		// Files that weren't pushed since starting are pushed even if they didn't change, as the ruler might not have them.
+		// Their groups were pushed before restarting, so the ones that are gone are deleted.
+		previous, ok := w.pushed[path]
+		if !ok {
+			previous = current
+		}
+		if !ok || !bytes.Equal(previous, content) {
+			if err := w.push(path, previous, content); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err == nil && bytes.Equal(current, content) {
+		return nil
+	}
+	w.changed = append(w.changed, path)
+
+	if w.diff != nil {
+		from := path
+		if err != nil {
+			from = "/dev/null"
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        diffLines(current),
+			B:        diffLines(content),
+			FromFile: from,
+			ToFile:   path,
+			Context:  3,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to diff file %q: %w", path, err)
+		}
+		if _, err := io.WriteString(w.diff, diff); err != nil {
+			return fmt.Errorf("failed to write diff of file %q: %w", path, err)
+		}
+	}
 
-	if err := os.WriteFile(path, bytes, 0o644); err != nil {
+	if w.dryRun {
+		return nil
+	}
+	if err := writeFileAtomic(path, content, 0o644); err != nil {
 		return fmt.Errorf("failed to write file %q: %w", path, err)
 	}
 	return nil
 }
 
-func objectiveFromFile(file string) (v1alpha1.ServiceLevelObjective, slo.Objective, error) {
+// rulerTimeout is the timeout of the requests to the ruler for a single rule file.
+const rulerTimeout = 30 * time.Second
+
+// push sets the rule groups of the content in the ruler and deletes the ones of the previous content that are gone.
+func (w *ruleWriter) push(path string, previous, content []byte) error {
+	groups, errs := rulefmt.Parse(content, false, model.UTF8Validation)
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid rules for %q: %w", path, errors.Join(errs...))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), rulerTimeout)
+	defer cancel()
+
+	namespace := rulerNamespace(path)
+	for _, group := range groups.Groups {
+		if err := w.rulerClient.SetRuleGroup(ctx, namespace, group); err != nil {
+			return fmt.Errorf("failed to push rule group %q of %q to ruler: %w", group.Name, path, err)
+		}
+	}
+
+	// Previous contents that don't parse, like empty ones, have no groups to delete.
+	if previousGroups, errs := rulefmt.Parse(previous, false, model.UTF8Validation); len(errs) == 0 {
+		for _, group := range previousGroups.Groups {
+			if slices.ContainsFunc(groups.Groups, func(g rulefmt.RuleGroup) bool { return g.Name == group.Name }) {
+				continue
+			}
+			if err := w.rulerClient.DeleteRuleGroup(ctx, namespace, group.Name); err != nil {
+				return fmt.Errorf("failed to delete rule group %q of %q from ruler: %w", group.Name, path, err)
+			}
+		}
+	}
+
+	w.pushed[path] = content
+	return nil
+}
+
+// rulerNamespace returns the namespace of the ruler the rule groups of a rule file are pushed to.
+func rulerNamespace(file string) string {
+	base := filepath.Base(file)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// diffLines splits the content into lines keeping their line breaks.
+// Unlike difflib.SplitLines it doesn't add an empty last line.
+func diffLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// writeFileAtomic writes the data to a hidden temporary file next to the file and renames it to the file.
+// The temporary file doesn't match the rule file patterns of Prometheus, as it starts with a dot and ends with .tmp.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // Fails once the file was renamed.
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// configObjective is an objective of a config file.
+type configObjective struct {
+	kube      v1alpha1.ServiceLevelObjective
+	objective slo.Objective
+}
+
+// objectivesFromFile reads all objectives of a config file.
+// Files may hold several documents separated by ---, and documents may be Lists of objectives.
+func objectivesFromFile(file string) ([]configObjective, error) {
 	bytes, err := os.ReadFile(file)
 	if err != nil {
-		return v1alpha1.ServiceLevelObjective{}, slo.Objective{}, fmt.Errorf("failed to read file %q: %w", file, err)
+		return nil, fmt.Errorf("failed to read file %q: %w", file, err)
 	}
 
-	var config v1alpha1.ServiceLevelObjective
-	if err := yaml.UnmarshalStrict(bytes, &config); err != nil {
-		return v1alpha1.ServiceLevelObjective{}, slo.Objective{}, fmt.Errorf("failed to unmarshal objective %q: %w", file, err)
+	var configs []v1alpha1.ServiceLevelObjective
+	if openslo.IsOpenSLO(bytes) {
+		configs, err = openSLOFromBytes(bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert OpenSLO objectives %q: %w", file, err)
+		}
+	} else {
+		configs, err = kubeObjectivesFromBytes(bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal objectives %q: %w", file, err)
+		}
+	}
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("no objectives in %q", file)
 	}
 
-	objective, err := config.Internal()
+	objectives := make([]configObjective, 0, len(configs))
+	for _, config := range configs {
+		objective, err := config.Internal()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get objective %q of %q: %w", config.GetName(), file, err)
+		}
+		objectives = append(objectives, configObjective{kube: config, objective: objective})
+	}
+	return objectives, nil
+}
+
+// objectiveFromFile reads the objective of a config file with a single objective.
+func objectiveFromFile(file string) (v1alpha1.ServiceLevelObjective, slo.Objective, error) {
+	objectives, err := objectivesFromFile(file)
 	if err != nil {
-		return v1alpha1.ServiceLevelObjective{}, slo.Objective{}, fmt.Errorf("failed to get objective %q: %w", file, err)
+		return v1alpha1.ServiceLevelObjective{}, slo.Objective{}, err
 	}
+	if len(objectives) != 1 {
+		return v1alpha1.ServiceLevelObjective{}, slo.Objective{}, fmt.Errorf("expected one objective in %q, got %d", file, len(objectives))
+	}
+	return objectives[0].kube, objectives[0].objective, nil
+}
+
+// kubeObjectivesFromBytes unmarshals the ServiceLevelObjectives of all documents.
+// Documents of kind List or ServiceLevelObjectiveList hold several objectives as their items.
+func kubeObjectivesFromBytes(data []byte) ([]v1alpha1.ServiceLevelObjective, error) {
+	var objectives []v1alpha1.ServiceLevelObjective
+
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for i := 0; ; i++ {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objectives, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read document %d: %w", i, err)
+		}
+
+		// Documents without content, like the one before a leading --- or ones with only comments, are skipped.
+		var header *metav1.TypeMeta
+		if err := yaml.Unmarshal(doc, &header); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal document %d: %w", i, err)
+		}
+		if header == nil {
+			continue
+		}
 
-	return config, objective, nil
+		if header.Kind == "List" || header.Kind == "ServiceLevelObjectiveList" {
+			var list v1alpha1.ServiceLevelObjectiveList
+			if err := yaml.UnmarshalStrict(doc, &list); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal document %d: %w", i, err)
+			}
+			objectives = append(objectives, list.Items...)
+			continue
+		}
+
+		var objective v1alpha1.ServiceLevelObjective
+		if err := yaml.UnmarshalStrict(doc, &objective); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal document %d: %w", i, err)
+		}
+		objectives = append(objectives, objective)
+	}
+}
+
+// openSLOFromBytes converts the SLOs of OpenSLO documents to ServiceLevelObjectives.
+func openSLOFromBytes(bytes []byte) ([]v1alpha1.ServiceLevelObjective, error) {
+	docs, err := openslo.Parse(bytes)
+	if err != nil {
+		return nil, err
+	}
+	return docs.ServiceLevelObjectives()
 }
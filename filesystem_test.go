@@ -1,11 +1,33 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 	"testing"
 
+	connect "connectrpc.com/connect"
+	"github.com/go-kit/log"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/rulefmt"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
+	"github.com/pyrra-dev/pyrra/kubernetes/api/v1alpha1"
+	objectivesv1alpha1 "github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1"
+	"github.com/pyrra-dev/pyrra/ruler"
 	"github.com/pyrra-dev/pyrra/slo"
 )
 
@@ -56,3 +78,580 @@ func TestMatchObjectives(t *testing.T) {
 	require.Contains(t, matches, obj3)
 	require.Contains(t, matches, obj4)
 }
+
+func reconcileObjective(name string, performanceOverAccuracy bool) string {
+	return fmt.Sprintf(`apiVersion: pyrra.dev/v1alpha1
+kind: ServiceLevelObjective
+metadata:
+  name: %s
+  namespace: monitoring
+spec:
+  target: '99'
+  window: 1w
+  performanceOverAccuracy: %t
+  indicator:
+    ratio:
+      errors:
+        metric: http_requests_total{job="%[1]s",code=~"5.."}
+      total:
+        metric: http_requests_total{job="%[1]s"}
+`, name, performanceOverAccuracy)
+}
+
+func TestFileReconciler(t *testing.T) {
+	configDir := t.TempDir()
+	prometheusFolder := t.TempDir()
+
+	r := &fileReconciler{
+		logger:           log.NewNopLogger(),
+		objectives:       &Objectives{objectives: map[string]slo.Objective{}},
+		prometheusFolder: prometheusFolder,
+		reconcilesTotal:  prometheus.NewCounter(prometheus.CounterOpts{Name: "reconciles_total"}),
+		reconcilesErrors: prometheus.NewCounter(prometheus.CounterOpts{Name: "reconciles_errors_total"}),
+		fileValid:        prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "file_valid"}, []string{"file"}),
+		sources:          map[string]*fileSource{},
+		composites:       map[string]struct{}{},
+	}
+
+	write := func(t *testing.T, file, content string) string {
+		t.Helper()
+		path := filepath.Join(configDir, file)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+	state := func(t *testing.T) ([]string, []string) {
+		t.Helper()
+		var names []string
+		for _, o := range r.objectives.Match(nil) {
+			names = append(names, o.Name())
+		}
+		sort.Strings(names)
+
+		entries, err := os.ReadDir(prometheusFolder)
+		require.NoError(t, err)
+		var files []string
+		for _, e := range entries {
+			files = append(files, e.Name())
+		}
+		return names, files
+	}
+
+	api := write(t, "api.yaml", reconcileObjective("api", false))
+	require.True(t, r.reconcile(api))
+	web := write(t, "web.yaml", reconcileObjective("web", false))
+	require.True(t, r.reconcile(web))
+
+	objectives, files := state(t)
+	require.Equal(t, []string{"api", "web"}, objectives)
+	require.Equal(t, []string{"api.yaml", "web.yaml"}, files)
+
+	// Events of unchanged files don't regenerate their rules.
+	require.False(t, r.reconcile(api))
+
+	// Toggling performanceOverAccuracy replaces the rule file by the split ones.
+	write(t, "api.yaml", reconcileObjective("api", true))
+	require.True(t, r.reconcile(api))
+	_, files = state(t)
+	require.Equal(t, []string{"api-long.yaml", "api-short.yaml", "web.yaml"}, files)
+
+	// Changing the name of a file's objective removes the previous one.
+	write(t, "web.yaml", reconcileObjective("frontend", false))
+	require.True(t, r.reconcile(web))
+	objectives, _ = state(t)
+	require.Equal(t, []string{"api", "frontend"}, objectives)
+
+	// Invalid files keep their last valid objective and rules.
+	write(t, "web.yaml", "invalid: [")
+	require.False(t, r.reconcile(web))
+	objectives, files = state(t)
+	require.Equal(t, []string{"api", "frontend"}, objectives)
+	require.Equal(t, []string{"api-long.yaml", "api-short.yaml", "web.yaml"}, files)
+
+	// Removed and renamed files lose their objectives and rules.
+	require.NoError(t, os.Rename(api, filepath.Join(configDir, "backend.yaml")))
+	require.True(t, r.reconcile(api))
+	require.True(t, r.reconcile(filepath.Join(configDir, "backend.yaml")))
+	require.NoError(t, os.Remove(web))
+	require.True(t, r.reconcile(web))
+	require.False(t, r.reconcile(web))
+
+	objectives, files = state(t)
+	require.Equal(t, []string{"api"}, objectives)
+	require.Equal(t, []string{"backend-long.yaml", "backend-short.yaml"}, files)
+
+	// Resyncs find changes without events.
+	write(t, "web.yaml", reconcileObjective("web", false))
+	require.NoError(t, os.Remove(filepath.Join(configDir, "backend.yaml")))
+	write(t, "readme.md", "not an objective")
+
+	changed, err := r.resync(filepath.Join(configDir, "*"))
+	require.NoError(t, err)
+	require.True(t, changed)
+	objectives, files = state(t)
+	require.Equal(t, []string{"web"}, objectives)
+	require.Equal(t, []string{"web.yaml"}, files)
+
+	changed, err = r.resync(filepath.Join(configDir, "*"))
+	require.NoError(t, err)
+	require.False(t, changed)
+}
+
+func TestObjectivesFromFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(t *testing.T, content string) string {
+		t.Helper()
+		path := filepath.Join(dir, "objectives.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+	names := func(t *testing.T, file string) []string {
+		t.Helper()
+		objectives, err := objectivesFromFile(file)
+		require.NoError(t, err)
+		var names []string
+		for _, o := range objectives {
+			names = append(names, o.objective.Name())
+		}
+		return names
+	}
+
+	require.Equal(t, []string{"api"}, names(t, write(t, reconcileObjective("api", false))))
+	require.Equal(t, []string{"api", "web"}, names(t, write(t,
+		"---\n"+reconcileObjective("api", false)+"---\n# Only a comment\n---\n"+reconcileObjective("web", false),
+	)))
+
+	list := `apiVersion: v1
+kind: List
+items:
+`
+	for _, name := range []string{"api", "web"} {
+		for _, line := range strings.Split(strings.TrimSpace(reconcileObjective(name, false)), "\n") {
+			if strings.HasPrefix(line, "apiVersion") {
+				list += "- " + line + "\n"
+			} else {
+				list += "  " + line + "\n"
+			}
+		}
+	}
+	require.Equal(t, []string{"api", "web", "search"}, names(t, write(t, list+"---\n"+reconcileObjective("search", false))))
+	require.Equal(t, []string{"api", "web"}, names(t, write(t, strings.Replace(list, "kind: List", "kind: ServiceLevelObjectiveList", 1))))
+
+	// Single objective files are still required where only one objective makes sense.
+	_, _, err := objectiveFromFile(write(t, list))
+	require.ErrorContains(t, err, "expected one objective")
+
+	for name, content := range map[string]string{
+		"empty":        "# No objectives\n",
+		"unknownField": reconcileObjective("api", false) + "unknown: true\n",
+		"invalidList":  "kind: List\nitems: {}\n",
+		"invalidItem":  strings.Replace(list, "target: '99'", "target: '9a9'", 1),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := objectivesFromFile(write(t, content))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestRuleFileName(t *testing.T) {
+	objective := v1alpha1.ServiceLevelObjective{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "monitoring"}}
+
+	require.Equal(t, "api.yaml", ruleFileName("/etc/pyrra", "/etc/pyrra/api.yaml", objective, false))
+	require.Equal(t, "payments-checkout-api.yaml", ruleFileName("/etc/pyrra", "/etc/pyrra/payments/checkout/api.yaml", objective, false))
+	require.Equal(t, "payments-slos-monitoring-api.yaml", ruleFileName("/etc/pyrra", "/etc/pyrra/payments/slos.yaml", objective, true))
+	require.Equal(t, "slos-api.yaml", ruleFileName("/etc/pyrra", "/etc/pyrra/slos.yaml", v1alpha1.ServiceLevelObjective{ObjectMeta: metav1.ObjectMeta{Name: "api"}}, true))
+	// Files outside of the root keep their base name.
+	require.Equal(t, "api.yaml", ruleFileName("/etc/*", "/etc/pyrra/api.yaml", objective, false))
+	require.Equal(t, "api.yaml", ruleFileName("", "/etc/pyrra/api.yaml", objective, false))
+}
+
+func TestFileReconcilerDirectoryTree(t *testing.T) {
+	configDir := t.TempDir()
+	prometheusFolder := t.TempDir()
+
+	r := &fileReconciler{
+		logger:           log.NewNopLogger(),
+		objectives:       &Objectives{objectives: map[string]slo.Objective{}},
+		configRoot:       configDir,
+		prometheusFolder: prometheusFolder,
+		reconcilesTotal:  prometheus.NewCounter(prometheus.CounterOpts{Name: "reconciles_total"}),
+		reconcilesErrors: prometheus.NewCounter(prometheus.CounterOpts{Name: "reconciles_errors_total"}),
+		fileValid:        prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "file_valid"}, []string{"file"}),
+		sources:          map[string]*fileSource{},
+		composites:       map[string]struct{}{},
+	}
+
+	write := func(t *testing.T, file, content string) string {
+		t.Helper()
+		path := filepath.Join(configDir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+	state := func(t *testing.T) ([]string, []string) {
+		t.Helper()
+		var names []string
+		for _, o := range r.objectives.Match(nil) {
+			names = append(names, o.Name())
+		}
+		sort.Strings(names)
+
+		entries, err := os.ReadDir(prometheusFolder)
+		require.NoError(t, err)
+		var files []string
+		for _, e := range entries {
+			files = append(files, e.Name())
+		}
+		return names, files
+	}
+
+	pattern := filepath.Join(configDir, "**", "*.yaml")
+	write(t, "api.yaml", reconcileObjective("api", false))
+	write(t, "payments/api.yaml", reconcileObjective("payments", false))
+	write(t, "search/slos.yaml", reconcileObjective("search", false)+"---\n"+reconcileObjective("suggest", true))
+	write(t, ".hidden/api.yaml", reconcileObjective("hidden", false))
+
+	changed, err := r.resync(pattern)
+	require.NoError(t, err)
+	require.True(t, changed)
+	objectives, files := state(t)
+	require.Equal(t, []string{"api", "payments", "search", "suggest"}, objectives)
+	require.Equal(t, []string{
+		"api.yaml",
+		"payments-api.yaml",
+		"search-slos-monitoring-search.yaml",
+		"search-slos-monitoring-suggest-long.yaml",
+		"search-slos-monitoring-suggest-short.yaml",
+	}, files)
+
+	// Files generating the rule files of another file are rejected until the conflict is resolved.
+	conflicting := write(t, "payments-api.yaml", reconcileObjective("checkout", false))
+	require.False(t, r.reconcile(conflicting))
+	objectives, _ = state(t)
+	require.NotContains(t, objectives, "checkout")
+	require.Equal(t, 0.0, testutil.ToFloat64(r.fileValid.WithLabelValues(conflicting)))
+	require.Len(t, r.objectives.Errors(), 1)
+	require.Contains(t, r.objectives.Errors()[0], conflicting+": rule file")
+
+	require.NoError(t, os.Remove(filepath.Join(configDir, "payments", "api.yaml")))
+	changed, err = r.resync(pattern)
+	require.NoError(t, err)
+	require.True(t, changed)
+	objectives, _ = state(t)
+	require.Equal(t, []string{"api", "checkout", "search", "suggest"}, objectives)
+	require.Equal(t, 1.0, testutil.ToFloat64(r.fileValid.WithLabelValues(conflicting)))
+	require.Empty(t, r.objectives.Errors())
+
+	// Removing objectives from a file removes their rule files.
+	search := write(t, "search/slos.yaml", reconcileObjective("search", false))
+	require.True(t, r.reconcile(search))
+	objectives, files = state(t)
+	require.Equal(t, []string{"api", "checkout", "search"}, objectives)
+	require.Equal(t, []string{"api.yaml", "payments-api.yaml", "search-slos.yaml"}, files)
+
+	// Removed directories remove the objectives of all their files.
+	require.NoError(t, os.RemoveAll(filepath.Join(configDir, "search")))
+	require.True(t, r.reconcile(filepath.Join(configDir, "search")))
+	objectives, files = state(t)
+	require.Equal(t, []string{"api", "checkout"}, objectives)
+	require.Equal(t, []string{"api.yaml", "payments-api.yaml"}, files)
+}
+
+func TestRuleWriter(t *testing.T) {
+	folder := t.TempDir()
+	path := filepath.Join(folder, "api.yaml")
+
+	w := &ruleWriter{folder: folder}
+	require.NoError(t, w.write("api.yaml", []byte("groups: []\n")))
+	require.Equal(t, []string{path}, w.changed)
+
+	// Unchanged files aren't written again.
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	w = &ruleWriter{folder: folder}
+	require.NoError(t, w.write("api.yaml", []byte("groups: []\n")))
+	require.Empty(t, w.changed)
+	unchanged, err := os.Stat(path)
+	require.NoError(t, err)
+	require.True(t, os.SameFile(info, unchanged))
+
+	var diff strings.Builder
+	w = &ruleWriter{folder: folder, dryRun: true, diff: &diff}
+	require.NoError(t, w.write("api.yaml", []byte("groups:\n- name: api\n")))
+	require.NoError(t, w.write("web.yaml", []byte("groups: []\n")))
+	require.Equal(t, []string{path, filepath.Join(folder, "web.yaml")}, w.changed)
+	require.Equal(t, fmt.Sprintf(`--- %[1]s/api.yaml
++++ %[1]s/api.yaml
+@@ -1 +1,2 @@
+-groups: []
++groups:
++- name: api
+--- /dev/null
++++ %[1]s/web.yaml
+@@ -0,0 +1 @@
++groups: []
+`, folder), diff.String())
+
+	// Dry runs don't write any files.
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "groups: []\n", string(content))
+
+	w = &ruleWriter{folder: folder}
+	require.NoError(t, w.write("api.yaml", []byte("groups:\n- name: api\n")))
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "groups:\n- name: api\n", string(content))
+
+	// No temporary files are left behind.
+	entries, err := os.ReadDir(folder)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestWriteRuleSpecInvalid(t *testing.T) {
+	folder := t.TempDir()
+	path := filepath.Join(folder, "api.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("groups: []\n"), 0o644))
+
+	w := &ruleWriter{folder: folder}
+	rule := monitoringv1.PrometheusRuleSpec{Groups: []monitoringv1.RuleGroup{{
+		Name:  "api",
+		Rules: []monitoringv1.Rule{{Record: "http_requests:rate5m", Expr: intstr.FromString("sum(rate(http_requests_total[5m])")}},
+	}}}
+	err := writeRuleSpec(log.NewNopLogger(), w, v1alpha1.ServiceLevelObjective{}, rule, "api.yaml", false)
+	require.ErrorContains(t, err, "could not parse expression")
+	require.Empty(t, w.changed)
+
+	// The last valid rules are kept.
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "groups: []\n", string(content))
+
+	rule.Groups[0].Rules[0].Expr = intstr.FromString("sum(rate(http_requests_total[5m]))")
+	require.NoError(t, writeRuleSpec(log.NewNopLogger(), w, v1alpha1.ServiceLevelObjective{}, rule, "api.yaml", false))
+	require.Equal(t, []string{path}, w.changed)
+}
+
+func TestFilesystemObjectiveServerErrors(t *testing.T) {
+	objectives := &Objectives{objectives: map[string]slo.Objective{}}
+	s := &FilesystemObjectiveServer{objectives: objectives}
+
+	objectives.SetError("/etc/pyrra/web.yaml", errors.New("invalid rules"))
+	_, err := s.List(context.Background(), connect.NewRequest(&objectivesv1alpha1.ListRequest{}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	require.ErrorContains(t, err, "/etc/pyrra/web.yaml: invalid rules")
+
+	objectives.Set(slo.Objective{Labels: labels.FromStrings(labels.MetricName, "api")})
+	resp, err := s.List(context.Background(), connect.NewRequest(&objectivesv1alpha1.ListRequest{}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Objectives, 1)
+	require.Equal(t, []string{"invalid config file /etc/pyrra/web.yaml: invalid rules"}, resp.Msg.Warnings)
+
+	objectives.SetError("/etc/pyrra/web.yaml", nil)
+	resp, err = s.List(context.Background(), connect.NewRequest(&objectivesv1alpha1.ListRequest{}))
+	require.NoError(t, err)
+	require.Empty(t, resp.Msg.Warnings)
+}
+
+// fakeRuler keeps the names of the rule groups pushed to it by namespace.
+type fakeRuler struct {
+	namespaces map[string][]string
+	pushes     int
+	err        error
+}
+
+func (f *fakeRuler) Ready(context.Context) error { return nil }
+
+func (f *fakeRuler) SetRuleGroup(_ context.Context, namespace string, ruleGroup rulefmt.RuleGroup) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.pushes++
+	if !slices.Contains(f.namespaces[namespace], ruleGroup.Name) {
+		f.namespaces[namespace] = append(f.namespaces[namespace], ruleGroup.Name)
+	}
+	return nil
+}
+
+func (f *fakeRuler) DeleteRuleGroup(_ context.Context, namespace, ruleGroup string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.namespaces[namespace] = slices.DeleteFunc(f.namespaces[namespace], func(g string) bool { return g == ruleGroup })
+	if len(f.namespaces[namespace]) == 0 {
+		delete(f.namespaces, namespace)
+	}
+	return nil
+}
+
+func (f *fakeRuler) DeleteNamespace(_ context.Context, namespace string) error {
+	if f.err != nil {
+		return f.err
+	}
+	delete(f.namespaces, namespace)
+	return nil
+}
+
+func (f *fakeRuler) names() []string {
+	names := make([]string, 0, len(f.namespaces))
+	for ns := range f.namespaces {
+		names = append(names, ns)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestRuleWriterRuler(t *testing.T) {
+	folder := t.TempDir()
+	fake := &fakeRuler{namespaces: map[string][]string{}}
+	pushed := map[string][]byte{}
+
+	w := &ruleWriter{folder: folder, rulerClient: fake, pushed: pushed}
+	require.NoError(t, w.write("api.yaml", []byte("groups:\n- name: a\n  rules: []\n- name: b\n  rules: []\n")))
+	require.Equal(t, map[string][]string{"api": {"a", "b"}}, fake.namespaces)
+
+	// Groups that are gone are deleted from the ruler.
+	w = &ruleWriter{folder: folder, rulerClient: fake, pushed: pushed}
+	require.NoError(t, w.write("api.yaml", []byte("groups:\n- name: a\n  rules: []\n")))
+	require.Equal(t, map[string][]string{"api": {"a"}}, fake.namespaces)
+	require.Equal(t, 3, fake.pushes)
+
+	// Unchanged files aren't pushed again.
+	require.NoError(t, w.write("api.yaml", []byte("groups:\n- name: a\n  rules: []\n")))
+	require.Equal(t, 3, fake.pushes)
+
+	// Files that weren't pushed since starting are, and the groups of the file on disk that are gone are deleted.
+	fake.namespaces["api"] = []string{"a", "c"}
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "api.yaml"), []byte("groups:\n- name: a\n  rules: []\n- name: c\n  rules: []\n"), 0o644))
+	w = &ruleWriter{folder: folder, rulerClient: fake, pushed: map[string][]byte{}}
+	require.NoError(t, w.write("api.yaml", []byte("groups:\n- name: a\n  rules: []\n")))
+	require.Equal(t, map[string][]string{"api": {"a"}}, fake.namespaces)
+	require.Equal(t, 4, fake.pushes)
+
+	// Files failing to push aren't written.
+	fake.err = errors.New("unavailable")
+	w = &ruleWriter{folder: folder, rulerClient: fake, pushed: pushed}
+	require.ErrorContains(t, w.write("web.yaml", []byte("groups:\n- name: web\n  rules: []\n")), "unavailable")
+	require.Empty(t, w.changed)
+	require.NoFileExists(t, filepath.Join(folder, "web.yaml"))
+}
+
+func TestFileReconcilerRuler(t *testing.T) {
+	configDir := t.TempDir()
+	fake := &fakeRuler{namespaces: map[string][]string{}}
+
+	r := &fileReconciler{
+		logger:           log.NewNopLogger(),
+		objectives:       &Objectives{objectives: map[string]slo.Objective{}},
+		prometheusFolder: t.TempDir(),
+		reconcilesTotal:  prometheus.NewCounter(prometheus.CounterOpts{Name: "reconciles_total"}),
+		reconcilesErrors: prometheus.NewCounter(prometheus.CounterOpts{Name: "reconciles_errors_total"}),
+		fileValid:        prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "file_valid"}, []string{"file"}),
+		rulerClient:      fake,
+		sources:          map[string]*fileSource{},
+		composites:       map[string]struct{}{},
+	}
+
+	write := func(t *testing.T, file, content string) string {
+		t.Helper()
+		path := filepath.Join(configDir, file)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+
+	api := write(t, "api.yaml", reconcileObjective("api", false))
+	require.True(t, r.reconcile(api))
+	require.Equal(t, []string{"api"}, fake.names())
+
+	// Split rule files are pushed to a namespace each and the namespace of the previous rule file is deleted.
+	write(t, "api.yaml", reconcileObjective("api", true))
+	require.True(t, r.reconcile(api))
+	require.Equal(t, []string{"api-long", "api-short"}, fake.names())
+
+	// Files failing to push are reconciled again, even if they didn't change.
+	fake.err = errors.New("unavailable")
+	web := write(t, "web.yaml", reconcileObjective("web", false))
+	require.False(t, r.reconcile(web))
+	require.Equal(t, 0.0, testutil.ToFloat64(r.fileValid.WithLabelValues(web)))
+	fake.err = nil
+	require.True(t, r.reconcile(web))
+	require.Equal(t, 1.0, testutil.ToFloat64(r.fileValid.WithLabelValues(web)))
+	require.Equal(t, []string{"api-long", "api-short", "web"}, fake.names())
+
+	// Removed files remove their namespaces.
+	require.NoError(t, os.Remove(api))
+	require.True(t, r.reconcile(api))
+	require.Equal(t, []string{"web"}, fake.names())
+}
+
+func TestFileReconcilerRulerDeleteRetry(t *testing.T) {
+	configDir := t.TempDir()
+	prometheusFolder := t.TempDir()
+
+	var failDelete bool
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodDelete {
+			if failDelete {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			deleted = append(deleted, path.Base(req.URL.Path))
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	rulerClient, err := ruler.NewClient(ruler.Config{Kind: ruler.KindCortex, Address: server.URL})
+	require.NoError(t, err)
+
+	r := &fileReconciler{
+		logger:           log.NewNopLogger(),
+		objectives:       &Objectives{objectives: map[string]slo.Objective{}},
+		prometheusFolder: prometheusFolder,
+		reconcilesTotal:  prometheus.NewCounter(prometheus.CounterOpts{Name: "reconciles_total"}),
+		reconcilesErrors: prometheus.NewCounter(prometheus.CounterOpts{Name: "reconciles_errors_total"}),
+		fileValid:        prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "file_valid"}, []string{"file"}),
+		rulerClient:      rulerClient,
+		sources:          map[string]*fileSource{},
+		composites:       map[string]struct{}{},
+	}
+
+	pattern := filepath.Join(configDir, "*.yaml")
+	api := filepath.Join(configDir, "api.yaml")
+	require.NoError(t, os.WriteFile(api, []byte(reconcileObjective("api", false)), 0o644))
+	require.True(t, r.reconcile(api))
+	require.FileExists(t, filepath.Join(prometheusFolder, "api.yaml"))
+
+	// Rule files that aren't generated anymore are kept until their namespaces are deleted from the ruler.
+	failDelete = true
+	require.NoError(t, os.WriteFile(api, []byte(reconcileObjective("api", true)), 0o644))
+	r.reconcile(api)
+	require.FileExists(t, filepath.Join(prometheusFolder, "api.yaml"))
+	require.Contains(t, r.sources[api].ruleFiles, filepath.Join(prometheusFolder, "api.yaml"))
+
+	failDelete = false
+	_, err = r.resync(pattern)
+	require.NoError(t, err)
+	require.NoFileExists(t, filepath.Join(prometheusFolder, "api.yaml"))
+	require.Equal(t, []string{"api"}, deleted)
+
+	// Removed config files are removed again by every resync until their namespaces are deleted from the ruler.
+	failDelete = true
+	require.NoError(t, os.Remove(api))
+	require.True(t, r.reconcile(api))
+	_, err = r.resync(pattern)
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(prometheusFolder, "api-short.yaml"))
+	require.FileExists(t, filepath.Join(prometheusFolder, "api-long.yaml"))
+	require.Empty(t, r.objectives.Match(nil))
+
+	failDelete = false
+	_, err = r.resync(pattern)
+	require.NoError(t, err)
+	require.NoFileExists(t, filepath.Join(prometheusFolder, "api-short.yaml"))
+	require.NoFileExists(t, filepath.Join(prometheusFolder, "api-long.yaml"))
+	require.Equal(t, []string{"api", "api-short", "api-long"}, deleted)
+	require.Empty(t, r.sources)
+}
@@ -14,15 +14,23 @@ limitations under the License.
 package main
 
 import (
+	"fmt"
+	"io/fs"
 	"net/url"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
+
+	"github.com/pyrra-dev/pyrra/slo"
 )
 
-func cmdGenerate(logger log.Logger, configFiles, prometheusFolder string, genericRules, operatorRule, enablePrometheus3Migration bool, externalURL *url.URL) int {
-	filenames, err := filepath.Glob(configFiles)
+// cmdGenerate writes the rule files of the config files.
+// Unless dryRun is set, the rule files are written. If diff or dryRun are set, a unified diff of every changed rule file is printed.
+func cmdGenerate(logger log.Logger, configFiles, prometheusFolder string, genericRules, operatorRule, enablePrometheus3Migration bool, externalURL *url.URL, dryRun, diff bool) int {
+	filenames, err := configFilenames(configFiles)
 	if err != nil {
 		level.Error(logger).Log("msg", "getting file names", "err", err)
 		return 1
@@ -33,12 +41,123 @@ func cmdGenerate(logger log.Logger, configFiles, prometheusFolder string, generi
 		externalURLStr = externalURL.String()
 	}
 
+	// All objectives are read upfront as composite objectives select their components among them.
+	configs := make([][]configObjective, 0, len(filenames))
+	var objectives []slo.Objective
 	for _, file := range filenames {
-		err := writeRuleFile(logger, file, prometheusFolder, genericRules, operatorRule, enablePrometheus3Migration, externalURLStr)
+		fileObjectives, err := objectivesFromFile(file)
 		if err != nil {
 			level.Error(logger).Log("msg", "generating rule files", "err", err)
 			return 1
 		}
+		configs = append(configs, fileObjectives)
+		for _, o := range fileObjectives {
+			objectives = append(objectives, o.objective)
+		}
+	}
+
+	// Rule files are checked to be unique before any is written, as objectives would overwrite each other's rules.
+	root := configRoot(configFiles)
+	ruleNames := make([][]string, len(filenames))
+	generated := map[string]string{}
+	for i, file := range filenames {
+		for _, o := range configs[i] {
+			name := ruleFileName(root, file, o.kube, len(configs[i]) > 1)
+			for _, f := range ruleFilenames(name, o.objective, prometheusFolder) {
+				if other, ok := generated[f]; ok {
+					level.Error(logger).Log("msg", "generating rule files", "err", fmt.Errorf("objectives of %q and %q generate the same rule file %q", other, file, f))
+					return 1
+				}
+				generated[f] = file
+			}
+			ruleNames[i] = append(ruleNames[i], name)
+		}
+	}
+
+	w := &ruleWriter{folder: prometheusFolder, dryRun: dryRun}
+	if diff || dryRun {
+		w.diff = os.Stdout
+	}
+	for i, file := range filenames {
+		for j, o := range configs[i] {
+			err := writeRuleFile(logger, w, ruleNames[i][j], o.kube, o.objective, objectives, genericRules, operatorRule, enablePrometheus3Migration, externalURLStr)
+			if err != nil {
+				level.Error(logger).Log("msg", "generating rule files", "file", file, "err", err)
+				return 1
+			}
+		}
+	}
+
+	if dryRun {
+		level.Info(logger).Log("msg", "rule files would change", "changed", len(w.changed))
 	}
 	return 0
 }
+
+// recursivePattern returns the root directory and file name pattern of patterns like /etc/pyrra/**/*.yaml,
+// which match the files of a whole directory tree.
+func recursivePattern(pattern string) (string, string, bool) {
+	dir, name := filepath.Split(pattern)
+	dir = filepath.Clean(dir)
+	if filepath.Base(dir) != "**" {
+		return "", "", false
+	}
+	return filepath.Dir(dir), name, true
+}
+
+// configRoot returns the directory the config files are found in.
+// The names of the rule files of config files in its subdirectories are prefixed with their directories.
+func configRoot(pattern string) string {
+	if root, _, ok := recursivePattern(pattern); ok {
+		return root
+	}
+	return filepath.Dir(pattern)
+}
+
+// configFilenames returns the config files matching the pattern, skipping the objectives' unit test files.
+// Patterns like /etc/pyrra/**/*.yaml match the files in all subdirectories too,
+// skipping hidden files and directories, like the ..data directory of mounted ConfigMaps.
+func configFilenames(pattern string) ([]string, error) {
+	var filenames []string
+	if root, name, ok := recursivePattern(pattern); ok {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if path == root {
+				return nil
+			}
+			if strings.HasPrefix(d.Name(), ".") {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() {
+				return nil
+			}
+			matched, err := filepath.Match(name, d.Name())
+			if matched {
+				filenames = append(filenames, path)
+			}
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		filenames, err = filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	configs := filenames[:0]
+	for _, f := range filenames {
+		if !isTestFile(f) {
+			configs = append(configs, f)
+		}
+	}
+	return configs, nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFilenames(t *testing.T) {
+	dir := t.TempDir()
+	for _, file := range []string{
+		"api.yaml",
+		"api_test.yaml",
+		"readme.md",
+		"payments/checkout.yaml",
+		"payments/checkout/api.yaml",
+		"payments/checkout/api_test.yaml",
+		".hidden.yaml",
+		"..data/api.yaml",
+	} {
+		path := filepath.Join(dir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, nil, 0o644))
+	}
+
+	filenames, err := configFilenames(filepath.Join(dir, "*.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(dir, ".hidden.yaml"), filepath.Join(dir, "api.yaml")}, filenames)
+
+	filenames, err = configFilenames(filepath.Join(dir, "**", "*.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(dir, "api.yaml"),
+		filepath.Join(dir, "payments", "checkout", "api.yaml"),
+		filepath.Join(dir, "payments", "checkout.yaml"),
+	}, filenames)
+
+	dirs, err := configDirs(filepath.Join(dir, "**", "*.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, []string{dir, filepath.Join(dir, "payments"), filepath.Join(dir, "payments", "checkout")}, dirs)
+
+	require.Equal(t, dir, configRoot(filepath.Join(dir, "**", "*.yaml")))
+	require.Equal(t, dir, configRoot(filepath.Join(dir, "*.yaml")))
+}
+
+func TestGenerate(t *testing.T) {
+	configDir := t.TempDir()
+	prometheusFolder := t.TempDir()
+
+	write := func(t *testing.T, file, content string) {
+		t.Helper()
+		path := filepath.Join(configDir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	write(t, "payments/api.yaml", reconcileObjective("payments", false))
+	write(t, "search/slos.yaml", reconcileObjective("search", false)+"---\n"+reconcileObjective("suggest", false))
+
+	pattern := filepath.Join(configDir, "**", "*.yaml")
+	require.Equal(t, 0, cmdGenerate(log.NewNopLogger(), pattern, prometheusFolder, false, false, false, nil, false, false))
+
+	entries, err := os.ReadDir(prometheusFolder)
+	require.NoError(t, err)
+	var files []string
+	for _, e := range entries {
+		files = append(files, e.Name())
+	}
+	require.Equal(t, []string{"payments-api.yaml", "search-slos-monitoring-search.yaml", "search-slos-monitoring-suggest.yaml"}, files)
+
+	// Dry runs leave the rule files as they are.
+	before, err := os.ReadFile(filepath.Join(prometheusFolder, "payments-api.yaml"))
+	require.NoError(t, err)
+	write(t, "payments/api.yaml", strings.Replace(reconcileObjective("payments", false), "target: '99'", "target: '99.9'", 1))
+	require.Equal(t, 0, cmdGenerate(log.NewNopLogger(), pattern, prometheusFolder, false, false, false, nil, true, false))
+	after, err := os.ReadFile(filepath.Join(prometheusFolder, "payments-api.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, string(before), string(after))
+
+	// Objectives generating the same rule file fail before any rules are written.
+	require.NoError(t, os.RemoveAll(prometheusFolder))
+	require.NoError(t, os.Mkdir(prometheusFolder, 0o755))
+	write(t, "payments-api.yaml", reconcileObjective("checkout", false))
+	require.Equal(t, 1, cmdGenerate(log.NewNopLogger(), pattern, prometheusFolder, false, false, false, nil, false, false))
+
+	entries, err = os.ReadDir(prometheusFolder)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"github.com/prometheus/common/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	objectivesv1alpha1 "github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1"
+	"github.com/pyrra-dev/pyrra/slo"
+)
+
+const (
+	defaultHistoryPeriods = 12
+	maxHistoryPeriods     = 120
+)
+
+// historyPeriod is one of the windows of an objective that GetStatusHistory evaluates.
+type historyPeriod struct {
+	start, end time.Time
+}
+
+// historyPeriods returns the n windows of the objective before ts, the latest last.
+// Rolling windows end at ts, calendar periods with the last full period before ts.
+func historyPeriods(objective slo.Objective, ts time.Time, n int) []historyPeriod {
+	periods := make([]historyPeriod, n)
+
+	end := ts
+	if objective.Calendar != nil {
+		end = objective.Calendar.Start(ts)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		start := end.Add(-time.Duration(objective.Window))
+		if objective.Calendar != nil {
+			start = objective.Calendar.Start(end.Add(-time.Nanosecond))
+		}
+		periods[i] = historyPeriod{start: start, end: end}
+		end = start
+	}
+
+	return periods
+}
+
+func (s *objectiveServer) GetStatusHistory(ctx context.Context, req *connect.Request[objectivesv1alpha1.GetStatusHistoryRequest]) (*connect.Response[objectivesv1alpha1.GetStatusHistoryResponse], error) {
+	n := int(req.Msg.Periods)
+	if n == 0 {
+		n = defaultHistoryPeriods
+	}
+	if n > maxHistoryPeriods {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("periods must not be more than %d", maxHistoryPeriods))
+	}
+
+	objective, err := s.getObjective(ctx, req.Msg.Expr)
+	if err != nil {
+		return nil, err
+	}
+	if err := addGroupingMatchers(&objective, req.Msg.Grouping); err != nil {
+		return nil, err
+	}
+
+	ts := time.Now()
+	if req.Msg.Time != nil {
+		ts = req.Msg.Time.AsTime()
+	}
+
+	// The queries of rolling windows are the same for every period.
+	// They are queried without the cache, as it doesn't tell them apart by their time.
+	histories := map[model.Fingerprint]*objectivesv1alpha1.ObjectiveStatusHistory{}
+	for _, period := range historyPeriods(objective, ts, n) {
+		var status []*objectivesv1alpha1.ObjectiveStatus
+		if objective.IndicatorType() == slo.Composite {
+			status, err = s.getCompositeStatus(ctx, objective, period.end)
+		} else {
+			// A calendar period is selected by a time within it, but summed up until the evaluation time.
+			// Evaluating at the end of the period includes its last increase, which the next period doesn't.
+			status, err = s.getStatus(ctx, objective, period.end.Add(-time.Second), period.end)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, st := range status {
+			fingerprint := labelSet(st.Labels).Fingerprint()
+			history, ok := histories[fingerprint]
+			if !ok {
+				history = &objectivesv1alpha1.ObjectiveStatusHistory{Labels: st.Labels}
+				histories[fingerprint] = history
+			}
+
+			history.Periods = append(history.Periods, &objectivesv1alpha1.PeriodStatus{
+				Start:        timestamppb.New(period.start),
+				End:          timestamppb.New(period.end),
+				Availability: st.Availability,
+				Budget:       st.Budget,
+				Met:          st.Availability.Percentage >= objective.Target,
+			})
+		}
+	}
+
+	history := make([]*objectivesv1alpha1.ObjectiveStatusHistory, 0, len(histories))
+	for _, h := range histories {
+		history = append(history, h)
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return labelSet(history[i].Labels).String() < labelSet(history[j].Labels).String()
+	})
+
+	return connect.NewResponse(&objectivesv1alpha1.GetStatusHistoryResponse{
+		History: history,
+	}), nil
+}
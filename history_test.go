@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"github.com/go-kit/log"
+	prometheusapiv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	objectivesv1alpha1 "github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1"
+	"github.com/pyrra-dev/pyrra/slo"
+)
+
+// staticObjectives is a backend that lists the same objectives for every expr.
+type staticObjectives []slo.Objective
+
+func (o staticObjectives) List(_ context.Context, _ *connect.Request[objectivesv1alpha1.ListRequest]) (*connect.Response[objectivesv1alpha1.ListResponse], error) {
+	objectives := make([]*objectivesv1alpha1.Objective, 0, len(o))
+	for _, objective := range o {
+		objectives = append(objectives, objectivesv1alpha1.FromInternal(objective))
+	}
+	return connect.NewResponse(&objectivesv1alpha1.ListResponse{Objectives: objectives}), nil
+}
+
+// historyPrometheus returns the vector of an instant query at a time and an empty vector for unknown queries.
+type historyPrometheus map[string]map[time.Time]model.Vector
+
+func (p historyPrometheus) Query(_ context.Context, query string, ts time.Time, _ ...prometheusapiv1.Option) (model.Value, prometheusapiv1.Warnings, error) {
+	if vector, ok := p[query][ts]; ok {
+		return vector, nil, nil
+	}
+	return model.Vector{}, nil, nil
+}
+
+func (p historyPrometheus) QueryRange(_ context.Context, query string, _ prometheusapiv1.Range, _ ...prometheusapiv1.Option) (model.Value, prometheusapiv1.Warnings, error) {
+	return nil, nil, fmt.Errorf("unexpected range query %q", query)
+}
+
+func TestHistoryPeriods(t *testing.T) {
+	ts := time.Date(2026, 3, 17, 12, 0, 0, 0, time.UTC)
+
+	t.Run("rolling", func(t *testing.T) {
+		objective := slo.Objective{Window: model.Duration(28 * 24 * time.Hour)}
+		require.Equal(t, []historyPeriod{
+			{start: ts.AddDate(0, 0, -56), end: ts.AddDate(0, 0, -28)},
+			{start: ts.AddDate(0, 0, -28), end: ts},
+		}, historyPeriods(objective, ts, 2))
+	})
+
+	t.Run("calendar", func(t *testing.T) {
+		calendar, err := slo.NewCalendar("month", "")
+		require.NoError(t, err)
+		objective := slo.Objective{Window: calendar.Window(), Calendar: calendar}
+		require.Equal(t, []historyPeriod{
+			{start: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), end: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+			{start: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), end: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		}, historyPeriods(objective, ts, 2))
+	})
+}
+
+func TestGetStatusHistory(t *testing.T) {
+	objective := slo.Objective{
+		Labels: labels.FromStrings(labels.MetricName, "api"),
+		Target: 0.99,
+		Window: model.Duration(28 * 24 * time.Hour),
+		Indicator: slo.Indicator{
+			Ratio: &slo.RatioIndicator{
+				Errors: slo.Metric{
+					Name: "http_requests_total",
+					LabelMatchers: []*labels.Matcher{
+						labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "http_requests_total"),
+						labels.MustNewMatcher(labels.MatchRegexp, "code", "5.."),
+					},
+				},
+				Total: slo.Metric{
+					Name: "http_requests_total",
+					LabelMatchers: []*labels.Matcher{
+						labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "http_requests_total"),
+					},
+				},
+				Grouping: []string{"handler"},
+			},
+		},
+	}
+
+	ts := time.Date(2026, 3, 17, 12, 0, 0, 0, time.UTC)
+	previous := ts.AddDate(0, 0, -28)
+
+	prometheus := historyPrometheus{
+		objective.QueryTotal(objective.Window, slo.GenerationOptions{}): {
+			previous: {
+				{Metric: model.Metric{"handler": "/"}, Value: 1000},
+			},
+			ts: {
+				{Metric: model.Metric{"handler": "/"}, Value: 1000},
+				{Metric: model.Metric{"handler": "/api"}, Value: 100},
+			},
+		},
+		objective.QueryErrors(objective.Window, slo.GenerationOptions{}): {
+			previous: {
+				{Metric: model.Metric{"handler": "/"}, Value: 20},
+			},
+			ts: {
+				{Metric: model.Metric{"handler": "/"}, Value: 1},
+			},
+		},
+	}
+
+	s := &objectiveServer{
+		logger:  log.NewNopLogger(),
+		promAPI: &promCache{api: prometheus},
+		client:  staticObjectives{objective},
+	}
+
+	resp, err := s.GetStatusHistory(context.Background(), connect.NewRequest(&objectivesv1alpha1.GetStatusHistoryRequest{
+		Expr:    `{__name__="api"}`,
+		Time:    timestamppb.New(ts),
+		Periods: 3,
+	}))
+	require.NoError(t, err)
+
+	history := resp.Msg.History
+	require.Len(t, history, 2)
+
+	require.Equal(t, map[string]string{"handler": "/"}, history[0].Labels)
+	require.Len(t, history[0].Periods, 2)
+	require.Equal(t, previous.AddDate(0, 0, -28), history[0].Periods[0].Start.AsTime())
+	require.Equal(t, previous, history[0].Periods[0].End.AsTime())
+	require.InDelta(t, 0.98, history[0].Periods[0].Availability.Percentage, 1e-9)
+	require.False(t, history[0].Periods[0].Met)
+	require.Equal(t, ts, history[0].Periods[1].End.AsTime())
+	require.InDelta(t, 0.999, history[0].Periods[1].Availability.Percentage, 1e-9)
+	require.InDelta(t, 0.9, history[0].Periods[1].Budget.Remaining, 1e-9)
+	require.True(t, history[0].Periods[1].Met)
+
+	require.Equal(t, map[string]string{"handler": "/api"}, history[1].Labels)
+	require.Len(t, history[1].Periods, 1)
+	require.Equal(t, ts, history[1].Periods[0].End.AsTime())
+	require.Equal(t, 1.0, history[1].Periods[0].Availability.Percentage)
+	require.True(t, history[1].Periods[0].Met)
+
+	_, err = s.GetStatusHistory(context.Background(), connect.NewRequest(&objectivesv1alpha1.GetStatusHistoryRequest{
+		Periods: maxHistoryPeriods + 1,
+	}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/common/model"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pyrra-dev/pyrra/sloth"
+)
+
+// cmdImportSloth converts Sloth specs to ServiceLevelObjectives.
+// Every file is converted before anything is written, so that a failing SLO doesn't leave a partial migration behind.
+func cmdImportSloth(logger log.Logger, files []string, outputFolder, namespace, window string) int {
+	sloWindow, err := model.ParseDuration(window)
+	if err != nil {
+		level.Error(logger).Log("msg", "parsing window", "err", err)
+		return 1
+	}
+
+	var outputs [][]byte
+	var names []string
+	for _, file := range files {
+		bytes, err := os.ReadFile(file)
+		if err != nil {
+			level.Error(logger).Log("msg", "reading Sloth spec", "file", file, "err", err)
+			return 1
+		}
+
+		specs, err := sloth.Parse(bytes)
+		if err != nil {
+			level.Error(logger).Log("msg", "parsing Sloth spec", "file", file, "err", err)
+			return 1
+		}
+
+		for _, spec := range specs {
+			if spec.Namespace == "" {
+				spec.Namespace = namespace
+			}
+
+			objectives, err := spec.ServiceLevelObjectives(sloWindow)
+			if err != nil {
+				level.Error(logger).Log("msg", "converting Sloth spec", "file", file, "err", err)
+				return 1
+			}
+
+			for _, o := range objectives {
+				bytes, err := yaml.Marshal(o)
+				if err != nil {
+					level.Error(logger).Log("msg", "marshaling objective", "name", o.Name, "err", err)
+					return 1
+				}
+				outputs = append(outputs, bytes)
+				names = append(names, o.Name)
+			}
+		}
+	}
+
+	for i, bytes := range outputs {
+		if outputFolder == "" {
+			fmt.Printf("---\n%s", bytes)
+			continue
+		}
+
+		path := filepath.Join(outputFolder, names[i]+".yaml")
+		if err := os.WriteFile(path, bytes, 0o644); err != nil {
+			level.Error(logger).Log("msg", "writing objective", "file", path, "err", err)
+			return 1
+		}
+	}
+
+	return 0
+}
@@ -30,6 +30,8 @@ import (
 	"github.com/go-logr/logr" //nolint:depguard // Required for logr.LogSink adapter bridging go-kit/log with controller-runtime.
 	"github.com/oklog/run"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus/client_golang/api"
+	prometheusapiv1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/promql/parser"
@@ -45,9 +47,10 @@ import (
 
 	pyrrav1alpha1 "github.com/pyrra-dev/pyrra/kubernetes/api/v1alpha1"
 	"github.com/pyrra-dev/pyrra/kubernetes/controllers"
-	"github.com/pyrra-dev/pyrra/mimir"
 	objectivesv1alpha1 "github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1"
 	"github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1/objectivesv1alpha1connect"
+	"github.com/pyrra-dev/pyrra/ruler"
+	"github.com/pyrra-dev/pyrra/slo"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -127,13 +130,15 @@ func cmdKubernetes(
 	metricsAddr string,
 	configMapMode, genericRules, disableWebhooks bool,
 	certFile, privateKeyFile string,
-	mimirClient *mimir.Client,
+	rulerClient ruler.Client,
 	mimirWriteAlertingRules bool,
 	enablePrometheus3Migration bool,
 	pyrraExternalURL *url.URL,
 	enableLeaderElection bool,
 	leaderElectionNamespace string,
 	namespaces []string,
+	promClient api.Client,
+	statusInterval time.Duration,
 ) int {
 	setupLog := ctrl.Log.WithName("setup")
 	ctrl.SetLogger(newGoKitLogr(logger))
@@ -172,10 +177,14 @@ func cmdKubernetes(
 		Logger:                     log.With(logger, "controllers", "ServiceLevelObjective"),
 		GenericRules:               genericRules,
 		ConfigMapMode:              configMapMode,
-		MimirClient:                mimirClient,
+		RulerClient:                rulerClient,
 		MimirWriteAlertingRules:    mimirWriteAlertingRules,
 		EnablePrometheus3Migration: enablePrometheus3Migration,
 		PyrraExternalURL:           pyrraURL,
+		StatusInterval:             statusInterval,
+	}
+	if promClient != nil {
+		reconciler.Prometheus = prometheusapiv1.NewAPI(promClient)
 	}
 	if err = reconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ServiceLevelObjective")
@@ -322,6 +331,10 @@ func (s *KubernetesObjectiveServer) List(ctx context.Context, req *connect.Reque
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
+	// Composite objectives might select objectives in other namespaces than the ones listed,
+	// so all objectives are only listed once a composite objective needs them.
+	var compositeObjectives []slo.Objective
+
 	objectives := make([]*objectivesv1alpha1.Objective, 0, len(list.Items))
 	for _, slo := range list.Items {
 		if nameMatcher != nil {
@@ -339,10 +352,24 @@ func (s *KubernetesObjectiveServer) List(ctx context.Context, req *connect.Reque
 		if err != nil {
 			return nil, connect.NewError(connect.CodeInternal, err)
 		}
+		if internal.Indicator.Composite != nil {
+			if compositeObjectives == nil {
+				compositeObjectives, err = controllers.ListObjectives(ctx, s.client)
+				if err != nil {
+					return nil, connect.NewError(connect.CodeInternal, err)
+				}
+			}
+			internal, err = internal.ResolveComposite(compositeObjectives)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		}
 		objectives = append(objectives, objectivesv1alpha1.FromInternal(internal))
 	}
 
-	return connect.NewResponse(&objectivesv1alpha1.ListResponse{
-		Objectives: objectives,
-	}), nil
+	page, err := objectivesv1alpha1.ListPage(req.Msg, objectives)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return connect.NewResponse(page), nil
 }
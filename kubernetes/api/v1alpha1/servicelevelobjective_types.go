@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
@@ -54,8 +55,12 @@ type ServiceLevelObjectiveList struct {
 // +kubebuilder:resource:shortName=slo
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Window",type=string,JSONPath=`.spec.window`
+// +kubebuilder:printcolumn:name="Calendar",type=string,JSONPath=`.spec.calendar.period`,priority=1
 // +kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.target`
+// +kubebuilder:printcolumn:name="Availability",type=string,JSONPath=`.status.availability`
+// +kubebuilder:printcolumn:name="Budget",type=string,JSONPath=`.status.budget`
 // +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.status.type`
+// +kubebuilder:printcolumn:name="Valid",type=string,JSONPath=`.status.conditions[?(@.type=="Valid")].status`,priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // ServiceLevelObjective is the Schema for the ServiceLevelObjectives API.
@@ -79,9 +84,16 @@ type ServiceLevelObjectiveSpec struct {
 	// float64 are not supported: https://github.com/kubernetes-sigs/controller-tools/issues/245
 	Target string `json:"target"`
 
+	// +optional
 	// Window within which the Target is supposed to be kept. Usually something like 1d, 7d or 28d.
+	// It must be set unless a Calendar is used.
 	Window string `json:"window"`
 
+	// +optional
+	// Calendar aligns the window to calendar periods instead of a rolling Window.
+	// The error budget resets at the start of every period.
+	Calendar *CalendarWindow `json:"calendar,omitempty"`
+
 	// ServiceLevelIndicator is the underlying data source that indicates how the service is doing.
 	// This will be a Prometheus metric with specific selectors for your service.
 	ServiceLevelIndicator ServiceLevelIndicator `json:"indicator"`
@@ -90,6 +102,16 @@ type ServiceLevelObjectiveSpec struct {
 	// Alerting customizes the alerting rules generated by Pyrra.
 	Alerting Alerting `json:"alerting"`
 
+	// +optional
+	// BurnRatePolicy replaces the default multi window, multi burn rate alerts
+	// that are derived from the Window with custom tiers.
+	BurnRatePolicy *BurnRatePolicy `json:"burnRatePolicy,omitempty"`
+
+	// +optional
+	// BudgetPolicy generates alerts once the remaining error budget drops
+	// to the thresholds of its stages, like freezing deployments at 25% remaining.
+	BudgetPolicy *BudgetPolicy `json:"budgetPolicy,omitempty"`
+
 	// +optional
 	// +kubebuilder:validation:Enum:=abort;warn;""
 	// +kubebuilder:default:=abort
@@ -108,6 +130,11 @@ type ServiceLevelObjectiveSpec struct {
 	// (5m increase + burnrate + alert) rules and long (subquery) rules to
 	// different Prometheus/Thanos instances via label selectors.
 	RuleOutput *RuleOutput `json:"ruleOutput,omitempty"`
+
+	// +optional
+	// Datasource is the name of the Prometheus datasource the API queries for this objective,
+	// as configured with --datasources-file. The default Prometheus is queried if empty.
+	Datasource string `json:"datasource,omitempty"`
 }
 
 // RuleOutput configures per-rule-file labels when performance_over_accuracy is true.
@@ -152,6 +179,16 @@ type ServiceLevelIndicator struct {
 	// BoolGauge is the indicator that measures whether a boolean gauge is
 	// successful.
 	BoolGauge *BoolGaugeIndicator `json:"bool_gauge,omitempty"`
+
+	// +optional
+	// Composite is the indicator that combines other ServiceLevelObjectives,
+	// like the services of a request chain, into one.
+	Composite *CompositeIndicator `json:"composite,omitempty"`
+
+	// +optional
+	// Raw is the indicator with freeform PromQL expressions for the good and total events,
+	// for indicators that aren't a single metric.
+	Raw *RawIndicator `json:"raw,omitempty"`
 }
 
 type Alerting struct {
@@ -180,6 +217,15 @@ type Alerting struct {
 	// Severities sets the Prometheus alert label "severity" per burn-rate tier and for absent alerts.
 	// Pyrra defaults to critical for fast/medium burn and absent; warning for slow/long-term.
 	Severities *AlertingSeverities `json:"severities,omitempty"`
+
+	// +optional
+	// Labels are added to all burn rate alerts, for example to route them in Alertmanager.
+	// They can't override the labels set by Pyrra, like severity.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// +optional
+	// Annotations are added to all burn rate alerts, like a runbook_url.
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 type AlertingSeverities struct {
@@ -195,6 +241,79 @@ type AlertingSeverities struct {
 	LongTermBurn string `json:"longTermBurn,omitempty"`
 }
 
+// CalendarWindow is a window aligned to calendar periods.
+type CalendarWindow struct {
+	// +kubebuilder:validation:Enum=week;month;quarter
+	// Period is the calendar period the error budget is calculated for. Weeks start on Monday.
+	Period string `json:"period"`
+
+	// +optional
+	// TimeZone is the IANA time zone, like Europe/Berlin, the periods start in. Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// BurnRatePolicy configures the tiers of the multi window, multi burn rate alerts.
+type BurnRatePolicy struct {
+	// Windows are the burn rate tiers ordered from the most to the least urgent.
+	// The first window is used to calculate when the absent alerts fire.
+	// +kubebuilder:validation:MinItems=1
+	Windows []BurnRateWindow `json:"windows"`
+}
+
+type BurnRateWindow struct {
+	// Short is the short window of the alert, like 5m.
+	Short string `json:"short"`
+
+	// Long is the long window of the alert, like 1h. It must be longer than Short.
+	Long string `json:"long"`
+
+	// Factor is a string that's casted to a float64 greater than 0.
+	// The alert fires once the error budget is burned factor times faster than allowed.
+	Factor string `json:"factor"`
+
+	// +optional
+	// For is the duration both windows have to be above the threshold before the alert fires.
+	For string `json:"for,omitempty"`
+
+	// +optional
+	// Severity is the Prometheus alert label "severity" of this tier. Defaults to critical.
+	Severity string `json:"severity,omitempty"`
+
+	// +optional
+	// Labels are added to the alert of this tier. They take precedence over the labels of alerting.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// +optional
+	// Annotations are added to the alert of this tier. They take precedence over the annotations of alerting.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// BudgetPolicy configures the stages of an error budget policy.
+type BudgetPolicy struct {
+	// Stages of the policy. Only the alert of the stage with the lowest
+	// threshold the remaining error budget dropped to fires.
+	// +kubebuilder:validation:MinItems=1
+	Stages []BudgetPolicyStage `json:"stages"`
+}
+
+type BudgetPolicyStage struct {
+	// Name of the stage, like freeze-deploys. It's the Prometheus alert label "stage".
+	Name string `json:"name"`
+
+	// Remaining is a string that's casted to a float64 between 0 - 100.
+	// The stage starts once the remaining error budget in percent drops to it.
+	Remaining string `json:"remaining"`
+
+	// +optional
+	// Severity is the Prometheus alert label "severity" of this stage.
+	// Defaults to warning, and critical for stages at 0% remaining.
+	Severity string `json:"severity,omitempty"`
+
+	// +optional
+	// Labels are added to the alert of this stage to route it in Alertmanager.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
 type RatioIndicator struct {
 	// Errors is the metric that returns how many errors there are.
 	Errors Query `json:"errors"`
@@ -235,15 +354,89 @@ type BoolGaugeIndicator struct {
 	Grouping []string `json:"grouping"`
 }
 
+// CompositeIndicator combines the indicators of other ServiceLevelObjectives.
+type CompositeIndicator struct {
+	// +optional
+	// +kubebuilder:validation:Enum=weighted;multiplicative
+	// +kubebuilder:default:=weighted
+	// Model is how the objectives are combined.
+	// weighted averages the error ratios of the objectives by their weight.
+	// multiplicative multiplies the availabilities of the objectives,
+	// as requests have to succeed in every one of them.
+	Model string `json:"model,omitempty"`
+
+	// Objectives select the ServiceLevelObjectives to combine.
+	// +kubebuilder:validation:MinItems=1
+	Objectives []CompositeObjective `json:"objectives"`
+}
+
+type CompositeObjective struct {
+	// Selector is a Prometheus label selector, like {__name__="cart-errors"}, selecting
+	// ServiceLevelObjectives by their name, namespace and propagated pyrra.dev/ labels.
+	// Only ServiceLevelObjectives in the same namespace are selected unless the selector has a namespace matcher.
+	Selector string `json:"selector"`
+
+	// +optional
+	// Weight is a string that's casted to a float64 greater than 0. Defaults to 1.
+	// It's only used by the weighted model.
+	Weight string `json:"weight,omitempty"`
+}
+
+type RawIndicator struct {
+	// Good is a PromQL expression that returns the counters of good events,
+	// like sum by (handler) (label_replace(http_requests_total{code!~"5.."}, ...)).
+	// The expression must not contain ranges, Pyrra wraps every selector with rate or increase.
+	Good string `json:"good"`
+	// Total is a PromQL expression that returns the counters of all events.
+	Total string `json:"total"`
+	// +optional
+	// Grouping allows an SLO to be defined for many SLI at once, like HTTP handlers for example.
+	// The grouping labels must be kept by both expressions.
+	Grouping []string `json:"grouping"`
+}
+
 // Query contains a PromQL metric.
 type Query struct {
 	Metric string `json:"metric"`
 }
 
+// Condition types of a ServiceLevelObjective's status.
+const (
+	// ConditionValid tells if the ServiceLevelObjective's spec is valid.
+	ConditionValid = "Valid"
+	// ConditionRulesGenerated tells if the rules were generated and written to the generated resources.
+	ConditionRulesGenerated = "RulesGenerated"
+	// ConditionMimirSynced tells if the rules were synced to the ruler, like the ones of Mimir or Cortex.
+	// Only set when rules are provisioned via a ruler. It keeps its name from when only Mimir was supported.
+	ConditionMimirSynced = "MimirSynced"
+)
+
 // ServiceLevelObjectiveStatus defines the observed state of ServiceLevelObjective.
 type ServiceLevelObjectiveStatus struct {
 	// Type is the generated resource type, like PrometheusRule or ConfigMap
 	Type string `json:"type,omitempty"`
+	// ObservedGeneration is the generation of the spec the status was last written for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions are the latest observations of the ServiceLevelObjective's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// GeneratedResources are the names of the generated resources, like PrometheusRules or ConfigMaps.
+	// +optional
+	GeneratedResources []string `json:"generatedResources,omitempty"`
+	// Availability over the window in percent, like 99.95. Grouped objectives report the availability of their group with the least remaining error budget.
+	// Only set if the operator is configured to query Prometheus.
+	// +optional
+	Availability string `json:"availability,omitempty"`
+	// Budget is the remaining error budget in percent, like 42.5. It's negative once the error budget is exhausted. Grouped objectives report the least remaining error budget of all their groups.
+	// Only set if the operator is configured to query Prometheus.
+	// +optional
+	Budget string `json:"budget,omitempty"`
+	// LastQueried is the time the availability and error budget were last queried.
+	// +optional
+	LastQueried *metav1.Time `json:"lastQueried,omitempty"`
 }
 
 func (in *ServiceLevelObjective) ValidateCreate(_ context.Context, obj *ServiceLevelObjective) (admission.Warnings, error) {
@@ -282,19 +475,58 @@ func (in *ServiceLevelObjective) validate() (admission.Warnings, error) {
 		warnings = append(warnings, fmt.Sprintf("target is from 0-100 (%v), not 0-1 (%v)", 100*target, target))
 	}
 
-	if in.Spec.Window == "" {
-		return warnings, fmt.Errorf("window must be set")
+	var window model.Duration
+	if in.Spec.Calendar != nil {
+		if in.Spec.Window != "" {
+			return warnings, fmt.Errorf("window and calendar cannot be set at the same time")
+		}
+		calendar, err := slo.NewCalendar(in.Spec.Calendar.Period, in.Spec.Calendar.TimeZone)
+		if err != nil {
+			return warnings, err
+		}
+		window = calendar.Window()
+	} else {
+		if in.Spec.Window == "" {
+			return warnings, fmt.Errorf("window must be set")
+		}
+		window, err = model.ParseDuration(in.Spec.Window)
+		if err != nil {
+			return warnings, err
+		}
 	}
-	_, err = model.ParseDuration(in.Spec.Window)
-	if err != nil {
-		return warnings, err
+
+	if in.Spec.BurnRatePolicy != nil {
+		windows, err := in.Spec.BurnRatePolicy.windows()
+		if err != nil {
+			return warnings, err
+		}
+		for _, w := range windows {
+			if model.Duration(w.Long) > window {
+				warnings = append(warnings, fmt.Sprintf("burnRatePolicy long window %s is longer than the objective's window %s", model.Duration(w.Long), window))
+			}
+		}
+	}
+
+	if err := validateBurnRateAlertLabels(in.Spec.Alerting.Labels); err != nil {
+		return warnings, fmt.Errorf("alerting %w", err)
+	}
+
+	if in.Spec.BudgetPolicy != nil {
+		if in.Spec.Calendar != nil {
+			return warnings, fmt.Errorf("budgetPolicy doesn't support calendar windows")
+		}
+		if _, err := in.Spec.BudgetPolicy.stages(); err != nil {
+			return warnings, err
+		}
 	}
 
 	if in.Spec.ServiceLevelIndicator.Ratio == nil &&
 		in.Spec.ServiceLevelIndicator.Latency == nil &&
 		in.Spec.ServiceLevelIndicator.LatencyNative == nil &&
-		in.Spec.ServiceLevelIndicator.BoolGauge == nil {
-		return warnings, fmt.Errorf("one of ratio, latency, latencyNative or bool_gauge must be set")
+		in.Spec.ServiceLevelIndicator.BoolGauge == nil &&
+		in.Spec.ServiceLevelIndicator.Composite == nil &&
+		in.Spec.ServiceLevelIndicator.Raw == nil {
+		return warnings, fmt.Errorf("one of ratio, latency, latencyNative, bool_gauge, composite or raw must be set")
 	}
 
 	if in.Spec.ServiceLevelIndicator.Ratio != nil {
@@ -413,6 +645,33 @@ func (in *ServiceLevelObjective) validate() (admission.Warnings, error) {
 		}
 	}
 
+	if in.Spec.ServiceLevelIndicator.Composite != nil {
+		composite := in.Spec.ServiceLevelIndicator.Composite
+		if in.Spec.Calendar != nil {
+			return warnings, fmt.Errorf("composite objectives don't support calendar windows")
+		}
+		if _, err := composite.internal(in.GetNamespace()); err != nil {
+			return warnings, err
+		}
+		if composite.Model == string(slo.CompositeMultiplicative) {
+			for i, o := range composite.Objectives {
+				if o.Weight != "" {
+					warnings = append(warnings, fmt.Sprintf("composite objective %d weight is ignored by the multiplicative model", i))
+				}
+			}
+		}
+	}
+
+	if in.Spec.ServiceLevelIndicator.Raw != nil {
+		raw := in.Spec.ServiceLevelIndicator.Raw
+		if _, err := slo.NewRawIndicator(raw.Good, raw.Total, raw.Grouping); err != nil {
+			return warnings, err
+		}
+		if raw.Good == raw.Total {
+			warnings = append(warnings, "raw good expression should be different from raw total expression")
+		}
+	}
+
 	return warnings, nil
 }
 
@@ -422,9 +681,21 @@ func (in *ServiceLevelObjective) Internal() (slo.Objective, error) {
 		return slo.Objective{}, fmt.Errorf("failed to parse objective target: %w", err)
 	}
 
-	window, err := model.ParseDuration(in.Spec.Window)
-	if err != nil {
-		return slo.Objective{}, fmt.Errorf("failed to parse objective window: %w", err)
+	var (
+		window   model.Duration
+		calendar *slo.Calendar
+	)
+	if in.Spec.Calendar != nil {
+		calendar, err = slo.NewCalendar(in.Spec.Calendar.Period, in.Spec.Calendar.TimeZone)
+		if err != nil {
+			return slo.Objective{}, fmt.Errorf("failed to parse objective calendar: %w", err)
+		}
+		window = calendar.Window()
+	} else {
+		window, err = model.ParseDuration(in.Spec.Window)
+		if err != nil {
+			return slo.Objective{}, fmt.Errorf("failed to parse objective window: %w", err)
+		}
 	}
 
 	var alerting slo.Alerting
@@ -455,6 +726,27 @@ func (in *ServiceLevelObjective) Internal() (slo.Objective, error) {
 		alerting.Severities.SlowBurn = in.Spec.Alerting.Severities.SlowBurn
 		alerting.Severities.LongTermBurn = in.Spec.Alerting.Severities.LongTermBurn
 	}
+	if err := validateBurnRateAlertLabels(in.Spec.Alerting.Labels); err != nil {
+		return slo.Objective{}, fmt.Errorf("alerting %w", err)
+	}
+	alerting.Labels = in.Spec.Alerting.Labels
+	alerting.Annotations = in.Spec.Alerting.Annotations
+
+	var burnRatePolicy []slo.Window
+	if in.Spec.BurnRatePolicy != nil {
+		burnRatePolicy, err = in.Spec.BurnRatePolicy.windows()
+		if err != nil {
+			return slo.Objective{}, err
+		}
+	}
+
+	var budgetPolicy []slo.BudgetPolicyStage
+	if in.Spec.BudgetPolicy != nil {
+		budgetPolicy, err = in.Spec.BudgetPolicy.stages()
+		if err != nil {
+			return slo.Objective{}, err
+		}
+	}
 
 	if in.Spec.ServiceLevelIndicator.Ratio != nil && in.Spec.ServiceLevelIndicator.Latency != nil {
 		return slo.Objective{}, fmt.Errorf("cannot have ratio and latency indicators at the same time")
@@ -608,6 +900,26 @@ func (in *ServiceLevelObjective) Internal() (slo.Objective, error) {
 		}
 	}
 
+	var composite *slo.CompositeIndicator
+	if in.Spec.ServiceLevelIndicator.Composite != nil {
+		composite, err = in.Spec.ServiceLevelIndicator.Composite.internal(in.GetNamespace())
+		if err != nil {
+			return slo.Objective{}, err
+		}
+	}
+
+	var raw *slo.RawIndicator
+	if in.Spec.ServiceLevelIndicator.Raw != nil {
+		raw, err = slo.NewRawIndicator(
+			in.Spec.ServiceLevelIndicator.Raw.Good,
+			in.Spec.ServiceLevelIndicator.Raw.Total,
+			in.Spec.ServiceLevelIndicator.Raw.Grouping,
+		)
+		if err != nil {
+			return slo.Objective{}, err
+		}
+	}
+
 	inCopy := in.DeepCopy()
 	inCopy.ManagedFields = nil
 	delete(inCopy.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
@@ -648,8 +960,12 @@ func (in *ServiceLevelObjective) Internal() (slo.Objective, error) {
 		Description:             in.Spec.Description,
 		Target:                  target / 100,
 		Window:                  window,
+		Calendar:                calendar,
 		PerformanceOverAccuracy: in.Spec.PerformanceOverAccuracy,
 		RuleOutput:              ruleOutput,
+		Datasource:              in.Spec.Datasource,
+		BurnRatePolicy:          burnRatePolicy,
+		BudgetPolicy:            budgetPolicy,
 		Config:                  string(config),
 		Alerting:                alerting,
 		Indicator: slo.Indicator{
@@ -657,6 +973,200 @@ func (in *ServiceLevelObjective) Internal() (slo.Objective, error) {
 			Latency:       latency,
 			LatencyNative: latencyNative,
 			BoolGauge:     boolGauge,
+			Composite:     composite,
+			Raw:           raw,
 		},
 	}, nil
 }
+
+// internal parses the selectors and weights of the composite indicator.
+// Selectors without a namespace matcher only select objectives in the given namespace.
+// The components still need to be resolved with slo.Objective.ResolveComposite.
+func (c *CompositeIndicator) internal(namespace string) (*slo.CompositeIndicator, error) {
+	if len(c.Objectives) == 0 {
+		return nil, fmt.Errorf("composite must have at least one objective")
+	}
+
+	compositeModel := slo.CompositeWeighted
+	if c.Model != "" {
+		compositeModel = slo.CompositeModel(c.Model)
+	}
+	if compositeModel != slo.CompositeWeighted && compositeModel != slo.CompositeMultiplicative {
+		return nil, fmt.Errorf("composite model must be weighted or multiplicative, but got %q", c.Model)
+	}
+
+	components := make([]slo.CompositeComponent, 0, len(c.Objectives))
+	for i, o := range c.Objectives {
+		matchers, err := parser.ParseMetricSelector(o.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse composite objective %d selector: %w", i, err)
+		}
+
+		var hasNamespace bool
+		// Copy the matchers to get rid of the re field for unit testing...
+		selector := make([]*labels.Matcher, 0, len(matchers)+1)
+		for _, m := range matchers {
+			if m.Name == "namespace" {
+				hasNamespace = true
+			}
+			selector = append(selector, &labels.Matcher{Type: m.Type, Name: m.Name, Value: m.Value})
+		}
+		if !hasNamespace && namespace != "" {
+			selector = append(selector, &labels.Matcher{Type: labels.MatchEqual, Name: "namespace", Value: namespace})
+		}
+
+		weight := 1.0
+		if o.Weight != "" {
+			weight, err = strconv.ParseFloat(o.Weight, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse composite objective %d weight: %w", i, err)
+			}
+			if weight <= 0 {
+				return nil, fmt.Errorf("composite objective %d weight must be greater than 0", i)
+			}
+		}
+
+		components = append(components, slo.CompositeComponent{
+			Selector: selector,
+			Weight:   weight,
+		})
+	}
+
+	return &slo.CompositeIndicator{
+		Model:      compositeModel,
+		Components: components,
+	}, nil
+}
+
+// windows parses and validates the burn rate tiers of the policy.
+func (p *BurnRatePolicy) windows() ([]slo.Window, error) {
+	if len(p.Windows) == 0 {
+		return nil, fmt.Errorf("burnRatePolicy must have at least one window")
+	}
+
+	type shortLong struct{ short, long model.Duration }
+	seen := make(map[shortLong]struct{}, len(p.Windows))
+
+	windows := make([]slo.Window, 0, len(p.Windows))
+	for i, w := range p.Windows {
+		short, err := model.ParseDuration(w.Short)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse burnRatePolicy window %d short: %w", i, err)
+		}
+		long, err := model.ParseDuration(w.Long)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse burnRatePolicy window %d long: %w", i, err)
+		}
+		if short <= 0 || long <= short {
+			return nil, fmt.Errorf("burnRatePolicy window %d long (%s) must be longer than short (%s)", i, long, short)
+		}
+		if _, ok := seen[shortLong{short, long}]; ok {
+			return nil, fmt.Errorf("burnRatePolicy window %d duplicates short %s and long %s", i, short, long)
+		}
+		seen[shortLong{short, long}] = struct{}{}
+
+		factor, err := strconv.ParseFloat(w.Factor, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse burnRatePolicy window %d factor: %w", i, err)
+		}
+		if factor <= 0 {
+			return nil, fmt.Errorf("burnRatePolicy window %d factor must be greater than 0", i)
+		}
+
+		var forDuration model.Duration
+		if w.For != "" {
+			forDuration, err = model.ParseDuration(w.For)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse burnRatePolicy window %d for: %w", i, err)
+			}
+		}
+
+		severity := w.Severity
+		if severity == "" {
+			severity = "critical"
+		}
+
+		if err := validateBurnRateAlertLabels(w.Labels); err != nil {
+			return nil, fmt.Errorf("burnRatePolicy window %d %w", i, err)
+		}
+
+		windows = append(windows, slo.Window{
+			Severity:    slo.Severity(severity),
+			For:         time.Duration(forDuration),
+			Long:        time.Duration(long),
+			Short:       time.Duration(short),
+			Factor:      factor,
+			Labels:      w.Labels,
+			Annotations: w.Annotations,
+		})
+	}
+
+	return windows, nil
+}
+
+// validateBurnRateAlertLabels checks that custom labels of burn rate alerts are valid
+// and don't collide with the labels Pyrra sets itself.
+func validateBurnRateAlertLabels(labels map[string]string) error {
+	for name := range labels {
+		if !model.LabelName(name).IsValid() {
+			return fmt.Errorf("label %q is not a valid label name", name)
+		}
+		switch name {
+		case "slo", "short", "long", "severity", "exhaustion":
+			return fmt.Errorf("label %q is set by Pyrra", name)
+		}
+	}
+	return nil
+}
+
+// stages parses and validates the stages of the error budget policy.
+func (p *BudgetPolicy) stages() ([]slo.BudgetPolicyStage, error) {
+	if len(p.Stages) == 0 {
+		return nil, fmt.Errorf("budgetPolicy must have at least one stage")
+	}
+
+	names := make(map[string]struct{}, len(p.Stages))
+	thresholds := make(map[float64]struct{}, len(p.Stages))
+
+	stages := make([]slo.BudgetPolicyStage, 0, len(p.Stages))
+	for i, s := range p.Stages {
+		if s.Name == "" {
+			return nil, fmt.Errorf("budgetPolicy stage %d name must be set", i)
+		}
+		if _, ok := names[s.Name]; ok {
+			return nil, fmt.Errorf("budgetPolicy stage %d duplicates name %s", i, s.Name)
+		}
+		names[s.Name] = struct{}{}
+
+		remaining, err := strconv.ParseFloat(s.Remaining, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse budgetPolicy stage %d remaining: %w", i, err)
+		}
+		if remaining < 0 || remaining > 100 {
+			return nil, fmt.Errorf("budgetPolicy stage %d remaining must be between 0 and 100", i)
+		}
+		if _, ok := thresholds[remaining]; ok {
+			return nil, fmt.Errorf("budgetPolicy stage %d duplicates remaining %s", i, s.Remaining)
+		}
+		thresholds[remaining] = struct{}{}
+
+		for name := range s.Labels {
+			if !model.LabelName(name).IsValid() {
+				return nil, fmt.Errorf("budgetPolicy stage %d label %q is not a valid label name", i, name)
+			}
+			switch name {
+			case "slo", "stage", "severity":
+				return nil, fmt.Errorf("budgetPolicy stage %d label %q is set by Pyrra", i, name)
+			}
+		}
+
+		stages = append(stages, slo.BudgetPolicyStage{
+			Name:      s.Name,
+			Remaining: remaining / 100,
+			Severity:  slo.Severity(s.Severity),
+			Labels:    s.Labels,
+		})
+	}
+
+	return stages, nil
+}
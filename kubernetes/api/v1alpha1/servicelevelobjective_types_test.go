@@ -407,7 +407,7 @@ func TestServiceLevelObjective_Validate(t *testing.T) {
 		empty.Spec.Window = "2w"
 		warn, err = empty.ValidateCreate(ctx, empty)
 		require.Nil(t, warn)
-		require.EqualError(t, err, "one of ratio, latency, latencyNative or bool_gauge must be set")
+		require.EqualError(t, err, "one of ratio, latency, latencyNative, bool_gauge, composite or raw must be set")
 	})
 
 	t.Run("ratio", func(t *testing.T) {
@@ -864,4 +864,374 @@ func TestServiceLevelObjective_Validate(t *testing.T) {
 			require.Empty(t, internal.Alerting.Severities.LongTermBurn)
 		})
 	})
+
+	t.Run("burnRatePolicy", func(t *testing.T) {
+		policy := func() *v1alpha1.ServiceLevelObjective {
+			return &v1alpha1.ServiceLevelObjective{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "name",
+					Namespace: "namespace",
+				},
+				Spec: v1alpha1.ServiceLevelObjectiveSpec{
+					Target: "99",
+					Window: "2w",
+					ServiceLevelIndicator: v1alpha1.ServiceLevelIndicator{
+						Ratio: &v1alpha1.RatioIndicator{
+							Errors: v1alpha1.Query{Metric: `errors{foo="bar"}`},
+							Total:  v1alpha1.Query{Metric: `total{foo="bar"}`},
+						},
+					},
+					BurnRatePolicy: &v1alpha1.BurnRatePolicy{
+						Windows: []v1alpha1.BurnRateWindow{{
+							Short:    "10m",
+							Long:     "2h",
+							Factor:   "12.5",
+							For:      "3m",
+							Severity: "page",
+						}, {
+							Short:  "2h",
+							Long:   "1d",
+							Factor: "2",
+						}},
+					},
+				},
+			}
+		}
+
+		warn, err := policy().ValidateCreate(ctx, policy())
+		require.NoError(t, err)
+		require.Nil(t, warn)
+
+		internal, err := policy().Internal()
+		require.NoError(t, err)
+		require.Equal(t, []slo.Window{{
+			Severity: "page",
+			For:      3 * time.Minute,
+			Long:     2 * time.Hour,
+			Short:    10 * time.Minute,
+			Factor:   12.5,
+		}, {
+			Severity: "critical",
+			Long:     24 * time.Hour,
+			Short:    2 * time.Hour,
+			Factor:   2,
+		}}, internal.Windows())
+
+		t.Run("empty", func(t *testing.T) {
+			slo := policy()
+			slo.Spec.BurnRatePolicy.Windows = nil
+			_, err := slo.ValidateCreate(ctx, slo)
+			require.EqualError(t, err, "burnRatePolicy must have at least one window")
+		})
+
+		t.Run("invalid", func(t *testing.T) {
+			slo := policy()
+			slo.Spec.BurnRatePolicy.Windows[1].Long = "1h"
+			_, err := slo.ValidateCreate(ctx, slo)
+			require.EqualError(t, err, "burnRatePolicy window 1 long (1h) must be longer than short (2h)")
+
+			slo = policy()
+			slo.Spec.BurnRatePolicy.Windows[1].Short = "10m"
+			slo.Spec.BurnRatePolicy.Windows[1].Long = "2h"
+			_, err = slo.ValidateCreate(ctx, slo)
+			require.EqualError(t, err, "burnRatePolicy window 1 duplicates short 10m and long 2h")
+
+			slo = policy()
+			slo.Spec.BurnRatePolicy.Windows[0].Factor = "0"
+			_, err = slo.ValidateCreate(ctx, slo)
+			require.EqualError(t, err, "burnRatePolicy window 0 factor must be greater than 0")
+
+			slo = policy()
+			slo.Spec.BurnRatePolicy.Windows[0].For = "soon"
+			_, err = slo.Internal()
+			require.EqualError(t, err, `failed to parse burnRatePolicy window 0 for: not a valid duration string: "soon"`)
+
+			slo = policy()
+			slo.Spec.BurnRatePolicy.Windows[1].Labels = map[string]string{"long": "1d"}
+			_, err = slo.ValidateCreate(ctx, slo)
+			require.EqualError(t, err, `burnRatePolicy window 1 label "long" is set by Pyrra`)
+
+			slo = policy()
+			slo.Spec.Alerting.Labels = map[string]string{"severity": "page"}
+			_, err = slo.ValidateCreate(ctx, slo)
+			require.EqualError(t, err, `alerting label "severity" is set by Pyrra`)
+		})
+
+		t.Run("labels", func(t *testing.T) {
+			slo := policy()
+			slo.Spec.Alerting.Labels = map[string]string{"team": "observability"}
+			slo.Spec.Alerting.Annotations = map[string]string{"runbook_url": "https://example.com/runbook"}
+			slo.Spec.BurnRatePolicy.Windows[0].Labels = map[string]string{"routing": "pager"}
+			slo.Spec.BurnRatePolicy.Windows[0].Annotations = map[string]string{"summary": "High error budget burn"}
+			_, err := slo.ValidateCreate(ctx, slo)
+			require.NoError(t, err)
+
+			internal, err := slo.Internal()
+			require.NoError(t, err)
+			require.Equal(t, map[string]string{"team": "observability"}, internal.Alerting.Labels)
+			require.Equal(t, map[string]string{"runbook_url": "https://example.com/runbook"}, internal.Alerting.Annotations)
+			require.Equal(t, map[string]string{"routing": "pager"}, internal.BurnRatePolicy[0].Labels)
+			require.Equal(t, map[string]string{"summary": "High error budget burn"}, internal.BurnRatePolicy[0].Annotations)
+		})
+
+		t.Run("longer than window", func(t *testing.T) {
+			slo := policy()
+			slo.Spec.BurnRatePolicy.Windows[1].Long = "3w"
+			warn, err := slo.ValidateCreate(ctx, slo)
+			require.NoError(t, err)
+			require.Equal(t, "burnRatePolicy long window 3w is longer than the objective's window 2w", warn[0])
+		})
+	})
+
+	t.Run("budgetPolicy", func(t *testing.T) {
+		policy := func() *v1alpha1.ServiceLevelObjective {
+			return &v1alpha1.ServiceLevelObjective{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "name",
+					Namespace: "namespace",
+				},
+				Spec: v1alpha1.ServiceLevelObjectiveSpec{
+					Target: "99",
+					Window: "2w",
+					ServiceLevelIndicator: v1alpha1.ServiceLevelIndicator{
+						Ratio: &v1alpha1.RatioIndicator{
+							Errors: v1alpha1.Query{Metric: `errors{foo="bar"}`},
+							Total:  v1alpha1.Query{Metric: `total{foo="bar"}`},
+						},
+					},
+					BudgetPolicy: &v1alpha1.BudgetPolicy{
+						Stages: []v1alpha1.BudgetPolicyStage{{
+							Name:      "freeze-deploys",
+							Remaining: "25",
+							Labels:    map[string]string{"action": "freeze"},
+						}, {
+							Name:      "exhausted",
+							Remaining: "0",
+							Severity:  "page",
+						}},
+					},
+				},
+			}
+		}
+
+		warn, err := policy().ValidateCreate(ctx, policy())
+		require.NoError(t, err)
+		require.Nil(t, warn)
+
+		internal, err := policy().Internal()
+		require.NoError(t, err)
+		require.Equal(t, []slo.BudgetPolicyStage{{
+			Name:      "freeze-deploys",
+			Remaining: 0.25,
+			Labels:    map[string]string{"action": "freeze"},
+		}, {
+			Name:      "exhausted",
+			Remaining: 0,
+			Severity:  "page",
+		}}, internal.BudgetPolicy)
+
+		t.Run("invalid", func(t *testing.T) {
+			slo := policy()
+			slo.Spec.BudgetPolicy.Stages = nil
+			_, err := slo.ValidateCreate(ctx, slo)
+			require.EqualError(t, err, "budgetPolicy must have at least one stage")
+
+			slo = policy()
+			slo.Spec.BudgetPolicy.Stages[1].Name = "freeze-deploys"
+			_, err = slo.ValidateCreate(ctx, slo)
+			require.EqualError(t, err, "budgetPolicy stage 1 duplicates name freeze-deploys")
+
+			slo = policy()
+			slo.Spec.BudgetPolicy.Stages[1].Remaining = "25.0"
+			_, err = slo.ValidateCreate(ctx, slo)
+			require.EqualError(t, err, "budgetPolicy stage 1 duplicates remaining 25.0")
+
+			slo = policy()
+			slo.Spec.BudgetPolicy.Stages[0].Remaining = "120"
+			_, err = slo.ValidateCreate(ctx, slo)
+			require.EqualError(t, err, "budgetPolicy stage 0 remaining must be between 0 and 100")
+
+			slo = policy()
+			slo.Spec.BudgetPolicy.Stages[0].Labels = map[string]string{"stage": "other"}
+			_, err = slo.ValidateCreate(ctx, slo)
+			require.EqualError(t, err, `budgetPolicy stage 0 label "stage" is set by Pyrra`)
+
+			slo = policy()
+			slo.Spec.Window = ""
+			slo.Spec.Calendar = &v1alpha1.CalendarWindow{Period: "month"}
+			_, err = slo.ValidateCreate(ctx, slo)
+			require.EqualError(t, err, "budgetPolicy doesn't support calendar windows")
+		})
+	})
+
+	t.Run("calendar", func(t *testing.T) {
+		calendar := func() *v1alpha1.ServiceLevelObjective {
+			return &v1alpha1.ServiceLevelObjective{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "name",
+					Namespace: "namespace",
+				},
+				Spec: v1alpha1.ServiceLevelObjectiveSpec{
+					Target: "99",
+					Calendar: &v1alpha1.CalendarWindow{
+						Period:   "month",
+						TimeZone: "Europe/Berlin",
+					},
+					ServiceLevelIndicator: v1alpha1.ServiceLevelIndicator{
+						Ratio: &v1alpha1.RatioIndicator{
+							Errors: v1alpha1.Query{Metric: `errors{foo="bar"}`},
+							Total:  v1alpha1.Query{Metric: `total{foo="bar"}`},
+						},
+					},
+				},
+			}
+		}
+
+		warn, err := calendar().ValidateCreate(ctx, calendar())
+		require.NoError(t, err)
+		require.Nil(t, warn)
+
+		internal, err := calendar().Internal()
+		require.NoError(t, err)
+		require.Equal(t, slo.CalendarMonth, internal.Calendar.Period)
+		require.Equal(t, "Europe/Berlin", internal.Calendar.Location.String())
+		require.Equal(t, model.Duration(30*24*time.Hour), internal.Window)
+
+		t.Run("window", func(t *testing.T) {
+			slo := calendar()
+			slo.Spec.Window = "4w"
+			_, err := slo.ValidateCreate(ctx, slo)
+			require.EqualError(t, err, "window and calendar cannot be set at the same time")
+		})
+
+		t.Run("invalid", func(t *testing.T) {
+			slo := calendar()
+			slo.Spec.Calendar.Period = "year"
+			_, err := slo.ValidateCreate(ctx, slo)
+			require.EqualError(t, err, `calendar period must be one of week, month or quarter, but got "year"`)
+
+			slo = calendar()
+			slo.Spec.Calendar.TimeZone = "Mars/Olympus"
+			_, err = slo.Internal()
+			require.EqualError(t, err, "failed to parse objective calendar: failed to load calendar time zone: unknown time zone Mars/Olympus")
+		})
+	})
+
+	t.Run("composite", func(t *testing.T) {
+		composite := func() *v1alpha1.ServiceLevelObjective {
+			return &v1alpha1.ServiceLevelObjective{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "checkout",
+					Namespace: "shop",
+				},
+				Spec: v1alpha1.ServiceLevelObjectiveSpec{
+					Target: "99",
+					Window: "2w",
+					ServiceLevelIndicator: v1alpha1.ServiceLevelIndicator{
+						Composite: &v1alpha1.CompositeIndicator{
+							Objectives: []v1alpha1.CompositeObjective{
+								{Selector: `{__name__="cart-errors"}`, Weight: "2"},
+								{Selector: `{__name__="payment-errors",namespace="payment"}`},
+							},
+						},
+					},
+				},
+			}
+		}
+
+		warn, err := composite().ValidateCreate(ctx, composite())
+		require.NoError(t, err)
+		require.Nil(t, warn)
+
+		internal, err := composite().Internal()
+		require.NoError(t, err)
+		require.Equal(t, &slo.CompositeIndicator{
+			Model: slo.CompositeWeighted,
+			Components: []slo.CompositeComponent{{
+				Selector: []*labels.Matcher{
+					{Type: labels.MatchEqual, Name: model.MetricNameLabel, Value: "cart-errors"},
+					{Type: labels.MatchEqual, Name: "namespace", Value: "shop"},
+				},
+				Weight: 2,
+			}, {
+				Selector: []*labels.Matcher{
+					{Type: labels.MatchEqual, Name: model.MetricNameLabel, Value: "payment-errors"},
+					{Type: labels.MatchEqual, Name: "namespace", Value: "payment"},
+				},
+				Weight: 1,
+			}},
+		}, internal.Indicator.Composite)
+
+		t.Run("multiplicative", func(t *testing.T) {
+			slo := composite()
+			slo.Spec.ServiceLevelIndicator.Composite.Model = "multiplicative"
+			warn, err := slo.ValidateCreate(ctx, slo)
+			require.NoError(t, err)
+			require.Equal(t, admission.Warnings{"composite objective 0 weight is ignored by the multiplicative model"}, warn)
+		})
+
+		t.Run("invalid", func(t *testing.T) {
+			slo := composite()
+			slo.Spec.ServiceLevelIndicator.Composite.Objectives[0].Selector = `{__name__=}`
+			_, err := slo.ValidateCreate(ctx, slo)
+			require.ErrorContains(t, err, "failed to parse composite objective 0 selector")
+
+			slo = composite()
+			slo.Spec.ServiceLevelIndicator.Composite.Objectives[1].Weight = "0"
+			_, err = slo.ValidateCreate(ctx, slo)
+			require.EqualError(t, err, "composite objective 1 weight must be greater than 0")
+
+			slo = composite()
+			slo.Spec.ServiceLevelIndicator.Composite.Model = "average"
+			_, err = slo.ValidateCreate(ctx, slo)
+			require.EqualError(t, err, `composite model must be weighted or multiplicative, but got "average"`)
+
+			slo = composite()
+			slo.Spec.Window = ""
+			slo.Spec.Calendar = &v1alpha1.CalendarWindow{Period: "month"}
+			_, err = slo.ValidateCreate(ctx, slo)
+			require.EqualError(t, err, "composite objectives don't support calendar windows")
+		})
+	})
+
+	t.Run("raw", func(t *testing.T) {
+		raw := func() *v1alpha1.ServiceLevelObjective {
+			return &v1alpha1.ServiceLevelObjective{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "api-raw",
+					Namespace: "monitoring",
+				},
+				Spec: v1alpha1.ServiceLevelObjectiveSpec{
+					Target: "99",
+					Window: "2w",
+					ServiceLevelIndicator: v1alpha1.ServiceLevelIndicator{
+						Raw: &v1alpha1.RawIndicator{
+							Good:     `sum by (handler) (label_replace(http_requests_total{code!~"5.."}, "handler", "$1", "path", "(.*)"))`,
+							Total:    `sum by (handler) (label_replace(http_requests_total, "handler", "$1", "path", "(.*)"))`,
+							Grouping: []string{"handler"},
+						},
+					},
+				},
+			}
+		}
+
+		warn, err := raw().ValidateCreate(ctx, raw())
+		require.NoError(t, err)
+		require.Nil(t, warn)
+
+		internal, err := raw().Internal()
+		require.NoError(t, err)
+		require.Equal(t, slo.Raw, internal.IndicatorType())
+		require.Equal(t, []string{"handler"}, internal.Grouping())
+
+		slo := raw()
+		slo.Spec.ServiceLevelIndicator.Raw.Grouping = []string{"path", "handler"}
+		_, err = slo.ValidateCreate(ctx, slo)
+		require.EqualError(t, err, `raw grouping label "path" is not kept by the good expression`)
+
+		slo = raw()
+		slo.Spec.ServiceLevelIndicator.Raw.Total = `sum(rate(http_requests_total[5m]))`
+		_, err = slo.ValidateCreate(ctx, slo)
+		require.EqualError(t, err, "raw total expression must select counters without a range, rate and increase are added by Pyrra")
+	})
 }
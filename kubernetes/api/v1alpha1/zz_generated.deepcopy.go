@@ -21,6 +21,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -47,6 +48,20 @@ func (in *Alerting) DeepCopyInto(out *Alerting) {
 		*out = new(AlertingSeverities)
 		**out = **in
 	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Alerting.
@@ -95,6 +110,151 @@ func (in *BoolGaugeIndicator) DeepCopy() *BoolGaugeIndicator {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BudgetPolicy) DeepCopyInto(out *BudgetPolicy) {
+	*out = *in
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]BudgetPolicyStage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BudgetPolicy.
+func (in *BudgetPolicy) DeepCopy() *BudgetPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BudgetPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BudgetPolicyStage) DeepCopyInto(out *BudgetPolicyStage) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BudgetPolicyStage.
+func (in *BudgetPolicyStage) DeepCopy() *BudgetPolicyStage {
+	if in == nil {
+		return nil
+	}
+	out := new(BudgetPolicyStage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BurnRatePolicy) DeepCopyInto(out *BurnRatePolicy) {
+	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]BurnRateWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BurnRatePolicy.
+func (in *BurnRatePolicy) DeepCopy() *BurnRatePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BurnRatePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BurnRateWindow) DeepCopyInto(out *BurnRateWindow) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BurnRateWindow.
+func (in *BurnRateWindow) DeepCopy() *BurnRateWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(BurnRateWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CalendarWindow) DeepCopyInto(out *CalendarWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalendarWindow.
+func (in *CalendarWindow) DeepCopy() *CalendarWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(CalendarWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositeIndicator) DeepCopyInto(out *CompositeIndicator) {
+	*out = *in
+	if in.Objectives != nil {
+		in, out := &in.Objectives, &out.Objectives
+		*out = make([]CompositeObjective, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositeIndicator.
+func (in *CompositeIndicator) DeepCopy() *CompositeIndicator {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositeIndicator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositeObjective) DeepCopyInto(out *CompositeObjective) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositeObjective.
+func (in *CompositeObjective) DeepCopy() *CompositeObjective {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositeObjective)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LatencyIndicator) DeepCopyInto(out *LatencyIndicator) {
 	*out = *in
@@ -175,6 +335,26 @@ func (in *RatioIndicator) DeepCopy() *RatioIndicator {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RawIndicator) DeepCopyInto(out *RawIndicator) {
+	*out = *in
+	if in.Grouping != nil {
+		in, out := &in.Grouping, &out.Grouping
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RawIndicator.
+func (in *RawIndicator) DeepCopy() *RawIndicator {
+	if in == nil {
+		return nil
+	}
+	out := new(RawIndicator)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RuleOutput) DeepCopyInto(out *RuleOutput) {
 	*out = *in
@@ -227,6 +407,16 @@ func (in *ServiceLevelIndicator) DeepCopyInto(out *ServiceLevelIndicator) {
 		*out = new(BoolGaugeIndicator)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Composite != nil {
+		in, out := &in.Composite, &out.Composite
+		*out = new(CompositeIndicator)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Raw != nil {
+		in, out := &in.Raw, &out.Raw
+		*out = new(RawIndicator)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceLevelIndicator.
@@ -245,7 +435,7 @@ func (in *ServiceLevelObjective) DeepCopyInto(out *ServiceLevelObjective) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceLevelObjective.
@@ -301,8 +491,23 @@ func (in *ServiceLevelObjectiveList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceLevelObjectiveSpec) DeepCopyInto(out *ServiceLevelObjectiveSpec) {
 	*out = *in
+	if in.Calendar != nil {
+		in, out := &in.Calendar, &out.Calendar
+		*out = new(CalendarWindow)
+		**out = **in
+	}
 	in.ServiceLevelIndicator.DeepCopyInto(&out.ServiceLevelIndicator)
 	in.Alerting.DeepCopyInto(&out.Alerting)
+	if in.BurnRatePolicy != nil {
+		in, out := &in.BurnRatePolicy, &out.BurnRatePolicy
+		*out = new(BurnRatePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BudgetPolicy != nil {
+		in, out := &in.BudgetPolicy, &out.BudgetPolicy
+		*out = new(BudgetPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RuleOutput != nil {
 		in, out := &in.RuleOutput, &out.RuleOutput
 		*out = new(RuleOutput)
@@ -323,6 +528,22 @@ func (in *ServiceLevelObjectiveSpec) DeepCopy() *ServiceLevelObjectiveSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceLevelObjectiveStatus) DeepCopyInto(out *ServiceLevelObjectiveStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GeneratedResources != nil {
+		in, out := &in.GeneratedResources, &out.GeneratedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastQueried != nil {
+		in, out := &in.LastQueried, &out.LastQueried
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceLevelObjectiveStatus.
@@ -19,32 +19,42 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"math"
+	"strconv"
 	"time"
 
 	kitlog "github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/rulefmt"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/yaml"
 
 	pyrrav1alpha1 "github.com/pyrra-dev/pyrra/kubernetes/api/v1alpha1"
-	"github.com/pyrra-dev/pyrra/mimir"
+	"github.com/pyrra-dev/pyrra/ruler"
 	"github.com/pyrra-dev/pyrra/slo"
 )
 
 // ServiceLevelObjectiveReconciler reconciles a ServiceLevelObjective object.
 type ServiceLevelObjectiveReconciler struct {
 	client.Client
-	MimirClient                *mimir.Client
+	RulerClient                ruler.Client
 	MimirWriteAlertingRules    bool
 	Logger                     kitlog.Logger
 	Scheme                     *runtime.Scheme
@@ -52,6 +62,16 @@ type ServiceLevelObjectiveReconciler struct {
 	GenericRules               bool
 	EnablePrometheus3Migration bool
 	PyrraExternalURL           string
+	// Prometheus is queried for the availability and error budget written to the status.
+	// Nothing is queried if it's nil.
+	Prometheus PrometheusQuerier
+	// StatusInterval is how often the availability and error budget of all objectives are queried.
+	StatusInterval time.Duration
+}
+
+// PrometheusQuerier runs instant queries against Prometheus.
+type PrometheusQuerier interface {
+	Query(ctx context.Context, query string, ts time.Time, opts ...prometheusv1.Option) (model.Value, prometheusv1.Warnings, error)
 }
 
 // +kubebuilder:rbac:groups=pyrra.dev,resources=servicelevelobjectives,verbs=get;list;watch;create;update;patch;delete
@@ -69,11 +89,8 @@ func (r *ServiceLevelObjectiveReconciler) Reconcile(ctx context.Context, req ctr
 		return ctrl.Result{}, client.IgnoreNotFound(fmt.Errorf("getting SLO: %w", err))
 	}
 
-	if r.ConfigMapMode {
-		return r.reconcileConfigMap(ctx, logger, req, slo)
-	}
-
-	if r.MimirClient != nil {
+	if !r.ConfigMapMode && r.RulerClient != nil {
+		// The finalizer keeps its name from when only Mimir was supported, so existing objectives keep it.
 		mimirFinalizer := "mimir.servicelevelobjective.pyrra.dev/finalizer"
 		if slo.DeletionTimestamp.IsZero() {
 			// slo is not being deleted, add our finalizer if not already present
@@ -86,8 +103,8 @@ func (r *ServiceLevelObjectiveReconciler) Reconcile(ctx context.Context, req ctr
 		} else {
 			// slo is being deleted
 			if controllerutil.ContainsFinalizer(&slo, mimirFinalizer) {
-				level.Info(logger).Log("msg", "deleting mimir rule group", "name", slo.GetName())
-				if err := r.deleteMimirRuleGroup(ctx, slo); err != nil {
+				level.Info(logger).Log("msg", "deleting ruler rule group", "name", slo.GetName())
+				if err := r.deleteRulerRuleGroup(ctx, slo); err != nil {
 					return ctrl.Result{}, err
 				}
 
@@ -100,14 +117,159 @@ func (r *ServiceLevelObjectiveReconciler) Reconcile(ctx context.Context, req ctr
 			// Stop reconciliation as the item is being deleted
 			return ctrl.Result{}, nil
 		}
+	}
+
+	status := slo.Status.DeepCopy()
+	slo.Status.ObservedGeneration = slo.GetGeneration()
+
+	_, err := slo.Internal()
+	setCondition(&slo, pyrrav1alpha1.ConditionValid, err, "Valid", "Invalid")
+	if err != nil {
+		// The rules can't be generated until the spec is fixed, which triggers another reconciliation.
+		setCondition(&slo, pyrrav1alpha1.ConditionRulesGenerated, errInvalidSpec, "Generated", "InvalidSpec")
+		level.Warn(logger).Log("msg", "invalid objective", "err", err)
+		return ctrl.Result{}, r.updateStatus(ctx, status, &slo)
+	}
+
+	switch {
+	case r.ConfigMapMode:
+		err = r.reconcileConfigMap(ctx, logger, req, &slo)
+	case r.RulerClient != nil:
+		err = r.reconcileRulerRuleGroup(ctx, logger, &slo)
+	default:
+		err = r.reconcilePrometheusRule(ctx, logger, req, &slo)
+	}
+	setCondition(&slo, pyrrav1alpha1.ConditionRulesGenerated, err, "Generated", "GenerationFailed")
+	if err != nil {
+		if statusErr := r.updateStatus(ctx, status, &slo); statusErr != nil {
+			level.Warn(logger).Log("msg", "failed to update status", "err", statusErr)
+		}
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, r.updateStatus(ctx, status, &slo)
+}
+
+var errInvalidSpec = fmt.Errorf("the spec is invalid")
+
+// setCondition sets the condition to true with the reason,
+// or to false with the failedReason and the error as message if err isn't nil.
+func setCondition(kubeObjective *pyrrav1alpha1.ServiceLevelObjective, conditionType string, err error, reason, failedReason string) {
+	condition := metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		ObservedGeneration: kubeObjective.GetGeneration(),
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = failedReason
+		condition.Message = err.Error()
+	}
+	meta.SetStatusCondition(&kubeObjective.Status.Conditions, condition)
+}
+
+// updateStatus writes the objective's status unless it's unchanged.
+// Skipping unchanged statuses saves the API server writes for every reconciliation and status poll.
+func (r *ServiceLevelObjectiveReconciler) updateStatus(ctx context.Context, previous *pyrrav1alpha1.ServiceLevelObjectiveStatus, kubeObjective *pyrrav1alpha1.ServiceLevelObjective) error {
+	if equality.Semantic.DeepEqual(*previous, kubeObjective.Status) {
+		return nil
+	}
+	if err := r.Status().Update(ctx, kubeObjective); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+	return nil
+}
+
+// pollStatus queries the availability and error budget of all objectives every StatusInterval until ctx is done.
+// It runs separately from Reconcile, so that the rules aren't generated again whenever the status is queried.
+func (r *ServiceLevelObjectiveReconciler) pollStatus(ctx context.Context) error {
+	ticker := time.NewTicker(r.StatusInterval)
+	defer ticker.Stop()
+
+	for {
+		r.refreshStatus(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshStatus queries the availability and error budget of all objectives and writes the ones that changed to their status.
+func (r *ServiceLevelObjectiveReconciler) refreshStatus(ctx context.Context) {
+	var list pyrrav1alpha1.ServiceLevelObjectiveList
+	if err := r.List(ctx, &list); err != nil {
+		level.Warn(r.Logger).Log("msg", "failed to list objectives for their status", "err", err)
+		return
+	}
+
+	for i := range list.Items {
+		kubeObjective := &list.Items[i]
+		if !kubeObjective.DeletionTimestamp.IsZero() {
+			continue
+		}
+		objective, err := kubeObjective.Internal()
+		if err != nil {
+			continue // Invalid objectives are reported in their conditions by Reconcile.
+		}
+
+		logger := kitlog.With(r.Logger, "namespace", kubeObjective.GetNamespace(), "name", kubeObjective.GetName())
+		status := kubeObjective.Status.DeepCopy()
+		if err := r.queryStatus(ctx, objective, kubeObjective); err != nil {
+			level.Warn(logger).Log("msg", "failed to query availability and error budget", "err", err)
+			continue
+		}
+		if err := r.updateStatus(ctx, status, kubeObjective); err != nil {
+			level.Warn(logger).Log("msg", "failed to update availability and error budget", "err", err)
+		}
+	}
+}
+
+// queryStatus queries the remaining error budget of the objective and writes it to the status.
+// The availability is derived from the error budget, so that a single query is needed.
+func (r *ServiceLevelObjectiveReconciler) queryStatus(ctx context.Context, objective slo.Objective, kubeObjective *pyrrav1alpha1.ServiceLevelObjective) error {
+	now := time.Now()
+	opts := slo.GenerationOptions{EnablePrometheus3Migration: r.EnablePrometheus3Migration}
+
+	query, err := objective.QueryCalendar(objective.QueryErrorBudget(opts), now, now)
+	if err != nil {
+		return err
+	}
+	if query == "" {
+		return nil
+	}
+	if len(objective.Grouping()) > 0 {
+		// The status has room for a single budget only, so grouped objectives report their worst group.
+		query = "min(" + query + ")"
+	}
 
-		return r.reconcileMimirRuleGroup(ctx, logger, slo)
+	value, _, err := r.Prometheus.Query(ctx, query, now)
+	if err != nil {
+		return err
+	}
+	vector, ok := value.(model.Vector)
+	if !ok {
+		return fmt.Errorf("expected vector, got %s", value.Type())
 	}
+	if len(vector) == 0 || math.IsNaN(float64(vector[0].Value)) {
+		// Without any requests there's nothing to report.
+		kubeObjective.Status.Availability = ""
+		kubeObjective.Status.Budget = ""
+		return nil
+	}
+
+	budget := float64(vector[0].Value)
+	availability := 1 - (1-objective.Target)*(1-budget)
 
-	return r.reconcilePrometheusRule(ctx, logger, req, slo)
+	kubeObjective.Status.Availability = strconv.FormatFloat(100*availability, 'f', 3, 64)
+	kubeObjective.Status.Budget = strconv.FormatFloat(100*budget, 'f', 2, 64)
+	return nil
 }
 
-func (r *ServiceLevelObjectiveReconciler) reconcilePrometheusRule(ctx context.Context, logger kitlog.Logger, req ctrl.Request, kubeObjective pyrrav1alpha1.ServiceLevelObjective) (ctrl.Result, error) {
+func (r *ServiceLevelObjectiveReconciler) reconcilePrometheusRule(ctx context.Context, logger kitlog.Logger, req ctrl.Request, kubeObjective *pyrrav1alpha1.ServiceLevelObjective) error {
 	if kubeObjective.Spec.PerformanceOverAccuracy {
 		return r.reconcileSplitPrometheusRules(ctx, logger, req, kubeObjective)
 	}
@@ -119,35 +281,43 @@ func (r *ServiceLevelObjectiveReconciler) reconcilePrometheusRule(ctx context.Co
 		req.Name+"-increase", // legacy name from before the -short/-long split
 	)
 
-	newRule, err := makePrometheusRule(kubeObjective, r.GenericRules, r.EnablePrometheus3Migration, r.PyrraExternalURL)
+	objectives, err := r.compositeObjectives(ctx, *kubeObjective)
 	if err != nil {
-		return ctrl.Result{}, err
+		return err
 	}
 
-	if err := r.upsertPrometheusRule(ctx, logger, newRule); err != nil {
-		return ctrl.Result{}, err
+	newRule, err := makePrometheusRule(*kubeObjective, objectives, r.GenericRules, r.EnablePrometheus3Migration, r.PyrraExternalURL)
+	if err != nil {
+		return err
 	}
 
-	kubeObjective.Status.Type = "PrometheusRule"
-	if err := r.Status().Update(ctx, &kubeObjective); err != nil {
-		return ctrl.Result{}, err
+	if err := r.upsertPrometheusRule(ctx, logger, newRule); err != nil {
+		return err
 	}
 
-	return ctrl.Result{}, nil
+	kubeObjective.Status.Type = "PrometheusRule"
+	kubeObjective.Status.GeneratedResources = []string{newRule.GetName()}
+	meta.RemoveStatusCondition(&kubeObjective.Status.Conditions, pyrrav1alpha1.ConditionMimirSynced)
+	return nil
 }
 
-func (r *ServiceLevelObjectiveReconciler) reconcileSplitPrometheusRules(ctx context.Context, logger kitlog.Logger, req ctrl.Request, kubeObjective pyrrav1alpha1.ServiceLevelObjective) (ctrl.Result, error) {
-	shortRule, longRule, err := makeSplitPrometheusRules(kubeObjective, r.GenericRules, r.EnablePrometheus3Migration, r.PyrraExternalURL)
+func (r *ServiceLevelObjectiveReconciler) reconcileSplitPrometheusRules(ctx context.Context, logger kitlog.Logger, req ctrl.Request, kubeObjective *pyrrav1alpha1.ServiceLevelObjective) error {
+	objectives, err := r.compositeObjectives(ctx, *kubeObjective)
 	if err != nil {
-		return ctrl.Result{}, err
+		return err
+	}
+
+	shortRule, longRule, err := makeSplitPrometheusRules(*kubeObjective, objectives, r.GenericRules, r.EnablePrometheus3Migration, r.PyrraExternalURL)
+	if err != nil {
+		return err
 	}
 
 	if err := r.upsertPrometheusRule(ctx, logger, shortRule); err != nil {
-		return ctrl.Result{}, err
+		return err
 	}
 
 	if err := r.upsertPrometheusRule(ctx, logger, longRule); err != nil {
-		return ctrl.Result{}, err
+		return err
 	}
 
 	// Clean up stale rules from previous configurations:
@@ -159,11 +329,9 @@ func (r *ServiceLevelObjectiveReconciler) reconcileSplitPrometheusRules(ctx cont
 	)
 
 	kubeObjective.Status.Type = "PrometheusRule"
-	if err := r.Status().Update(ctx, &kubeObjective); err != nil {
-		return ctrl.Result{}, err
-	}
-
-	return ctrl.Result{}, nil
+	kubeObjective.Status.GeneratedResources = []string{shortRule.GetName(), longRule.GetName()}
+	meta.RemoveStatusCondition(&kubeObjective.Status.Conditions, pyrrav1alpha1.ConditionMimirSynced)
+	return nil
 }
 
 func (r *ServiceLevelObjectiveReconciler) upsertPrometheusRule(ctx context.Context, logger kitlog.Logger, newRule *monitoringv1.PrometheusRule) error {
@@ -178,6 +346,14 @@ func (r *ServiceLevelObjectiveReconciler) upsertPrometheusRule(ctx context.Conte
 		return fmt.Errorf("failed to get prometheus rule: %w", err)
 	}
 
+	if equality.Semantic.DeepEqual(existing.Spec, newRule.Spec) &&
+		equality.Semantic.DeepEqual(existing.Labels, newRule.Labels) &&
+		equality.Semantic.DeepEqual(existing.Annotations, newRule.Annotations) &&
+		equality.Semantic.DeepEqual(existing.OwnerReferences, newRule.OwnerReferences) {
+		level.Debug(logger).Log("msg", "prometheus rule is up to date", "namespace", newRule.GetNamespace(), "name", newRule.GetName())
+		return nil
+	}
+
 	newRule.ResourceVersion = existing.ResourceVersion
 	level.Info(logger).Log("msg", "updating prometheus rule", "namespace", newRule.GetNamespace(), "name", newRule.GetName())
 	return r.Update(ctx, newRule)
@@ -199,42 +375,50 @@ func (r *ServiceLevelObjectiveReconciler) cleanupStalePrometheusRules(ctx contex
 	}
 }
 
-func (r *ServiceLevelObjectiveReconciler) reconcileMimirRuleGroup(ctx context.Context, logger kitlog.Logger, kubeObjective pyrrav1alpha1.ServiceLevelObjective) (ctrl.Result, error) {
-	newRuleGroup, err := makeMimirRuleGroup(kubeObjective, r.GenericRules, r.MimirWriteAlertingRules, r.EnablePrometheus3Migration, r.PyrraExternalURL)
+func (r *ServiceLevelObjectiveReconciler) reconcileRulerRuleGroup(ctx context.Context, logger kitlog.Logger, kubeObjective *pyrrav1alpha1.ServiceLevelObjective) error {
+	objectives, err := r.compositeObjectives(ctx, *kubeObjective)
 	if err != nil {
-		return ctrl.Result{}, err
+		return err
 	}
 
-	level.Info(logger).Log("msg", "updating mimir rule", "name", newRuleGroup.Name)
-
-	err = r.MimirClient.SetRuleGroup(ctx, kubeObjective.GetName(), *newRuleGroup)
+	newRuleGroup, err := makeMimirRuleGroup(*kubeObjective, objectives, r.GenericRules, r.MimirWriteAlertingRules, r.EnablePrometheus3Migration, r.PyrraExternalURL)
 	if err != nil {
-		return ctrl.Result{}, err
+		return err
 	}
 
-	kubeObjective.Status.Type = "MimirRule"
-	if err := r.Status().Update(ctx, &kubeObjective); err != nil {
-		return ctrl.Result{}, err
+	level.Info(logger).Log("msg", "updating ruler rule group", "name", newRuleGroup.Name)
+
+	err = r.RulerClient.SetRuleGroup(ctx, kubeObjective.GetName(), *newRuleGroup)
+	setCondition(kubeObjective, pyrrav1alpha1.ConditionMimirSynced, err, "Synced", "SyncFailed")
+	if err != nil {
+		return err
 	}
 
-	return ctrl.Result{}, nil
+	kubeObjective.Status.Type = "MimirRule"
+	kubeObjective.Status.GeneratedResources = []string{newRuleGroup.Name}
+	return nil
 }
 
-func (r *ServiceLevelObjectiveReconciler) deleteMimirRuleGroup(ctx context.Context, kubeObjective pyrrav1alpha1.ServiceLevelObjective) error {
-	return r.MimirClient.DeleteNamespace(ctx, kubeObjective.GetName())
+func (r *ServiceLevelObjectiveReconciler) deleteRulerRuleGroup(ctx context.Context, kubeObjective pyrrav1alpha1.ServiceLevelObjective) error {
+	return r.RulerClient.DeleteNamespace(ctx, kubeObjective.GetName())
 }
 
 func (r *ServiceLevelObjectiveReconciler) reconcileConfigMap(
 	ctx context.Context,
 	logger kitlog.Logger,
 	req ctrl.Request,
-	kubeObjective pyrrav1alpha1.ServiceLevelObjective,
-) (ctrl.Result, error) {
+	kubeObjective *pyrrav1alpha1.ServiceLevelObjective,
+) error {
 	name := fmt.Sprintf("pyrra-recording-rule-%s", kubeObjective.GetName())
 
-	newConfigMap, err := makeConfigMap(name, kubeObjective, r.GenericRules, r.EnablePrometheus3Migration, r.PyrraExternalURL)
+	objectives, err := r.compositeObjectives(ctx, *kubeObjective)
 	if err != nil {
-		return ctrl.Result{}, err
+		return err
+	}
+
+	newConfigMap, err := makeConfigMap(name, *kubeObjective, objectives, r.GenericRules, r.EnablePrometheus3Migration, r.PyrraExternalURL)
+	if err != nil {
+		return err
 	}
 
 	var existingConfigMap corev1.ConfigMap
@@ -245,35 +429,108 @@ func (r *ServiceLevelObjectiveReconciler) reconcileConfigMap(
 		if errors.IsNotFound(err) {
 			level.Info(logger).Log("msg", "creating config map", "namespace", newConfigMap.GetNamespace(), "name", newConfigMap.GetName())
 			if err := r.Create(ctx, newConfigMap); err != nil {
-				return ctrl.Result{}, fmt.Errorf("failed to create config map: %w", err)
+				return fmt.Errorf("failed to create config map: %w", err)
 			}
 		} else {
-			return ctrl.Result{}, fmt.Errorf("failed to get config map: %w", err)
+			return fmt.Errorf("failed to get config map: %w", err)
 		}
+	} else if equality.Semantic.DeepEqual(existingConfigMap.Data, newConfigMap.Data) &&
+		equality.Semantic.DeepEqual(existingConfigMap.Labels, newConfigMap.Labels) &&
+		equality.Semantic.DeepEqual(existingConfigMap.Annotations, newConfigMap.Annotations) &&
+		equality.Semantic.DeepEqual(existingConfigMap.OwnerReferences, newConfigMap.OwnerReferences) {
+		level.Debug(logger).Log("msg", "config map is up to date", "namespace", newConfigMap.GetNamespace(), "name", newConfigMap.GetName())
 	} else {
 		// Resource exists, update it
 		newConfigMap.ResourceVersion = existingConfigMap.ResourceVersion
 
 		level.Info(logger).Log("msg", "updating config map", "namespace", newConfigMap.GetNamespace(), "name", newConfigMap.GetName())
 		if err := r.Update(ctx, newConfigMap); err != nil {
-			return ctrl.Result{}, fmt.Errorf("failed to update config map: %w", err)
+			return fmt.Errorf("failed to update config map: %w", err)
 		}
 	}
 
 	kubeObjective.Status.Type = "ConfigMap"
-	if err := r.Status().Update(ctx, &kubeObjective); err != nil {
-		return ctrl.Result{}, err
+	kubeObjective.Status.GeneratedResources = []string{name}
+	meta.RemoveStatusCondition(&kubeObjective.Status.Conditions, pyrrav1alpha1.ConditionMimirSynced)
+	return nil
+}
+
+// compositeObjectives returns the objectives a composite objective selects its components from.
+// Other objectives don't need them, so nil is returned without listing anything.
+func (r *ServiceLevelObjectiveReconciler) compositeObjectives(ctx context.Context, kubeObjective pyrrav1alpha1.ServiceLevelObjective) ([]slo.Objective, error) {
+	if kubeObjective.Spec.ServiceLevelIndicator.Composite == nil {
+		return nil, nil
+	}
+	return ListObjectives(ctx, r.Client)
+}
+
+// enqueueComposites reconciles all composite objectives whenever another objective changes,
+// as the change might add, update or remove one of their components.
+func (r *ServiceLevelObjectiveReconciler) enqueueComposites(ctx context.Context, obj client.Object) []reconcile.Request {
+	var list pyrrav1alpha1.ServiceLevelObjectiveList
+	if err := r.List(ctx, &list); err != nil {
+		level.Warn(r.Logger).Log("msg", "failed to list composite objectives", "err", err)
+		return nil
 	}
 
-	return ctrl.Result{}, nil
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if item.Spec.ServiceLevelIndicator.Composite == nil {
+			continue
+		}
+		if item.GetNamespace() == obj.GetNamespace() && item.GetName() == obj.GetName() {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+	}
+	return requests
 }
 
 func (r *ServiceLevelObjectiveReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Prometheus != nil && r.StatusInterval > 0 {
+		// Like the controller, the status is only polled by the leader.
+		if err := mgr.Add(manager.RunnableFunc(r.pollStatus)); err != nil {
+			return err
+		}
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&pyrrav1alpha1.ServiceLevelObjective{}).
+		// Status updates don't change the rules, so objectives are only reconciled for spec and label changes.
+		// Deletions of objectives with finalizers change their generation too.
+		For(&pyrrav1alpha1.ServiceLevelObjective{},
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{})),
+		).
+		// Status updates don't change components, so composites are only enqueued for spec and label changes.
+		Watches(&pyrrav1alpha1.ServiceLevelObjective{}, handler.EnqueueRequestsFromMapFunc(r.enqueueComposites),
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{})),
+		).
 		Complete(r)
 }
 
+// ObjectiveLister lists Kubernetes objects, like a client.Client does.
+type ObjectiveLister interface {
+	List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error
+}
+
+// ListObjectives returns all ServiceLevelObjectives as objectives for composite objectives to select from.
+// Invalid ServiceLevelObjectives are skipped, they can't be part of a composite objective anyway.
+func ListObjectives(ctx context.Context, c ObjectiveLister) ([]slo.Objective, error) {
+	var list pyrrav1alpha1.ServiceLevelObjectiveList
+	if err := c.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf("failed to list objectives: %w", err)
+	}
+
+	objectives := make([]slo.Objective, 0, len(list.Items))
+	for _, item := range list.Items {
+		objective, err := item.Internal()
+		if err != nil {
+			continue
+		}
+		objectives = append(objectives, objective)
+	}
+	return objectives, nil
+}
+
 func (r *ServiceLevelObjectiveReconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	slo := &pyrrav1alpha1.ServiceLevelObjective{}
 	return ctrl.NewWebhookManagedBy(mgr, slo).
@@ -281,11 +538,15 @@ func (r *ServiceLevelObjectiveReconciler) SetupWebhookWithManager(mgr ctrl.Manag
 		Complete()
 }
 
-func makeConfigMap(name string, kubeObjective pyrrav1alpha1.ServiceLevelObjective, genericRules, enablePrometheus3Migration bool, externalURL string) (*corev1.ConfigMap, error) {
+func makeConfigMap(name string, kubeObjective pyrrav1alpha1.ServiceLevelObjective, objectives []slo.Objective, genericRules, enablePrometheus3Migration bool, externalURL string) (*corev1.ConfigMap, error) {
 	objective, err := kubeObjective.Internal()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get objective: %w", err)
 	}
+	objective, err = objective.ResolveComposite(objectives)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve composite objective: %w", err)
+	}
 
 	opts := slo.GenerationOptions{
 		EnablePrometheus3Migration: enablePrometheus3Migration,
@@ -317,6 +578,14 @@ func makeConfigMap(name string, kubeObjective pyrrav1alpha1.ServiceLevelObjectiv
 		}
 	}
 
+	if len(objective.BudgetPolicy) > 0 {
+		policy, err := objective.BudgetPolicyRules(opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get error budget policy rules: %w", err)
+		}
+		rule.Groups = append(rule.Groups, policy)
+	}
+
 	for i := range rule.Groups {
 		rule.Groups[i].PartialResponseStrategy = kubeObjective.Spec.PartialResponseStrategy
 	}
@@ -355,11 +624,15 @@ func makeConfigMap(name string, kubeObjective pyrrav1alpha1.ServiceLevelObjectiv
 	}, nil
 }
 
-func makeMimirRuleGroup(kubeObjective pyrrav1alpha1.ServiceLevelObjective, genericRules, writeAlertingRules, enablePrometheus3Migration bool, externalURL string) (*rulefmt.RuleGroup, error) {
+func makeMimirRuleGroup(kubeObjective pyrrav1alpha1.ServiceLevelObjective, objectives []slo.Objective, genericRules, writeAlertingRules, enablePrometheus3Migration bool, externalURL string) (*rulefmt.RuleGroup, error) {
 	objective, err := kubeObjective.Internal()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get objective: %w", err)
 	}
+	objective, err = objective.ResolveComposite(objectives)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve composite objective: %w", err)
+	}
 
 	opts := slo.GenerationOptions{
 		EnablePrometheus3Migration: enablePrometheus3Migration,
@@ -390,6 +663,14 @@ func makeMimirRuleGroup(kubeObjective pyrrav1alpha1.ServiceLevelObjective, gener
 		}
 	}
 
+	if len(objective.BudgetPolicy) > 0 {
+		policy, err := objective.BudgetPolicyRules(opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get error budget policy rules: %w", err)
+		}
+		genericMimirRules = append(genericMimirRules, prometheusRulesToMimirRules(policy.Rules, writeAlertingRules)...)
+	}
+
 	combinedRules := make([]rulefmt.Rule, len(increasesMimirRules)+len(burnratesMimirRules)+len(genericMimirRules))
 	i := 0
 	for _, r := range increasesMimirRules {
@@ -446,11 +727,15 @@ func prometheusRulesToMimirRules(promRules []monitoringv1.Rule, writeAlertingRul
 	return rules
 }
 
-func makePrometheusRule(kubeObjective pyrrav1alpha1.ServiceLevelObjective, genericRules, enablePrometheus3Migration bool, externalURL string) (*monitoringv1.PrometheusRule, error) {
+func makePrometheusRule(kubeObjective pyrrav1alpha1.ServiceLevelObjective, objectives []slo.Objective, genericRules, enablePrometheus3Migration bool, externalURL string) (*monitoringv1.PrometheusRule, error) {
 	objective, err := kubeObjective.Internal()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get objective: %w", err)
 	}
+	objective, err = objective.ResolveComposite(objectives)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve composite objective: %w", err)
+	}
 
 	opts := slo.GenerationOptions{
 		EnablePrometheus3Migration: enablePrometheus3Migration,
@@ -482,6 +767,14 @@ func makePrometheusRule(kubeObjective pyrrav1alpha1.ServiceLevelObjective, gener
 		}
 	}
 
+	if len(objective.BudgetPolicy) > 0 {
+		policy, err := objective.BudgetPolicyRules(opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get error budget policy rules: %w", err)
+		}
+		rule.Groups = append(rule.Groups, policy)
+	}
+
 	for i := range rule.Groups {
 		rule.Groups[i].PartialResponseStrategy = kubeObjective.Spec.PartialResponseStrategy
 	}
@@ -489,11 +782,15 @@ func makePrometheusRule(kubeObjective pyrrav1alpha1.ServiceLevelObjective, gener
 	return newPrometheusRule(kubeObjective, kubeObjective.GetLabels(), rule), nil
 }
 
-func makeSplitPrometheusRules(kubeObjective pyrrav1alpha1.ServiceLevelObjective, genericRules, enablePrometheus3Migration bool, externalURL string) (*monitoringv1.PrometheusRule, *monitoringv1.PrometheusRule, error) {
+func makeSplitPrometheusRules(kubeObjective pyrrav1alpha1.ServiceLevelObjective, objectives []slo.Objective, genericRules, enablePrometheus3Migration bool, externalURL string) (*monitoringv1.PrometheusRule, *monitoringv1.PrometheusRule, error) {
 	objective, err := kubeObjective.Internal()
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get objective: %w", err)
 	}
+	objective, err = objective.ResolveComposite(objectives)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve composite objective: %w", err)
+	}
 
 	opts := slo.GenerationOptions{
 		EnablePrometheus3Migration: enablePrometheus3Migration,
@@ -531,6 +828,14 @@ func makeSplitPrometheusRules(kubeObjective pyrrav1alpha1.ServiceLevelObjective,
 		}
 	}
 
+	if len(objective.BudgetPolicy) > 0 {
+		policy, err := objective.BudgetPolicyRules(opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get error budget policy rules: %w", err)
+		}
+		longSpec.Groups = append(longSpec.Groups, policy)
+	}
+
 	for i := range shortSpec.Groups {
 		shortSpec.Groups[i].PartialResponseStrategy = kubeObjective.Spec.PartialResponseStrategy
 	}
@@ -1,15 +1,29 @@
 package controllers
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-kit/log"
 	"github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	pyrrav1alpha1 "github.com/pyrra-dev/pyrra/kubernetes/api/v1alpha1"
 	"github.com/pyrra-dev/pyrra/slo"
@@ -191,13 +205,186 @@ func Test_makePrometheusRule(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			prometheusRule, err := makePrometheusRule(tt.objective, false, false, "")
+			prometheusRule, err := makePrometheusRule(tt.objective, nil, false, false, "")
 			require.NoError(t, err)
 			require.Equal(t, tt.rules, prometheusRule)
 		})
 	}
 }
 
+func Test_makePrometheusRuleComposite(t *testing.T) {
+	checkoutSLO := pyrrav1alpha1.ServiceLevelObjective{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "checkout",
+			UID:  "456",
+		},
+		Spec: pyrrav1alpha1.ServiceLevelObjectiveSpec{
+			Target: "99",
+			Window: "28d",
+			ServiceLevelIndicator: pyrrav1alpha1.ServiceLevelIndicator{
+				Composite: &pyrrav1alpha1.CompositeIndicator{
+					Objectives: []pyrrav1alpha1.CompositeObjective{{Selector: `{__name__="http"}`}},
+				},
+			},
+		},
+	}
+
+	_, err := makePrometheusRule(checkoutSLO, nil, false, false, "")
+	require.EqualError(t, err, `failed to resolve composite objective: composite component 0 selector {__name__="http"} matches no objectives`)
+
+	http, err := httpSLO.Internal()
+	require.NoError(t, err)
+
+	prometheusRule, err := makePrometheusRule(checkoutSLO, []slo.Objective{http}, false, false, "")
+	require.NoError(t, err)
+	require.Len(t, prometheusRule.Spec.Groups, 2)
+	require.Equal(t, "pyrra_composite:burnrate4w", prometheusRule.Spec.Groups[0].Rules[0].Record)
+	require.Equal(t,
+		`(1 * (sum(rate(http_requests_total{job="app",status=~"5.."}[4w])) / sum(rate(http_requests_total{job="app"}[4w])))) / 1`,
+		prometheusRule.Spec.Groups[0].Rules[0].Expr.String(),
+	)
+	require.Equal(t, "pyrra_composite:burnrate5m", prometheusRule.Spec.Groups[1].Rules[0].Record)
+}
+
+func Test_makePrometheusRuleBudgetPolicy(t *testing.T) {
+	policySLO := httpSLO.DeepCopy()
+	policySLO.Spec.BudgetPolicy = &pyrrav1alpha1.BudgetPolicy{
+		Stages: []pyrrav1alpha1.BudgetPolicyStage{{Name: "freeze-deploys", Remaining: "25"}},
+	}
+
+	prometheusRule, err := makePrometheusRule(*policySLO, nil, false, false, "")
+	require.NoError(t, err)
+	require.Len(t, prometheusRule.Spec.Groups, 3)
+	require.Equal(t, "http-budget-policy", prometheusRule.Spec.Groups[2].Name)
+	require.Equal(t, "ErrorBudgetPolicy", prometheusRule.Spec.Groups[2].Rules[0].Alert)
+	require.Equal(t, "freeze-deploys", prometheusRule.Spec.Groups[2].Rules[0].Labels["stage"])
+
+	policySLO.Spec.PerformanceOverAccuracy = true
+	_, longRule, err := makeSplitPrometheusRules(*policySLO, nil, false, false, "")
+	require.NoError(t, err)
+	require.Equal(t, "http-budget-policy", longRule.Spec.Groups[1].Name)
+}
+
+type fakePrometheus struct {
+	budget float64
+}
+
+func (p fakePrometheus) Query(_ context.Context, _ string, _ time.Time, _ ...prometheusv1.Option) (model.Value, prometheusv1.Warnings, error) {
+	return model.Vector{{Value: model.SampleValue(p.budget)}}, nil, nil
+}
+
+func TestServiceLevelObjectiveReconciler_ReconcileStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, pyrrav1alpha1.AddToScheme(scheme))
+	require.NoError(t, monitoringv1.AddToScheme(scheme))
+
+	valid := httpSLO.DeepCopy()
+	valid.Namespace = "monitoring"
+	valid.Generation = 2
+
+	invalid := httpSLO.DeepCopy()
+	invalid.Name = "invalid"
+	invalid.Namespace = "monitoring"
+	invalid.Generation = 1
+	invalid.Spec.Target = "foo"
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(valid, invalid).
+		WithStatusSubresource(valid, invalid).
+		Build()
+
+	r := &ServiceLevelObjectiveReconciler{
+		Client:         c,
+		Logger:         log.NewNopLogger(),
+		Prometheus:     fakePrometheus{budget: 0.5},
+		StatusInterval: time.Minute,
+	}
+
+	ctx := context.Background()
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(valid)})
+	require.NoError(t, err)
+	// The status is polled separately, reconciling again isn't needed.
+	require.Zero(t, result.RequeueAfter)
+
+	var got pyrrav1alpha1.ServiceLevelObjective
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(valid), &got))
+	require.Equal(t, int64(2), got.Status.ObservedGeneration)
+	require.Equal(t, "PrometheusRule", got.Status.Type)
+	require.Equal(t, []string{"http"}, got.Status.GeneratedResources)
+	require.True(t, meta.IsStatusConditionTrue(got.Status.Conditions, pyrrav1alpha1.ConditionValid))
+	require.True(t, meta.IsStatusConditionTrue(got.Status.Conditions, pyrrav1alpha1.ConditionRulesGenerated))
+	require.Nil(t, meta.FindStatusCondition(got.Status.Conditions, pyrrav1alpha1.ConditionMimirSynced))
+	require.Empty(t, got.Status.Availability)
+
+	var rule monitoringv1.PrometheusRule
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "monitoring", Name: "http"}, &rule))
+
+	// Unchanged rules aren't updated again.
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(valid)})
+	require.NoError(t, err)
+	var unchanged monitoringv1.PrometheusRule
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "monitoring", Name: "http"}, &unchanged))
+	require.Equal(t, rule.ResourceVersion, unchanged.ResourceVersion)
+
+	r.refreshStatus(ctx)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(valid), &got))
+	require.Equal(t, "99.750", got.Status.Availability)
+	require.Equal(t, "50.00", got.Status.Budget)
+	require.True(t, meta.IsStatusConditionTrue(got.Status.Conditions, pyrrav1alpha1.ConditionRulesGenerated))
+
+	result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(invalid)})
+	require.NoError(t, err)
+	require.Zero(t, result.RequeueAfter)
+
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(invalid), &got))
+	require.Equal(t, int64(1), got.Status.ObservedGeneration)
+	require.Empty(t, got.Status.GeneratedResources)
+	require.Empty(t, got.Status.Budget)
+	condition := meta.FindStatusCondition(got.Status.Conditions, pyrrav1alpha1.ConditionValid)
+	require.NotNil(t, condition)
+	require.Equal(t, metav1.ConditionFalse, condition.Status)
+	require.Equal(t, "Invalid", condition.Reason)
+	require.Contains(t, condition.Message, "foo")
+	generated := meta.FindStatusCondition(got.Status.Conditions, pyrrav1alpha1.ConditionRulesGenerated)
+	require.NotNil(t, generated)
+	require.Equal(t, "InvalidSpec", generated.Reason)
+}
+
+// groupedPrometheus returns the budget of every group, unless the query aggregates them with min.
+type groupedPrometheus map[string]float64
+
+func (p groupedPrometheus) Query(_ context.Context, query string, _ time.Time, _ ...prometheusv1.Option) (model.Value, prometheusv1.Warnings, error) {
+	if strings.HasPrefix(query, "min(") {
+		budget := math.Inf(1)
+		for _, b := range p {
+			budget = math.Min(budget, b)
+		}
+		return model.Vector{{Value: model.SampleValue(budget)}}, nil, nil
+	}
+
+	var vector model.Vector
+	for _, route := range slices.Sorted(maps.Keys(p)) {
+		vector = append(vector, &model.Sample{Metric: model.Metric{"route": model.LabelValue(route)}, Value: model.SampleValue(p[route])})
+	}
+	return vector, nil, nil
+}
+
+func TestServiceLevelObjectiveReconciler_QueryStatusGrouped(t *testing.T) {
+	grouped := httpSLO.DeepCopy()
+	grouped.Spec.ServiceLevelIndicator.Ratio.Grouping = []string{"route"}
+	objective, err := grouped.Internal()
+	require.NoError(t, err)
+
+	r := &ServiceLevelObjectiveReconciler{
+		Prometheus: groupedPrometheus{"/": 0.8, "/api": 0.2, "/metrics": 0.5},
+	}
+	require.NoError(t, r.queryStatus(context.Background(), objective, grouped))
+	// Grouped objectives report the group with the least remaining error budget.
+	require.Equal(t, "20.00", grouped.Status.Budget)
+	require.Equal(t, "99.600", grouped.Status.Availability)
+}
+
 func Test_makeSplitPrometheusRules(t *testing.T) {
 	perfSLO := httpSLO.DeepCopy()
 	perfSLO.Spec.PerformanceOverAccuracy = true
@@ -206,7 +393,7 @@ func Test_makeSplitPrometheusRules(t *testing.T) {
 		LongRulesLabels:  map[string]string{"prometheus": "thanos-k8s"},
 	}
 
-	shortRule, longRule, err := makeSplitPrometheusRules(*perfSLO, false, false, "")
+	shortRule, longRule, err := makeSplitPrometheusRules(*perfSLO, nil, false, false, "")
 	require.NoError(t, err)
 
 	expectedShortRule := &monitoringv1.PrometheusRule{
@@ -390,7 +577,7 @@ func Test_makeSplitPrometheusRulesWithoutRuleOutput(t *testing.T) {
 	perfSLO.Spec.PerformanceOverAccuracy = true
 	// No RuleOutput set — both should inherit SLO labels
 
-	shortRule, longRule, err := makeSplitPrometheusRules(*perfSLO, false, false, "")
+	shortRule, longRule, err := makeSplitPrometheusRules(*perfSLO, nil, false, false, "")
 	require.NoError(t, err)
 
 	// Both should have the SLO's labels
@@ -587,7 +774,7 @@ func Test_makeConfigMap(t *testing.T) {
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
-			configMap, err := makeConfigMap(tc.configMapName, tc.objective, false, false, "")
+			configMap, err := makeConfigMap(tc.configMapName, tc.objective, nil, false, false, "")
 
 			if tc.err != nil {
 				require.Error(t, err)
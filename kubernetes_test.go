@@ -193,3 +193,28 @@ func TestNamespacesConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestObjectiveServer_ListObjectivesPage(t *testing.T) {
+	s := KubernetesObjectiveServer{client: &mockClient{}}
+
+	response, err := s.List(context.Background(), connect.NewRequest(&objectivesv1alpha1.ListRequest{
+		PageSize: 2,
+		OrderBy:  "-target",
+	}))
+	require.NoError(t, err)
+	require.Equal(t, []*objectivesv1alpha1.Objective{i1, i2}, response.Msg.Objectives)
+	require.Equal(t, int32(3), response.Msg.TotalSize)
+	require.NotEmpty(t, response.Msg.NextPageToken)
+
+	response, err = s.List(context.Background(), connect.NewRequest(&objectivesv1alpha1.ListRequest{
+		PageSize:  2,
+		PageToken: response.Msg.NextPageToken,
+		OrderBy:   "-target",
+	}))
+	require.NoError(t, err)
+	require.Equal(t, []*objectivesv1alpha1.Objective{i3}, response.Msg.Objectives)
+	require.Empty(t, response.Msg.NextPageToken)
+
+	_, err = s.List(context.Background(), connect.NewRequest(&objectivesv1alpha1.ListRequest{OrderBy: "foo"}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/api"
+	prometheusapiv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	"github.com/pyrra-dev/pyrra/slo"
+)
+
+const (
+	lintError   = "error"
+	lintWarning = "warning"
+)
+
+// lintFinding is a problem with an objective that pyrra lint found in the data of Prometheus.
+type lintFinding struct {
+	Severity string
+	Message  string
+}
+
+func cmdLint(logger log.Logger, promClient api.Client, configFiles string) int {
+	filenames, err := configFilenames(configFiles)
+	if err != nil {
+		level.Error(logger).Log("msg", "getting file names", "err", err)
+		return 1
+	}
+
+	ctx := context.Background()
+	promAPI := prometheusapiv1.NewAPI(promClient)
+
+	var errors, warnings int
+	for _, file := range filenames {
+		objectives, err := objectivesFromFile(file)
+		if err != nil {
+			fmt.Printf("%s: %s: %v\n", file, lintError, err)
+			errors++
+			continue
+		}
+
+		for _, o := range objectives {
+			findings, err := lintObjective(ctx, promAPI, o.objective)
+			if err != nil {
+				level.Error(logger).Log("msg", "linting objective", "file", file, "err", err)
+				return 1
+			}
+
+			// Findings of files with multiple objectives name the objective they are about.
+			location := file
+			if len(objectives) > 1 {
+				location = fmt.Sprintf("%s: %s", file, o.objective.Name())
+			}
+			for _, f := range findings {
+				fmt.Printf("%s: %s: %s\n", location, f.Severity, f.Message)
+				if f.Severity == lintError {
+					errors++
+				} else {
+					warnings++
+				}
+			}
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "linted %d files: %d errors, %d warnings\n", len(filenames), errors, warnings)
+	if errors > 0 {
+		return 1
+	}
+	return 0
+}
+
+// lintObjective queries Prometheus to check that the objective's indicator selects the series it's meant to.
+// Errors are only returned if Prometheus can't be queried, problems with the objective are returned as findings.
+func lintObjective(ctx context.Context, promAPI prometheusAPI, objective slo.Objective) ([]lintFinding, error) {
+	l := &linter{ctx: ctx, api: promAPI, now: time.Now()}
+
+	switch objective.IndicatorType() {
+	case slo.Ratio:
+		ratio := objective.Indicator.Ratio
+		if !l.series("total", ratio.Total.Metric()) {
+			break
+		}
+		l.grouping(ratio.Total.Metric(), ratio.Grouping)
+
+		count, err := l.count(ratio.Errors.Metric())
+		if err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			l.warnf("errors selector %s returns no series. That's fine if there haven't been any errors yet, otherwise the selector doesn't match", ratio.Errors.Metric())
+			break
+		}
+		l.subset(ratio.Errors.Metric(), ratio.Total.Metric())
+	case slo.Latency:
+		latency := objective.Indicator.Latency
+		if !l.series("total", latency.Total.Metric()) {
+			break
+		}
+		l.grouping(latency.Total.Metric(), latency.Grouping)
+		l.buckets(latency.Success)
+	case slo.LatencyNative:
+		native := objective.Indicator.LatencyNative
+		if !l.series("total", native.Total.Metric()) {
+			break
+		}
+		l.grouping(native.Total.Metric(), native.Grouping)
+
+		count, err := l.count(fmt.Sprintf("histogram_count(%s)", native.Total.Metric()))
+		if err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			l.errorf("total selector %s doesn't return native histograms, use a latency indicator for classic histograms", native.Total.Metric())
+		}
+	case slo.BoolGauge:
+		gauge := objective.Indicator.BoolGauge
+		if !l.series("bool gauge", gauge.Metric.Metric()) {
+			break
+		}
+		l.grouping(gauge.Metric.Metric(), gauge.Grouping)
+	case slo.Raw:
+		raw := objective.Indicator.Raw
+		if !l.series("total", raw.Total) {
+			break
+		}
+		l.grouping(raw.Total, raw.Grouping)
+
+		count, err := l.count(raw.Good)
+		if err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			l.warnf("good expression returns no series. That's only fine if there haven't been any good events yet")
+		}
+	case slo.Composite:
+		// The components of composite objectives are linted on their own.
+	}
+
+	return l.findings, l.err
+}
+
+// linter collects the findings of an objective.
+// Once a query failed all further checks are skipped and the error is returned.
+type linter struct {
+	ctx context.Context
+	api prometheusAPI
+	now time.Time
+
+	findings []lintFinding
+	err      error
+}
+
+func (l *linter) errorf(format string, args ...interface{}) {
+	l.findings = append(l.findings, lintFinding{Severity: lintError, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *linter) warnf(format string, args ...interface{}) {
+	l.findings = append(l.findings, lintFinding{Severity: lintWarning, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *linter) query(query string) model.Vector {
+	if l.err != nil {
+		return nil
+	}
+	value, _, err := l.api.Query(l.ctx, query, l.now)
+	if err != nil {
+		l.err = fmt.Errorf("failed to query %q: %w", query, err)
+		return nil
+	}
+	vector, ok := value.(model.Vector)
+	if !ok {
+		l.err = fmt.Errorf("query %q returned %s instead of a vector", query, value.Type())
+		return nil
+	}
+	return vector
+}
+
+// count returns the number of series the expression returns.
+func (l *linter) count(expr string) (int, error) {
+	vector := l.query(fmt.Sprintf("count(%s)", expr))
+	if l.err != nil {
+		return 0, l.err
+	}
+	if len(vector) == 0 {
+		return 0, nil
+	}
+	return int(vector[0].Value), nil
+}
+
+// series checks that the expression returns any series at all.
+func (l *linter) series(name, expr string) bool {
+	count, err := l.count(expr)
+	if err != nil {
+		return false
+	}
+	if count == 0 {
+		l.errorf("%s selector %s returns no series, check the metric name and label matchers", name, expr)
+		return false
+	}
+	return true
+}
+
+// grouping checks that every grouping label exists on at least one series of the expression.
+func (l *linter) grouping(expr string, grouping []string) {
+	for _, name := range grouping {
+		vector := l.query(fmt.Sprintf("count by (%s) (%s)", name, expr))
+		if l.err != nil {
+			return
+		}
+
+		found := false
+		for _, sample := range vector {
+			if sample.Metric[model.LabelName(name)] != "" {
+				found = true
+				break
+			}
+		}
+		if !found {
+			l.errorf("grouping label %q doesn't exist on the series of %s", name, expr)
+		}
+	}
+}
+
+// subset checks that all series of the errors selector are also selected by the total selector.
+func (l *linter) subset(errors, total string) {
+	count, err := l.count(fmt.Sprintf("%s unless %s", errors, total))
+	if err != nil {
+		return
+	}
+	if count > 0 {
+		l.errorf("%d series of the errors selector %s don't have a matching series of the total selector %s, errors need to be a subset of total", count, errors, total)
+	}
+}
+
+// buckets checks that the bucket the success selector selects exists and lists the available buckets if not.
+func (l *linter) buckets(success slo.Metric) {
+	count, err := l.count(success.Metric())
+	if err != nil || count > 0 {
+		return
+	}
+
+	var le string
+	withoutLe := slo.Metric{Name: success.Name}
+	for _, m := range success.LabelMatchers {
+		if m.Name == model.BucketLabel {
+			le = m.Value
+			continue
+		}
+		withoutLe.LabelMatchers = append(withoutLe.LabelMatchers, m)
+	}
+
+	vector := l.query(fmt.Sprintf("count by (%s) (%s)", model.BucketLabel, withoutLe.Metric()))
+	if l.err != nil {
+		return
+	}
+	if len(vector) == 0 {
+		l.errorf("success selector %s returns no series, the histogram has no buckets", success.Metric())
+		return
+	}
+
+	buckets := make([]string, 0, len(vector))
+	for _, sample := range vector {
+		buckets = append(buckets, string(sample.Metric[model.BucketLabel]))
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return bucketLess(buckets[i], buckets[j])
+	})
+	l.errorf("success selector %s returns no series, there's no bucket %s=%q. Available buckets are %s", success.Metric(), model.BucketLabel, le, strings.Join(buckets, ", "))
+}
+
+// bucketLess sorts buckets by their upper bound, falling back to comparing the strings for unparsable ones.
+func bucketLess(a, b string) bool {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	if errA != nil || errB != nil {
+		return a < b
+	}
+	return fa < fb
+}
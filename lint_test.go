@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	prometheusapiv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pyrra-dev/pyrra/kubernetes/api/v1alpha1"
+)
+
+// lintPrometheus returns the vector of a query and an empty vector for unknown queries.
+type lintPrometheus map[string]model.Vector
+
+func (p lintPrometheus) Query(_ context.Context, query string, _ time.Time, _ ...prometheusapiv1.Option) (model.Value, prometheusapiv1.Warnings, error) {
+	if vector, ok := p[query]; ok {
+		return vector, nil, nil
+	}
+	return model.Vector{}, nil, nil
+}
+
+func (p lintPrometheus) QueryRange(_ context.Context, query string, _ prometheusapiv1.Range, _ ...prometheusapiv1.Option) (model.Value, prometheusapiv1.Warnings, error) {
+	return nil, nil, fmt.Errorf("unexpected range query %q", query)
+}
+
+func countVector(value float64) model.Vector {
+	return model.Vector{{Value: model.SampleValue(value)}}
+}
+
+func TestLintObjective(t *testing.T) {
+	ratio := v1alpha1.ServiceLevelObjective{
+		Spec: v1alpha1.ServiceLevelObjectiveSpec{
+			Target: "99",
+			Window: "4w",
+			ServiceLevelIndicator: v1alpha1.ServiceLevelIndicator{
+				Ratio: &v1alpha1.RatioIndicator{
+					Errors:   v1alpha1.Query{Metric: `http_requests_total{job="api",code=~"5.."}`},
+					Total:    v1alpha1.Query{Metric: `http_requests_total{job="api"}`},
+					Grouping: []string{"handler"},
+				},
+			},
+		},
+	}
+	ratio.Name = "api"
+
+	latency := v1alpha1.ServiceLevelObjective{
+		Spec: v1alpha1.ServiceLevelObjectiveSpec{
+			Target: "99",
+			Window: "4w",
+			ServiceLevelIndicator: v1alpha1.ServiceLevelIndicator{
+				Latency: &v1alpha1.LatencyIndicator{
+					Success: v1alpha1.Query{Metric: `http_request_duration_seconds_bucket{job="api",le="0.3"}`},
+					Total:   v1alpha1.Query{Metric: `http_request_duration_seconds_count{job="api"}`},
+				},
+			},
+		},
+	}
+	latency.Name = "api-latency"
+
+	testcases := []struct {
+		name       string
+		objective  v1alpha1.ServiceLevelObjective
+		prometheus lintPrometheus
+		findings   []lintFinding
+	}{{
+		name:      "ratio",
+		objective: ratio,
+		prometheus: lintPrometheus{
+			`count(http_requests_total{job="api"})`:                                                   countVector(10),
+			`count by (handler) (http_requests_total{job="api"})`:                                     {{Metric: model.Metric{"handler": "/"}, Value: 10}},
+			`count(http_requests_total{code=~"5..",job="api"})`:                                       countVector(2),
+			`count(http_requests_total{code=~"5..",job="api"} unless http_requests_total{job="api"})`: {},
+		},
+	}, {
+		name:       "ratio-no-series",
+		objective:  ratio,
+		prometheus: lintPrometheus{},
+		findings: []lintFinding{{
+			Severity: lintError,
+			Message:  `total selector http_requests_total{job="api"} returns no series, check the metric name and label matchers`,
+		}},
+	}, {
+		name:      "ratio-grouping-errors",
+		objective: ratio,
+		prometheus: lintPrometheus{
+			`count(http_requests_total{job="api"})`:                                                   countVector(10),
+			`count by (handler) (http_requests_total{job="api"})`:                                     {{Metric: model.Metric{}, Value: 10}},
+			`count(http_requests_total{code=~"5..",job="api"})`:                                       countVector(2),
+			`count(http_requests_total{code=~"5..",job="api"} unless http_requests_total{job="api"})`: countVector(2),
+		},
+		findings: []lintFinding{{
+			Severity: lintError,
+			Message:  `grouping label "handler" doesn't exist on the series of http_requests_total{job="api"}`,
+		}, {
+			Severity: lintError,
+			Message:  `2 series of the errors selector http_requests_total{code=~"5..",job="api"} don't have a matching series of the total selector http_requests_total{job="api"}, errors need to be a subset of total`,
+		}},
+	}, {
+		name:      "ratio-no-errors",
+		objective: ratio,
+		prometheus: lintPrometheus{
+			`count(http_requests_total{job="api"})`:               countVector(10),
+			`count by (handler) (http_requests_total{job="api"})`: {{Metric: model.Metric{"handler": "/"}, Value: 10}},
+		},
+		findings: []lintFinding{{
+			Severity: lintWarning,
+			Message:  `errors selector http_requests_total{code=~"5..",job="api"} returns no series. That's fine if there haven't been any errors yet, otherwise the selector doesn't match`,
+		}},
+	}, {
+		name:      "latency-buckets",
+		objective: latency,
+		prometheus: lintPrometheus{
+			`count(http_request_duration_seconds_count{job="api"})`: countVector(3),
+			`count by (le) (http_request_duration_seconds_bucket{job="api"})`: {
+				{Metric: model.Metric{"le": "+Inf"}, Value: 3},
+				{Metric: model.Metric{"le": "0.5"}, Value: 3},
+				{Metric: model.Metric{"le": "0.25"}, Value: 3},
+			},
+		},
+		findings: []lintFinding{{
+			Severity: lintError,
+			Message:  `success selector http_request_duration_seconds_bucket{job="api",le="0.3"} returns no series, there's no bucket le="0.3". Available buckets are 0.25, 0.5, +Inf`,
+		}},
+	}}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			objective, err := tc.objective.Internal()
+			require.NoError(t, err)
+
+			findings, err := lintObjective(context.Background(), tc.prometheus, objective)
+			require.NoError(t, err)
+			require.Equal(t, tc.findings, findings)
+		})
+	}
+}
@@ -39,10 +39,10 @@ import (
 	"github.com/prometheus/prometheus/promql/parser"
 	"google.golang.org/protobuf/types/known/durationpb"
 
-	"github.com/pyrra-dev/pyrra/mimir"
 	objectivesv1alpha1 "github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1"
 	"github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1/objectivesv1alpha1connect"
 	"github.com/pyrra-dev/pyrra/proto/prometheus/v1/prometheusv1connect"
+	"github.com/pyrra-dev/pyrra/ruler"
 	"github.com/pyrra-dev/pyrra/slo"
 )
 
@@ -58,59 +58,123 @@ var CLI struct {
 	Version kong.VersionFlag `help:"Print version information and quit."`
 	LoggerConfig
 	API struct {
-		PrometheusURL               *url.URL          `default:"http://localhost:9090" help:"The URL to the Prometheus to query."`
-		PrometheusExternalURL       *url.URL          `help:"The URL for the UI to redirect users to when opening Prometheus. If empty the same as prometheus.url"`
-		GrafanaExternalURL          *url.URL          `help:"The URL for the UI to redirect users to Grafana Explore page"`
-		GrafanaExternalOrgID        string            `default:"1" help:"The Grafana Explore organization id"`
-		GrafanaExternalDatasourceID string            `help:"The Grafana Explore prometheus datasource id"`
-		APIURL                      *url.URL          `name:"api-url" default:"http://localhost:9444" help:"The URL to the API service like a Kubernetes Operator."`
-		RoutePrefix                 string            `default:"" help:"The route prefix Pyrra uses. If run behind a proxy you can change it to something like /pyrra here."`
-		UIRoutePrefix               string            `default:"" help:"The route prefix Pyrra's UI uses. This is helpful for when the prefix is stripped by a proxy but still runs on /pyrra. Defaults to --route-prefix"`
-		PrometheusBearerTokenPath   string            `default:"" help:"Bearer token path"`
-		PrometheusBasicAuthUsername string            `default:"" help:"The HTTP basic authentication username"`
-		PrometheusBasicAuthPassword promconfig.Secret `default:"" help:"The HTTP basic authentication password"`
-		TLSCertFile                 string            `default:"" help:"File containing the default x509 Certificate for HTTPS."`
-		TLSPrivateKeyFile           string            `default:"" help:"File containing the default x509 private key matching --tls-cert-file."`
-		TLSClientCAFile             string            `default:"" help:"File containing the CA certificate for the client"`
-		MimirOrgID                  string            `default:"" help:"Mimir tenant ID to query if multi-tenancy is enabled."`
-		EnablePrometheus3Migration  bool              `default:"true" help:"Enable Prometheus 3 migration mode that makes queries compatible with both Prometheus 2 and 3. Enabled by default; pass --enable-prometheus-3-migration=false to opt out."`
+		PrometheusURL               *url.URL            `default:"http://localhost:9090" help:"The URL to the Prometheus to query."`
+		PrometheusExternalURL       *url.URL            `help:"The URL for the UI to redirect users to when opening Prometheus. If empty the same as prometheus.url"`
+		GrafanaExternalURL          *url.URL            `help:"The URL for the UI to redirect users to Grafana Explore page"`
+		GrafanaExternalOrgID        string              `default:"1" help:"The Grafana Explore organization id"`
+		GrafanaExternalDatasourceID string              `help:"The Grafana Explore prometheus datasource id"`
+		APIURL                      *url.URL            `name:"api-url" default:"http://localhost:9444" help:"The URL to the API service like a Kubernetes Operator."`
+		Backend                     map[string]*url.URL `help:"Named URLs of API services like the Kubernetes Operators of several clusters, as name=url. Their objectives are listed together instead of the ones of --api-url."`
+		BackendLabel                string              `default:"source" help:"The label with the name of the --backend an objective is listed from."`
+		RoutePrefix                 string              `default:"" help:"The route prefix Pyrra uses. If run behind a proxy you can change it to something like /pyrra here."`
+		UIRoutePrefix               string              `default:"" help:"The route prefix Pyrra's UI uses. This is helpful for when the prefix is stripped by a proxy but still runs on /pyrra. Defaults to --route-prefix"`
+		PrometheusBearerTokenPath   string              `default:"" help:"Bearer token path"`
+		PrometheusBasicAuthUsername string              `default:"" help:"The HTTP basic authentication username"`
+		PrometheusBasicAuthPassword promconfig.Secret   `default:"" help:"The HTTP basic authentication password"`
+		TLSCertFile                 string              `default:"" help:"File containing the default x509 Certificate for HTTPS."`
+		TLSPrivateKeyFile           string              `default:"" help:"File containing the default x509 private key matching --tls-cert-file."`
+		TLSClientCAFile             string              `default:"" help:"File containing the CA certificate for the client"`
+		MimirOrgID                  string              `default:"" help:"Mimir tenant ID to query if multi-tenancy is enabled."`
+		EnablePrometheus3Migration  bool                `default:"true" help:"Enable Prometheus 3 migration mode that makes queries compatible with both Prometheus 2 and 3. Enabled by default; pass --enable-prometheus-3-migration=false to opt out."`
+		WatchInterval               time.Duration       `default:"10s" help:"How often the status and alerts streamed by WatchStatus and WatchAlerts are evaluated."`
+		QueryRecordingRules         string              `default:"auto" enum:"auto,always,never" help:"Whether graphs query Pyrra's recording rules instead of the raw metrics. auto does so for objectives whose recording rules are found in Prometheus."`
+		DatasourcesFile             string              `default:"" help:"File with named Prometheus datasources for objectives that set a datasource. Other objectives query --prometheus-url."`
+		CORSAllowedOrigins          []string            `name:"cors-allowed-origins" help:"Origins allowed to make cross-origin requests to the API, like http://localhost:3000 when developing the UI. Cross-origin requests are denied if empty."`
+		AuthOIDCJWKSFile            string              `name:"auth-oidc-jwks-file" default:"" help:"File with the JSON Web Key Set to validate OIDC bearer tokens with. API requests need a valid token or proxy headers if set."`
+		AuthOIDCIssuer              string              `name:"auth-oidc-issuer" default:"" help:"The issuer OIDC bearer tokens need to be issued by."`
+		AuthOIDCAudience            string              `name:"auth-oidc-audience" default:"" help:"The audience OIDC bearer tokens need to be issued for."`
+		AuthOIDCTeamsClaim          string              `name:"auth-oidc-teams-claim" default:"groups" help:"The claim of OIDC bearer tokens with the caller's teams."`
+		AuthProxyUserHeader         string              `default:"" help:"Header with the user authenticated by a proxy in front of the API, like X-Forwarded-User. API requests need the header or a valid bearer token if set. Only set it if the API can't be reached without the proxy."`
+		AuthProxyTeamsHeader        string              `default:"" help:"Header with the comma-separated teams of the user authenticated by the proxy, like X-Forwarded-Groups."`
+		AuthTeamLabel               string              `default:"" help:"The label of objectives with the team that may see them. Authenticated callers only see the objectives of their teams if set."`
+		AuthAdminTeams              []string            `help:"Teams that may see all objectives regardless of --auth-team-label."`
 	} `cmd:"" help:"Runs Pyrra's API and UI."`
 	Filesystem struct {
-		ConfigFiles                string   `default:"/etc/pyrra/*.yaml" help:"The folder where Pyrra finds the config files to use. Any non yaml files will be ignored."`
-		PrometheusURL              *url.URL `default:"http://localhost:9090" help:"The URL to the Prometheus to query."`
-		PrometheusFolder           string   `default:"/etc/prometheus/pyrra/" help:"The folder where Pyrra writes the generates Prometheus rules and alerts."`
-		GenericRules               bool     `default:"false" help:"Enabled generic recording rules generation to make it easier for tools like Grafana."`
-		EnablePrometheus3Migration bool     `default:"true" help:"Enable Prometheus 3 migration mode that makes rules compatible with both Prometheus 2 and 3. Enabled by default; pass --enable-prometheus-3-migration=false to opt out."`
-		ExternalURL                *url.URL `default:"" help:"The URL for Pyrra to be included in alert annotations. This will be used to generate direct links to the Pyrra UI in alerts."`
+		ConfigFiles                string        `default:"/etc/pyrra/*.yaml" help:"The folder where Pyrra finds the config files to use. Any non yaml files will be ignored. Patterns like /etc/pyrra/**/*.yaml include all subdirectories."`
+		PrometheusURL              *url.URL      `default:"http://localhost:9090" help:"The URL to the Prometheus to query."`
+		PrometheusFolder           string        `default:"/etc/prometheus/pyrra/" help:"The folder where Pyrra writes the generates Prometheus rules and alerts."`
+		GenericRules               bool          `default:"false" help:"Enabled generic recording rules generation to make it easier for tools like Grafana."`
+		EnablePrometheus3Migration bool          `default:"true" help:"Enable Prometheus 3 migration mode that makes rules compatible with both Prometheus 2 and 3. Enabled by default; pass --enable-prometheus-3-migration=false to opt out."`
+		ExternalURL                *url.URL      `default:"" help:"The URL for Pyrra to be included in alert annotations. This will be used to generate direct links to the Pyrra UI in alerts."`
+		ResyncInterval             time.Duration `default:"1m" help:"How often all config files are compared with the generated rules, for changes the file watcher missed."`
+		RulerURL                   *url.URL      `default:"" name:"ruler-url" help:"The URL to a ruler API, like the ones of Mimir or Cortex. If specified pushes the rules to the ruler whenever their rule files in --prometheus-folder change, instead of reloading Prometheus."`
+		RulerKind                  string        `default:"mimir" enum:"mimir,cortex,generic" help:"The kind of ruler at --ruler-url, which sets the default paths of its API. One of: mimir, cortex, generic."`
+		RulerRulesPath             string        `default:"" help:"The path of the ruler's rules API, like /prometheus/config/v1/rules. Required for generic rulers, other kinds default to the path of their API."`
+		RulerTenantHeader          string        `default:"X-Scope-OrgID" help:"The header the ruler reads the tenant from."`
+		RulerTenantID              string        `default:"" name:"ruler-tenant-id" help:"The tenant to provision the rules for if the ruler is multi-tenant."`
+		RulerBasicAuthUsername     string        `default:"" help:"The HTTP basic authentication username for the ruler."`
+		RulerBasicAuthPassword     string        `default:"" help:"The HTTP basic authentication password for the ruler."`
+		RulerBearerTokenFile       string        `default:"" help:"File containing the bearer token for the ruler. It's read for every request, so that rotated tokens are used."`
 	} `cmd:"" help:"Runs Pyrra's filesystem operator and backend for the API."`
 	Kubernetes struct {
-		MetricsAddr                string   `default:":8080" help:"The address the metric endpoint binds to."`
-		ConfigMapMode              bool     `default:"false" help:"If the generated recording rules should instead be saved to config maps in the default Prometheus format."`
-		GenericRules               bool     `default:"false" help:"Enabled generic recording rules generation to make it easier for tools like Grafana."`
-		DisableWebhooks            bool     `default:"true" env:"DISABLE_WEBHOOKS" help:"Disable webhooks so the controller doesn't try to read certificates"`
-		TLSCertFile                string   `default:"" help:"File containing the default x509 Certificate for HTTPS."`
-		TLSPrivateKeyFile          string   `default:"" help:"File containing the default x509 private key matching --tls-cert-file."`
-		MimirURL                   *url.URL `default:"" help:"The URL to the Mimir API. If specified provisions rules via Mimir instead of Prometheus"`
-		MimirPrometheusPrefix      string   `default:"prometheus" help:"The prefix for the Prometheus API in Mimir"`
-		MimirBasicAuthUsername     string   `default:"" help:"The HTTP basic authentication username"`
-		MimirWriteAlertingRules    bool     `default:"false" help:"If alerting rules should be provisioned to the Mimir Ruler."`
-		MimirBasicAuthPassword     string   `default:"" help:"The HTTP basic authentication password"`
-		MimirOrgID                 string   `default:"" help:"Mimir tenant ID to query if multi-tenancy is enabled."`
-		MimirDeploymentMode        string   `default:"standalone" help:"Mimir deployment mode. Possible values: standalone (default), distributed"`
-		EnablePrometheus3Migration bool     `default:"true" help:"Enable Prometheus 3 migration mode that makes rules compatible with both Prometheus 2 and 3. Enabled by default; pass --enable-prometheus-3-migration=false to opt out."`
-		ExternalURL                *url.URL `default:"" help:"The URL for Pyrra to be included in alert annotations. This will be used to generate direct links to the Pyrra UI in alerts."`
-		EnableLeaderElection       bool     `default:"false" help:"Enable leader election for controller manager to enable running multiple replicas."`
-		LeaderElectionNamespace    string   `default:"" help:"Namespace used to perform leader election. Defaults to the namespace the controller is running in."`
-		Namespaces                 []string `default:"" help:"Comma-separated list of namespaces to watch for ServiceLevelObjectives. Defaults to all namespaces when unset."`
+		MetricsAddr                string        `default:":8080" help:"The address the metric endpoint binds to."`
+		ConfigMapMode              bool          `default:"false" help:"If the generated recording rules should instead be saved to config maps in the default Prometheus format."`
+		GenericRules               bool          `default:"false" help:"Enabled generic recording rules generation to make it easier for tools like Grafana."`
+		DisableWebhooks            bool          `default:"true" env:"DISABLE_WEBHOOKS" help:"Disable webhooks so the controller doesn't try to read certificates"`
+		TLSCertFile                string        `default:"" help:"File containing the default x509 Certificate for HTTPS."`
+		TLSPrivateKeyFile          string        `default:"" help:"File containing the default x509 private key matching --tls-cert-file."`
+		MimirURL                   *url.URL      `default:"" help:"The URL to the Mimir API. If specified provisions rules via Mimir instead of Prometheus. The same as --ruler-url with --ruler-kind=mimir."`
+		MimirPrometheusPrefix      string        `default:"prometheus" help:"The prefix for the Prometheus API in Mimir"`
+		MimirBasicAuthUsername     string        `default:"" help:"The HTTP basic authentication username"`
+		MimirWriteAlertingRules    bool          `default:"false" help:"If alerting rules should be provisioned to the ruler."`
+		MimirBasicAuthPassword     string        `default:"" help:"The HTTP basic authentication password"`
+		MimirOrgID                 string        `default:"" help:"Mimir tenant ID to query if multi-tenancy is enabled."`
+		MimirDeploymentMode        string        `default:"standalone" help:"Mimir deployment mode. Possible values: standalone (default), distributed"`
+		RulerURL                   *url.URL      `default:"" name:"ruler-url" help:"The URL to a ruler API, like the ones of Mimir or Cortex. If specified provisions rules via the ruler instead of Prometheus. Use instead of --mimir-url for other rulers than Mimir."`
+		RulerKind                  string        `default:"mimir" enum:"mimir,cortex,generic" help:"The kind of ruler at --ruler-url, which sets the default paths of its API. One of: mimir, cortex, generic."`
+		RulerRulesPath             string        `default:"" help:"The path of the ruler's rules API, like /prometheus/config/v1/rules. Required for generic rulers, other kinds default to the path of their API."`
+		RulerTenantHeader          string        `default:"X-Scope-OrgID" help:"The header the ruler reads the tenant from."`
+		RulerTenantID              string        `default:"" name:"ruler-tenant-id" help:"The tenant to provision the rules for if the ruler is multi-tenant."`
+		RulerBasicAuthUsername     string        `default:"" help:"The HTTP basic authentication username for the ruler."`
+		RulerBasicAuthPassword     string        `default:"" help:"The HTTP basic authentication password for the ruler."`
+		RulerBearerTokenFile       string        `default:"" help:"File containing the bearer token for the ruler. It's read for every request, so that rotated tokens are used."`
+		EnablePrometheus3Migration bool          `default:"true" help:"Enable Prometheus 3 migration mode that makes rules compatible with both Prometheus 2 and 3. Enabled by default; pass --enable-prometheus-3-migration=false to opt out."`
+		ExternalURL                *url.URL      `default:"" help:"The URL for Pyrra to be included in alert annotations. This will be used to generate direct links to the Pyrra UI in alerts."`
+		EnableLeaderElection       bool          `default:"false" help:"Enable leader election for controller manager to enable running multiple replicas."`
+		LeaderElectionNamespace    string        `default:"" help:"Namespace used to perform leader election. Defaults to the namespace the controller is running in."`
+		Namespaces                 []string      `default:"" help:"Comma-separated list of namespaces to watch for ServiceLevelObjectives. Defaults to all namespaces when unset."`
+		PrometheusURL              *url.URL      `default:"" help:"The URL to the Prometheus to query for the availability and error budget written to the status of ServiceLevelObjectives. Nothing is queried if unset."`
+		StatusInterval             time.Duration `default:"1m" help:"How often the availability and error budget of ServiceLevelObjectives are queried."`
 	} `cmd:"" help:"Runs Pyrra's Kubernetes operator and backend for the API."`
 	Generate struct {
-		ConfigFiles                string   `default:"/etc/pyrra/*.yaml" help:"The folder where Pyrra finds the config files to use."`
+		ConfigFiles                string   `default:"/etc/pyrra/*.yaml" help:"The folder where Pyrra finds the config files to use. Patterns like /etc/pyrra/**/*.yaml include all subdirectories."`
 		PrometheusFolder           string   `default:"/etc/prometheus/pyrra/" help:"The folder where Pyrra writes the generated Prometheus rules and alerts."`
 		GenericRules               bool     `default:"false" help:"Enabled generic recording rules generation to make it easier for tools like Grafana."`
 		OperatorRule               bool     `default:"false" help:"Generate rule files as prometheus-operator PrometheusRule: https://prometheus-operator.dev/docs/operator/api/#monitoring.coreos.com/v1.PrometheusRule."`
 		EnablePrometheus3Migration bool     `default:"true" help:"Enable Prometheus 3 migration mode that makes rules compatible with both Prometheus 2 and 3. Enabled by default; pass --enable-prometheus-3-migration=false to opt out."`
 		ExternalURL                *url.URL `default:"" help:"The URL for Pyrra to be included in alert annotations. This will be used to generate direct links to the Pyrra UI in alerts."`
+		DryRun                     bool     `default:"false" help:"Print a unified diff of the rule files that would change without writing them."`
+		Diff                       bool     `default:"false" help:"Print a unified diff of the rule files that change."`
 	} `cmd:"" help:"Read SLO config files and rewrites them as Prometheus rules and alerts."`
+	Lint struct {
+		ConfigFiles   string   `default:"/etc/pyrra/*.yaml" help:"The folder where Pyrra finds the config files to lint. Patterns like /etc/pyrra/**/*.yaml include all subdirectories."`
+		PrometheusURL *url.URL `default:"http://localhost:9090" help:"The URL to the Prometheus to query."`
+	} `cmd:"" help:"Checks that the SLO config files select series in Prometheus."`
+	Test struct {
+		Files []string `arg:"" name:"file" type:"existingfile" help:"The unit test files of objectives, like api-errors_test.yaml."`
+	} `cmd:"" help:"Runs unit tests for objectives by evaluating their rules against series from test fixtures."`
+	Backtest struct {
+		File                       string        `arg:"" name:"file" type:"existingfile" help:"The config file of the objective to backtest."`
+		PrometheusURL              *url.URL      `default:"http://localhost:9090" help:"The URL to the Prometheus to query."`
+		Start                      time.Time     `help:"The start of the time range to backtest as RFC3339. Defaults to the objective's window before --end."`
+		End                        time.Time     `help:"The end of the time range to backtest as RFC3339. Defaults to now."`
+		Step                       time.Duration `default:"0" help:"The resolution of the backtest. Defaults to 1/1000 of the time range, but at least 30s."`
+		EnablePrometheus3Migration bool          `default:"true" help:"Enable Prometheus 3 migration mode that makes queries compatible with both Prometheus 2 and 3. Enabled by default; pass --enable-prometheus-3-migration=false to opt out."`
+	} `cmd:"" help:"Replays the burn rate alerts of an objective against the historical data in Prometheus."`
+	Export struct {
+		OpenSLO struct {
+			ConfigFiles  string `default:"/etc/pyrra/*.yaml" help:"The folder where Pyrra finds the config files to export. Patterns like /etc/pyrra/**/*.yaml include all subdirectories."`
+			OutputFolder string `default:"" help:"The folder where Pyrra writes the OpenSLO files. All SLOs are written to stdout if empty."`
+		} `cmd:"" name:"openslo" help:"Writes the SLO config files as OpenSLO v1 SLOs."`
+	} `cmd:"" help:"Exports the SLO config files to other formats."`
+	Import struct {
+		Sloth struct {
+			Files        []string `arg:"" name:"file" type:"existingfile" help:"The Sloth prometheus/v1 specs or PrometheusServiceLevel resources to import."`
+			OutputFolder string   `default:"" help:"The folder where Pyrra writes a config file per objective. All objectives are written to stdout if empty."`
+			Namespace    string   `default:"" help:"The namespace of the objectives. PrometheusServiceLevel resources keep their own namespace."`
+			Window       string   `default:"30d" help:"The SLO period Sloth generated the rules for."`
+		} `cmd:"" name:"sloth" help:"Converts Sloth SLO specs to config files."`
+	} `cmd:"" help:"Imports SLOs from other formats as config files."`
 }
 
 func main() {
@@ -144,6 +208,15 @@ func main() {
 		prometheusURL = CLI.API.PrometheusURL
 	case "filesystem":
 		prometheusURL = CLI.Filesystem.PrometheusURL
+	case "lint":
+		prometheusURL = CLI.Lint.PrometheusURL
+	case "backtest <file>":
+		prometheusURL = CLI.Backtest.PrometheusURL
+	case "kubernetes":
+		prometheusURL = CLI.Kubernetes.PrometheusURL
+		if prometheusURL.String() == "" {
+			prometheusURL, _ = url.Parse("http://localhost:9090")
+		}
 	default:
 		prometheusURL, _ = url.Parse("http://localhost:9090")
 	}
@@ -165,7 +238,7 @@ func main() {
 	if !strings.EqualFold(CLI.API.MimirOrgID, "") {
 		clientConfig.HTTPHeaders = &promconfig.Headers{
 			Headers: map[string]promconfig.Header{
-				mimir.TenantHeaderName: {
+				ruler.TenantHeaderName: {
 					Values: []string{CLI.API.MimirOrgID},
 				},
 			},
@@ -208,42 +281,125 @@ func main() {
 		externalDatasourceURL = CLI.API.GrafanaExternalURL
 	}
 
-	// Mimir Client
-	var mimirClient *mimir.Client
+	// Ruler Client
+	var rulerClient ruler.Client
 
-	// if a MimirURL has been specified, provision rules via Mimir instead of Prometheus
-	if CLI.Kubernetes.MimirURL.String() != "" {
-		level.Info(logger).Log("msg", "using Mimir", "url", CLI.Kubernetes.MimirURL.String())
-		mimirConfig := mimir.Config{
-			Address:           CLI.Kubernetes.MimirURL.String(),
-			PrometheusPrefix:  CLI.Kubernetes.MimirPrometheusPrefix,
-			BasicAuthUsername: CLI.Kubernetes.MimirBasicAuthUsername,
-			BasicAuthPassword: CLI.Kubernetes.MimirBasicAuthPassword,
-			OrgID:             CLI.Kubernetes.MimirOrgID,
-			DeploymentMode:    CLI.Kubernetes.MimirDeploymentMode,
+	var rulerConfig *ruler.Config
+	switch ctx.Command() {
+	case "kubernetes":
+		if CLI.Kubernetes.MimirURL.String() != "" && CLI.Kubernetes.RulerURL.String() != "" {
+			level.Error(logger).Log("msg", "--mimir-url and --ruler-url cannot be used together")
+			os.Exit(1)
+		}
+		// if a MimirURL has been specified, provision rules via Mimir instead of Prometheus
+		if CLI.Kubernetes.MimirURL.String() != "" {
+			rulerConfig = &ruler.Config{
+				Kind:              ruler.KindMimir,
+				Address:           CLI.Kubernetes.MimirURL.String(),
+				PrometheusPrefix:  CLI.Kubernetes.MimirPrometheusPrefix,
+				BasicAuthUsername: CLI.Kubernetes.MimirBasicAuthUsername,
+				BasicAuthPassword: CLI.Kubernetes.MimirBasicAuthPassword,
+				TenantID:          CLI.Kubernetes.MimirOrgID,
+				DeploymentMode:    CLI.Kubernetes.MimirDeploymentMode,
+			}
 		}
+		if CLI.Kubernetes.RulerURL.String() != "" {
+			rulerConfig = &ruler.Config{
+				Kind:              CLI.Kubernetes.RulerKind,
+				Address:           CLI.Kubernetes.RulerURL.String(),
+				RulesPath:         CLI.Kubernetes.RulerRulesPath,
+				TenantHeader:      CLI.Kubernetes.RulerTenantHeader,
+				TenantID:          CLI.Kubernetes.RulerTenantID,
+				BasicAuthUsername: CLI.Kubernetes.RulerBasicAuthUsername,
+				BasicAuthPassword: CLI.Kubernetes.RulerBasicAuthPassword,
+				BearerTokenFile:   CLI.Kubernetes.RulerBearerTokenFile,
+			}
+		}
+	case "filesystem":
+		if CLI.Filesystem.RulerURL.String() != "" {
+			rulerConfig = &ruler.Config{
+				Kind:              CLI.Filesystem.RulerKind,
+				Address:           CLI.Filesystem.RulerURL.String(),
+				RulesPath:         CLI.Filesystem.RulerRulesPath,
+				TenantHeader:      CLI.Filesystem.RulerTenantHeader,
+				TenantID:          CLI.Filesystem.RulerTenantID,
+				BasicAuthUsername: CLI.Filesystem.RulerBasicAuthUsername,
+				BasicAuthPassword: CLI.Filesystem.RulerBasicAuthPassword,
+				BearerTokenFile:   CLI.Filesystem.RulerBearerTokenFile,
+			}
+		}
+	}
 
-		mimirClient, err = mimir.NewClient(mimirConfig)
+	// if a ruler has been configured, provision rules via the ruler instead of Prometheus
+	if rulerConfig != nil {
+		level.Info(logger).Log("msg", "using ruler", "kind", rulerConfig.Kind, "url", rulerConfig.Address)
+
+		c, err := ruler.NewClient(*rulerConfig)
 		if err != nil {
-			level.Error(logger).Log("msg", "failed to create Mimirclient", "err", err)
+			level.Error(logger).Log("msg", "failed to create ruler client", "err", err)
 			os.Exit(1)
 		}
-		err := mimirClient.Ready(context.TODO())
-		if err != nil {
-			level.Error(logger).Log("msg", "failed to connect to Mimir", "err", err)
+		if err := c.Ready(context.TODO()); err != nil {
+			level.Error(logger).Log("msg", "failed to connect to ruler", "err", err)
 			os.Exit(1)
 		}
+		rulerClient = c
 	}
 
 	var code int
 	switch ctx.Command() {
 	case "api":
+		var datasources map[string]api.Client
+		if CLI.API.DatasourcesFile != "" {
+			datasources, err = loadDatasources(CLI.API.DatasourcesFile)
+			if err != nil {
+				level.Error(logger).Log("msg", "failed to load datasources", "file", CLI.API.DatasourcesFile, "err", err)
+				os.Exit(1)
+			}
+			for name := range datasources {
+				level.Info(logger).Log("msg", "using datasource", "name", name)
+			}
+		}
+
+		var authenticators []authenticator
+		if CLI.API.AuthOIDCJWKSFile != "" {
+			oidc, err := newOIDCAuthenticator(
+				CLI.API.AuthOIDCJWKSFile,
+				CLI.API.AuthOIDCIssuer,
+				CLI.API.AuthOIDCAudience,
+				CLI.API.AuthOIDCTeamsClaim,
+			)
+			if err != nil {
+				level.Error(logger).Log("msg", "failed to load OIDC keys", "file", CLI.API.AuthOIDCJWKSFile, "err", err)
+				os.Exit(1)
+			}
+			authenticators = append(authenticators, oidc)
+		}
+		if CLI.API.AuthProxyUserHeader != "" {
+			authenticators = append(authenticators, &proxyAuthenticator{
+				userHeader:  CLI.API.AuthProxyUserHeader,
+				teamsHeader: CLI.API.AuthProxyTeamsHeader,
+			})
+		}
+
+		var authorizer *teamAuthorizer
+		if CLI.API.AuthTeamLabel != "" {
+			if len(authenticators) == 0 {
+				level.Error(logger).Log("msg", "--auth-team-label needs --auth-oidc-jwks-file or --auth-proxy-user-header to know the caller's teams")
+				os.Exit(1)
+			}
+			authorizer = newTeamAuthorizer(CLI.API.AuthTeamLabel, CLI.API.AuthAdminTeams)
+		}
+
 		code = cmdAPI(
 			logger,
 			reg,
 			client,
+			datasources,
 			externalDatasourceURL,
 			CLI.API.APIURL,
+			CLI.API.Backend,
+			CLI.API.BackendLabel,
 			CLI.API.GrafanaExternalOrgID,
 			CLI.API.GrafanaExternalDatasourceID,
 			CLI.API.RoutePrefix,
@@ -251,6 +407,11 @@ func main() {
 			CLI.API.TLSCertFile,
 			CLI.API.TLSPrivateKeyFile,
 			CLI.API.EnablePrometheus3Migration,
+			CLI.API.WatchInterval,
+			recordingRulesMode(CLI.API.QueryRecordingRules),
+			authenticators,
+			authorizer,
+			CLI.API.CORSAllowedOrigins,
 		)
 	case "filesystem":
 		code = cmdFilesystem(
@@ -262,8 +423,15 @@ func main() {
 			CLI.Filesystem.GenericRules,
 			CLI.Filesystem.EnablePrometheus3Migration,
 			CLI.Filesystem.ExternalURL,
+			CLI.Filesystem.ResyncInterval,
+			rulerClient,
 		)
 	case "kubernetes":
+		// The operator only queries Prometheus if a URL is configured.
+		var statusClient api.Client
+		if CLI.Kubernetes.PrometheusURL.String() != "" {
+			statusClient = client
+		}
 		code = cmdKubernetes(
 			logger,
 			CLI.Kubernetes.MetricsAddr,
@@ -272,13 +440,15 @@ func main() {
 			CLI.Kubernetes.DisableWebhooks,
 			CLI.Kubernetes.TLSCertFile,
 			CLI.Kubernetes.TLSPrivateKeyFile,
-			mimirClient,
+			rulerClient,
 			CLI.Kubernetes.MimirWriteAlertingRules,
 			CLI.Kubernetes.EnablePrometheus3Migration,
 			CLI.Kubernetes.ExternalURL,
 			CLI.Kubernetes.EnableLeaderElection,
 			CLI.Kubernetes.LeaderElectionNamespace,
 			CLI.Kubernetes.Namespaces,
+			statusClient,
+			CLI.Kubernetes.StatusInterval,
 		)
 	case "generate":
 		code = cmdGenerate(
@@ -289,6 +459,36 @@ func main() {
 			CLI.Generate.OperatorRule,
 			CLI.Generate.EnablePrometheus3Migration,
 			CLI.Generate.ExternalURL,
+			CLI.Generate.DryRun,
+			CLI.Generate.Diff,
+		)
+	case "lint":
+		code = cmdLint(
+			logger,
+			client,
+			CLI.Lint.ConfigFiles,
+		)
+	case "test <file>":
+		code = cmdTest(logger, CLI.Test.Files)
+	case "backtest <file>":
+		code = cmdBacktest(
+			logger,
+			client,
+			CLI.Backtest.File,
+			CLI.Backtest.Start,
+			CLI.Backtest.End,
+			CLI.Backtest.Step,
+			CLI.Backtest.EnablePrometheus3Migration,
+		)
+	case "export openslo":
+		code = cmdExportOpenSLO(logger, CLI.Export.OpenSLO.ConfigFiles, CLI.Export.OpenSLO.OutputFolder)
+	case "import sloth <file>":
+		code = cmdImportSloth(
+			logger,
+			CLI.Import.Sloth.Files,
+			CLI.Import.Sloth.OutputFolder,
+			CLI.Import.Sloth.Namespace,
+			CLI.Import.Sloth.Window,
 		)
 	}
 	os.Exit(code)
@@ -298,11 +498,19 @@ func cmdAPI(
 	logger log.Logger,
 	reg *prometheus.Registry,
 	promClient api.Client,
+	datasources map[string]api.Client,
 	externalDatasourceURL, apiURL *url.URL,
+	backends map[string]*url.URL,
+	backendLabel string,
 	externalGrafanaOrgID, externalGrafanaDatasourceID string,
 	routePrefix, uiRoutePrefix string,
 	tlsCertFile, tlsPrivateKeyFile string,
 	enablePrometheus3Migration bool,
+	watchInterval time.Duration,
+	recordingRules recordingRulesMode,
+	authenticators []authenticator,
+	authorizer *teamAuthorizer,
+	corsAllowedOrigins []string,
 ) int {
 	build, err := fs.Sub(ui, "ui/build")
 	if err != nil {
@@ -324,7 +532,12 @@ func cmdAPI(
 		level.Info(logger).Log("msg", "UI redirect to Grafana", "url", externalDatasourceURL.String(),
 			"datasourceId", externalGrafanaDatasourceID, "orgId", externalGrafanaOrgID)
 	}
-	level.Info(logger).Log("msg", "using API at", "url", apiURL.String())
+	if len(backends) == 0 {
+		level.Info(logger).Log("msg", "using API at", "url", apiURL.String())
+	}
+	for name, u := range backends {
+		level.Info(logger).Log("msg", "using API at", "url", u.String(), "backend", name)
+	}
 	level.Info(logger).Log("msg", "using route prefix", "prefix", routePrefix)
 
 	cache, err := ristretto.NewCache(&ristretto.Config[string, any]{
@@ -337,10 +550,20 @@ func cmdAPI(
 		return 1
 	}
 	defer cache.Close()
+	datasourceAPIs := make(map[string]prometheusAPI, len(datasources))
+	for name, client := range datasources {
+		datasourceAPIs[name] = &promLogger{
+			api:    prometheusapiv1.NewAPI(client),
+			logger: log.WithPrefix(logger, "datasource", name),
+		}
+	}
 	promAPI := &promCache{
-		api: &promLogger{
-			api:    prometheusapiv1.NewAPI(promClient),
-			logger: logger,
+		api: &datasourceRouter{
+			defaultAPI: &promLogger{
+				api:    prometheusapiv1.NewAPI(promClient),
+				logger: logger,
+			},
+			datasources: datasourceAPIs,
 		},
 		cache: cache,
 	}
@@ -352,12 +575,16 @@ func cmdAPI(
 	}
 
 	r := chi.NewRouter()
-	r.Use(cors.Handler(cors.Options{
-		AllowedHeaders: []string{
-			"Content-Type",
-			"Connect-Protocol-Version",
-		},
-	})) // TODO: Disable by default
+	if len(corsAllowedOrigins) > 0 {
+		r.Use(cors.Handler(cors.Options{
+			AllowedOrigins: corsAllowedOrigins,
+			AllowedHeaders: []string{
+				"Authorization",
+				"Content-Type",
+				"Connect-Protocol-Version",
+			},
+		}))
+	}
 
 	prometheusInterceptor := connectprometheus.NewInterceptor(reg)
 
@@ -378,17 +605,36 @@ func cmdAPI(
 			Transport: roundTripper,
 		}
 
-		objectiveService := &objectiveServer{
-			logger:  log.WithPrefix(logger, "service", "objective"),
-			promAPI: promAPI,
-			client: newBackendClientCache(
-				objectivesv1alpha1connect.NewObjectiveBackendServiceClient(
+		var backendClient objectivesv1alpha1connect.ObjectiveBackendServiceClient = objectivesv1alpha1connect.NewObjectiveBackendServiceClient(
+			client,
+			apiURL.String(),
+			connect.WithInterceptors(prometheusInterceptor),
+		)
+		if len(backends) > 0 {
+			backendClients := make(map[string]objectivesv1alpha1connect.ObjectiveBackendServiceClient, len(backends))
+			for name, u := range backends {
+				backendClients[name] = objectivesv1alpha1connect.NewObjectiveBackendServiceClient(
 					client,
-					apiURL.String(),
+					u.String(),
 					connect.WithInterceptors(prometheusInterceptor),
-				),
-			),
-			opts: slo.GenerationOptions{EnablePrometheus3Migration: enablePrometheus3Migration},
+				)
+			}
+			backendClient = newMultiBackendClient(backendLabel, backendClients)
+		}
+		backendClient = newBackendClientCache(backendClient)
+		queries := &queryAuthorizer{client: backendClient, authorizer: authorizer}
+		if authorizer != nil {
+			backendClient = &authorizedBackendClient{client: backendClient, authorizer: authorizer}
+		}
+
+		objectiveService := &objectiveServer{
+			logger:         log.WithPrefix(logger, "service", "objective"),
+			promAPI:        promAPI,
+			client:         backendClient,
+			opts:           slo.GenerationOptions{EnablePrometheus3Migration: enablePrometheus3Migration},
+			watcher:        newWatcher(log.WithPrefix(logger, "service", "watch"), watchInterval),
+			recordingRules: recordingRules,
+			queries:        queries,
 		}
 
 		objectivePath, objectiveHandler := objectivesv1alpha1connect.NewObjectiveServiceHandler(
@@ -399,20 +645,31 @@ func cmdAPI(
 		prometheusService := &prometheusServer{
 			logger:  log.WithPrefix(logger, "service", "prometheus"),
 			promAPI: promAPI,
+			queries: queries,
 		}
 		prometheusPath, prometheusHandler := prometheusv1connect.NewPrometheusServiceHandler(prometheusService)
 
 		mcpHandler := newMCPHandler(objectiveService, log.WithPrefix(logger, "service", "mcp"))
 
-		if routePrefix != "/" {
-			r.Mount(objectivePath, http.StripPrefix(routePrefix, objectiveHandler))
-			r.Mount(prometheusPath, http.StripPrefix(routePrefix, prometheusHandler))
-			r.Mount("/mcp", http.StripPrefix(routePrefix, mcpHandler))
-		} else {
-			r.Mount(objectivePath, objectiveHandler)
-			r.Mount(prometheusPath, prometheusHandler)
-			r.Mount("/mcp", mcpHandler)
-		}
+		// Everything serving objectives and their series needs authentication, if configured.
+		// The UI's static files and the metrics don't.
+		r.Group(func(r chi.Router) {
+			if len(authenticators) > 0 {
+				r.Use(authenticate(log.WithPrefix(logger, "handler", "auth"), authenticators))
+			}
+
+			if routePrefix != "/" {
+				r.Mount(objectivePath, http.StripPrefix(routePrefix, objectiveHandler))
+				r.Mount(prometheusPath, http.StripPrefix(routePrefix, prometheusHandler))
+				r.Mount("/mcp", http.StripPrefix(routePrefix, mcpHandler))
+			} else {
+				r.Mount(objectivePath, objectiveHandler)
+				r.Mount(prometheusPath, prometheusHandler)
+				r.Mount("/mcp", mcpHandler)
+			}
+
+			r.Method(http.MethodGet, "/report", newReportHandler(objectiveService, log.WithPrefix(logger, "handler", "report")))
+		})
 
 		r.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
 
@@ -527,9 +784,16 @@ type backendClientCache struct {
 	cache  *ristretto.Cache[string, any]
 }
 
-// List calls the backend service and caches the result for 10 seconds if the request is successful.
+// List calls the backend service and caches the result for 10 seconds if the request is successful and complete.
 func (b *backendClientCache) List(ctx context.Context, req *connect.Request[objectivesv1alpha1.ListRequest]) (*connect.Response[objectivesv1alpha1.ListResponse], error) {
-	key := req.Msg.Expr + req.Msg.Grouping
+	key := strings.Join([]string{
+		req.Msg.Expr,
+		req.Msg.Grouping,
+		strconv.Itoa(int(req.Msg.PageSize)),
+		req.Msg.PageToken,
+		req.Msg.OrderBy,
+		req.Msg.Search,
+	}, "\x00")
 
 	list, found := b.cache.Get(key)
 	if found {
@@ -541,6 +805,10 @@ func (b *backendClientCache) List(ctx context.Context, req *connect.Request[obje
 	if err != nil {
 		return nil, err
 	}
+	if len(resp.Msg.Warnings) > 0 {
+		// Don't keep objectives missing from partial responses for longer.
+		return resp, nil
+	}
 
 	_ = b.cache.SetWithTTL(key, resp.Msg, time.Since(start).Milliseconds(), 10*time.Second)
 
@@ -679,8 +947,17 @@ func contextGetGraphStep(ctx context.Context) time.Duration {
 }
 
 func (p *promCache) Query(ctx context.Context, query string, ts time.Time) (model.Value, prometheusapiv1.Warnings, error) {
-	if value, exists := p.cache.Get(query); exists {
-		return value.(model.Value), nil, nil
+	// Cached values are looked up by their datasource and query only, regardless of ts.
+	// Only callers that opted into the cache accept values from a few seconds before.
+	cacheKey := query
+	if datasource := contextGetDatasource(ctx); datasource != "" {
+		cacheKey = datasource + ";" + query
+	}
+	cacheDuration := contextGetPromCache(ctx)
+	if cacheDuration > 0 {
+		if value, exists := p.cache.Get(cacheKey); exists {
+			return value.(model.Value), nil, nil
+		}
 	}
 
 	start := time.Now()
@@ -693,11 +970,10 @@ func (p *promCache) Query(ctx context.Context, query string, ts time.Time) (mode
 		return value, warnings, nil
 	}
 
-	cacheDuration := contextGetPromCache(ctx)
 	if cacheDuration > 0 {
 		if v, ok := value.(model.Vector); ok {
 			if len(v) > 0 {
-				_ = p.cache.SetWithTTL(query, value, duration.Milliseconds(), cacheDuration)
+				_ = p.cache.SetWithTTL(cacheKey, value, duration.Milliseconds(), cacheDuration)
 			}
 		}
 	}
@@ -710,6 +986,9 @@ func (p *promCache) QueryRange(ctx context.Context, query string, r prometheusap
 	// We round by 10s to adjust for small imperfections to increase cache hits.
 	timeRange := r.End.Sub(r.Start).Round(10 * time.Second)
 	cacheKey := fmt.Sprintf("%d;%s", timeRange.Milliseconds(), query)
+	if datasource := contextGetDatasource(ctx); datasource != "" {
+		cacheKey = datasource + ";" + cacheKey
+	}
 
 	if value, exists := p.cache.Get(cacheKey); exists {
 		return value.(model.Value), nil, nil
@@ -738,10 +1017,14 @@ func (p *promCache) QueryRange(ctx context.Context, query string, r prometheusap
 }
 
 type objectiveServer struct {
-	logger  log.Logger
-	promAPI *promCache
-	client  objectivesv1alpha1connect.ObjectiveBackendServiceClient
-	opts    slo.GenerationOptions
+	logger         log.Logger
+	promAPI        *promCache
+	client         objectivesv1alpha1connect.ObjectiveBackendServiceClient
+	opts           slo.GenerationOptions
+	watcher        *watcher
+	recordingRules recordingRulesMode
+	// queries restricts the series of objectives passed along with requests, like backtests.
+	queries *queryAuthorizer
 }
 
 func (s *objectiveServer) getObjective(ctx context.Context, expr string) (slo.Objective, error) {
@@ -756,7 +1039,11 @@ func (s *objectiveServer) getObjective(ctx context.Context, expr string) (slo.Ob
 		return slo.Objective{}, connect.NewError(connect.CodeAborted, fmt.Errorf("expr matches more than one SLO, it matches: %d", len(resp.Msg.Objectives)))
 	}
 
-	return objectivesv1alpha1.ToInternal(resp.Msg.Objectives[0]), nil
+	objective, err := objectivesv1alpha1.ToInternal(resp.Msg.Objectives[0])
+	if err != nil {
+		return slo.Objective{}, connect.NewError(connect.CodeInternal, err)
+	}
+	return objective, nil
 }
 
 func (s *objectiveServer) List(ctx context.Context, req *connect.Request[objectivesv1alpha1.ListRequest]) (*connect.Response[objectivesv1alpha1.ListResponse], error) {
@@ -767,7 +1054,11 @@ func (s *objectiveServer) List(ctx context.Context, req *connect.Request[objecti
 	}
 
 	resp, err := s.client.List(ctx, connect.NewRequest(&objectivesv1alpha1.ListRequest{
-		Expr: req.Msg.Expr,
+		Expr:      req.Msg.Expr,
+		PageSize:  req.Msg.PageSize,
+		PageToken: req.Msg.PageToken,
+		OrderBy:   req.Msg.OrderBy,
+		Search:    req.Msg.Search,
 	}))
 	if err != nil {
 		return nil, err
@@ -785,7 +1076,10 @@ func (s *objectiveServer) List(ctx context.Context, req *connect.Request[objecti
 	}
 
 	for _, o := range resp.Msg.Objectives {
-		oi := objectivesv1alpha1.ToInternal(o)
+		oi, err := objectivesv1alpha1.ToInternal(o)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
 
 		// If specific grouping was selected we need to merge the label matchers for the queries.
 		if len(groupingMatchers) > 0 {
@@ -877,17 +1171,35 @@ func (s *objectiveServer) List(ctx context.Context, req *connect.Request[objecti
 			}
 		}
 
+		// Calendar objectives show the queries for the current period.
+		now := time.Now()
+		countTotal, err := oi.QueryCalendar(oi.QueryTotal(oi.Window, s.opts), now, now)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		countErrors, err := oi.QueryCalendar(oi.QueryErrors(oi.Window, s.opts), now, now)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		graphErrorBudget, err := oi.QueryCalendar(oi.QueryErrorBudget(s.opts), now, now)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
 		o.Queries = &objectivesv1alpha1.Queries{
-			CountTotal:       oi.QueryTotal(oi.Window, s.opts),
-			CountErrors:      oi.QueryErrors(oi.Window, s.opts),
-			GraphErrorBudget: oi.QueryErrorBudget(s.opts),
+			CountTotal:       countTotal,
+			CountErrors:      countErrors,
+			GraphErrorBudget: graphErrorBudget,
 			GraphRequests:    oi.RequestRange(time.Second, s.opts),
 			GraphErrors:      oi.ErrorsRange(time.Second, s.opts),
 		}
 	}
 
 	return connect.NewResponse(&objectivesv1alpha1.ListResponse{
-		Objectives: resp.Msg.Objectives,
+		Objectives:    resp.Msg.Objectives,
+		NextPageToken: resp.Msg.NextPageToken,
+		TotalSize:     resp.Msg.TotalSize,
+		Warnings:      resp.Msg.Warnings,
 	}), nil
 }
 
@@ -898,26 +1210,8 @@ func (s *objectiveServer) GetStatus(ctx context.Context, req *connect.Request[ob
 	}
 
 	// Merge grouping into objective's query
-	if req.Msg.Grouping != "" {
-		groupingMatchers, err := parser.ParseMetricSelector(req.Msg.Grouping)
-		if err != nil {
-			return nil, connect.NewError(connect.CodeInvalidArgument, err)
-		}
-		if objective.Indicator.Ratio != nil {
-			for _, m := range groupingMatchers {
-				objective.Indicator.Ratio.Errors.LabelMatchers = append(objective.Indicator.Ratio.Errors.LabelMatchers, m)
-				objective.Indicator.Ratio.Total.LabelMatchers = append(objective.Indicator.Ratio.Total.LabelMatchers, m)
-			}
-		}
-		if objective.Indicator.Latency != nil {
-			for _, m := range groupingMatchers {
-				objective.Indicator.Latency.Success.LabelMatchers = append(objective.Indicator.Latency.Success.LabelMatchers, m)
-				objective.Indicator.Latency.Total.LabelMatchers = append(objective.Indicator.Latency.Total.LabelMatchers, m)
-			}
-		}
-		if objective.Indicator.BoolGauge != nil {
-			objective.Indicator.BoolGauge.LabelMatchers = append(objective.Indicator.BoolGauge.LabelMatchers, groupingMatchers...)
-		}
+	if err := addGroupingMatchers(&objective, req.Msg.Grouping); err != nil {
+		return nil, err
 	}
 
 	ts := time.Now()
@@ -925,16 +1219,81 @@ func (s *objectiveServer) GetStatus(ctx context.Context, req *connect.Request[ob
 		ts = req.Msg.Time.AsTime()
 	}
 
-	queryTotal := objective.QueryTotal(objective.Window, s.opts)
-	value, _, err := s.promAPI.Query(contextSetPromCache(ctx, 15*time.Second), queryTotal, ts)
+	var status []*objectivesv1alpha1.ObjectiveStatus
+	if objective.IndicatorType() == slo.Composite {
+		status, err = s.getCompositeStatus(contextSetPromCache(ctx, 15*time.Second), objective, ts)
+	} else {
+		status, err = s.getStatus(contextSetPromCache(ctx, 15*time.Second), objective, ts, ts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&objectivesv1alpha1.GetStatusResponse{
+		Status: status,
+	}), nil
+}
+
+// addGroupingMatchers adds the matchers of a grouping selector like {handler="/api"} to the objective's queries.
+func addGroupingMatchers(objective *slo.Objective, grouping string) error {
+	if grouping == "" {
+		return nil
+	}
+
+	groupingMatchers, err := parser.ParseMetricSelector(grouping)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if objective.Indicator.Ratio != nil {
+		for _, m := range groupingMatchers {
+			objective.Indicator.Ratio.Errors.LabelMatchers = append(objective.Indicator.Ratio.Errors.LabelMatchers, m)
+			objective.Indicator.Ratio.Total.LabelMatchers = append(objective.Indicator.Ratio.Total.LabelMatchers, m)
+		}
+	}
+	if objective.Indicator.Latency != nil {
+		for _, m := range groupingMatchers {
+			objective.Indicator.Latency.Success.LabelMatchers = append(objective.Indicator.Latency.Success.LabelMatchers, m)
+			objective.Indicator.Latency.Total.LabelMatchers = append(objective.Indicator.Latency.Total.LabelMatchers, m)
+		}
+	}
+	if objective.Indicator.BoolGauge != nil {
+		objective.Indicator.BoolGauge.LabelMatchers = append(objective.Indicator.BoolGauge.LabelMatchers, groupingMatchers...)
+	}
+	return nil
+}
+
+// getStatus returns the status of every group of the objective over its window at ts.
+// The calendar period is selected with periodTime, which is ts itself unless the period ending at ts is queried.
+func (s *objectiveServer) getStatus(ctx context.Context, objective slo.Objective, periodTime, ts time.Time) ([]*objectivesv1alpha1.ObjectiveStatus, error) {
+	ctx = contextSetDatasource(ctx, objective.Datasource)
+	queryTotal, err := objective.QueryCalendar(objective.QueryTotal(objective.Window, s.opts), periodTime, periodTime)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	totalValue, _, err := s.promAPI.Query(ctx, queryTotal, ts)
 	if err != nil {
 		level.Warn(s.logger).Log("msg", "failed to query total", "query", queryTotal, "err", err)
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
+	queryErrors, err := objective.QueryCalendar(objective.QueryErrors(objective.Window, s.opts), periodTime, periodTime)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	errorsValue, _, err := s.promAPI.Query(ctx, queryErrors, ts)
+	if err != nil {
+		level.Warn(s.logger).Log("msg", "failed to query errors", "query", queryErrors, "err", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return objectiveStatus(objective, totalValue.(model.Vector), errorsValue.(model.Vector)), nil
+}
+
+// objectiveStatus returns the status of every group from the results of the objective's total and errors queries.
+func objectiveStatus(objective slo.Objective, total, errs model.Vector) []*objectivesv1alpha1.ObjectiveStatus {
 	statuses := map[model.Fingerprint]*objectivesv1alpha1.ObjectiveStatus{}
 
-	for _, v := range value.(model.Vector) {
+	for _, v := range total {
 		ls := make(map[string]string)
 		for k, v := range v.Metric {
 			ls[string(k)] = string(v)
@@ -949,13 +1308,7 @@ func (s *objectiveServer) GetStatus(ctx context.Context, req *connect.Request[ob
 		}
 	}
 
-	queryErrors := objective.QueryErrors(objective.Window, s.opts)
-	value, _, err = s.promAPI.Query(contextSetPromCache(ctx, 15*time.Second), queryErrors, ts)
-	if err != nil {
-		level.Warn(s.logger).Log("msg", "failed to query errors", "query", queryErrors, "err", err)
-		return nil, connect.NewError(connect.CodeInternal, err)
-	}
-	for _, v := range value.(model.Vector) {
+	for _, v := range errs {
 		if s, exists := statuses[v.Metric.Fingerprint()]; exists {
 			s.Availability.Errors = float64(v.Value)
 			s.Availability.Percentage = 1 - (s.Availability.Errors / s.Availability.Total)
@@ -994,13 +1347,51 @@ func (s *objectiveServer) GetStatus(ctx context.Context, req *connect.Request[ob
 		if math.IsNaN(s.Budget.Remaining) {
 			s.Budget.Remaining = 1
 		}
+		if stage, ok := objective.BudgetPolicyStage(s.Budget.Remaining); ok {
+			s.Budget.PolicyStage = stage.Name
+		}
 
 		statusSlice = append(statusSlice, s)
 	}
 
-	return connect.NewResponse(&objectivesv1alpha1.GetStatusResponse{
-		Status: statusSlice,
-	}), nil
+	return statusSlice
+}
+
+// getCompositeStatus returns the status of composite objectives.
+// They don't have requests of their own, so only their combined error ratio over the window is queried.
+func (s *objectiveServer) getCompositeStatus(ctx context.Context, objective slo.Objective, ts time.Time) ([]*objectivesv1alpha1.ObjectiveStatus, error) {
+	ctx = contextSetDatasource(ctx, objective.Datasource)
+	query, err := objective.QueryBurnrate(time.Duration(objective.Window), nil)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	value, _, err := s.promAPI.Query(ctx, query, ts)
+	if err != nil {
+		level.Warn(s.logger).Log("msg", "failed to query composite burn rate", "query", query, "err", err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	vector, ok := value.(model.Vector)
+	if !ok || len(vector) == 0 {
+		return nil, nil
+	}
+
+	errorRatio := float64(vector[0].Value)
+	if math.IsNaN(errorRatio) {
+		errorRatio = 0
+	}
+
+	budget := &objectivesv1alpha1.Budget{Total: 1 - objective.Target}
+	budget.Remaining = (budget.Total - errorRatio) / budget.Total
+	if stage, ok := objective.BudgetPolicyStage(budget.Remaining); ok {
+		budget.PolicyStage = stage.Name
+	}
+
+	return []*objectivesv1alpha1.ObjectiveStatus{{
+		Labels:       map[string]string{},
+		Availability: &objectivesv1alpha1.Availability{Percentage: 1 - errorRatio},
+		Budget:       budget,
+	}}, nil
 }
 
 func (s *objectiveServer) GraphErrorBudget(ctx context.Context, req *connect.Request[objectivesv1alpha1.GraphErrorBudgetRequest]) (*connect.Response[objectivesv1alpha1.GraphErrorBudgetResponse], error) {
@@ -1008,6 +1399,7 @@ func (s *objectiveServer) GraphErrorBudget(ctx context.Context, req *connect.Req
 	if err != nil {
 		return nil, err
 	}
+	ctx = contextSetDatasource(ctx, objective.Datasource)
 
 	if req.Msg.Grouping != "" && req.Msg.Grouping != "{}" {
 		groupingMatchers, err := parser.ParseMetricSelector(req.Msg.Grouping)
@@ -1081,7 +1473,11 @@ func (s *objectiveServer) GraphErrorBudget(ctx context.Context, req *connect.Req
 		step = s
 	}
 
-	query := objective.QueryErrorBudget(s.opts)
+	// Calendar objectives reset the error budget at the start of every period within the range.
+	query, err := objective.QueryCalendar(objective.QueryErrorBudget(s.opts), start, end)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
 	value, _, err := s.promAPI.QueryRange(contextSetPromCache(ctx, 15*time.Second), query, prometheusapiv1.Range{
 		Start: start,
 		End:   end,
@@ -1137,7 +1533,11 @@ func (s *objectiveServer) GetAlerts(ctx context.Context, req *connect.Request[ob
 
 	objectives := make([]slo.Objective, 0, len(resp.Msg.Objectives))
 	for _, o := range resp.Msg.Objectives {
-		objectives = append(objectives, objectivesv1alpha1.ToInternal(o))
+		objective, err := objectivesv1alpha1.ToInternal(o)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		objectives = append(objectives, objective)
 	}
 
 	// Match alerts that at least have one character for the slo name.
@@ -1168,20 +1568,33 @@ func (s *objectiveServer) GetAlerts(ctx context.Context, req *connect.Request[ob
 		queryAlerts = vec.String()
 	}
 
-	value, _, err := s.promAPI.Query(contextSetPromCache(ctx, 5*time.Second), queryAlerts, time.Now())
-	if err != nil {
-		level.Warn(s.logger).Log("msg", "failed to query alerts", "query", queryAlerts, "err", err)
-		return nil, connect.NewError(connect.CodeInternal, err)
+	// Alerts are queried from every datasource and only belong to the objectives of that datasource.
+	var datasources []string
+	datasourceObjectives := map[string][]slo.Objective{}
+	for _, objective := range objectives {
+		if _, ok := datasourceObjectives[objective.Datasource]; !ok {
+			datasources = append(datasources, objective.Datasource)
+		}
+		datasourceObjectives[objective.Datasource] = append(datasourceObjectives[objective.Datasource], objective)
 	}
 
-	vector, ok := value.(model.Vector)
-	if !ok {
-		err := fmt.Errorf("no vector returned")
-		level.Debug(s.logger).Log("msg", "returned data wasn't of type vector", "query", queryAlerts, "err", err)
-		return nil, connect.NewError(connect.CodeInternal, err)
-	}
+	var alerts []*objectivesv1alpha1.Alert
+	for _, datasource := range datasources {
+		value, _, err := s.promAPI.Query(contextSetPromCache(contextSetDatasource(ctx, datasource), 5*time.Second), queryAlerts, time.Now())
+		if err != nil {
+			level.Warn(s.logger).Log("msg", "failed to query alerts", "query", queryAlerts, "datasource", datasource, "err", err)
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
 
-	alerts := alertsMatchingObjectives(vector, objectives, groupingMatchers, req.Msg.Inactive)
+		vector, ok := value.(model.Vector)
+		if !ok {
+			err := fmt.Errorf("no vector returned")
+			level.Debug(s.logger).Log("msg", "returned data wasn't of type vector", "query", queryAlerts, "err", err)
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		alerts = append(alerts, alertsMatchingObjectives(vector, datasourceObjectives[datasource], groupingMatchers, req.Msg.Inactive)...)
+	}
 
 	if req.Msg.Current {
 		for _, objective := range objectives {
@@ -1203,7 +1616,7 @@ func (s *objectiveServer) GetAlerts(ctx context.Context, req *connect.Request[ob
 						level.Warn(s.logger).Log("msg", "failed to prepare current burn rate query", "err", err)
 						return
 					}
-					value, _, err := s.promAPI.Query(contextSetPromCache(ctx, instantCache(w)), query, time.Now())
+					value, _, err := s.promAPI.Query(contextSetPromCache(contextSetDatasource(ctx, objective.Datasource), instantCache(w)), query, time.Now())
 					if err != nil {
 						level.Warn(s.logger).Log("msg", "failed to query current burn rate", "query", query, "err", err)
 						return
@@ -1427,6 +1840,7 @@ func (s *objectiveServer) GraphRate(ctx context.Context, req *connect.Request[ob
 	if err != nil {
 		return nil, err
 	}
+	ctx = contextSetDatasource(ctx, objective.Datasource)
 
 	// Merge grouping into objective's query
 	if req.Msg.Grouping != "" {
@@ -1467,6 +1881,9 @@ func (s *objectiveServer) GraphRate(ctx context.Context, req *connect.Request[ob
 	cacheDuration := rangeCache(start, end)
 
 	query := objective.RequestRange(timeRange, s.opts)
+	if recorded, ok := objective.RecordedRequestRange(timeRange); ok && s.useRecordingRules(ctx, objective) {
+		query = recorded
+	}
 
 	value, _, err := s.promAPI.QueryRange(contextSetPromCache(ctx, cacheDuration), query, prometheusapiv1.Range{
 		Start: start,
@@ -1529,10 +1946,12 @@ func (s *objectiveServer) GraphErrors(ctx context.Context, req *connect.Request[
 	if err != nil {
 		return nil, err
 	}
+	ctx = contextSetDatasource(ctx, objective.Datasource)
 
 	// Merge grouping into objective's query
+	var groupingMatchers []*labels.Matcher
 	if req.Msg.Grouping != "" {
-		groupingMatchers, err := parser.ParseMetricSelector(req.Msg.Grouping)
+		groupingMatchers, err = parser.ParseMetricSelector(req.Msg.Grouping)
 		if err != nil {
 			return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("failed to parse expr: %w", err))
 		}
@@ -1569,6 +1988,12 @@ func (s *objectiveServer) GraphErrors(ctx context.Context, req *connect.Request[
 	cacheDuration := rangeCache(start, end)
 
 	query := objective.ErrorsRange(timeRange, s.opts)
+	// The recorded burn rates are only grouped by the objective's grouping, so a single group is needed.
+	if (len(objective.Grouping()) == 0 || len(groupingMatchers) > 0) && s.useRecordingRules(ctx, objective) {
+		if recorded, err := objective.QueryBurnrate(objective.BurnrateWindow(timeRange), groupingMatchers); err == nil {
+			query = recorded
+		}
+	}
 	value, _, err := s.promAPI.QueryRange(contextSetPromCache(ctx, cacheDuration), query, prometheusapiv1.Range{
 		Start: start,
 		End:   end,
@@ -1633,6 +2058,7 @@ func (s *objectiveServer) GraphDuration(ctx context.Context, req *connect.Reques
 	if err != nil {
 		return nil, err
 	}
+	ctx = contextSetDatasource(ctx, objective.Datasource)
 
 	// Merge grouping into objective's query
 	if req.Msg.Grouping != "" {
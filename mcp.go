@@ -243,6 +243,11 @@ func (m *mcpServer) getObjective(ctx context.Context, _ *mcpsdk.CallToolRequest,
 		return nil, nil, err
 	}
 
+	queries, err := m.statusQueries(o, in.Grouping)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	res := getObjectiveResult{
 		Name:        in.Name,
 		Description: o.GetDescription(),
@@ -251,7 +256,7 @@ func (m *mcpServer) getObjective(ctx context.Context, _ *mcpsdk.CallToolRequest,
 		Window:      humanizeDuration(o.GetWindow().AsDuration()),
 		Type:        indicatorType(o),
 		Latency:     latencyThreshold(o),
-		Queries:     m.statusQueries(o, in.Grouping),
+		Queries:     queries,
 	}
 	for _, st := range status.Msg.Status {
 		res.Status = append(res.Status, statusRow{
@@ -373,8 +378,11 @@ func buildAlertRows(alerts []*objectivesv1alpha1.Alert, window time.Duration, ta
 // statusQueries reconstructs the instantaneous total/errors PromQL that
 // GetStatus runs for this objective (with grouping merged in the same way), so
 // the exact queries behind the status tiles travel with the result.
-func (m *mcpServer) statusQueries(o *objectivesv1alpha1.Objective, grouping map[string]string) *querySet {
-	obj := objectivesv1alpha1.ToInternal(o)
+func (m *mcpServer) statusQueries(o *objectivesv1alpha1.Objective, grouping map[string]string) (*querySet, error) {
+	obj, err := objectivesv1alpha1.ToInternal(o)
+	if err != nil {
+		return nil, err
+	}
 
 	for _, k := range slices.Sorted(maps.Keys(grouping)) {
 		mt := &labels.Matcher{Type: labels.MatchEqual, Name: k, Value: grouping[k]}
@@ -390,10 +398,19 @@ func (m *mcpServer) statusQueries(o *objectivesv1alpha1.Objective, grouping map[
 		}
 	}
 
-	return &querySet{
+	qs := &querySet{
 		Total:  obj.QueryTotal(obj.Window, m.objectives.opts),
 		Errors: obj.QueryErrors(obj.Window, m.objectives.opts),
 	}
+	// Calendar objectives are queried for the current period.
+	now := time.Now()
+	if qs.Total, err = obj.QueryCalendar(qs.Total, now, now); err != nil {
+		return nil, err
+	}
+	if qs.Errors, err = obj.QueryCalendar(qs.Errors, now, now); err != nil {
+		return nil, err
+	}
+	return qs, nil
 }
 
 func (m *mcpServer) listOne(ctx context.Context, name string) (*objectivesv1alpha1.Objective, error) {
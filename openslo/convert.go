@@ -0,0 +1,713 @@
+package openslo
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pyrra-dev/pyrra/kubernetes/api/v1alpha1"
+)
+
+// Annotations keep the fields that only one of both formats has, so that they survive a round trip.
+const (
+	AnnotationDisplayName = "openslo.pyrra.dev/display-name"
+	AnnotationService     = "openslo.pyrra.dev/service"
+	AnnotationNamespace   = "openslo.pyrra.dev/namespace"
+)
+
+// calendarStartTime is a Monday, so that it starts weeks, months and quarters alike.
+const calendarStartTime = "2024-01-01 00:00:00"
+
+const calendarTimeFormat = "2006-01-02 15:04:05"
+
+// calendarPeriods maps OpenSLO's calendar durations to Pyrra's calendar periods.
+var calendarPeriods = map[string]string{
+	"1w": "week",
+	"1M": "month",
+	"1Q": "quarter",
+}
+
+// shortWindowRatio is how much shorter the short window of a burn rate alert is than its long window.
+// OpenSLO's alert conditions only have the long lookback window.
+const shortWindowRatio = 12
+
+// UnsupportedError lists all fields of a document that can't be converted.
+type UnsupportedError struct {
+	Kind   string
+	Name   string
+	Fields []UnsupportedField
+}
+
+type UnsupportedField struct {
+	Path   string
+	Reason string
+}
+
+func (e *UnsupportedError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		fields = append(fields, f.Path+": "+f.Reason)
+	}
+	return fmt.Sprintf("%s %q has unsupported fields: %s", e.Kind, e.Name, strings.Join(fields, "; "))
+}
+
+// converter collects the unsupported fields of a conversion instead of failing on the first one.
+type converter struct {
+	unsupported []UnsupportedField
+}
+
+func (c *converter) unsupportedf(path, format string, args ...any) {
+	c.unsupported = append(c.unsupported, UnsupportedField{Path: path, Reason: fmt.Sprintf(format, args...)})
+}
+
+func (c *converter) err(kind, name string) error {
+	if len(c.unsupported) == 0 {
+		return nil
+	}
+	return &UnsupportedError{Kind: kind, Name: name, Fields: c.unsupported}
+}
+
+// ServiceLevelObjectives converts every SLO of the documents with the SLIs, AlertPolicies and AlertConditions they reference.
+func (d Documents) ServiceLevelObjectives() ([]v1alpha1.ServiceLevelObjective, error) {
+	objectives := make([]v1alpha1.ServiceLevelObjective, 0, len(d.SLOs))
+	for _, s := range d.SLOs {
+		o, err := d.serviceLevelObjective(s)
+		if err != nil {
+			return nil, err
+		}
+		objectives = append(objectives, o)
+	}
+	return objectives, nil
+}
+
+func (d Documents) serviceLevelObjective(s SLO) (v1alpha1.ServiceLevelObjective, error) {
+	c := &converter{}
+
+	o := v1alpha1.ServiceLevelObjective{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.GroupVersion.String(),
+			Kind:       "ServiceLevelObjective",
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: s.Metadata.Name},
+	}
+	for name, value := range s.Metadata.Annotations {
+		if name == AnnotationNamespace {
+			o.Namespace = value
+			continue
+		}
+		o.SetAnnotations(setAnnotation(o.Annotations, name, value))
+	}
+	if s.Metadata.DisplayName != "" {
+		o.SetAnnotations(setAnnotation(o.Annotations, AnnotationDisplayName, s.Metadata.DisplayName))
+	}
+	if s.Spec.Service != "" {
+		o.SetAnnotations(setAnnotation(o.Annotations, AnnotationService, s.Spec.Service))
+	}
+	for name, values := range s.Metadata.Labels {
+		if len(values) != 1 {
+			c.unsupportedf("metadata.labels."+name, "Pyrra only supports labels with a single value")
+			continue
+		}
+		if o.Labels == nil {
+			o.Labels = map[string]string{}
+		}
+		o.Labels[name] = values[0]
+	}
+
+	o.Spec.Description = s.Spec.Description
+
+	if len(s.Spec.TimeWindow) != 1 {
+		c.unsupportedf("spec.timeWindow", "Pyrra only supports exactly one time window")
+	} else {
+		c.timeWindow(&o.Spec, s.Spec.TimeWindow[0])
+	}
+
+	if s.Spec.BudgetingMethod != "" && s.Spec.BudgetingMethod != "Occurrences" {
+		c.unsupportedf("spec.budgetingMethod", "%s isn't supported, Pyrra only supports Occurrences", s.Spec.BudgetingMethod)
+	}
+
+	indicator, indicatorRef, indicatorPath := s.Spec.Indicator, s.Spec.IndicatorRef, "spec"
+	if len(s.Spec.Objectives) != 1 {
+		c.unsupportedf("spec.objectives", "Pyrra only supports exactly one objective")
+	} else {
+		objective := s.Spec.Objectives[0]
+		o.Spec.Target = c.target(objective)
+		if objective.Indicator != nil || objective.IndicatorRef != "" {
+			if indicator != nil || indicatorRef != "" {
+				c.unsupportedf("spec.objectives[0]", "the indicator can't be set for both the SLO and the objective")
+			}
+			indicator, indicatorRef, indicatorPath = objective.Indicator, objective.IndicatorRef, "spec.objectives[0]"
+		}
+	}
+
+	switch {
+	case indicator != nil:
+		o.Spec.ServiceLevelIndicator = c.indicator(indicatorPath+".indicator.spec", indicator.Spec)
+	case indicatorRef != "":
+		sli, ok := d.SLIs[indicatorRef]
+		if !ok {
+			c.unsupportedf(indicatorPath+".indicatorRef", "there's no SLI %q", indicatorRef)
+			break
+		}
+		o.Spec.ServiceLevelIndicator = c.indicator(fmt.Sprintf("SLI %q spec", indicatorRef), sli.Spec)
+	default:
+		c.unsupportedf(indicatorPath+".indicator", "either indicator or indicatorRef is required")
+	}
+
+	d.alertPolicies(c, &o.Spec, s.Spec.AlertPolicies)
+
+	return o, c.err(KindSLO, s.Metadata.Name)
+}
+
+func setAnnotation(annotations map[string]string, name, value string) map[string]string {
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[name] = value
+	return annotations
+}
+
+func (c *converter) timeWindow(spec *v1alpha1.ServiceLevelObjectiveSpec, w TimeWindow) {
+	if w.Calendar == nil {
+		if !w.IsRolling {
+			c.unsupportedf("spec.timeWindow[0].isRolling", "time windows without a calendar need to be rolling")
+			return
+		}
+		window, err := model.ParseDuration(w.Duration)
+		if err != nil {
+			c.unsupportedf("spec.timeWindow[0].duration", "%v", err)
+			return
+		}
+		spec.Window = window.String()
+		return
+	}
+
+	period, ok := calendarPeriods[w.Duration]
+	if !ok {
+		c.unsupportedf("spec.timeWindow[0].duration", "Pyrra only supports calendar windows of 1w, 1M and 1Q")
+		return
+	}
+
+	location, err := time.LoadLocation(w.Calendar.TimeZone)
+	if err != nil {
+		c.unsupportedf("spec.timeWindow[0].calendar.timeZone", "%v", err)
+		return
+	}
+	start, err := time.ParseInLocation(calendarTimeFormat, w.Calendar.StartTime, location)
+	if err != nil {
+		c.unsupportedf("spec.timeWindow[0].calendar.startTime", "%v", err)
+		return
+	}
+	aligned := start.Hour() == 0 && start.Minute() == 0 && start.Second() == 0
+	switch period {
+	case "week":
+		aligned = aligned && start.Weekday() == time.Monday
+	case "month":
+		aligned = aligned && start.Day() == 1
+	case "quarter":
+		aligned = aligned && start.Day() == 1 && start.Month()%3 == 1
+	}
+	if !aligned {
+		c.unsupportedf("spec.timeWindow[0].calendar.startTime", "Pyrra's calendar windows start at midnight of Mondays, the first day of months or quarters")
+		return
+	}
+
+	spec.Calendar = &v1alpha1.CalendarWindow{Period: period}
+	if w.Calendar.TimeZone != "UTC" {
+		spec.Calendar.TimeZone = w.Calendar.TimeZone
+	}
+}
+
+func (c *converter) target(objective Objective) string {
+	if objective.Op != "" || objective.Value != nil {
+		c.unsupportedf("spec.objectives[0].op", "thresholds are only used by threshold metrics, which Pyrra doesn't support")
+	}
+	if objective.TimeSliceTarget != nil || objective.TimeSliceWindow != "" {
+		c.unsupportedf("spec.objectives[0].timeSliceTarget", "Pyrra only supports the Occurrences budgeting method")
+	}
+	if objective.CompositeWeight != nil {
+		c.unsupportedf("spec.objectives[0].compositeWeight", "use a composite ServiceLevelObjective to combine objectives")
+	}
+
+	var percent float64
+	switch {
+	case objective.Target != nil:
+		percent = *objective.Target * 100
+	case objective.TargetPercent != nil:
+		percent = *objective.TargetPercent
+	default:
+		c.unsupportedf("spec.objectives[0].target", "either target or targetPercent is required")
+		return ""
+	}
+	// Round away floating point artifacts of multiplying targets like 0.999 by 100.
+	return strconv.FormatFloat(math.Round(percent*1e9)/1e9, 'f', -1, 64)
+}
+
+func (c *converter) indicator(path string, spec SLISpec) v1alpha1.ServiceLevelIndicator {
+	if spec.ThresholdMetric != nil {
+		c.unsupportedf(path+".thresholdMetric", "Pyrra only supports ratio metrics")
+		return v1alpha1.ServiceLevelIndicator{}
+	}
+	ratio := spec.RatioMetric
+	if ratio == nil {
+		c.unsupportedf(path+".ratioMetric", "ratioMetric is required")
+		return v1alpha1.ServiceLevelIndicator{}
+	}
+	path += ".ratioMetric"
+
+	if !ratio.Counter {
+		c.unsupportedf(path+".counter", "Pyrra only supports counters")
+	}
+	if ratio.Raw != nil || ratio.RawType != "" {
+		c.unsupportedf(path+".raw", "Pyrra calculates the ratio from the good or bad and total counters")
+		return v1alpha1.ServiceLevelIndicator{}
+	}
+	if ratio.Total == nil {
+		c.unsupportedf(path+".total", "total is required")
+		return v1alpha1.ServiceLevelIndicator{}
+	}
+	total := c.query(path+".total", ratio.Total)
+
+	switch {
+	case ratio.Good != nil && ratio.Bad != nil:
+		c.unsupportedf(path, "only one of good and bad can be set")
+	case ratio.Bad != nil:
+		bad := c.query(path+".bad", ratio.Bad)
+		if !isSelector(bad) || !isSelector(total) {
+			c.unsupportedf(path+".bad", "the bad and total queries need to be metric selectors, use good for other PromQL expressions")
+			break
+		}
+		return v1alpha1.ServiceLevelIndicator{Ratio: &v1alpha1.RatioIndicator{
+			Errors: v1alpha1.Query{Metric: bad},
+			Total:  v1alpha1.Query{Metric: total},
+		}}
+	case ratio.Good != nil:
+		good := c.query(path+".good", ratio.Good)
+		if isHistogram(good, total) {
+			return v1alpha1.ServiceLevelIndicator{Latency: &v1alpha1.LatencyIndicator{
+				Success: v1alpha1.Query{Metric: good},
+				Total:   v1alpha1.Query{Metric: total},
+			}}
+		}
+		return v1alpha1.ServiceLevelIndicator{Raw: &v1alpha1.RawIndicator{
+			Good:  good,
+			Total: total,
+		}}
+	default:
+		c.unsupportedf(path, "either good or bad is required")
+	}
+
+	return v1alpha1.ServiceLevelIndicator{}
+}
+
+func (c *converter) query(path string, holder *MetricSourceHolder) string {
+	source := holder.MetricSource
+	if source.MetricSourceRef != "" {
+		c.unsupportedf(path+".metricSource.metricSourceRef", "Pyrra queries the Prometheus it's configured with, use an inline metricSource of type Prometheus")
+		return ""
+	}
+	if !strings.EqualFold(source.Type, "Prometheus") {
+		c.unsupportedf(path+".metricSource.type", "Pyrra only supports Prometheus metric sources")
+		return ""
+	}
+	query, _ := source.Spec["query"].(string)
+	if query == "" {
+		c.unsupportedf(path+".metricSource.spec.query", "query is required")
+		return ""
+	}
+	for name := range source.Spec {
+		if name != "query" {
+			c.unsupportedf(path+".metricSource.spec."+name, "Pyrra only supports the query of Prometheus metric sources")
+		}
+	}
+	return query
+}
+
+func isSelector(query string) bool {
+	_, err := parser.ParseMetricSelector(query)
+	return err == nil
+}
+
+// isHistogram returns true if good selects a bucket of the histogram that total counts, like a latency indicator.
+func isHistogram(good, total string) bool {
+	goodMatchers, err := parser.ParseMetricSelector(good)
+	if err != nil {
+		return false
+	}
+	totalMatchers, err := parser.ParseMetricSelector(total)
+	if err != nil {
+		return false
+	}
+
+	var goodName, totalName string
+	var le bool
+	for _, m := range goodMatchers {
+		switch m.Name {
+		case labels.MetricName:
+			goodName = m.Value
+		case model.BucketLabel:
+			le = true
+		}
+	}
+	for _, m := range totalMatchers {
+		if m.Name == labels.MetricName {
+			totalName = m.Value
+		}
+	}
+
+	return le &&
+		strings.HasSuffix(goodName, "_bucket") &&
+		strings.HasSuffix(totalName, "_count") &&
+		strings.TrimSuffix(goodName, "_bucket") == strings.TrimSuffix(totalName, "_count")
+}
+
+// alertPolicies converts every burn rate condition of the alert policies to a window of the burn rate policy.
+// Without alert policies Pyrra's default burn rate alerts are generated.
+func (d Documents) alertPolicies(c *converter, spec *v1alpha1.ServiceLevelObjectiveSpec, items []AlertPolicyItem) {
+	type window struct {
+		factor float64
+		window v1alpha1.BurnRateWindow
+	}
+	var windows []window
+
+	for i, item := range items {
+		path := fmt.Sprintf("spec.alertPolicies[%d]", i)
+		policy := item.AlertPolicy
+		if item.AlertPolicyRef != "" {
+			var ok bool
+			policy, ok = d.AlertPolicies[item.AlertPolicyRef]
+			if !ok {
+				c.unsupportedf(path+".alertPolicyRef", "there's no AlertPolicy %q", item.AlertPolicyRef)
+				continue
+			}
+			path = fmt.Sprintf("AlertPolicy %q", item.AlertPolicyRef)
+		}
+
+		if len(policy.Spec.NotificationTargets) > 0 {
+			c.unsupportedf(path+".spec.notificationTargets", "notifications are routed by Alertmanager")
+		}
+		if policy.Spec.AlertWhenResolved != nil && *policy.Spec.AlertWhenResolved {
+			c.unsupportedf(path+".spec.alertWhenResolved", "resolved notifications are configured in Alertmanager")
+		}
+		if policy.Spec.AlertWhenBreaching != nil && !*policy.Spec.AlertWhenBreaching {
+			c.unsupportedf(path+".spec.alertWhenBreaching", "Pyrra's alerts always fire when breaching")
+		}
+		if noData := policy.Spec.AlertWhenNoData; noData != nil {
+			if spec.Alerting.Absent != nil && *spec.Alerting.Absent != *noData {
+				c.unsupportedf(path+".spec.alertWhenNoData", "all alert policies need the same alertWhenNoData, as Pyrra has one absent alert")
+			}
+			spec.Alerting.Absent = noData
+		}
+
+		for j, item := range policy.Spec.Conditions {
+			conditionPath := fmt.Sprintf("%s.spec.conditions[%d]", path, j)
+			condition := item.AlertCondition
+			if item.ConditionRef != "" {
+				var ok bool
+				condition, ok = d.AlertConditions[item.ConditionRef]
+				if !ok {
+					c.unsupportedf(conditionPath+".conditionRef", "there's no AlertCondition %q", item.ConditionRef)
+					continue
+				}
+				conditionPath = fmt.Sprintf("AlertCondition %q", item.ConditionRef)
+			}
+
+			burnrate := condition.Spec.Condition
+			if burnrate.Kind != "burnrate" {
+				c.unsupportedf(conditionPath+".spec.condition.kind", "Pyrra only supports burnrate conditions")
+				continue
+			}
+			if burnrate.Op != "gt" && burnrate.Op != "gte" {
+				c.unsupportedf(conditionPath+".spec.condition.op", "Pyrra's alerts fire when the burn rate is greater than the threshold")
+				continue
+			}
+			if burnrate.Threshold <= 0 {
+				c.unsupportedf(conditionPath+".spec.condition.threshold", "the threshold needs to be greater than 0")
+				continue
+			}
+			long, err := model.ParseDuration(burnrate.LookbackWindow)
+			if err != nil {
+				c.unsupportedf(conditionPath+".spec.condition.lookbackWindow", "%v", err)
+				continue
+			}
+			var alertAfter string
+			if burnrate.AlertAfter != "" {
+				after, err := model.ParseDuration(burnrate.AlertAfter)
+				if err != nil {
+					c.unsupportedf(conditionPath+".spec.condition.alertAfter", "%v", err)
+					continue
+				}
+				alertAfter = after.String()
+			}
+
+			windows = append(windows, window{
+				factor: burnrate.Threshold,
+				window: v1alpha1.BurnRateWindow{
+					Short:    (long / shortWindowRatio).String(),
+					Long:     long.String(),
+					Factor:   strconv.FormatFloat(burnrate.Threshold, 'f', -1, 64),
+					For:      alertAfter,
+					Severity: condition.Spec.Severity,
+				},
+			})
+		}
+	}
+
+	if len(windows) == 0 {
+		return
+	}
+
+	// Pyrra's windows are ordered from the most to the least urgent.
+	slices.SortStableFunc(windows, func(a, b window) int {
+		switch {
+		case a.factor > b.factor:
+			return -1
+		case a.factor < b.factor:
+			return 1
+		}
+		return 0
+	})
+
+	spec.BurnRatePolicy = &v1alpha1.BurnRatePolicy{}
+	for _, w := range windows {
+		spec.BurnRatePolicy.Windows = append(spec.BurnRatePolicy.Windows, w.window)
+	}
+}
+
+// FromServiceLevelObjective converts a ServiceLevelObjective to an OpenSLO SLO with an inline SLI and AlertPolicies.
+// Without a burn rate policy no AlertPolicies are added, as Pyrra generates its default burn rate alerts for those.
+func FromServiceLevelObjective(o v1alpha1.ServiceLevelObjective) (SLO, error) {
+	c := &converter{}
+
+	s := SLO{
+		APIVersion: APIVersion,
+		Kind:       KindSLO,
+		Metadata: Metadata{
+			Name:        o.Name,
+			DisplayName: o.Annotations[AnnotationDisplayName],
+		},
+		Spec: SLOSpec{
+			Description:     o.Spec.Description,
+			Service:         o.Annotations[AnnotationService],
+			BudgetingMethod: "Occurrences",
+		},
+	}
+	if s.Spec.Service == "" {
+		// The service is required by OpenSLO.
+		s.Spec.Service = o.Name
+	}
+
+	for name, value := range o.Labels {
+		if s.Metadata.Labels == nil {
+			s.Metadata.Labels = Labels{}
+		}
+		s.Metadata.Labels[name] = LabelValues{value}
+	}
+	for name, value := range o.Annotations {
+		if name == AnnotationDisplayName || name == AnnotationService {
+			continue
+		}
+		s.Metadata.Annotations = setAnnotation(s.Metadata.Annotations, name, value)
+	}
+	if o.Namespace != "" {
+		s.Metadata.Annotations = setAnnotation(s.Metadata.Annotations, AnnotationNamespace, o.Namespace)
+	}
+
+	target, err := strconv.ParseFloat(o.Spec.Target, 64)
+	if err != nil {
+		c.unsupportedf("spec.target", "%v", err)
+	}
+	// Round away floating point artifacts of dividing targets like 99.9 by 100.
+	target = math.Round(target/100*1e9) / 1e9
+	s.Spec.Objectives = []Objective{{Target: &target}}
+
+	if o.Spec.Calendar != nil {
+		var duration string
+		for d, period := range calendarPeriods {
+			if period == o.Spec.Calendar.Period {
+				duration = d
+			}
+		}
+		timeZone := o.Spec.Calendar.TimeZone
+		if timeZone == "" {
+			timeZone = "UTC"
+		}
+		s.Spec.TimeWindow = []TimeWindow{{
+			Duration: duration,
+			Calendar: &Calendar{StartTime: calendarStartTime, TimeZone: timeZone},
+		}}
+	} else {
+		s.Spec.TimeWindow = []TimeWindow{{Duration: o.Spec.Window, IsRolling: true}}
+	}
+
+	s.Spec.Indicator = &SLI{
+		Metadata: Metadata{Name: o.Name},
+		Spec:     SLISpec{RatioMetric: c.ratioMetric(o.Spec.ServiceLevelIndicator)},
+	}
+
+	s.Spec.AlertPolicies = c.alertPolicies(o)
+
+	if o.Spec.BudgetPolicy != nil {
+		c.unsupportedf("spec.budgetPolicy", "OpenSLO has no error budget policies")
+	}
+	if o.Spec.PerformanceOverAccuracy {
+		c.unsupportedf("spec.performanceOverAccuracy", "OpenSLO doesn't configure how rules are generated")
+	}
+	if o.Spec.RuleOutput != nil {
+		c.unsupportedf("spec.ruleOutput", "OpenSLO doesn't configure how rules are generated")
+	}
+	if o.Spec.PartialResponseStrategy != "" && o.Spec.PartialResponseStrategy != "abort" {
+		c.unsupportedf("spec.partial_response_strategy", "OpenSLO doesn't configure how rules are generated")
+	}
+
+	return s, c.err("ServiceLevelObjective", o.Name)
+}
+
+func prometheusSource(query string) *MetricSourceHolder {
+	return &MetricSourceHolder{MetricSource: MetricSource{
+		Type: "Prometheus",
+		Spec: map[string]any{"query": query},
+	}}
+}
+
+func (c *converter) ratioMetric(indicator v1alpha1.ServiceLevelIndicator) *RatioMetric {
+	const grouping = "OpenSLO has no equivalent of grouping an objective by labels"
+
+	switch {
+	case indicator.Ratio != nil:
+		if len(indicator.Ratio.Grouping) > 0 {
+			c.unsupportedf("spec.indicator.ratio.grouping", grouping)
+		}
+		return &RatioMetric{
+			Counter: true,
+			Bad:     prometheusSource(indicator.Ratio.Errors.Metric),
+			Total:   prometheusSource(indicator.Ratio.Total.Metric),
+		}
+	case indicator.Latency != nil:
+		if len(indicator.Latency.Grouping) > 0 {
+			c.unsupportedf("spec.indicator.latency.grouping", grouping)
+		}
+		return &RatioMetric{
+			Counter: true,
+			Good:    prometheusSource(indicator.Latency.Success.Metric),
+			Total:   prometheusSource(indicator.Latency.Total.Metric),
+		}
+	case indicator.Raw != nil:
+		if len(indicator.Raw.Grouping) > 0 {
+			c.unsupportedf("spec.indicator.raw.grouping", grouping)
+		}
+		return &RatioMetric{
+			Counter: true,
+			Good:    prometheusSource(indicator.Raw.Good),
+			Total:   prometheusSource(indicator.Raw.Total),
+		}
+	case indicator.LatencyNative != nil:
+		c.unsupportedf("spec.indicator.latencyNative", "OpenSLO's ratio metrics need counters, not native histograms")
+	case indicator.BoolGauge != nil:
+		c.unsupportedf("spec.indicator.bool_gauge", "OpenSLO's ratio metrics need counters")
+	case indicator.Composite != nil:
+		c.unsupportedf("spec.indicator.composite", "OpenSLO v1 has no composite SLOs")
+	default:
+		c.unsupportedf("spec.indicator", "an indicator is required")
+	}
+	return nil
+}
+
+func (c *converter) alertPolicies(o v1alpha1.ServiceLevelObjective) []AlertPolicyItem {
+	alerting := o.Spec.Alerting
+	if (alerting.Disabled != nil && *alerting.Disabled) || (alerting.Burnrates != nil && !*alerting.Burnrates) {
+		c.unsupportedf("spec.alerting.burnrates", "without alert policies Pyrra generates the default burn rate alerts")
+	}
+	if alerting.Name != "" {
+		c.unsupportedf("spec.alerting.name", "OpenSLO doesn't name the alerts")
+	}
+	if alerting.AbsentName != "" {
+		c.unsupportedf("spec.alerting.absentName", "OpenSLO doesn't name the alerts")
+	}
+	if alerting.Severities != nil {
+		c.unsupportedf("spec.alerting.severities", "use the severity of a burnRatePolicy's windows instead")
+	}
+	if len(alerting.Labels) > 0 {
+		c.unsupportedf("spec.alerting.labels", "OpenSLO has no alert labels")
+	}
+	if len(alerting.Annotations) > 0 {
+		c.unsupportedf("spec.alerting.annotations", "OpenSLO has no alert annotations")
+	}
+
+	if o.Spec.BurnRatePolicy == nil {
+		if alerting.Absent != nil && !*alerting.Absent {
+			c.unsupportedf("spec.alerting.absent", "alertWhenNoData can only be exported with a burnRatePolicy")
+		}
+		return nil
+	}
+
+	items := make([]AlertPolicyItem, 0, len(o.Spec.BurnRatePolicy.Windows))
+	for i, w := range o.Spec.BurnRatePolicy.Windows {
+		path := fmt.Sprintf("spec.burnRatePolicy.windows[%d]", i)
+
+		if len(w.Labels) > 0 {
+			c.unsupportedf(path+".labels", "OpenSLO has no alert labels")
+		}
+		if len(w.Annotations) > 0 {
+			c.unsupportedf(path+".annotations", "OpenSLO has no alert annotations")
+		}
+
+		short, err := model.ParseDuration(w.Short)
+		if err != nil {
+			c.unsupportedf(path+".short", "%v", err)
+			continue
+		}
+		long, err := model.ParseDuration(w.Long)
+		if err != nil {
+			c.unsupportedf(path+".long", "%v", err)
+			continue
+		}
+		if short != long/shortWindowRatio {
+			c.unsupportedf(path+".short", "OpenSLO only has the long window, the short window needs to be 1/%d of it", shortWindowRatio)
+			continue
+		}
+		factor, err := strconv.ParseFloat(w.Factor, 64)
+		if err != nil {
+			c.unsupportedf(path+".factor", "%v", err)
+			continue
+		}
+		severity := w.Severity
+		if severity == "" {
+			severity = "critical"
+		}
+
+		name := fmt.Sprintf("%s-%s", o.Name, long)
+		breaching := true
+		items = append(items, AlertPolicyItem{AlertPolicy: AlertPolicy{
+			Kind:     KindAlertPolicy,
+			Metadata: Metadata{Name: name},
+			Spec: AlertPolicySpec{
+				AlertWhenNoData:    alerting.Absent,
+				AlertWhenBreaching: &breaching,
+				Conditions: []AlertConditionItem{{AlertCondition: AlertCondition{
+					Kind:     KindAlertCondition,
+					Metadata: Metadata{Name: name},
+					Spec: AlertConditionSpec{
+						Severity: severity,
+						Condition: BurnRateCondition{
+							Kind:           "burnrate",
+							Op:             "gt",
+							Threshold:      factor,
+							LookbackWindow: long.String(),
+							AlertAfter:     w.For,
+						},
+					},
+				}}},
+			},
+		}})
+	}
+	return items
+}
@@ -0,0 +1,437 @@
+package openslo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pyrra-dev/pyrra/kubernetes/api/v1alpha1"
+)
+
+func TestIsOpenSLO(t *testing.T) {
+	require.True(t, IsOpenSLO([]byte("apiVersion: openslo/v1\nkind: SLO\n")))
+	require.True(t, IsOpenSLO([]byte("---\napiVersion: openslo/v1\nkind: SLI\n---\napiVersion: openslo/v1\nkind: SLO\n")))
+	require.False(t, IsOpenSLO([]byte("apiVersion: pyrra.dev/v1alpha1\nkind: ServiceLevelObjective\n")))
+	require.False(t, IsOpenSLO([]byte("- not an object")))
+}
+
+func TestDocuments_ServiceLevelObjectives(t *testing.T) {
+	docs, err := Parse([]byte(`
+apiVersion: openslo/v1
+kind: SLI
+metadata:
+  name: http-errors
+spec:
+  ratioMetric:
+    counter: true
+    bad:
+      metricSource:
+        type: Prometheus
+        spec:
+          query: http_requests_total{job="api",code=~"5.."}
+    total:
+      metricSource:
+        type: Prometheus
+        spec:
+          query: http_requests_total{job="api"}
+---
+apiVersion: openslo/v1
+kind: AlertCondition
+metadata:
+  name: slow-burn
+spec:
+  severity: warning
+  condition:
+    kind: burnrate
+    op: gt
+    threshold: 1
+    lookbackWindow: 4d
+    alertAfter: 3h
+---
+apiVersion: openslo/v1
+kind: AlertPolicy
+metadata:
+  name: slow-burn
+spec:
+  alertWhenNoData: false
+  conditions:
+    - conditionRef: slow-burn
+---
+apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: api-errors
+  displayName: API Errors
+  labels:
+    team: api
+  annotations:
+    openslo.pyrra.dev/namespace: monitoring
+spec:
+  service: api
+  indicatorRef: http-errors
+  timeWindow:
+    - duration: 4w
+      isRolling: true
+  budgetingMethod: Occurrences
+  objectives:
+    - target: 0.999
+  alertPolicies:
+    - alertPolicyRef: slow-burn
+    - kind: AlertPolicy
+      metadata:
+        name: fast-burn
+      spec:
+        alertWhenNoData: false
+        conditions:
+          - kind: AlertCondition
+            metadata:
+              name: fast-burn
+            spec:
+              severity: critical
+              condition:
+                kind: burnrate
+                op: gte
+                threshold: 14
+                lookbackWindow: 1h
+                alertAfter: 2m
+`))
+	require.NoError(t, err)
+
+	objectives, err := docs.ServiceLevelObjectives()
+	require.NoError(t, err)
+
+	absent := false
+	require.Equal(t, []v1alpha1.ServiceLevelObjective{{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "pyrra.dev/v1alpha1",
+			Kind:       "ServiceLevelObjective",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api-errors",
+			Namespace: "monitoring",
+			Labels:    map[string]string{"team": "api"},
+			Annotations: map[string]string{
+				AnnotationDisplayName: "API Errors",
+				AnnotationService:     "api",
+			},
+		},
+		Spec: v1alpha1.ServiceLevelObjectiveSpec{
+			Target: "99.9",
+			Window: "4w",
+			ServiceLevelIndicator: v1alpha1.ServiceLevelIndicator{
+				Ratio: &v1alpha1.RatioIndicator{
+					Errors: v1alpha1.Query{Metric: `http_requests_total{job="api",code=~"5.."}`},
+					Total:  v1alpha1.Query{Metric: `http_requests_total{job="api"}`},
+				},
+			},
+			Alerting: v1alpha1.Alerting{Absent: &absent},
+			BurnRatePolicy: &v1alpha1.BurnRatePolicy{
+				Windows: []v1alpha1.BurnRateWindow{
+					{Short: "5m", Long: "1h", Factor: "14", For: "2m", Severity: "critical"},
+					{Short: "8h", Long: "4d", Factor: "1", For: "3h", Severity: "warning"},
+				},
+			},
+		},
+	}}, objectives)
+
+	_, err = objectives[0].Internal()
+	require.NoError(t, err)
+}
+
+func TestDocuments_ServiceLevelObjectivesIndicators(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ratio     string
+		indicator v1alpha1.ServiceLevelIndicator
+	}{{
+		name: "latency",
+		ratio: `
+        good:
+          metricSource:
+            type: prometheus
+            spec:
+              query: http_request_duration_seconds_bucket{job="api",le="0.1"}
+        total:
+          metricSource:
+            type: prometheus
+            spec:
+              query: http_request_duration_seconds_count{job="api"}`,
+		indicator: v1alpha1.ServiceLevelIndicator{Latency: &v1alpha1.LatencyIndicator{
+			Success: v1alpha1.Query{Metric: `http_request_duration_seconds_bucket{job="api",le="0.1"}`},
+			Total:   v1alpha1.Query{Metric: `http_request_duration_seconds_count{job="api"}`},
+		}},
+	}, {
+		name: "raw",
+		ratio: `
+        good:
+          metricSource:
+            type: Prometheus
+            spec:
+              query: sum(http_requests_total{job="api",code!~"5.."})
+        total:
+          metricSource:
+            type: Prometheus
+            spec:
+              query: sum(http_requests_total{job="api"})`,
+		indicator: v1alpha1.ServiceLevelIndicator{Raw: &v1alpha1.RawIndicator{
+			Good:  `sum(http_requests_total{job="api",code!~"5.."})`,
+			Total: `sum(http_requests_total{job="api"})`,
+		}},
+	}}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			docs, err := Parse([]byte(`
+apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: api
+spec:
+  service: api
+  indicator:
+    metadata:
+      name: api
+    spec:
+      ratioMetric:
+        counter: true` + tc.ratio + `
+  timeWindow:
+    - duration: 1M
+      calendar:
+        startTime: "2022-01-01 00:00:00"
+        timeZone: Europe/Berlin
+  budgetingMethod: Occurrences
+  objectives:
+    - targetPercent: 99
+`))
+			require.NoError(t, err)
+
+			objectives, err := docs.ServiceLevelObjectives()
+			require.NoError(t, err)
+			require.Len(t, objectives, 1)
+			require.Equal(t, tc.indicator, objectives[0].Spec.ServiceLevelIndicator)
+			require.Equal(t, "99", objectives[0].Spec.Target)
+			require.Equal(t, &v1alpha1.CalendarWindow{Period: "month", TimeZone: "Europe/Berlin"}, objectives[0].Spec.Calendar)
+		})
+	}
+}
+
+func TestDocuments_ServiceLevelObjectivesUnsupported(t *testing.T) {
+	docs, err := Parse([]byte(`
+apiVersion: openslo/v1
+kind: SLO
+metadata:
+  name: api
+  labels:
+    team: [api, platform]
+spec:
+  service: api
+  indicator:
+    metadata:
+      name: api
+    spec:
+      thresholdMetric:
+        metricSource:
+          type: Prometheus
+          spec:
+            query: http_request_duration_seconds{quantile="0.99"}
+  timeWindow:
+    - duration: 1M
+      calendar:
+        startTime: "2022-01-15 00:00:00"
+        timeZone: UTC
+  budgetingMethod: Timeslices
+  objectives:
+    - op: lte
+      value: 0.1
+      target: 0.99
+      timeSliceTarget: 0.95
+      timeSliceWindow: 1m
+  alertPolicies:
+    - alertPolicyRef: missing
+`))
+	require.NoError(t, err)
+
+	_, err = docs.ServiceLevelObjectives()
+	require.EqualError(t, err, `SLO "api" has unsupported fields: `+
+		`metadata.labels.team: Pyrra only supports labels with a single value; `+
+		`spec.timeWindow[0].calendar.startTime: Pyrra's calendar windows start at midnight of Mondays, the first day of months or quarters; `+
+		`spec.budgetingMethod: Timeslices isn't supported, Pyrra only supports Occurrences; `+
+		`spec.objectives[0].op: thresholds are only used by threshold metrics, which Pyrra doesn't support; `+
+		`spec.objectives[0].timeSliceTarget: Pyrra only supports the Occurrences budgeting method; `+
+		`spec.indicator.spec.thresholdMetric: Pyrra only supports ratio metrics; `+
+		`spec.alertPolicies[0].alertPolicyRef: there's no AlertPolicy "missing"`,
+	)
+
+	var unsupported *UnsupportedError
+	require.ErrorAs(t, err, &unsupported)
+	require.Len(t, unsupported.Fields, 7)
+}
+
+func TestParse(t *testing.T) {
+	_, err := Parse([]byte("apiVersion: openslo/v2alpha\nkind: SLO\n"))
+	require.EqualError(t, err, `document 0: unsupported apiVersion "openslo/v2alpha", only openslo/v1 is supported`)
+
+	_, err = Parse([]byte("apiVersion: openslo/v1\nkind: Unknown\n"))
+	require.EqualError(t, err, `document 0: unsupported kind "Unknown"`)
+
+	_, err = Parse([]byte("apiVersion: openslo/v1\nkind: SLO\nmetadata:\n  name: api\nspec:\n  servcie: api\n"))
+	require.ErrorContains(t, err, `unknown field "servcie"`)
+
+	docs, err := Parse([]byte("apiVersion: openslo/v1\nkind: Service\nmetadata:\n  name: api\n---\n"))
+	require.NoError(t, err)
+	require.Empty(t, docs.SLOs)
+}
+
+func TestFromServiceLevelObjective(t *testing.T) {
+	absent := false
+	o := v1alpha1.ServiceLevelObjective{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api-errors",
+			Namespace: "monitoring",
+			Labels:    map[string]string{"team": "api"},
+			Annotations: map[string]string{
+				AnnotationService: "api",
+			},
+		},
+		Spec: v1alpha1.ServiceLevelObjectiveSpec{
+			Description: "Errors of the API",
+			Target:      "99.9",
+			Calendar:    &v1alpha1.CalendarWindow{Period: "quarter"},
+			ServiceLevelIndicator: v1alpha1.ServiceLevelIndicator{
+				Ratio: &v1alpha1.RatioIndicator{
+					Errors: v1alpha1.Query{Metric: `http_requests_total{job="api",code=~"5.."}`},
+					Total:  v1alpha1.Query{Metric: `http_requests_total{job="api"}`},
+				},
+			},
+			Alerting: v1alpha1.Alerting{Absent: &absent},
+			BurnRatePolicy: &v1alpha1.BurnRatePolicy{
+				Windows: []v1alpha1.BurnRateWindow{
+					{Short: "5m", Long: "1h", Factor: "14", For: "2m", Severity: "critical"},
+					{Short: "8h", Long: "4d", Factor: "1", For: "3h", Severity: "warning"},
+				},
+			},
+		},
+	}
+
+	s, err := FromServiceLevelObjective(o)
+	require.NoError(t, err)
+
+	bytes, err := yaml.Marshal(s)
+	require.NoError(t, err)
+	require.Equal(t, `apiVersion: openslo/v1
+kind: SLO
+metadata:
+  annotations:
+    openslo.pyrra.dev/namespace: monitoring
+  labels:
+    team: api
+  name: api-errors
+spec:
+  alertPolicies:
+  - kind: AlertPolicy
+    metadata:
+      name: api-errors-1h
+    spec:
+      alertWhenBreaching: true
+      alertWhenNoData: false
+      conditions:
+      - kind: AlertCondition
+        metadata:
+          name: api-errors-1h
+        spec:
+          condition:
+            alertAfter: 2m
+            kind: burnrate
+            lookbackWindow: 1h
+            op: gt
+            threshold: 14
+          severity: critical
+  - kind: AlertPolicy
+    metadata:
+      name: api-errors-4d
+    spec:
+      alertWhenBreaching: true
+      alertWhenNoData: false
+      conditions:
+      - kind: AlertCondition
+        metadata:
+          name: api-errors-4d
+        spec:
+          condition:
+            alertAfter: 3h
+            kind: burnrate
+            lookbackWindow: 4d
+            op: gt
+            threshold: 1
+          severity: warning
+  budgetingMethod: Occurrences
+  description: Errors of the API
+  indicator:
+    metadata:
+      name: api-errors
+    spec:
+      ratioMetric:
+        bad:
+          metricSource:
+            spec:
+              query: http_requests_total{job="api",code=~"5.."}
+            type: Prometheus
+        counter: true
+        total:
+          metricSource:
+            spec:
+              query: http_requests_total{job="api"}
+            type: Prometheus
+  objectives:
+  - target: 0.999
+  service: api
+  timeWindow:
+  - calendar:
+      startTime: "2024-01-01 00:00:00"
+      timeZone: UTC
+    duration: 1Q
+`, string(bytes))
+
+	// Exporting and importing again returns the same objective.
+	docs, err := Parse(bytes)
+	require.NoError(t, err)
+	objectives, err := docs.ServiceLevelObjectives()
+	require.NoError(t, err)
+	require.Len(t, objectives, 1)
+	require.Equal(t, o.ObjectMeta, objectives[0].ObjectMeta)
+	require.Equal(t, o.Spec, objectives[0].Spec)
+}
+
+func TestFromServiceLevelObjectiveUnsupported(t *testing.T) {
+	o := v1alpha1.ServiceLevelObjective{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-latency"},
+		Spec: v1alpha1.ServiceLevelObjectiveSpec{
+			Target: "99",
+			Window: "4w",
+			ServiceLevelIndicator: v1alpha1.ServiceLevelIndicator{
+				Ratio: &v1alpha1.RatioIndicator{
+					Errors:   v1alpha1.Query{Metric: `http_requests_total{job="api",code=~"5.."}`},
+					Total:    v1alpha1.Query{Metric: `http_requests_total{job="api"}`},
+					Grouping: []string{"handler"},
+				},
+			},
+			Alerting: v1alpha1.Alerting{Name: "APIErrors"},
+			BurnRatePolicy: &v1alpha1.BurnRatePolicy{
+				Windows: []v1alpha1.BurnRateWindow{{Short: "10m", Long: "1h", Factor: "14"}},
+			},
+			BudgetPolicy: &v1alpha1.BudgetPolicy{
+				Stages: []v1alpha1.BudgetPolicyStage{{Name: "freeze", Remaining: "25"}},
+			},
+		},
+	}
+
+	_, err := FromServiceLevelObjective(o)
+	require.EqualError(t, err, `ServiceLevelObjective "api-latency" has unsupported fields: `+
+		`spec.indicator.ratio.grouping: OpenSLO has no equivalent of grouping an objective by labels; `+
+		`spec.alerting.name: OpenSLO doesn't name the alerts; `+
+		`spec.burnRatePolicy.windows[0].short: OpenSLO only has the long window, the short window needs to be 1/12 of it; `+
+		`spec.budgetPolicy: OpenSLO has no error budget policies`,
+	)
+}
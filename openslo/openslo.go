@@ -0,0 +1,278 @@
+// Package openslo converts between OpenSLO v1 documents and Pyrra's ServiceLevelObjectives.
+// See https://github.com/OpenSLO/OpenSLO for the specification.
+package openslo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+const APIVersion = "openslo/v1"
+
+const (
+	KindSLO            = "SLO"
+	KindSLI            = "SLI"
+	KindAlertPolicy    = "AlertPolicy"
+	KindAlertCondition = "AlertCondition"
+	// KindService and KindDataSource documents are accepted, but not used by Pyrra.
+	KindService    = "Service"
+	KindDataSource = "DataSource"
+)
+
+type Metadata struct {
+	Name        string            `json:"name"`
+	DisplayName string            `json:"displayName,omitempty"`
+	Labels      Labels            `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Labels of OpenSLO documents can have a single value or a list of values.
+type Labels map[string]LabelValues
+
+type LabelValues []string
+
+func (v *LabelValues) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*v = LabelValues{value}
+		return nil
+	}
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("label values need to be a string or a list of strings")
+	}
+	*v = values
+	return nil
+}
+
+func (v LabelValues) MarshalJSON() ([]byte, error) {
+	if len(v) == 1 {
+		return json.Marshal(v[0])
+	}
+	return json.Marshal([]string(v))
+}
+
+type SLO struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Metadata   Metadata `json:"metadata"`
+	Spec       SLOSpec  `json:"spec"`
+}
+
+type SLOSpec struct {
+	Description     string            `json:"description,omitempty"`
+	Service         string            `json:"service"`
+	Indicator       *SLI              `json:"indicator,omitempty"`
+	IndicatorRef    string            `json:"indicatorRef,omitempty"`
+	TimeWindow      []TimeWindow      `json:"timeWindow"`
+	BudgetingMethod string            `json:"budgetingMethod"`
+	Objectives      []Objective       `json:"objectives"`
+	AlertPolicies   []AlertPolicyItem `json:"alertPolicies,omitempty"`
+}
+
+type TimeWindow struct {
+	Duration  string    `json:"duration"`
+	IsRolling bool      `json:"isRolling,omitempty"`
+	Calendar  *Calendar `json:"calendar,omitempty"`
+}
+
+type Calendar struct {
+	// StartTime is formatted as 2006-01-02 15:04:05.
+	StartTime string `json:"startTime"`
+	TimeZone  string `json:"timeZone"`
+}
+
+type Objective struct {
+	DisplayName     string   `json:"displayName,omitempty"`
+	Op              string   `json:"op,omitempty"`
+	Value           *float64 `json:"value,omitempty"`
+	Target          *float64 `json:"target,omitempty"`
+	TargetPercent   *float64 `json:"targetPercent,omitempty"`
+	TimeSliceTarget *float64 `json:"timeSliceTarget,omitempty"`
+	TimeSliceWindow string   `json:"timeSliceWindow,omitempty"`
+	Indicator       *SLI     `json:"indicator,omitempty"`
+	IndicatorRef    string   `json:"indicatorRef,omitempty"`
+	CompositeWeight *float64 `json:"compositeWeight,omitempty"`
+}
+
+// SLI is either its own document or inlined into an SLO without apiVersion and kind.
+type SLI struct {
+	APIVersion string   `json:"apiVersion,omitempty"`
+	Kind       string   `json:"kind,omitempty"`
+	Metadata   Metadata `json:"metadata"`
+	Spec       SLISpec  `json:"spec"`
+}
+
+type SLISpec struct {
+	Description     string              `json:"description,omitempty"`
+	ThresholdMetric *MetricSourceHolder `json:"thresholdMetric,omitempty"`
+	RatioMetric     *RatioMetric        `json:"ratioMetric,omitempty"`
+}
+
+type RatioMetric struct {
+	Counter bool                `json:"counter"`
+	Good    *MetricSourceHolder `json:"good,omitempty"`
+	Bad     *MetricSourceHolder `json:"bad,omitempty"`
+	Total   *MetricSourceHolder `json:"total,omitempty"`
+	RawType string              `json:"rawType,omitempty"`
+	Raw     *MetricSourceHolder `json:"raw,omitempty"`
+}
+
+type MetricSourceHolder struct {
+	MetricSource MetricSource `json:"metricSource"`
+}
+
+type MetricSource struct {
+	MetricSourceRef string `json:"metricSourceRef,omitempty"`
+	Type            string `json:"type,omitempty"`
+	// Spec depends on the type. Prometheus sources have a query.
+	Spec map[string]any `json:"spec"`
+}
+
+// AlertPolicyItem is an AlertPolicy inlined into an SLO or a reference to one.
+type AlertPolicyItem struct {
+	AlertPolicy    `json:",inline"`
+	AlertPolicyRef string `json:"alertPolicyRef,omitempty"`
+}
+
+type AlertPolicy struct {
+	APIVersion string          `json:"apiVersion,omitempty"`
+	Kind       string          `json:"kind,omitempty"`
+	Metadata   Metadata        `json:"metadata"`
+	Spec       AlertPolicySpec `json:"spec"`
+}
+
+type AlertPolicySpec struct {
+	Description         string               `json:"description,omitempty"`
+	AlertWhenNoData     *bool                `json:"alertWhenNoData,omitempty"`
+	AlertWhenResolved   *bool                `json:"alertWhenResolved,omitempty"`
+	AlertWhenBreaching  *bool                `json:"alertWhenBreaching,omitempty"`
+	Conditions          []AlertConditionItem `json:"conditions"`
+	NotificationTargets []map[string]any     `json:"notificationTargets,omitempty"`
+}
+
+// AlertConditionItem is an AlertCondition inlined into an AlertPolicy or a reference to one.
+type AlertConditionItem struct {
+	AlertCondition `json:",inline"`
+	ConditionRef   string `json:"conditionRef,omitempty"`
+}
+
+type AlertCondition struct {
+	APIVersion string             `json:"apiVersion,omitempty"`
+	Kind       string             `json:"kind,omitempty"`
+	Metadata   Metadata           `json:"metadata"`
+	Spec       AlertConditionSpec `json:"spec"`
+}
+
+type AlertConditionSpec struct {
+	Description string            `json:"description,omitempty"`
+	Severity    string            `json:"severity"`
+	Condition   BurnRateCondition `json:"condition"`
+}
+
+type BurnRateCondition struct {
+	Kind           string  `json:"kind"`
+	Op             string  `json:"op"`
+	Threshold      float64 `json:"threshold"`
+	LookbackWindow string  `json:"lookbackWindow"`
+	AlertAfter     string  `json:"alertAfter,omitempty"`
+}
+
+// Documents are the OpenSLO documents of a file.
+// SLIs, AlertPolicies and AlertConditions are indexed by name to resolve references.
+type Documents struct {
+	SLOs            []SLO
+	SLIs            map[string]SLI
+	AlertPolicies   map[string]AlertPolicy
+	AlertConditions map[string]AlertCondition
+}
+
+// IsOpenSLO returns true if the first document of the YAML is an OpenSLO document.
+func IsOpenSLO(data []byte) bool {
+	doc, err := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data))).Read()
+	if err != nil {
+		return false
+	}
+	var header struct {
+		APIVersion string `json:"apiVersion"`
+	}
+	if err := yaml.Unmarshal(doc, &header); err != nil {
+		return false
+	}
+	return strings.HasPrefix(header.APIVersion, "openslo/")
+}
+
+// Parse reads all OpenSLO documents of multi document YAML.
+// Unknown fields are errors, so that typos don't go unnoticed.
+func Parse(data []byte) (Documents, error) {
+	docs := Documents{
+		SLIs:            map[string]SLI{},
+		AlertPolicies:   map[string]AlertPolicy{},
+		AlertConditions: map[string]AlertCondition{},
+	}
+
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for i := 0; ; i++ {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Documents{}, fmt.Errorf("failed to read document %d: %w", i, err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		var header struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+		}
+		if err := yaml.Unmarshal(doc, &header); err != nil {
+			return Documents{}, fmt.Errorf("failed to unmarshal document %d: %w", i, err)
+		}
+		if header.APIVersion != APIVersion {
+			return Documents{}, fmt.Errorf("document %d: unsupported apiVersion %q, only %s is supported", i, header.APIVersion, APIVersion)
+		}
+
+		switch header.Kind {
+		case KindSLO:
+			var slo SLO
+			if err := yaml.UnmarshalStrict(doc, &slo); err != nil {
+				return Documents{}, fmt.Errorf("failed to unmarshal SLO: %w", err)
+			}
+			docs.SLOs = append(docs.SLOs, slo)
+		case KindSLI:
+			var sli SLI
+			if err := yaml.UnmarshalStrict(doc, &sli); err != nil {
+				return Documents{}, fmt.Errorf("failed to unmarshal SLI: %w", err)
+			}
+			docs.SLIs[sli.Metadata.Name] = sli
+		case KindAlertPolicy:
+			var policy AlertPolicy
+			if err := yaml.UnmarshalStrict(doc, &policy); err != nil {
+				return Documents{}, fmt.Errorf("failed to unmarshal AlertPolicy: %w", err)
+			}
+			docs.AlertPolicies[policy.Metadata.Name] = policy
+		case KindAlertCondition:
+			var condition AlertCondition
+			if err := yaml.UnmarshalStrict(doc, &condition); err != nil {
+				return Documents{}, fmt.Errorf("failed to unmarshal AlertCondition: %w", err)
+			}
+			docs.AlertConditions[condition.Metadata.Name] = condition
+		case KindService, KindDataSource:
+		default:
+			return Documents{}, fmt.Errorf("document %d: unsupported kind %q", i, header.Kind)
+		}
+	}
+
+	return docs, nil
+}
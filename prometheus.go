@@ -15,10 +15,15 @@ import (
 type prometheusServer struct {
 	logger  log.Logger
 	promAPI *promCache
+	queries *queryAuthorizer
 }
 
 func (ps *prometheusServer) Query(ctx context.Context, req *connect.Request[v1.QueryRequest]) (*connect.Response[v1.QueryResponse], error) {
-	value, warnings, err := ps.promAPI.Query(ctx, req.Msg.Query, time.Unix(req.Msg.Time, 0))
+	if err := ps.queries.authorizeQuery(ctx, req.Msg.Query, req.Msg.GetDatasource()); err != nil {
+		return nil, err
+	}
+
+	value, warnings, err := ps.promAPI.Query(contextSetDatasource(ctx, req.Msg.GetDatasource()), req.Msg.Query, time.Unix(req.Msg.Time, 0))
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +66,11 @@ func (ps *prometheusServer) Query(ctx context.Context, req *connect.Request[v1.Q
 }
 
 func (ps *prometheusServer) QueryRange(ctx context.Context, req *connect.Request[v1.QueryRangeRequest]) (*connect.Response[v1.QueryRangeResponse], error) {
-	value, warnings, err := ps.promAPI.QueryRange(ctx, req.Msg.GetQuery(), prometheusapiv1.Range{
+	if err := ps.queries.authorizeQuery(ctx, req.Msg.GetQuery(), req.Msg.GetDatasource()); err != nil {
+		return nil, err
+	}
+
+	value, warnings, err := ps.promAPI.QueryRange(contextSetDatasource(ctx, req.Msg.GetDatasource()), req.Msg.GetQuery(), prometheusapiv1.Range{
 		Start: time.Unix(req.Msg.GetStart(), 0),
 		End:   time.Unix(req.Msg.GetEnd(), 0),
 		Step:  time.Duration(req.Msg.GetStep()) * time.Second,
@@ -0,0 +1,145 @@
+package objectivesv1alpha1
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/common/model"
+)
+
+// Sort keys of ListRequest.OrderBy.
+const (
+	OrderByName      = "name"
+	OrderByNamespace = "namespace"
+	OrderByTarget    = "target"
+	OrderByWindow    = "window"
+)
+
+// ListPage searches, sorts and paginates the objectives like the ListRequest asks for.
+// Backends call it with all objectives matching the request's expr.
+func ListPage(req *ListRequest, objectives []*Objective) (*ListResponse, error) {
+	less, err := objectivesLess(req.GetOrderBy())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetPageSize() < 0 {
+		return nil, fmt.Errorf("page size must not be negative")
+	}
+	offset, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	matching := objectives
+	if search := strings.ToLower(req.GetSearch()); search != "" {
+		matching = make([]*Objective, 0, len(objectives))
+		for _, o := range objectives {
+			if strings.Contains(strings.ToLower(o.GetLabels()[model.MetricNameLabel]), search) ||
+				strings.Contains(strings.ToLower(o.GetDescription()), search) {
+				matching = append(matching, o)
+			}
+		}
+	} else {
+		matching = make([]*Objective, len(objectives))
+		copy(matching, objectives)
+	}
+
+	if less != nil {
+		sort.SliceStable(matching, func(i, j int) bool {
+			return less(matching[i], matching[j])
+		})
+	}
+
+	resp := &ListResponse{TotalSize: int32(len(matching))}
+	if offset > len(matching) {
+		offset = len(matching)
+	}
+	end := len(matching)
+	if size := int(req.GetPageSize()); size > 0 && offset+size < end {
+		end = offset + size
+		resp.NextPageToken = encodePageToken(end)
+	}
+	resp.Objectives = matching[offset:end]
+
+	return resp, nil
+}
+
+// objectivesLess returns the order of the sort key, optionally prefixed with - for descending order.
+// Objectives with the same key are ordered by their name and namespace.
+// Without a sort key the backend's order is kept.
+func objectivesLess(orderBy string) (func(a, b *Objective) bool, error) {
+	if orderBy == "" {
+		return nil, nil
+	}
+
+	descending := strings.HasPrefix(orderBy, "-")
+	key := strings.TrimPrefix(orderBy, "-")
+
+	var compare func(a, b *Objective) int
+	switch key {
+	case OrderByName:
+		compare = func(a, b *Objective) int { return 0 }
+	case OrderByNamespace:
+		compare = func(a, b *Objective) int {
+			return strings.Compare(a.GetLabels()["namespace"], b.GetLabels()["namespace"])
+		}
+	case OrderByTarget:
+		compare = func(a, b *Objective) int { return compareFloats(a.GetTarget(), b.GetTarget()) }
+	case OrderByWindow:
+		compare = func(a, b *Objective) int {
+			return compareFloats(float64(a.GetWindow().AsDuration()), float64(b.GetWindow().AsDuration()))
+		}
+	default:
+		return nil, fmt.Errorf("unknown order %q, must be one of name, namespace, target or window", orderBy)
+	}
+
+	return func(a, b *Objective) bool {
+		c := compare(a, b)
+		if c == 0 {
+			c = strings.Compare(a.GetLabels()[model.MetricNameLabel], b.GetLabels()[model.MetricNameLabel])
+		}
+		if c == 0 {
+			c = strings.Compare(a.GetLabels()["namespace"], b.GetLabels()["namespace"])
+		}
+		if descending {
+			return c > 0
+		}
+		return c < 0
+	}, nil
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Page tokens are the opaque offset of the page's first objective.
+// Objectives added or removed between two requests shift the following pages.
+
+func encodePageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid page token")
+	}
+	offset, err := strconv.Atoi(string(decoded))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid page token")
+	}
+	return offset, nil
+}
@@ -0,0 +1,145 @@
+package objectivesv1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func listObjective(name, namespace string, target float64, window time.Duration, description string) *Objective {
+	return &Objective{
+		Labels:      map[string]string{"__name__": name, "namespace": namespace},
+		Target:      target,
+		Window:      durationpb.New(window),
+		Description: description,
+	}
+}
+
+func names(objectives []*Objective) []string {
+	n := make([]string, 0, len(objectives))
+	for _, o := range objectives {
+		n = append(n, o.Labels["__name__"])
+	}
+	return n
+}
+
+func TestListPage(t *testing.T) {
+	week := 7 * 24 * time.Hour
+	objectives := []*Objective{
+		listObjective("b-errors", "monitoring", 0.99, 4*week, "Errors of the API"),
+		listObjective("a-latency", "default", 0.95, 2*week, "Latency of the checkout"),
+		listObjective("c-errors", "default", 0.999, week, ""),
+		listObjective("a-latency", "monitoring", 0.9, 4*week, "Latency of the API"),
+	}
+
+	testcases := []struct {
+		name    string
+		req     *ListRequest
+		names   []string
+		next    bool
+		total   int32
+		invalid bool
+	}{{
+		name:  "all",
+		req:   &ListRequest{},
+		names: []string{"b-errors", "a-latency", "c-errors", "a-latency"},
+		total: 4,
+	}, {
+		name:  "name",
+		req:   &ListRequest{OrderBy: OrderByName},
+		names: []string{"a-latency", "a-latency", "b-errors", "c-errors"},
+		total: 4,
+	}, {
+		name:  "targetDescending",
+		req:   &ListRequest{OrderBy: "-" + OrderByTarget},
+		names: []string{"c-errors", "b-errors", "a-latency", "a-latency"},
+		total: 4,
+	}, {
+		name:  "window",
+		req:   &ListRequest{OrderBy: OrderByWindow},
+		names: []string{"c-errors", "a-latency", "a-latency", "b-errors"},
+		total: 4,
+	}, {
+		name:  "namespace",
+		req:   &ListRequest{OrderBy: OrderByNamespace},
+		names: []string{"a-latency", "c-errors", "a-latency", "b-errors"},
+		total: 4,
+	}, {
+		name:  "search",
+		req:   &ListRequest{Search: "api", OrderBy: OrderByName},
+		names: []string{"a-latency", "b-errors"},
+		total: 2,
+	}, {
+		name:  "searchName",
+		req:   &ListRequest{Search: "ERRORS"},
+		names: []string{"b-errors", "c-errors"},
+		total: 2,
+	}, {
+		name:  "firstPage",
+		req:   &ListRequest{PageSize: 3, OrderBy: OrderByName},
+		names: []string{"a-latency", "a-latency", "b-errors"},
+		next:  true,
+		total: 4,
+	}, {
+		name:  "lastPage",
+		req:   &ListRequest{PageSize: 3, PageToken: encodePageToken(3), OrderBy: OrderByName},
+		names: []string{"c-errors"},
+		total: 4,
+	}, {
+		name:  "pastLastPage",
+		req:   &ListRequest{PageSize: 3, PageToken: encodePageToken(6)},
+		names: []string{},
+		total: 4,
+	}, {
+		name:    "unknownOrder",
+		req:     &ListRequest{OrderBy: "availability"},
+		invalid: true,
+	}, {
+		name:    "invalidToken",
+		req:     &ListRequest{PageToken: "foo"},
+		invalid: true,
+	}, {
+		name:    "negativePageSize",
+		req:     &ListRequest{PageSize: -1},
+		invalid: true,
+	}}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := ListPage(tc.req, objectives)
+			if tc.invalid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.names, names(resp.Objectives))
+			require.Equal(t, tc.next, resp.NextPageToken != "")
+			require.Equal(t, tc.total, resp.TotalSize)
+		})
+	}
+
+	// The objectives passed in keep their order.
+	require.Equal(t, []string{"b-errors", "a-latency", "c-errors", "a-latency"}, names(objectives))
+}
+
+func TestListPageTokens(t *testing.T) {
+	objectives := make([]*Objective, 0, 10)
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"} {
+		objectives = append(objectives, listObjective(name, "default", 0.99, time.Hour, ""))
+	}
+
+	var all []string
+	req := &ListRequest{PageSize: 4}
+	for {
+		resp, err := ListPage(req, objectives)
+		require.NoError(t, err)
+		all = append(all, names(resp.Objectives)...)
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	require.Equal(t, names(objectives), all)
+}
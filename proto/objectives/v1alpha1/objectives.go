@@ -1,22 +1,26 @@
 package objectivesv1alpha1
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
 	"github.com/prometheus/prometheus/util/strutil"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/pyrra-dev/pyrra/slo"
 )
 
-func ToInternal(o *Objective) slo.Objective {
+func ToInternal(o *Objective) (slo.Objective, error) {
 	var ratio *slo.RatioIndicator
 	var latency *slo.LatencyIndicator
 	var latencyNative *slo.LatencyNativeIndicator
 	var boolGauge *slo.BoolGaugeIndicator
+	var composite *slo.CompositeIndicator
+	var raw *slo.RawIndicator
 
 	if o.Indicator != nil {
 		if r := o.Indicator.GetRatio(); r != nil {
@@ -65,7 +69,7 @@ func ToInternal(o *Objective) slo.Objective {
 		if l := o.Indicator.GetLatencyNative(); l != nil {
 			latency, err := model.ParseDuration(l.Latency)
 			if err != nil {
-				return slo.Objective{}
+				return slo.Objective{}, fmt.Errorf("invalid latency: %w", err)
 			}
 			latencyNative = &slo.LatencyNativeIndicator{
 				Total:    slo.Metric{Name: l.Total.GetName()},
@@ -94,6 +98,39 @@ func ToInternal(o *Objective) slo.Objective {
 				})
 			}
 		}
+
+		if c := o.Indicator.GetComposite(); c != nil {
+			composite = &slo.CompositeIndicator{
+				Model: slo.CompositeModel(c.GetModel()),
+			}
+			for _, component := range c.GetComponents() {
+				selector, err := parser.ParseMetricSelector(component.GetSelector())
+				if err != nil {
+					return slo.Objective{}, fmt.Errorf("invalid component selector: %w", err)
+				}
+				objectives := make([]slo.Objective, 0, len(component.GetObjectives()))
+				for _, obj := range component.GetObjectives() {
+					objective, err := ToInternal(obj)
+					if err != nil {
+						return slo.Objective{}, err
+					}
+					objectives = append(objectives, objective)
+				}
+				composite.Components = append(composite.Components, slo.CompositeComponent{
+					Selector:   selector,
+					Weight:     component.GetWeight(),
+					Objectives: objectives,
+				})
+			}
+		}
+
+		if r := o.Indicator.GetRaw(); r != nil {
+			raw = &slo.RawIndicator{
+				Good:     r.GetGood(),
+				Total:    r.GetTotal(),
+				Grouping: r.GetGrouping(),
+			}
+		}
 	}
 
 	labelsList := make([]labels.Label, 0, len(o.Labels))
@@ -101,20 +138,60 @@ func ToInternal(o *Objective) slo.Objective {
 		labelsList = append(labelsList, labels.Label{Name: name, Value: value})
 	}
 
+	var burnRatePolicy []slo.Window
+	for _, w := range o.GetBurnRatePolicy() {
+		burnRatePolicy = append(burnRatePolicy, slo.Window{
+			Severity:    slo.Severity(w.GetSeverity()),
+			For:         w.GetFor().AsDuration(),
+			Long:        w.GetLong().AsDuration(),
+			Short:       w.GetShort().AsDuration(),
+			Factor:      w.GetFactor(),
+			Labels:      w.GetLabels(),
+			Annotations: w.GetAnnotations(),
+		})
+	}
+
+	var budgetPolicy []slo.BudgetPolicyStage
+	for _, s := range o.GetBudgetPolicy() {
+		budgetPolicy = append(budgetPolicy, slo.BudgetPolicyStage{
+			Name:      s.GetName(),
+			Remaining: s.GetRemaining(),
+			Severity:  slo.Severity(s.GetSeverity()),
+			Labels:    s.GetLabels(),
+		})
+	}
+
+	var calendar *slo.Calendar
+	if c := o.GetCalendar(); c != nil {
+		var err error
+		calendar, err = slo.NewCalendar(c.GetPeriod(), c.GetTimeZone())
+		if err != nil {
+			return slo.Objective{}, fmt.Errorf("invalid calendar: %w", err)
+		}
+	}
+
 	return slo.Objective{
-		Labels:      labels.New(labelsList...),
-		Description: o.Description,
-		Target:      o.Target,
-		Window:      model.Duration(o.Window.AsDuration()),
-		Config:      o.Config,
-		Alerting:    slo.Alerting{}, // TODO
+		Labels:         labels.New(labelsList...),
+		Description:    o.Description,
+		Target:         o.Target,
+		Window:         model.Duration(o.Window.AsDuration()),
+		Config:         o.Config,
+		Calendar:       calendar,
+		BurnRatePolicy: burnRatePolicy,
+		BudgetPolicy:   budgetPolicy,
+		Alerting:       slo.Alerting{}, // TODO
+
+		PerformanceOverAccuracy: o.GetPerformanceOverAccuracy(),
+		Datasource:              o.GetDatasource(),
 		Indicator: slo.Indicator{
 			Ratio:         ratio,
 			Latency:       latency,
 			LatencyNative: latencyNative,
 			BoolGauge:     boolGauge,
+			Composite:     composite,
+			Raw:           raw,
 		},
-	}
+	}, nil
 }
 
 func FromInternal(o slo.Objective) *Objective {
@@ -212,6 +289,32 @@ func FromInternal(o slo.Objective) *Objective {
 		}
 	}
 
+	var composite *Composite
+	if c := o.Indicator.Composite; c != nil {
+		composite = &Composite{Model: string(c.Model)}
+		for _, component := range c.Components {
+			selector := &parser.VectorSelector{LabelMatchers: component.Selector}
+			objectives := make([]*Objective, 0, len(component.Objectives))
+			for _, obj := range component.Objectives {
+				objectives = append(objectives, FromInternal(obj))
+			}
+			composite.Components = append(composite.Components, &CompositeComponent{
+				Selector:   selector.String(),
+				Weight:     component.Weight,
+				Objectives: objectives,
+			})
+		}
+	}
+
+	var raw *Raw
+	if r := o.Indicator.Raw; r != nil {
+		raw = &Raw{
+			Good:     r.Good,
+			Total:    r.Total,
+			Grouping: r.Grouping,
+		}
+	}
+
 	lset := make(map[string]string, o.Labels.Len())
 	for n, v := range o.Labels.Map() {
 		name := strings.TrimPrefix(n, slo.PropagationLabelsPrefix)
@@ -225,6 +328,34 @@ func FromInternal(o slo.Objective) *Objective {
 		Window:      durationpb.New(time.Duration(o.Window)),
 		Description: o.Description,
 		Config:      o.Config,
+
+		PerformanceOverAccuracy: o.PerformanceOverAccuracy,
+		Datasource:              o.Datasource,
+	}
+	if o.Calendar != nil {
+		objective.Calendar = &Calendar{
+			Period:   string(o.Calendar.Period),
+			TimeZone: o.Calendar.Location.String(),
+		}
+	}
+	for _, w := range o.BurnRatePolicy {
+		objective.BurnRatePolicy = append(objective.BurnRatePolicy, &BurnRateWindow{
+			Severity:    string(w.Severity),
+			For:         durationpb.New(w.For),
+			Factor:      w.Factor,
+			Short:       durationpb.New(w.Short),
+			Long:        durationpb.New(w.Long),
+			Labels:      w.Labels,
+			Annotations: w.Annotations,
+		})
+	}
+	for _, s := range o.BudgetPolicy {
+		objective.BudgetPolicy = append(objective.BudgetPolicy, &BudgetPolicyStage{
+			Name:      s.Name,
+			Remaining: s.Remaining,
+			Severity:  string(s.Severity),
+			Labels:    s.Labels,
+		})
 	}
 	if ratio != nil {
 		objective.Indicator = &Indicator{
@@ -246,5 +377,15 @@ func FromInternal(o slo.Objective) *Objective {
 			Options: &Indicator_BoolGauge{boolGauge},
 		}
 	}
+	if composite != nil {
+		objective.Indicator = &Indicator{
+			Options: &Indicator_Composite{composite},
+		}
+	}
+	if raw != nil {
+		objective.Indicator = &Indicator{
+			Options: &Indicator_Raw{raw},
+		}
+	}
 	return objective
 }
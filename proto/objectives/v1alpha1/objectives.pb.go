@@ -125,9 +125,17 @@ func (Alert_State) EnumDescriptor() ([]byte, []int) {
 }
 
 type ListRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Expr          string                 `protobuf:"bytes,1,opt,name=expr,proto3" json:"expr,omitempty"`
-	Grouping      string                 `protobuf:"bytes,2,opt,name=grouping,proto3" json:"grouping,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Expr     string                 `protobuf:"bytes,1,opt,name=expr,proto3" json:"expr,omitempty"`
+	Grouping string                 `protobuf:"bytes,2,opt,name=grouping,proto3" json:"grouping,omitempty"`
+	// page_size is the maximum number of objectives to return. All objectives are returned if 0.
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of the previous page.
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// order_by is one of name, namespace, target or window. A - prefix sorts descending. The backend's order is kept if empty.
+	OrderBy string `protobuf:"bytes,5,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// search only returns objectives with the search in their name or description, ignoring case.
+	Search        string `protobuf:"bytes,6,opt,name=search,proto3" json:"search,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -176,9 +184,43 @@ func (x *ListRequest) GetGrouping() string {
 	return ""
 }
 
+func (x *ListRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
 type ListResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Objectives    []*Objective           `protobuf:"bytes,1,rep,name=objectives,proto3" json:"objectives,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Objectives []*Objective           `protobuf:"bytes,1,rep,name=objectives,proto3" json:"objectives,omitempty"`
+	// next_page_token is the page_token of the next page. It's empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// total_size is the number of objectives on all pages.
+	TotalSize int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	// warnings explain objectives missing from the response, like the ones of backends that failed to list them.
+	Warnings      []string `protobuf:"bytes,4,rep,name=warnings,proto3" json:"warnings,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -220,17 +262,43 @@ func (x *ListResponse) GetObjectives() []*Objective {
 	return nil
 }
 
+func (x *ListResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *ListResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
 type Objective struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Labels        map[string]string      `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Target        float64                `protobuf:"fixed64,2,opt,name=target,proto3" json:"target,omitempty"`
-	Window        *durationpb.Duration   `protobuf:"bytes,3,opt,name=window,proto3" json:"window,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Indicator     *Indicator             `protobuf:"bytes,5,opt,name=indicator,proto3" json:"indicator,omitempty"`
-	Config        string                 `protobuf:"bytes,6,opt,name=config,proto3" json:"config,omitempty"`
-	Queries       *Queries               `protobuf:"bytes,7,opt,name=queries,proto3" json:"queries,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Labels                  map[string]string      `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Target                  float64                `protobuf:"fixed64,2,opt,name=target,proto3" json:"target,omitempty"`
+	Window                  *durationpb.Duration   `protobuf:"bytes,3,opt,name=window,proto3" json:"window,omitempty"`
+	Description             string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Indicator               *Indicator             `protobuf:"bytes,5,opt,name=indicator,proto3" json:"indicator,omitempty"`
+	Config                  string                 `protobuf:"bytes,6,opt,name=config,proto3" json:"config,omitempty"`
+	Queries                 *Queries               `protobuf:"bytes,7,opt,name=queries,proto3" json:"queries,omitempty"`
+	BurnRatePolicy          []*BurnRateWindow      `protobuf:"bytes,8,rep,name=burn_rate_policy,json=burnRatePolicy,proto3" json:"burn_rate_policy,omitempty"`
+	Calendar                *Calendar              `protobuf:"bytes,9,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	BudgetPolicy            []*BudgetPolicyStage   `protobuf:"bytes,10,rep,name=budget_policy,json=budgetPolicy,proto3" json:"budget_policy,omitempty"`
+	PerformanceOverAccuracy bool                   `protobuf:"varint,11,opt,name=performance_over_accuracy,json=performanceOverAccuracy,proto3" json:"performance_over_accuracy,omitempty"`
+	Datasource              string                 `protobuf:"bytes,12,opt,name=datasource,proto3" json:"datasource,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
 }
 
 func (x *Objective) Reset() {
@@ -312,6 +380,41 @@ func (x *Objective) GetQueries() *Queries {
 	return nil
 }
 
+func (x *Objective) GetBurnRatePolicy() []*BurnRateWindow {
+	if x != nil {
+		return x.BurnRatePolicy
+	}
+	return nil
+}
+
+func (x *Objective) GetCalendar() *Calendar {
+	if x != nil {
+		return x.Calendar
+	}
+	return nil
+}
+
+func (x *Objective) GetBudgetPolicy() []*BudgetPolicyStage {
+	if x != nil {
+		return x.BudgetPolicy
+	}
+	return nil
+}
+
+func (x *Objective) GetPerformanceOverAccuracy() bool {
+	if x != nil {
+		return x.PerformanceOverAccuracy
+	}
+	return false
+}
+
+func (x *Objective) GetDatasource() string {
+	if x != nil {
+		return x.Datasource
+	}
+	return ""
+}
+
 type Indicator struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Options:
@@ -320,6 +423,8 @@ type Indicator struct {
 	//	*Indicator_Latency
 	//	*Indicator_BoolGauge
 	//	*Indicator_LatencyNative
+	//	*Indicator_Composite
+	//	*Indicator_Raw
 	Options       isIndicator_Options `protobuf_oneof:"options"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -398,6 +503,24 @@ func (x *Indicator) GetLatencyNative() *LatencyNative {
 	return nil
 }
 
+func (x *Indicator) GetComposite() *Composite {
+	if x != nil {
+		if x, ok := x.Options.(*Indicator_Composite); ok {
+			return x.Composite
+		}
+	}
+	return nil
+}
+
+func (x *Indicator) GetRaw() *Raw {
+	if x != nil {
+		if x, ok := x.Options.(*Indicator_Raw); ok {
+			return x.Raw
+		}
+	}
+	return nil
+}
+
 type isIndicator_Options interface {
 	isIndicator_Options()
 }
@@ -418,6 +541,14 @@ type Indicator_LatencyNative struct {
 	LatencyNative *LatencyNative `protobuf:"bytes,4,opt,name=latency_native,json=latencyNative,proto3,oneof"`
 }
 
+type Indicator_Composite struct {
+	Composite *Composite `protobuf:"bytes,5,opt,name=composite,proto3,oneof"`
+}
+
+type Indicator_Raw struct {
+	Raw *Raw `protobuf:"bytes,6,opt,name=raw,proto3,oneof"`
+}
+
 func (*Indicator_Ratio) isIndicator_Options() {}
 
 func (*Indicator_Latency) isIndicator_Options() {}
@@ -426,6 +557,10 @@ func (*Indicator_BoolGauge) isIndicator_Options() {}
 
 func (*Indicator_LatencyNative) isIndicator_Options() {}
 
+func (*Indicator_Composite) isIndicator_Options() {}
+
+func (*Indicator_Raw) isIndicator_Options() {}
+
 type Ratio struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Total         *Query                 `protobuf:"bytes,1,opt,name=total,proto3" json:"total,omitempty"`
@@ -1085,6 +1220,7 @@ type Budget struct {
 	Total         float64                `protobuf:"fixed64,1,opt,name=total,proto3" json:"total,omitempty"`
 	Remaining     float64                `protobuf:"fixed64,2,opt,name=remaining,proto3" json:"remaining,omitempty"`
 	Max           float64                `protobuf:"fixed64,3,opt,name=max,proto3" json:"max,omitempty"`
+	PolicyStage   string                 `protobuf:"bytes,4,opt,name=policy_stage,json=policyStage,proto3" json:"policy_stage,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1140,6 +1276,13 @@ func (x *Budget) GetMax() float64 {
 	return 0
 }
 
+func (x *Budget) GetPolicyStage() string {
+	if x != nil {
+		return x.PolicyStage
+	}
+	return ""
+}
+
 type GetAlertsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Expr          string                 `protobuf:"bytes,1,opt,name=expr,proto3" json:"expr,omitempty"`
@@ -1956,172 +2099,1678 @@ func (x *GraphDurationResponse) GetTimeseries() []*Timeseries {
 	return nil
 }
 
-var File_objectives_v1alpha1_objectives_proto protoreflect.FileDescriptor
+type BurnRateWindow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Severity      string                 `protobuf:"bytes,1,opt,name=severity,proto3" json:"severity,omitempty"`
+	For           *durationpb.Duration   `protobuf:"bytes,2,opt,name=for,proto3" json:"for,omitempty"`
+	Factor        float64                `protobuf:"fixed64,3,opt,name=factor,proto3" json:"factor,omitempty"`
+	Short         *durationpb.Duration   `protobuf:"bytes,4,opt,name=short,proto3" json:"short,omitempty"`
+	Long          *durationpb.Duration   `protobuf:"bytes,5,opt,name=long,proto3" json:"long,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Annotations   map[string]string      `protobuf:"bytes,7,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_objectives_v1alpha1_objectives_proto_rawDesc = "" +
-	"\n" +
-	"$objectives/v1alpha1/objectives.proto\x12\x13objectives.v1alpha1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"=\n" +
-	"\vListRequest\x12\x12\n" +
-	"\x04expr\x18\x01 \x01(\tR\x04expr\x12\x1a\n" +
-	"\bgrouping\x18\x02 \x01(\tR\bgrouping\"N\n" +
-	"\fListResponse\x12>\n" +
-	"\n" +
-	"objectives\x18\x01 \x03(\v2\x1e.objectives.v1alpha1.ObjectiveR\n" +
-	"objectives\"\x85\x03\n" +
-	"\tObjective\x12B\n" +
-	"\x06labels\x18\x01 \x03(\v2*.objectives.v1alpha1.Objective.LabelsEntryR\x06labels\x12\x16\n" +
-	"\x06target\x18\x02 \x01(\x01R\x06target\x121\n" +
-	"\x06window\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x06window\x12 \n" +
-	"\vdescription\x18\x04 \x01(\tR\vdescription\x12<\n" +
-	"\tindicator\x18\x05 \x01(\v2\x1e.objectives.v1alpha1.IndicatorR\tindicator\x12\x16\n" +
-	"\x06config\x18\x06 \x01(\tR\x06config\x126\n" +
-	"\aqueries\x18\a \x01(\v2\x1c.objectives.v1alpha1.QueriesR\aqueries\x1a9\n" +
-	"\vLabelsEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x91\x02\n" +
-	"\tIndicator\x122\n" +
-	"\x05ratio\x18\x01 \x01(\v2\x1a.objectives.v1alpha1.RatioH\x00R\x05ratio\x128\n" +
-	"\alatency\x18\x02 \x01(\v2\x1c.objectives.v1alpha1.LatencyH\x00R\alatency\x12>\n" +
-	"\tboolGauge\x18\x03 \x01(\v2\x1e.objectives.v1alpha1.BoolGaugeH\x00R\tboolGauge\x12K\n" +
-	"\x0elatency_native\x18\x04 \x01(\v2\".objectives.v1alpha1.LatencyNativeH\x00R\rlatencyNativeB\t\n" +
-	"\aoptions\"\x89\x01\n" +
-	"\x05Ratio\x120\n" +
-	"\x05total\x18\x01 \x01(\v2\x1a.objectives.v1alpha1.QueryR\x05total\x122\n" +
-	"\x06errors\x18\x02 \x01(\v2\x1a.objectives.v1alpha1.QueryR\x06errors\x12\x1a\n" +
-	"\bgrouping\x18\x03 \x03(\tR\bgrouping\"\x8d\x01\n" +
-	"\aLatency\x120\n" +
-	"\x05total\x18\x01 \x01(\v2\x1a.objectives.v1alpha1.QueryR\x05total\x124\n" +
-	"\asuccess\x18\x02 \x01(\v2\x1a.objectives.v1alpha1.QueryR\asuccess\x12\x1a\n" +
-	"\bgrouping\x18\x03 \x03(\tR\bgrouping\"w\n" +
-	"\rLatencyNative\x120\n" +
-	"\x05total\x18\x01 \x01(\v2\x1a.objectives.v1alpha1.QueryR\x05total\x12\x18\n" +
-	"\alatency\x18\x02 \x01(\tR\alatency\x12\x1a\n" +
-	"\bgrouping\x18\x03 \x03(\tR\bgrouping\"a\n" +
-	"\tBoolGauge\x128\n" +
-	"\tboolGauge\x18\x01 \x01(\v2\x1a.objectives.v1alpha1.QueryR\tboolGauge\x12\x1a\n" +
-	"\bgrouping\x18\x03 \x03(\tR\bgrouping\"r\n" +
-	"\x05Query\x12\x16\n" +
-	"\x06metric\x18\x01 \x01(\tR\x06metric\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12=\n" +
-	"\bmatchers\x18\x03 \x03(\v2!.objectives.v1alpha1.LabelMatcherR\bmatchers\"\xbf\x01\n" +
-	"\aQueries\x12\x1e\n" +
-	"\n" +
-	"countTotal\x18\x01 \x01(\tR\n" +
-	"countTotal\x12 \n" +
-	"\vcountErrors\x18\x02 \x01(\tR\vcountErrors\x12*\n" +
-	"\x10graphErrorBudget\x18\x03 \x01(\tR\x10graphErrorBudget\x12$\n" +
-	"\rgraphRequests\x18\x04 \x01(\tR\rgraphRequests\x12 \n" +
-	"\vgraphErrors\x18\x05 \x01(\tR\vgraphErrors\"\x9e\x01\n" +
-	"\fLabelMatcher\x12:\n" +
-	"\x04type\x18\x01 \x01(\x0e2&.objectives.v1alpha1.LabelMatcher.TypeR\x04type\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
-	"\x05value\x18\x03 \x01(\tR\x05value\"(\n" +
-	"\x04Type\x12\x06\n" +
-	"\x02EQ\x10\x00\x12\a\n" +
-	"\x03NEQ\x10\x01\x12\x06\n" +
-	"\x02RE\x10\x02\x12\a\n" +
-	"\x03NRE\x10\x03\"r\n" +
-	"\x10GetStatusRequest\x12\x12\n" +
-	"\x04expr\x18\x01 \x01(\tR\x04expr\x12\x1a\n" +
-	"\bgrouping\x18\x02 \x01(\tR\bgrouping\x12.\n" +
-	"\x04time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"Q\n" +
-	"\x11GetStatusResponse\x12<\n" +
-	"\x06status\x18\x01 \x03(\v2$.objectives.v1alpha1.ObjectiveStatusR\x06status\"\x92\x02\n" +
-	"\x0fObjectiveStatus\x12H\n" +
-	"\x06labels\x18\x01 \x03(\v20.objectives.v1alpha1.ObjectiveStatus.LabelsEntryR\x06labels\x12E\n" +
-	"\favailability\x18\x02 \x01(\v2!.objectives.v1alpha1.AvailabilityR\favailability\x123\n" +
-	"\x06budget\x18\x03 \x01(\v2\x1b.objectives.v1alpha1.BudgetR\x06budget\x1a9\n" +
-	"\vLabelsEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\\\n" +
-	"\fAvailability\x12\x1e\n" +
-	"\n" +
-	"percentage\x18\x01 \x01(\x01R\n" +
-	"percentage\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x01R\x05total\x12\x16\n" +
-	"\x06errors\x18\x03 \x01(\x01R\x06errors\"N\n" +
-	"\x06Budget\x12\x14\n" +
-	"\x05total\x18\x01 \x01(\x01R\x05total\x12\x1c\n" +
-	"\tremaining\x18\x02 \x01(\x01R\tremaining\x12\x10\n" +
-	"\x03max\x18\x03 \x01(\x01R\x03max\"x\n" +
-	"\x10GetAlertsRequest\x12\x12\n" +
-	"\x04expr\x18\x01 \x01(\tR\x04expr\x12\x1a\n" +
-	"\bgrouping\x18\x02 \x01(\tR\bgrouping\x12\x1a\n" +
-	"\binactive\x18\x03 \x01(\bR\binactive\x12\x18\n" +
-	"\acurrent\x18\x04 \x01(\bR\acurrent\"G\n" +
-	"\x11GetAlertsResponse\x122\n" +
-	"\x06alerts\x18\x01 \x03(\v2\x1a.objectives.v1alpha1.AlertR\x06alerts\"\xb3\x03\n" +
-	"\x05Alert\x12>\n" +
-	"\x06labels\x18\x01 \x03(\v2&.objectives.v1alpha1.Alert.LabelsEntryR\x06labels\x12\x1a\n" +
-	"\bseverity\x18\x02 \x01(\tR\bseverity\x12+\n" +
-	"\x03for\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03for\x12\x16\n" +
-	"\x06factor\x18\x04 \x01(\x01R\x06factor\x126\n" +
-	"\x05state\x18\x05 \x01(\x0e2 .objectives.v1alpha1.Alert.StateR\x05state\x123\n" +
-	"\x05short\x18\x06 \x01(\v2\x1d.objectives.v1alpha1.BurnrateR\x05short\x121\n" +
-	"\x04long\x18\a \x01(\v2\x1d.objectives.v1alpha1.BurnrateR\x04long\x1a9\n" +
-	"\vLabelsEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\".\n" +
-	"\x05State\x12\f\n" +
-	"\binactive\x10\x00\x12\v\n" +
-	"\apending\x10\x01\x12\n" +
-	"\n" +
-	"\x06firing\x10\x02\"m\n" +
-	"\bBurnrate\x121\n" +
-	"\x06window\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x06window\x12\x18\n" +
-	"\acurrent\x18\x02 \x01(\x01R\acurrent\x12\x14\n" +
-	"\x05query\x18\x03 \x01(\tR\x05query\"\xa9\x01\n" +
-	"\x17GraphErrorBudgetRequest\x12\x12\n" +
-	"\x04expr\x18\x01 \x01(\tR\x04expr\x12\x1a\n" +
-	"\bgrouping\x18\x02 \x01(\tR\bgrouping\x120\n" +
-	"\x05start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
-	"\x03end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"[\n" +
-	"\x18GraphErrorBudgetResponse\x12?\n" +
-	"\n" +
-	"timeseries\x18\x01 \x01(\v2\x1f.objectives.v1alpha1.TimeseriesR\n" +
-	"timeseries\"\xa2\x01\n" +
-	"\x10GraphRateRequest\x12\x12\n" +
-	"\x04expr\x18\x01 \x01(\tR\x04expr\x12\x1a\n" +
-	"\bgrouping\x18\x02 \x01(\tR\bgrouping\x120\n" +
-	"\x05start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
-	"\x03end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"T\n" +
-	"\x11GraphRateResponse\x12?\n" +
-	"\n" +
-	"timeseries\x18\x01 \x01(\v2\x1f.objectives.v1alpha1.TimeseriesR\n" +
-	"timeseries\"\xa4\x01\n" +
-	"\x12GraphErrorsRequest\x12\x12\n" +
-	"\x04expr\x18\x01 \x01(\tR\x04expr\x12\x1a\n" +
-	"\bgrouping\x18\x02 \x01(\tR\bgrouping\x120\n" +
-	"\x05start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
-	"\x03end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"V\n" +
-	"\x13GraphErrorsResponse\x12?\n" +
-	"\n" +
-	"timeseries\x18\x01 \x01(\v2\x1f.objectives.v1alpha1.TimeseriesR\n" +
-	"timeseries\"o\n" +
-	"\n" +
-	"Timeseries\x12\x16\n" +
-	"\x06labels\x18\x01 \x03(\tR\x06labels\x12\x14\n" +
-	"\x05query\x18\x02 \x01(\tR\x05query\x123\n" +
-	"\x06series\x18\x03 \x03(\v2\x1b.objectives.v1alpha1.SeriesR\x06series\" \n" +
-	"\x06Series\x12\x16\n" +
-	"\x06values\x18\x01 \x03(\x01R\x06values\"\xa6\x01\n" +
-	"\x14GraphDurationRequest\x12\x12\n" +
-	"\x04expr\x18\x01 \x01(\tR\x04expr\x12\x1a\n" +
-	"\bgrouping\x18\x02 \x01(\tR\bgrouping\x120\n" +
-	"\x05start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
-	"\x03end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"X\n" +
-	"\x15GraphDurationResponse\x12?\n" +
-	"\n" +
-	"timeseries\x18\x01 \x03(\v2\x1f.objectives.v1alpha1.TimeseriesR\n" +
-	"timeseries2\xbc\x05\n" +
+func (x *BurnRateWindow) Reset() {
+	*x = BurnRateWindow{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BurnRateWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BurnRateWindow) ProtoMessage() {}
+
+func (x *BurnRateWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BurnRateWindow.ProtoReflect.Descriptor instead.
+func (*BurnRateWindow) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *BurnRateWindow) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *BurnRateWindow) GetFor() *durationpb.Duration {
+	if x != nil {
+		return x.For
+	}
+	return nil
+}
+
+func (x *BurnRateWindow) GetFactor() float64 {
+	if x != nil {
+		return x.Factor
+	}
+	return 0
+}
+
+func (x *BurnRateWindow) GetShort() *durationpb.Duration {
+	if x != nil {
+		return x.Short
+	}
+	return nil
+}
+
+func (x *BurnRateWindow) GetLong() *durationpb.Duration {
+	if x != nil {
+		return x.Long
+	}
+	return nil
+}
+
+func (x *BurnRateWindow) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *BurnRateWindow) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+type Calendar struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Period        string                 `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"`
+	TimeZone      string                 `protobuf:"bytes,2,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Calendar) Reset() {
+	*x = Calendar{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Calendar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Calendar) ProtoMessage() {}
+
+func (x *Calendar) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Calendar.ProtoReflect.Descriptor instead.
+func (*Calendar) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Calendar) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *Calendar) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type Composite struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Components    []*CompositeComponent  `protobuf:"bytes,2,rep,name=components,proto3" json:"components,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Composite) Reset() {
+	*x = Composite{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Composite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Composite) ProtoMessage() {}
+
+func (x *Composite) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Composite.ProtoReflect.Descriptor instead.
+func (*Composite) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Composite) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *Composite) GetComponents() []*CompositeComponent {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+type CompositeComponent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Selector      string                 `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
+	Weight        float64                `protobuf:"fixed64,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	Objectives    []*Objective           `protobuf:"bytes,3,rep,name=objectives,proto3" json:"objectives,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompositeComponent) Reset() {
+	*x = CompositeComponent{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompositeComponent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompositeComponent) ProtoMessage() {}
+
+func (x *CompositeComponent) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompositeComponent.ProtoReflect.Descriptor instead.
+func (*CompositeComponent) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CompositeComponent) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *CompositeComponent) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *CompositeComponent) GetObjectives() []*Objective {
+	if x != nil {
+		return x.Objectives
+	}
+	return nil
+}
+
+type Raw struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Good          string                 `protobuf:"bytes,1,opt,name=good,proto3" json:"good,omitempty"`
+	Total         string                 `protobuf:"bytes,2,opt,name=total,proto3" json:"total,omitempty"`
+	Grouping      []string               `protobuf:"bytes,3,rep,name=grouping,proto3" json:"grouping,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Raw) Reset() {
+	*x = Raw{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Raw) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Raw) ProtoMessage() {}
+
+func (x *Raw) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Raw.ProtoReflect.Descriptor instead.
+func (*Raw) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Raw) GetGood() string {
+	if x != nil {
+		return x.Good
+	}
+	return ""
+}
+
+func (x *Raw) GetTotal() string {
+	if x != nil {
+		return x.Total
+	}
+	return ""
+}
+
+func (x *Raw) GetGrouping() []string {
+	if x != nil {
+		return x.Grouping
+	}
+	return nil
+}
+
+type BudgetPolicyStage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Remaining     float64                `protobuf:"fixed64,2,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	Severity      string                 `protobuf:"bytes,3,opt,name=severity,proto3" json:"severity,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BudgetPolicyStage) Reset() {
+	*x = BudgetPolicyStage{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BudgetPolicyStage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BudgetPolicyStage) ProtoMessage() {}
+
+func (x *BudgetPolicyStage) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BudgetPolicyStage.ProtoReflect.Descriptor instead.
+func (*BudgetPolicyStage) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *BudgetPolicyStage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BudgetPolicyStage) GetRemaining() float64 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *BudgetPolicyStage) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *BudgetPolicyStage) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type BacktestRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Expr  string                 `protobuf:"bytes,1,opt,name=expr,proto3" json:"expr,omitempty"`
+	Start *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	Step  *durationpb.Duration   `protobuf:"bytes,4,opt,name=step,proto3" json:"step,omitempty"`
+	// objective is backtested instead of looking up expr, to tune objectives before they're deployed.
+	Objective     *Objective `protobuf:"bytes,5,opt,name=objective,proto3" json:"objective,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BacktestRequest) Reset() {
+	*x = BacktestRequest{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BacktestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BacktestRequest) ProtoMessage() {}
+
+func (x *BacktestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BacktestRequest.ProtoReflect.Descriptor instead.
+func (*BacktestRequest) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *BacktestRequest) GetExpr() string {
+	if x != nil {
+		return x.Expr
+	}
+	return ""
+}
+
+func (x *BacktestRequest) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *BacktestRequest) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *BacktestRequest) GetStep() *durationpb.Duration {
+	if x != nil {
+		return x.Step
+	}
+	return nil
+}
+
+func (x *BacktestRequest) GetObjective() *Objective {
+	if x != nil {
+		return x.Objective
+	}
+	return nil
+}
+
+type BacktestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alerts        []*BacktestAlert       `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BacktestResponse) Reset() {
+	*x = BacktestResponse{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BacktestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BacktestResponse) ProtoMessage() {}
+
+func (x *BacktestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BacktestResponse.ProtoReflect.Descriptor instead.
+func (*BacktestResponse) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *BacktestResponse) GetAlerts() []*BacktestAlert {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
+type BacktestAlert struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Window        *BurnRateWindow        `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Intervals     []*BacktestInterval    `protobuf:"bytes,3,rep,name=intervals,proto3" json:"intervals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BacktestAlert) Reset() {
+	*x = BacktestAlert{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BacktestAlert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BacktestAlert) ProtoMessage() {}
+
+func (x *BacktestAlert) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BacktestAlert.ProtoReflect.Descriptor instead.
+func (*BacktestAlert) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *BacktestAlert) GetWindow() *BurnRateWindow {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *BacktestAlert) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *BacktestAlert) GetIntervals() []*BacktestInterval {
+	if x != nil {
+		return x.Intervals
+	}
+	return nil
+}
+
+type BacktestInterval struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Labels        map[string]string      `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	State         Alert_State            `protobuf:"varint,2,opt,name=state,proto3,enum=objectives.v1alpha1.Alert_State" json:"state,omitempty"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BacktestInterval) Reset() {
+	*x = BacktestInterval{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BacktestInterval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BacktestInterval) ProtoMessage() {}
+
+func (x *BacktestInterval) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BacktestInterval.ProtoReflect.Descriptor instead.
+func (*BacktestInterval) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *BacktestInterval) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *BacktestInterval) GetState() Alert_State {
+	if x != nil {
+		return x.State
+	}
+	return Alert_inactive
+}
+
+func (x *BacktestInterval) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *BacktestInterval) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+type ExportReportRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// expr selects the objectives of the report. All objectives are reported if empty.
+	Expr string `protobuf:"bytes,1,opt,name=expr,proto3" json:"expr,omitempty"`
+	// start and end of the reported period. They default to the last full calendar month.
+	Start         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportReportRequest) Reset() {
+	*x = ExportReportRequest{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportReportRequest) ProtoMessage() {}
+
+func (x *ExportReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportReportRequest.ProtoReflect.Descriptor instead.
+func (*ExportReportRequest) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ExportReportRequest) GetExpr() string {
+	if x != nil {
+		return x.Expr
+	}
+	return ""
+}
+
+func (x *ExportReportRequest) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *ExportReportRequest) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+type ExportReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	Rows          []*ReportRow           `protobuf:"bytes,3,rep,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportReportResponse) Reset() {
+	*x = ExportReportResponse{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportReportResponse) ProtoMessage() {}
+
+func (x *ExportReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportReportResponse.ProtoReflect.Descriptor instead.
+func (*ExportReportResponse) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ExportReportResponse) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *ExportReportResponse) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *ExportReportResponse) GetRows() []*ReportRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+// ReportRow is the report of an objective, or of one group of an objective with grouping.
+type ReportRow struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Labels       map[string]string      `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Grouping     map[string]string      `protobuf:"bytes,2,rep,name=grouping,proto3" json:"grouping,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Target       float64                `protobuf:"fixed64,3,opt,name=target,proto3" json:"target,omitempty"`
+	Availability float64                `protobuf:"fixed64,4,opt,name=availability,proto3" json:"availability,omitempty"`
+	// budget_consumed is the share of the period's error budget the errors consumed. It's above 1 once the objective is missed.
+	BudgetConsumed float64 `protobuf:"fixed64,5,opt,name=budget_consumed,json=budgetConsumed,proto3" json:"budget_consumed,omitempty"`
+	// alert_minutes is how long any of the burn rate alerts fired in the period.
+	AlertMinutes  float64 `protobuf:"fixed64,6,opt,name=alert_minutes,json=alertMinutes,proto3" json:"alert_minutes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportRow) Reset() {
+	*x = ReportRow{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportRow) ProtoMessage() {}
+
+func (x *ReportRow) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportRow.ProtoReflect.Descriptor instead.
+func (*ReportRow) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ReportRow) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ReportRow) GetGrouping() map[string]string {
+	if x != nil {
+		return x.Grouping
+	}
+	return nil
+}
+
+func (x *ReportRow) GetTarget() float64 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+func (x *ReportRow) GetAvailability() float64 {
+	if x != nil {
+		return x.Availability
+	}
+	return 0
+}
+
+func (x *ReportRow) GetBudgetConsumed() float64 {
+	if x != nil {
+		return x.BudgetConsumed
+	}
+	return 0
+}
+
+func (x *ReportRow) GetAlertMinutes() float64 {
+	if x != nil {
+		return x.AlertMinutes
+	}
+	return 0
+}
+
+type GetStatusHistoryRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Expr     string                 `protobuf:"bytes,1,opt,name=expr,proto3" json:"expr,omitempty"`
+	Grouping string                 `protobuf:"bytes,2,opt,name=grouping,proto3" json:"grouping,omitempty"`
+	// time is the end of the latest period and defaults to now.
+	// The periods of calendar objectives end with the last full period before time.
+	Time *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	// periods is the number of windows before time. It defaults to 12.
+	Periods       uint32 `protobuf:"varint,4,opt,name=periods,proto3" json:"periods,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusHistoryRequest) Reset() {
+	*x = GetStatusHistoryRequest{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusHistoryRequest) ProtoMessage() {}
+
+func (x *GetStatusHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetStatusHistoryRequest) GetExpr() string {
+	if x != nil {
+		return x.Expr
+	}
+	return ""
+}
+
+func (x *GetStatusHistoryRequest) GetGrouping() string {
+	if x != nil {
+		return x.Grouping
+	}
+	return ""
+}
+
+func (x *GetStatusHistoryRequest) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *GetStatusHistoryRequest) GetPeriods() uint32 {
+	if x != nil {
+		return x.Periods
+	}
+	return 0
+}
+
+type GetStatusHistoryResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	History       []*ObjectiveStatusHistory `protobuf:"bytes,1,rep,name=history,proto3" json:"history,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusHistoryResponse) Reset() {
+	*x = GetStatusHistoryResponse{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusHistoryResponse) ProtoMessage() {}
+
+func (x *GetStatusHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetStatusHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetStatusHistoryResponse) GetHistory() []*ObjectiveStatusHistory {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+// ObjectiveStatusHistory is the status of an objective, or of one group of an objective with grouping, in past periods.
+type ObjectiveStatusHistory struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Labels map[string]string      `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// periods are sorted by time, the latest last. Periods without any requests are left out.
+	Periods       []*PeriodStatus `protobuf:"bytes,2,rep,name=periods,proto3" json:"periods,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectiveStatusHistory) Reset() {
+	*x = ObjectiveStatusHistory{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectiveStatusHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectiveStatusHistory) ProtoMessage() {}
+
+func (x *ObjectiveStatusHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectiveStatusHistory.ProtoReflect.Descriptor instead.
+func (*ObjectiveStatusHistory) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ObjectiveStatusHistory) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ObjectiveStatusHistory) GetPeriods() []*PeriodStatus {
+	if x != nil {
+		return x.Periods
+	}
+	return nil
+}
+
+type PeriodStatus struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Start        *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	Availability *Availability          `protobuf:"bytes,3,opt,name=availability,proto3" json:"availability,omitempty"`
+	Budget       *Budget                `protobuf:"bytes,4,opt,name=budget,proto3" json:"budget,omitempty"`
+	// met is whether the availability reached the objective's target in the period.
+	Met           bool `protobuf:"varint,5,opt,name=met,proto3" json:"met,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeriodStatus) Reset() {
+	*x = PeriodStatus{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeriodStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeriodStatus) ProtoMessage() {}
+
+func (x *PeriodStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeriodStatus.ProtoReflect.Descriptor instead.
+func (*PeriodStatus) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *PeriodStatus) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *PeriodStatus) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *PeriodStatus) GetAvailability() *Availability {
+	if x != nil {
+		return x.Availability
+	}
+	return nil
+}
+
+func (x *PeriodStatus) GetBudget() *Budget {
+	if x != nil {
+		return x.Budget
+	}
+	return nil
+}
+
+func (x *PeriodStatus) GetMet() bool {
+	if x != nil {
+		return x.Met
+	}
+	return false
+}
+
+type WatchStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Expr          string                 `protobuf:"bytes,1,opt,name=expr,proto3" json:"expr,omitempty"`
+	Grouping      string                 `protobuf:"bytes,2,opt,name=grouping,proto3" json:"grouping,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchStatusRequest) Reset() {
+	*x = WatchStatusRequest{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchStatusRequest) ProtoMessage() {}
+
+func (x *WatchStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchStatusRequest.ProtoReflect.Descriptor instead.
+func (*WatchStatusRequest) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *WatchStatusRequest) GetExpr() string {
+	if x != nil {
+		return x.Expr
+	}
+	return ""
+}
+
+func (x *WatchStatusRequest) GetGrouping() string {
+	if x != nil {
+		return x.Grouping
+	}
+	return ""
+}
+
+type WatchAlertsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Expr          string                 `protobuf:"bytes,1,opt,name=expr,proto3" json:"expr,omitempty"`
+	Grouping      string                 `protobuf:"bytes,2,opt,name=grouping,proto3" json:"grouping,omitempty"`
+	Inactive      bool                   `protobuf:"varint,3,opt,name=inactive,proto3" json:"inactive,omitempty"`
+	Current       bool                   `protobuf:"varint,4,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchAlertsRequest) Reset() {
+	*x = WatchAlertsRequest{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAlertsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAlertsRequest) ProtoMessage() {}
+
+func (x *WatchAlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAlertsRequest.ProtoReflect.Descriptor instead.
+func (*WatchAlertsRequest) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *WatchAlertsRequest) GetExpr() string {
+	if x != nil {
+		return x.Expr
+	}
+	return ""
+}
+
+func (x *WatchAlertsRequest) GetGrouping() string {
+	if x != nil {
+		return x.Grouping
+	}
+	return ""
+}
+
+func (x *WatchAlertsRequest) GetInactive() bool {
+	if x != nil {
+		return x.Inactive
+	}
+	return false
+}
+
+func (x *WatchAlertsRequest) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type BatchGetStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// expr selects the objectives. All objectives are returned if empty.
+	Expr          string                 `protobuf:"bytes,1,opt,name=expr,proto3" json:"expr,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetStatusRequest) Reset() {
+	*x = BatchGetStatusRequest{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetStatusRequest) ProtoMessage() {}
+
+func (x *BatchGetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetStatusRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *BatchGetStatusRequest) GetExpr() string {
+	if x != nil {
+		return x.Expr
+	}
+	return ""
+}
+
+func (x *BatchGetStatusRequest) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type BatchGetStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Objectives    []*BatchStatus         `protobuf:"bytes,1,rep,name=objectives,proto3" json:"objectives,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetStatusResponse) Reset() {
+	*x = BatchGetStatusResponse{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetStatusResponse) ProtoMessage() {}
+
+func (x *BatchGetStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetStatusResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetStatusResponse) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *BatchGetStatusResponse) GetObjectives() []*BatchStatus {
+	if x != nil {
+		return x.Objectives
+	}
+	return nil
+}
+
+// BatchStatus is the status of an objective, like GetStatus returns it.
+type BatchStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Labels        map[string]string      `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Status        []*ObjectiveStatus     `protobuf:"bytes,2,rep,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchStatus) Reset() {
+	*x = BatchStatus{}
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchStatus) ProtoMessage() {}
+
+func (x *BatchStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_objectives_v1alpha1_objectives_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchStatus.ProtoReflect.Descriptor instead.
+func (*BatchStatus) Descriptor() ([]byte, []int) {
+	return file_objectives_v1alpha1_objectives_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *BatchStatus) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *BatchStatus) GetStatus() []*ObjectiveStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+var File_objectives_v1alpha1_objectives_proto protoreflect.FileDescriptor
+
+const file_objectives_v1alpha1_objectives_proto_rawDesc = "" +
+	"\n" +
+	"$objectives/v1alpha1/objectives.proto\x12\x13objectives.v1alpha1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xac\x01\n" +
+	"\vListRequest\x12\x12\n" +
+	"\x04expr\x18\x01 \x01(\tR\x04expr\x12\x1a\n" +
+	"\bgrouping\x18\x02 \x01(\tR\bgrouping\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x05 \x01(\tR\aorderBy\x12\x16\n" +
+	"\x06search\x18\x06 \x01(\tR\x06search\"\xb1\x01\n" +
+	"\fListResponse\x12>\n" +
+	"\n" +
+	"objectives\x18\x01 \x03(\v2\x1e.objectives.v1alpha1.ObjectiveR\n" +
+	"objectives\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\x12\x1a\n" +
+	"\bwarnings\x18\x04 \x03(\tR\bwarnings\"\xb8\x05\n" +
+	"\tObjective\x12B\n" +
+	"\x06labels\x18\x01 \x03(\v2*.objectives.v1alpha1.Objective.LabelsEntryR\x06labels\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\x01R\x06target\x121\n" +
+	"\x06window\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x06window\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12<\n" +
+	"\tindicator\x18\x05 \x01(\v2\x1e.objectives.v1alpha1.IndicatorR\tindicator\x12\x16\n" +
+	"\x06config\x18\x06 \x01(\tR\x06config\x126\n" +
+	"\aqueries\x18\a \x01(\v2\x1c.objectives.v1alpha1.QueriesR\aqueries\x12M\n" +
+	"\x10burn_rate_policy\x18\b \x03(\v2#.objectives.v1alpha1.BurnRateWindowR\x0eburnRatePolicy\x129\n" +
+	"\bcalendar\x18\t \x01(\v2\x1d.objectives.v1alpha1.CalendarR\bcalendar\x12K\n" +
+	"\rbudget_policy\x18\n" +
+	" \x03(\v2&.objectives.v1alpha1.BudgetPolicyStageR\fbudgetPolicy\x12:\n" +
+	"\x19performance_over_accuracy\x18\v \x01(\bR\x17performanceOverAccuracy\x12\x1e\n" +
+	"\n" +
+	"datasource\x18\f \x01(\tR\n" +
+	"datasource\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xff\x02\n" +
+	"\tIndicator\x122\n" +
+	"\x05ratio\x18\x01 \x01(\v2\x1a.objectives.v1alpha1.RatioH\x00R\x05ratio\x128\n" +
+	"\alatency\x18\x02 \x01(\v2\x1c.objectives.v1alpha1.LatencyH\x00R\alatency\x12>\n" +
+	"\tboolGauge\x18\x03 \x01(\v2\x1e.objectives.v1alpha1.BoolGaugeH\x00R\tboolGauge\x12K\n" +
+	"\x0elatency_native\x18\x04 \x01(\v2\".objectives.v1alpha1.LatencyNativeH\x00R\rlatencyNative\x12>\n" +
+	"\tcomposite\x18\x05 \x01(\v2\x1e.objectives.v1alpha1.CompositeH\x00R\tcomposite\x12,\n" +
+	"\x03raw\x18\x06 \x01(\v2\x18.objectives.v1alpha1.RawH\x00R\x03rawB\t\n" +
+	"\aoptions\"\x89\x01\n" +
+	"\x05Ratio\x120\n" +
+	"\x05total\x18\x01 \x01(\v2\x1a.objectives.v1alpha1.QueryR\x05total\x122\n" +
+	"\x06errors\x18\x02 \x01(\v2\x1a.objectives.v1alpha1.QueryR\x06errors\x12\x1a\n" +
+	"\bgrouping\x18\x03 \x03(\tR\bgrouping\"\x8d\x01\n" +
+	"\aLatency\x120\n" +
+	"\x05total\x18\x01 \x01(\v2\x1a.objectives.v1alpha1.QueryR\x05total\x124\n" +
+	"\asuccess\x18\x02 \x01(\v2\x1a.objectives.v1alpha1.QueryR\asuccess\x12\x1a\n" +
+	"\bgrouping\x18\x03 \x03(\tR\bgrouping\"w\n" +
+	"\rLatencyNative\x120\n" +
+	"\x05total\x18\x01 \x01(\v2\x1a.objectives.v1alpha1.QueryR\x05total\x12\x18\n" +
+	"\alatency\x18\x02 \x01(\tR\alatency\x12\x1a\n" +
+	"\bgrouping\x18\x03 \x03(\tR\bgrouping\"a\n" +
+	"\tBoolGauge\x128\n" +
+	"\tboolGauge\x18\x01 \x01(\v2\x1a.objectives.v1alpha1.QueryR\tboolGauge\x12\x1a\n" +
+	"\bgrouping\x18\x03 \x03(\tR\bgrouping\"r\n" +
+	"\x05Query\x12\x16\n" +
+	"\x06metric\x18\x01 \x01(\tR\x06metric\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12=\n" +
+	"\bmatchers\x18\x03 \x03(\v2!.objectives.v1alpha1.LabelMatcherR\bmatchers\"\xbf\x01\n" +
+	"\aQueries\x12\x1e\n" +
+	"\n" +
+	"countTotal\x18\x01 \x01(\tR\n" +
+	"countTotal\x12 \n" +
+	"\vcountErrors\x18\x02 \x01(\tR\vcountErrors\x12*\n" +
+	"\x10graphErrorBudget\x18\x03 \x01(\tR\x10graphErrorBudget\x12$\n" +
+	"\rgraphRequests\x18\x04 \x01(\tR\rgraphRequests\x12 \n" +
+	"\vgraphErrors\x18\x05 \x01(\tR\vgraphErrors\"\x9e\x01\n" +
+	"\fLabelMatcher\x12:\n" +
+	"\x04type\x18\x01 \x01(\x0e2&.objectives.v1alpha1.LabelMatcher.TypeR\x04type\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\"(\n" +
+	"\x04Type\x12\x06\n" +
+	"\x02EQ\x10\x00\x12\a\n" +
+	"\x03NEQ\x10\x01\x12\x06\n" +
+	"\x02RE\x10\x02\x12\a\n" +
+	"\x03NRE\x10\x03\"r\n" +
+	"\x10GetStatusRequest\x12\x12\n" +
+	"\x04expr\x18\x01 \x01(\tR\x04expr\x12\x1a\n" +
+	"\bgrouping\x18\x02 \x01(\tR\bgrouping\x12.\n" +
+	"\x04time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"Q\n" +
+	"\x11GetStatusResponse\x12<\n" +
+	"\x06status\x18\x01 \x03(\v2$.objectives.v1alpha1.ObjectiveStatusR\x06status\"\x92\x02\n" +
+	"\x0fObjectiveStatus\x12H\n" +
+	"\x06labels\x18\x01 \x03(\v20.objectives.v1alpha1.ObjectiveStatus.LabelsEntryR\x06labels\x12E\n" +
+	"\favailability\x18\x02 \x01(\v2!.objectives.v1alpha1.AvailabilityR\favailability\x123\n" +
+	"\x06budget\x18\x03 \x01(\v2\x1b.objectives.v1alpha1.BudgetR\x06budget\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\\\n" +
+	"\fAvailability\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x01 \x01(\x01R\n" +
+	"percentage\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x01R\x05total\x12\x16\n" +
+	"\x06errors\x18\x03 \x01(\x01R\x06errors\"q\n" +
+	"\x06Budget\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x01R\x05total\x12\x1c\n" +
+	"\tremaining\x18\x02 \x01(\x01R\tremaining\x12\x10\n" +
+	"\x03max\x18\x03 \x01(\x01R\x03max\x12!\n" +
+	"\fpolicy_stage\x18\x04 \x01(\tR\vpolicyStage\"x\n" +
+	"\x10GetAlertsRequest\x12\x12\n" +
+	"\x04expr\x18\x01 \x01(\tR\x04expr\x12\x1a\n" +
+	"\bgrouping\x18\x02 \x01(\tR\bgrouping\x12\x1a\n" +
+	"\binactive\x18\x03 \x01(\bR\binactive\x12\x18\n" +
+	"\acurrent\x18\x04 \x01(\bR\acurrent\"G\n" +
+	"\x11GetAlertsResponse\x122\n" +
+	"\x06alerts\x18\x01 \x03(\v2\x1a.objectives.v1alpha1.AlertR\x06alerts\"\xb3\x03\n" +
+	"\x05Alert\x12>\n" +
+	"\x06labels\x18\x01 \x03(\v2&.objectives.v1alpha1.Alert.LabelsEntryR\x06labels\x12\x1a\n" +
+	"\bseverity\x18\x02 \x01(\tR\bseverity\x12+\n" +
+	"\x03for\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03for\x12\x16\n" +
+	"\x06factor\x18\x04 \x01(\x01R\x06factor\x126\n" +
+	"\x05state\x18\x05 \x01(\x0e2 .objectives.v1alpha1.Alert.StateR\x05state\x123\n" +
+	"\x05short\x18\x06 \x01(\v2\x1d.objectives.v1alpha1.BurnrateR\x05short\x121\n" +
+	"\x04long\x18\a \x01(\v2\x1d.objectives.v1alpha1.BurnrateR\x04long\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\".\n" +
+	"\x05State\x12\f\n" +
+	"\binactive\x10\x00\x12\v\n" +
+	"\apending\x10\x01\x12\n" +
+	"\n" +
+	"\x06firing\x10\x02\"m\n" +
+	"\bBurnrate\x121\n" +
+	"\x06window\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x06window\x12\x18\n" +
+	"\acurrent\x18\x02 \x01(\x01R\acurrent\x12\x14\n" +
+	"\x05query\x18\x03 \x01(\tR\x05query\"\xa9\x01\n" +
+	"\x17GraphErrorBudgetRequest\x12\x12\n" +
+	"\x04expr\x18\x01 \x01(\tR\x04expr\x12\x1a\n" +
+	"\bgrouping\x18\x02 \x01(\tR\bgrouping\x120\n" +
+	"\x05start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"[\n" +
+	"\x18GraphErrorBudgetResponse\x12?\n" +
+	"\n" +
+	"timeseries\x18\x01 \x01(\v2\x1f.objectives.v1alpha1.TimeseriesR\n" +
+	"timeseries\"\xa2\x01\n" +
+	"\x10GraphRateRequest\x12\x12\n" +
+	"\x04expr\x18\x01 \x01(\tR\x04expr\x12\x1a\n" +
+	"\bgrouping\x18\x02 \x01(\tR\bgrouping\x120\n" +
+	"\x05start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"T\n" +
+	"\x11GraphRateResponse\x12?\n" +
+	"\n" +
+	"timeseries\x18\x01 \x01(\v2\x1f.objectives.v1alpha1.TimeseriesR\n" +
+	"timeseries\"\xa4\x01\n" +
+	"\x12GraphErrorsRequest\x12\x12\n" +
+	"\x04expr\x18\x01 \x01(\tR\x04expr\x12\x1a\n" +
+	"\bgrouping\x18\x02 \x01(\tR\bgrouping\x120\n" +
+	"\x05start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"V\n" +
+	"\x13GraphErrorsResponse\x12?\n" +
+	"\n" +
+	"timeseries\x18\x01 \x01(\v2\x1f.objectives.v1alpha1.TimeseriesR\n" +
+	"timeseries\"o\n" +
+	"\n" +
+	"Timeseries\x12\x16\n" +
+	"\x06labels\x18\x01 \x03(\tR\x06labels\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x123\n" +
+	"\x06series\x18\x03 \x03(\v2\x1b.objectives.v1alpha1.SeriesR\x06series\" \n" +
+	"\x06Series\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\x01R\x06values\"\xa6\x01\n" +
+	"\x14GraphDurationRequest\x12\x12\n" +
+	"\x04expr\x18\x01 \x01(\tR\x04expr\x12\x1a\n" +
+	"\bgrouping\x18\x02 \x01(\tR\bgrouping\x120\n" +
+	"\x05start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"X\n" +
+	"\x15GraphDurationResponse\x12?\n" +
+	"\n" +
+	"timeseries\x18\x01 \x03(\v2\x1f.objectives.v1alpha1.TimeseriesR\n" +
+	"timeseries\"\xed\x03\n" +
+	"\x0eBurnRateWindow\x12\x1a\n" +
+	"\bseverity\x18\x01 \x01(\tR\bseverity\x12+\n" +
+	"\x03for\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x03for\x12\x16\n" +
+	"\x06factor\x18\x03 \x01(\x01R\x06factor\x12/\n" +
+	"\x05short\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x05short\x12-\n" +
+	"\x04long\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x04long\x12G\n" +
+	"\x06labels\x18\x06 \x03(\v2/.objectives.v1alpha1.BurnRateWindow.LabelsEntryR\x06labels\x12V\n" +
+	"\vannotations\x18\a \x03(\v24.objectives.v1alpha1.BurnRateWindow.AnnotationsEntryR\vannotations\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"?\n" +
+	"\bCalendar\x12\x16\n" +
+	"\x06period\x18\x01 \x01(\tR\x06period\x12\x1b\n" +
+	"\ttime_zone\x18\x02 \x01(\tR\btimeZone\"j\n" +
+	"\tComposite\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12G\n" +
+	"\n" +
+	"components\x18\x02 \x03(\v2'.objectives.v1alpha1.CompositeComponentR\n" +
+	"components\"\x88\x01\n" +
+	"\x12CompositeComponent\x12\x1a\n" +
+	"\bselector\x18\x01 \x01(\tR\bselector\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\x01R\x06weight\x12>\n" +
+	"\n" +
+	"objectives\x18\x03 \x03(\v2\x1e.objectives.v1alpha1.ObjectiveR\n" +
+	"objectives\"K\n" +
+	"\x03Raw\x12\x12\n" +
+	"\x04good\x18\x01 \x01(\tR\x04good\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\tR\x05total\x12\x1a\n" +
+	"\bgrouping\x18\x03 \x03(\tR\bgrouping\"\xe8\x01\n" +
+	"\x11BudgetPolicyStage\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tremaining\x18\x02 \x01(\x01R\tremaining\x12\x1a\n" +
+	"\bseverity\x18\x03 \x01(\tR\bseverity\x12J\n" +
+	"\x06labels\x18\x04 \x03(\v22.objectives.v1alpha1.BudgetPolicyStage.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf2\x01\n" +
+	"\x0fBacktestRequest\x12\x12\n" +
+	"\x04expr\x18\x01 \x01(\tR\x04expr\x120\n" +
+	"\x05start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12-\n" +
+	"\x04step\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x04step\x12<\n" +
+	"\tobjective\x18\x05 \x01(\v2\x1e.objectives.v1alpha1.ObjectiveR\tobjective\"N\n" +
+	"\x10BacktestResponse\x12:\n" +
+	"\x06alerts\x18\x01 \x03(\v2\".objectives.v1alpha1.BacktestAlertR\x06alerts\"\xa7\x01\n" +
+	"\rBacktestAlert\x12;\n" +
+	"\x06window\x18\x01 \x01(\v2#.objectives.v1alpha1.BurnRateWindowR\x06window\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12C\n" +
+	"\tintervals\x18\x03 \x03(\v2%.objectives.v1alpha1.BacktestIntervalR\tintervals\"\xb0\x02\n" +
+	"\x10BacktestInterval\x12I\n" +
+	"\x06labels\x18\x01 \x03(\v21.objectives.v1alpha1.BacktestInterval.LabelsEntryR\x06labels\x126\n" +
+	"\x05state\x18\x02 \x01(\x0e2 .objectives.v1alpha1.Alert.StateR\x05state\x120\n" +
+	"\x05start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x89\x01\n" +
+	"\x13ExportReportRequest\x12\x12\n" +
+	"\x04expr\x18\x01 \x01(\tR\x04expr\x120\n" +
+	"\x05start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"\xaa\x01\n" +
+	"\x14ExportReportResponse\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x122\n" +
+	"\x04rows\x18\x03 \x03(\v2\x1e.objectives.v1alpha1.ReportRowR\x04rows\"\x9b\x03\n" +
+	"\tReportRow\x12B\n" +
+	"\x06labels\x18\x01 \x03(\v2*.objectives.v1alpha1.ReportRow.LabelsEntryR\x06labels\x12H\n" +
+	"\bgrouping\x18\x02 \x03(\v2,.objectives.v1alpha1.ReportRow.GroupingEntryR\bgrouping\x12\x16\n" +
+	"\x06target\x18\x03 \x01(\x01R\x06target\x12\"\n" +
+	"\favailability\x18\x04 \x01(\x01R\favailability\x12'\n" +
+	"\x0fbudget_consumed\x18\x05 \x01(\x01R\x0ebudgetConsumed\x12#\n" +
+	"\ralert_minutes\x18\x06 \x01(\x01R\falertMinutes\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a;\n" +
+	"\rGroupingEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x93\x01\n" +
+	"\x17GetStatusHistoryRequest\x12\x12\n" +
+	"\x04expr\x18\x01 \x01(\tR\x04expr\x12\x1a\n" +
+	"\bgrouping\x18\x02 \x01(\tR\bgrouping\x12.\n" +
+	"\x04time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x18\n" +
+	"\aperiods\x18\x04 \x01(\rR\aperiods\"a\n" +
+	"\x18GetStatusHistoryResponse\x12E\n" +
+	"\ahistory\x18\x01 \x03(\v2+.objectives.v1alpha1.ObjectiveStatusHistoryR\ahistory\"\xe1\x01\n" +
+	"\x16ObjectiveStatusHistory\x12O\n" +
+	"\x06labels\x18\x01 \x03(\v27.objectives.v1alpha1.ObjectiveStatusHistory.LabelsEntryR\x06labels\x12;\n" +
+	"\aperiods\x18\x02 \x03(\v2!.objectives.v1alpha1.PeriodStatusR\aperiods\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xfc\x01\n" +
+	"\fPeriodStatus\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12E\n" +
+	"\favailability\x18\x03 \x01(\v2!.objectives.v1alpha1.AvailabilityR\favailability\x123\n" +
+	"\x06budget\x18\x04 \x01(\v2\x1b.objectives.v1alpha1.BudgetR\x06budget\x12\x10\n" +
+	"\x03met\x18\x05 \x01(\bR\x03met\"D\n" +
+	"\x12WatchStatusRequest\x12\x12\n" +
+	"\x04expr\x18\x01 \x01(\tR\x04expr\x12\x1a\n" +
+	"\bgrouping\x18\x02 \x01(\tR\bgrouping\"z\n" +
+	"\x12WatchAlertsRequest\x12\x12\n" +
+	"\x04expr\x18\x01 \x01(\tR\x04expr\x12\x1a\n" +
+	"\bgrouping\x18\x02 \x01(\tR\bgrouping\x12\x1a\n" +
+	"\binactive\x18\x03 \x01(\bR\binactive\x12\x18\n" +
+	"\acurrent\x18\x04 \x01(\bR\acurrent\"[\n" +
+	"\x15BatchGetStatusRequest\x12\x12\n" +
+	"\x04expr\x18\x01 \x01(\tR\x04expr\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"Z\n" +
+	"\x16BatchGetStatusResponse\x12@\n" +
+	"\n" +
+	"objectives\x18\x01 \x03(\v2 .objectives.v1alpha1.BatchStatusR\n" +
+	"objectives\"\xcc\x01\n" +
+	"\vBatchStatus\x12D\n" +
+	"\x06labels\x18\x01 \x03(\v2,.objectives.v1alpha1.BatchStatus.LabelsEntryR\x06labels\x12<\n" +
+	"\x06status\x18\x02 \x03(\v2$.objectives.v1alpha1.ObjectiveStatusR\x06status\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xa6\n" +
+	"\n" +
 	"\x10ObjectiveService\x12M\n" +
 	"\x04List\x12 .objectives.v1alpha1.ListRequest\x1a!.objectives.v1alpha1.ListResponse\"\x00\x12\\\n" +
-	"\tGetStatus\x12%.objectives.v1alpha1.GetStatusRequest\x1a&.objectives.v1alpha1.GetStatusResponse\"\x00\x12\\\n" +
+	"\tGetStatus\x12%.objectives.v1alpha1.GetStatusRequest\x1a&.objectives.v1alpha1.GetStatusResponse\"\x00\x12k\n" +
+	"\x0eBatchGetStatus\x12*.objectives.v1alpha1.BatchGetStatusRequest\x1a+.objectives.v1alpha1.BatchGetStatusResponse\"\x00\x12\\\n" +
 	"\tGetAlerts\x12%.objectives.v1alpha1.GetAlertsRequest\x1a&.objectives.v1alpha1.GetAlertsResponse\"\x00\x12q\n" +
 	"\x10GraphErrorBudget\x12,.objectives.v1alpha1.GraphErrorBudgetRequest\x1a-.objectives.v1alpha1.GraphErrorBudgetResponse\"\x00\x12\\\n" +
 	"\tGraphRate\x12%.objectives.v1alpha1.GraphRateRequest\x1a&.objectives.v1alpha1.GraphRateResponse\"\x00\x12b\n" +
 	"\vGraphErrors\x12'.objectives.v1alpha1.GraphErrorsRequest\x1a(.objectives.v1alpha1.GraphErrorsResponse\"\x00\x12h\n" +
-	"\rGraphDuration\x12).objectives.v1alpha1.GraphDurationRequest\x1a*.objectives.v1alpha1.GraphDurationResponse\"\x002h\n" +
+	"\rGraphDuration\x12).objectives.v1alpha1.GraphDurationRequest\x1a*.objectives.v1alpha1.GraphDurationResponse\"\x00\x12Y\n" +
+	"\bBacktest\x12$.objectives.v1alpha1.BacktestRequest\x1a%.objectives.v1alpha1.BacktestResponse\"\x00\x12e\n" +
+	"\fExportReport\x12(.objectives.v1alpha1.ExportReportRequest\x1a).objectives.v1alpha1.ExportReportResponse\"\x00\x12q\n" +
+	"\x10GetStatusHistory\x12,.objectives.v1alpha1.GetStatusHistoryRequest\x1a-.objectives.v1alpha1.GetStatusHistoryResponse\"\x00\x12b\n" +
+	"\vWatchStatus\x12'.objectives.v1alpha1.WatchStatusRequest\x1a&.objectives.v1alpha1.GetStatusResponse\"\x000\x01\x12b\n" +
+	"\vWatchAlerts\x12'.objectives.v1alpha1.WatchAlertsRequest\x1a&.objectives.v1alpha1.GetAlertsResponse\"\x000\x012h\n" +
 	"\x17ObjectiveBackendService\x12M\n" +
 	"\x04List\x12 .objectives.v1alpha1.ListRequest\x1a!.objectives.v1alpha1.ListResponse\"\x00BIZGgithub.com/pyrra-dev/pyrra/proto/objectives/v1alpha1;objectivesv1alpha1b\x06proto3"
 
@@ -2138,7 +3787,7 @@ func file_objectives_v1alpha1_objectives_proto_rawDescGZIP() []byte {
 }
 
 var file_objectives_v1alpha1_objectives_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_objectives_v1alpha1_objectives_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
+var file_objectives_v1alpha1_objectives_proto_msgTypes = make([]protoimpl.MessageInfo, 63)
 var file_objectives_v1alpha1_objectives_proto_goTypes = []any{
 	(LabelMatcher_Type)(0),           // 0: objectives.v1alpha1.LabelMatcher.Type
 	(Alert_State)(0),                 // 1: objectives.v1alpha1.Alert.State
@@ -2172,76 +3821,161 @@ var file_objectives_v1alpha1_objectives_proto_goTypes = []any{
 	(*Series)(nil),                   // 29: objectives.v1alpha1.Series
 	(*GraphDurationRequest)(nil),     // 30: objectives.v1alpha1.GraphDurationRequest
 	(*GraphDurationResponse)(nil),    // 31: objectives.v1alpha1.GraphDurationResponse
-	nil,                              // 32: objectives.v1alpha1.Objective.LabelsEntry
-	nil,                              // 33: objectives.v1alpha1.ObjectiveStatus.LabelsEntry
-	nil,                              // 34: objectives.v1alpha1.Alert.LabelsEntry
-	(*durationpb.Duration)(nil),      // 35: google.protobuf.Duration
-	(*timestamppb.Timestamp)(nil),    // 36: google.protobuf.Timestamp
+	(*BurnRateWindow)(nil),           // 32: objectives.v1alpha1.BurnRateWindow
+	(*Calendar)(nil),                 // 33: objectives.v1alpha1.Calendar
+	(*Composite)(nil),                // 34: objectives.v1alpha1.Composite
+	(*CompositeComponent)(nil),       // 35: objectives.v1alpha1.CompositeComponent
+	(*Raw)(nil),                      // 36: objectives.v1alpha1.Raw
+	(*BudgetPolicyStage)(nil),        // 37: objectives.v1alpha1.BudgetPolicyStage
+	(*BacktestRequest)(nil),          // 38: objectives.v1alpha1.BacktestRequest
+	(*BacktestResponse)(nil),         // 39: objectives.v1alpha1.BacktestResponse
+	(*BacktestAlert)(nil),            // 40: objectives.v1alpha1.BacktestAlert
+	(*BacktestInterval)(nil),         // 41: objectives.v1alpha1.BacktestInterval
+	(*ExportReportRequest)(nil),      // 42: objectives.v1alpha1.ExportReportRequest
+	(*ExportReportResponse)(nil),     // 43: objectives.v1alpha1.ExportReportResponse
+	(*ReportRow)(nil),                // 44: objectives.v1alpha1.ReportRow
+	(*GetStatusHistoryRequest)(nil),  // 45: objectives.v1alpha1.GetStatusHistoryRequest
+	(*GetStatusHistoryResponse)(nil), // 46: objectives.v1alpha1.GetStatusHistoryResponse
+	(*ObjectiveStatusHistory)(nil),   // 47: objectives.v1alpha1.ObjectiveStatusHistory
+	(*PeriodStatus)(nil),             // 48: objectives.v1alpha1.PeriodStatus
+	(*WatchStatusRequest)(nil),       // 49: objectives.v1alpha1.WatchStatusRequest
+	(*WatchAlertsRequest)(nil),       // 50: objectives.v1alpha1.WatchAlertsRequest
+	(*BatchGetStatusRequest)(nil),    // 51: objectives.v1alpha1.BatchGetStatusRequest
+	(*BatchGetStatusResponse)(nil),   // 52: objectives.v1alpha1.BatchGetStatusResponse
+	(*BatchStatus)(nil),              // 53: objectives.v1alpha1.BatchStatus
+	nil,                              // 54: objectives.v1alpha1.Objective.LabelsEntry
+	nil,                              // 55: objectives.v1alpha1.ObjectiveStatus.LabelsEntry
+	nil,                              // 56: objectives.v1alpha1.Alert.LabelsEntry
+	nil,                              // 57: objectives.v1alpha1.BurnRateWindow.LabelsEntry
+	nil,                              // 58: objectives.v1alpha1.BurnRateWindow.AnnotationsEntry
+	nil,                              // 59: objectives.v1alpha1.BudgetPolicyStage.LabelsEntry
+	nil,                              // 60: objectives.v1alpha1.BacktestInterval.LabelsEntry
+	nil,                              // 61: objectives.v1alpha1.ReportRow.LabelsEntry
+	nil,                              // 62: objectives.v1alpha1.ReportRow.GroupingEntry
+	nil,                              // 63: objectives.v1alpha1.ObjectiveStatusHistory.LabelsEntry
+	nil,                              // 64: objectives.v1alpha1.BatchStatus.LabelsEntry
+	(*durationpb.Duration)(nil),      // 65: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),    // 66: google.protobuf.Timestamp
 }
 var file_objectives_v1alpha1_objectives_proto_depIdxs = []int32{
 	4,  // 0: objectives.v1alpha1.ListResponse.objectives:type_name -> objectives.v1alpha1.Objective
-	32, // 1: objectives.v1alpha1.Objective.labels:type_name -> objectives.v1alpha1.Objective.LabelsEntry
-	35, // 2: objectives.v1alpha1.Objective.window:type_name -> google.protobuf.Duration
+	54, // 1: objectives.v1alpha1.Objective.labels:type_name -> objectives.v1alpha1.Objective.LabelsEntry
+	65, // 2: objectives.v1alpha1.Objective.window:type_name -> google.protobuf.Duration
 	5,  // 3: objectives.v1alpha1.Objective.indicator:type_name -> objectives.v1alpha1.Indicator
 	11, // 4: objectives.v1alpha1.Objective.queries:type_name -> objectives.v1alpha1.Queries
-	6,  // 5: objectives.v1alpha1.Indicator.ratio:type_name -> objectives.v1alpha1.Ratio
-	7,  // 6: objectives.v1alpha1.Indicator.latency:type_name -> objectives.v1alpha1.Latency
-	9,  // 7: objectives.v1alpha1.Indicator.boolGauge:type_name -> objectives.v1alpha1.BoolGauge
-	8,  // 8: objectives.v1alpha1.Indicator.latency_native:type_name -> objectives.v1alpha1.LatencyNative
-	10, // 9: objectives.v1alpha1.Ratio.total:type_name -> objectives.v1alpha1.Query
-	10, // 10: objectives.v1alpha1.Ratio.errors:type_name -> objectives.v1alpha1.Query
-	10, // 11: objectives.v1alpha1.Latency.total:type_name -> objectives.v1alpha1.Query
-	10, // 12: objectives.v1alpha1.Latency.success:type_name -> objectives.v1alpha1.Query
-	10, // 13: objectives.v1alpha1.LatencyNative.total:type_name -> objectives.v1alpha1.Query
-	10, // 14: objectives.v1alpha1.BoolGauge.boolGauge:type_name -> objectives.v1alpha1.Query
-	12, // 15: objectives.v1alpha1.Query.matchers:type_name -> objectives.v1alpha1.LabelMatcher
-	0,  // 16: objectives.v1alpha1.LabelMatcher.type:type_name -> objectives.v1alpha1.LabelMatcher.Type
-	36, // 17: objectives.v1alpha1.GetStatusRequest.time:type_name -> google.protobuf.Timestamp
-	15, // 18: objectives.v1alpha1.GetStatusResponse.status:type_name -> objectives.v1alpha1.ObjectiveStatus
-	33, // 19: objectives.v1alpha1.ObjectiveStatus.labels:type_name -> objectives.v1alpha1.ObjectiveStatus.LabelsEntry
-	16, // 20: objectives.v1alpha1.ObjectiveStatus.availability:type_name -> objectives.v1alpha1.Availability
-	17, // 21: objectives.v1alpha1.ObjectiveStatus.budget:type_name -> objectives.v1alpha1.Budget
-	20, // 22: objectives.v1alpha1.GetAlertsResponse.alerts:type_name -> objectives.v1alpha1.Alert
-	34, // 23: objectives.v1alpha1.Alert.labels:type_name -> objectives.v1alpha1.Alert.LabelsEntry
-	35, // 24: objectives.v1alpha1.Alert.for:type_name -> google.protobuf.Duration
-	1,  // 25: objectives.v1alpha1.Alert.state:type_name -> objectives.v1alpha1.Alert.State
-	21, // 26: objectives.v1alpha1.Alert.short:type_name -> objectives.v1alpha1.Burnrate
-	21, // 27: objectives.v1alpha1.Alert.long:type_name -> objectives.v1alpha1.Burnrate
-	35, // 28: objectives.v1alpha1.Burnrate.window:type_name -> google.protobuf.Duration
-	36, // 29: objectives.v1alpha1.GraphErrorBudgetRequest.start:type_name -> google.protobuf.Timestamp
-	36, // 30: objectives.v1alpha1.GraphErrorBudgetRequest.end:type_name -> google.protobuf.Timestamp
-	28, // 31: objectives.v1alpha1.GraphErrorBudgetResponse.timeseries:type_name -> objectives.v1alpha1.Timeseries
-	36, // 32: objectives.v1alpha1.GraphRateRequest.start:type_name -> google.protobuf.Timestamp
-	36, // 33: objectives.v1alpha1.GraphRateRequest.end:type_name -> google.protobuf.Timestamp
-	28, // 34: objectives.v1alpha1.GraphRateResponse.timeseries:type_name -> objectives.v1alpha1.Timeseries
-	36, // 35: objectives.v1alpha1.GraphErrorsRequest.start:type_name -> google.protobuf.Timestamp
-	36, // 36: objectives.v1alpha1.GraphErrorsRequest.end:type_name -> google.protobuf.Timestamp
-	28, // 37: objectives.v1alpha1.GraphErrorsResponse.timeseries:type_name -> objectives.v1alpha1.Timeseries
-	29, // 38: objectives.v1alpha1.Timeseries.series:type_name -> objectives.v1alpha1.Series
-	36, // 39: objectives.v1alpha1.GraphDurationRequest.start:type_name -> google.protobuf.Timestamp
-	36, // 40: objectives.v1alpha1.GraphDurationRequest.end:type_name -> google.protobuf.Timestamp
-	28, // 41: objectives.v1alpha1.GraphDurationResponse.timeseries:type_name -> objectives.v1alpha1.Timeseries
-	2,  // 42: objectives.v1alpha1.ObjectiveService.List:input_type -> objectives.v1alpha1.ListRequest
-	13, // 43: objectives.v1alpha1.ObjectiveService.GetStatus:input_type -> objectives.v1alpha1.GetStatusRequest
-	18, // 44: objectives.v1alpha1.ObjectiveService.GetAlerts:input_type -> objectives.v1alpha1.GetAlertsRequest
-	22, // 45: objectives.v1alpha1.ObjectiveService.GraphErrorBudget:input_type -> objectives.v1alpha1.GraphErrorBudgetRequest
-	24, // 46: objectives.v1alpha1.ObjectiveService.GraphRate:input_type -> objectives.v1alpha1.GraphRateRequest
-	26, // 47: objectives.v1alpha1.ObjectiveService.GraphErrors:input_type -> objectives.v1alpha1.GraphErrorsRequest
-	30, // 48: objectives.v1alpha1.ObjectiveService.GraphDuration:input_type -> objectives.v1alpha1.GraphDurationRequest
-	2,  // 49: objectives.v1alpha1.ObjectiveBackendService.List:input_type -> objectives.v1alpha1.ListRequest
-	3,  // 50: objectives.v1alpha1.ObjectiveService.List:output_type -> objectives.v1alpha1.ListResponse
-	14, // 51: objectives.v1alpha1.ObjectiveService.GetStatus:output_type -> objectives.v1alpha1.GetStatusResponse
-	19, // 52: objectives.v1alpha1.ObjectiveService.GetAlerts:output_type -> objectives.v1alpha1.GetAlertsResponse
-	23, // 53: objectives.v1alpha1.ObjectiveService.GraphErrorBudget:output_type -> objectives.v1alpha1.GraphErrorBudgetResponse
-	25, // 54: objectives.v1alpha1.ObjectiveService.GraphRate:output_type -> objectives.v1alpha1.GraphRateResponse
-	27, // 55: objectives.v1alpha1.ObjectiveService.GraphErrors:output_type -> objectives.v1alpha1.GraphErrorsResponse
-	31, // 56: objectives.v1alpha1.ObjectiveService.GraphDuration:output_type -> objectives.v1alpha1.GraphDurationResponse
-	3,  // 57: objectives.v1alpha1.ObjectiveBackendService.List:output_type -> objectives.v1alpha1.ListResponse
-	50, // [50:58] is the sub-list for method output_type
-	42, // [42:50] is the sub-list for method input_type
-	42, // [42:42] is the sub-list for extension type_name
-	42, // [42:42] is the sub-list for extension extendee
-	0,  // [0:42] is the sub-list for field type_name
+	32, // 5: objectives.v1alpha1.Objective.burn_rate_policy:type_name -> objectives.v1alpha1.BurnRateWindow
+	33, // 6: objectives.v1alpha1.Objective.calendar:type_name -> objectives.v1alpha1.Calendar
+	37, // 7: objectives.v1alpha1.Objective.budget_policy:type_name -> objectives.v1alpha1.BudgetPolicyStage
+	6,  // 8: objectives.v1alpha1.Indicator.ratio:type_name -> objectives.v1alpha1.Ratio
+	7,  // 9: objectives.v1alpha1.Indicator.latency:type_name -> objectives.v1alpha1.Latency
+	9,  // 10: objectives.v1alpha1.Indicator.boolGauge:type_name -> objectives.v1alpha1.BoolGauge
+	8,  // 11: objectives.v1alpha1.Indicator.latency_native:type_name -> objectives.v1alpha1.LatencyNative
+	34, // 12: objectives.v1alpha1.Indicator.composite:type_name -> objectives.v1alpha1.Composite
+	36, // 13: objectives.v1alpha1.Indicator.raw:type_name -> objectives.v1alpha1.Raw
+	10, // 14: objectives.v1alpha1.Ratio.total:type_name -> objectives.v1alpha1.Query
+	10, // 15: objectives.v1alpha1.Ratio.errors:type_name -> objectives.v1alpha1.Query
+	10, // 16: objectives.v1alpha1.Latency.total:type_name -> objectives.v1alpha1.Query
+	10, // 17: objectives.v1alpha1.Latency.success:type_name -> objectives.v1alpha1.Query
+	10, // 18: objectives.v1alpha1.LatencyNative.total:type_name -> objectives.v1alpha1.Query
+	10, // 19: objectives.v1alpha1.BoolGauge.boolGauge:type_name -> objectives.v1alpha1.Query
+	12, // 20: objectives.v1alpha1.Query.matchers:type_name -> objectives.v1alpha1.LabelMatcher
+	0,  // 21: objectives.v1alpha1.LabelMatcher.type:type_name -> objectives.v1alpha1.LabelMatcher.Type
+	66, // 22: objectives.v1alpha1.GetStatusRequest.time:type_name -> google.protobuf.Timestamp
+	15, // 23: objectives.v1alpha1.GetStatusResponse.status:type_name -> objectives.v1alpha1.ObjectiveStatus
+	55, // 24: objectives.v1alpha1.ObjectiveStatus.labels:type_name -> objectives.v1alpha1.ObjectiveStatus.LabelsEntry
+	16, // 25: objectives.v1alpha1.ObjectiveStatus.availability:type_name -> objectives.v1alpha1.Availability
+	17, // 26: objectives.v1alpha1.ObjectiveStatus.budget:type_name -> objectives.v1alpha1.Budget
+	20, // 27: objectives.v1alpha1.GetAlertsResponse.alerts:type_name -> objectives.v1alpha1.Alert
+	56, // 28: objectives.v1alpha1.Alert.labels:type_name -> objectives.v1alpha1.Alert.LabelsEntry
+	65, // 29: objectives.v1alpha1.Alert.for:type_name -> google.protobuf.Duration
+	1,  // 30: objectives.v1alpha1.Alert.state:type_name -> objectives.v1alpha1.Alert.State
+	21, // 31: objectives.v1alpha1.Alert.short:type_name -> objectives.v1alpha1.Burnrate
+	21, // 32: objectives.v1alpha1.Alert.long:type_name -> objectives.v1alpha1.Burnrate
+	65, // 33: objectives.v1alpha1.Burnrate.window:type_name -> google.protobuf.Duration
+	66, // 34: objectives.v1alpha1.GraphErrorBudgetRequest.start:type_name -> google.protobuf.Timestamp
+	66, // 35: objectives.v1alpha1.GraphErrorBudgetRequest.end:type_name -> google.protobuf.Timestamp
+	28, // 36: objectives.v1alpha1.GraphErrorBudgetResponse.timeseries:type_name -> objectives.v1alpha1.Timeseries
+	66, // 37: objectives.v1alpha1.GraphRateRequest.start:type_name -> google.protobuf.Timestamp
+	66, // 38: objectives.v1alpha1.GraphRateRequest.end:type_name -> google.protobuf.Timestamp
+	28, // 39: objectives.v1alpha1.GraphRateResponse.timeseries:type_name -> objectives.v1alpha1.Timeseries
+	66, // 40: objectives.v1alpha1.GraphErrorsRequest.start:type_name -> google.protobuf.Timestamp
+	66, // 41: objectives.v1alpha1.GraphErrorsRequest.end:type_name -> google.protobuf.Timestamp
+	28, // 42: objectives.v1alpha1.GraphErrorsResponse.timeseries:type_name -> objectives.v1alpha1.Timeseries
+	29, // 43: objectives.v1alpha1.Timeseries.series:type_name -> objectives.v1alpha1.Series
+	66, // 44: objectives.v1alpha1.GraphDurationRequest.start:type_name -> google.protobuf.Timestamp
+	66, // 45: objectives.v1alpha1.GraphDurationRequest.end:type_name -> google.protobuf.Timestamp
+	28, // 46: objectives.v1alpha1.GraphDurationResponse.timeseries:type_name -> objectives.v1alpha1.Timeseries
+	65, // 47: objectives.v1alpha1.BurnRateWindow.for:type_name -> google.protobuf.Duration
+	65, // 48: objectives.v1alpha1.BurnRateWindow.short:type_name -> google.protobuf.Duration
+	65, // 49: objectives.v1alpha1.BurnRateWindow.long:type_name -> google.protobuf.Duration
+	57, // 50: objectives.v1alpha1.BurnRateWindow.labels:type_name -> objectives.v1alpha1.BurnRateWindow.LabelsEntry
+	58, // 51: objectives.v1alpha1.BurnRateWindow.annotations:type_name -> objectives.v1alpha1.BurnRateWindow.AnnotationsEntry
+	35, // 52: objectives.v1alpha1.Composite.components:type_name -> objectives.v1alpha1.CompositeComponent
+	4,  // 53: objectives.v1alpha1.CompositeComponent.objectives:type_name -> objectives.v1alpha1.Objective
+	59, // 54: objectives.v1alpha1.BudgetPolicyStage.labels:type_name -> objectives.v1alpha1.BudgetPolicyStage.LabelsEntry
+	66, // 55: objectives.v1alpha1.BacktestRequest.start:type_name -> google.protobuf.Timestamp
+	66, // 56: objectives.v1alpha1.BacktestRequest.end:type_name -> google.protobuf.Timestamp
+	65, // 57: objectives.v1alpha1.BacktestRequest.step:type_name -> google.protobuf.Duration
+	4,  // 58: objectives.v1alpha1.BacktestRequest.objective:type_name -> objectives.v1alpha1.Objective
+	40, // 59: objectives.v1alpha1.BacktestResponse.alerts:type_name -> objectives.v1alpha1.BacktestAlert
+	32, // 60: objectives.v1alpha1.BacktestAlert.window:type_name -> objectives.v1alpha1.BurnRateWindow
+	41, // 61: objectives.v1alpha1.BacktestAlert.intervals:type_name -> objectives.v1alpha1.BacktestInterval
+	60, // 62: objectives.v1alpha1.BacktestInterval.labels:type_name -> objectives.v1alpha1.BacktestInterval.LabelsEntry
+	1,  // 63: objectives.v1alpha1.BacktestInterval.state:type_name -> objectives.v1alpha1.Alert.State
+	66, // 64: objectives.v1alpha1.BacktestInterval.start:type_name -> google.protobuf.Timestamp
+	66, // 65: objectives.v1alpha1.BacktestInterval.end:type_name -> google.protobuf.Timestamp
+	66, // 66: objectives.v1alpha1.ExportReportRequest.start:type_name -> google.protobuf.Timestamp
+	66, // 67: objectives.v1alpha1.ExportReportRequest.end:type_name -> google.protobuf.Timestamp
+	66, // 68: objectives.v1alpha1.ExportReportResponse.start:type_name -> google.protobuf.Timestamp
+	66, // 69: objectives.v1alpha1.ExportReportResponse.end:type_name -> google.protobuf.Timestamp
+	44, // 70: objectives.v1alpha1.ExportReportResponse.rows:type_name -> objectives.v1alpha1.ReportRow
+	61, // 71: objectives.v1alpha1.ReportRow.labels:type_name -> objectives.v1alpha1.ReportRow.LabelsEntry
+	62, // 72: objectives.v1alpha1.ReportRow.grouping:type_name -> objectives.v1alpha1.ReportRow.GroupingEntry
+	66, // 73: objectives.v1alpha1.GetStatusHistoryRequest.time:type_name -> google.protobuf.Timestamp
+	47, // 74: objectives.v1alpha1.GetStatusHistoryResponse.history:type_name -> objectives.v1alpha1.ObjectiveStatusHistory
+	63, // 75: objectives.v1alpha1.ObjectiveStatusHistory.labels:type_name -> objectives.v1alpha1.ObjectiveStatusHistory.LabelsEntry
+	48, // 76: objectives.v1alpha1.ObjectiveStatusHistory.periods:type_name -> objectives.v1alpha1.PeriodStatus
+	66, // 77: objectives.v1alpha1.PeriodStatus.start:type_name -> google.protobuf.Timestamp
+	66, // 78: objectives.v1alpha1.PeriodStatus.end:type_name -> google.protobuf.Timestamp
+	16, // 79: objectives.v1alpha1.PeriodStatus.availability:type_name -> objectives.v1alpha1.Availability
+	17, // 80: objectives.v1alpha1.PeriodStatus.budget:type_name -> objectives.v1alpha1.Budget
+	66, // 81: objectives.v1alpha1.BatchGetStatusRequest.time:type_name -> google.protobuf.Timestamp
+	53, // 82: objectives.v1alpha1.BatchGetStatusResponse.objectives:type_name -> objectives.v1alpha1.BatchStatus
+	64, // 83: objectives.v1alpha1.BatchStatus.labels:type_name -> objectives.v1alpha1.BatchStatus.LabelsEntry
+	15, // 84: objectives.v1alpha1.BatchStatus.status:type_name -> objectives.v1alpha1.ObjectiveStatus
+	2,  // 85: objectives.v1alpha1.ObjectiveService.List:input_type -> objectives.v1alpha1.ListRequest
+	13, // 86: objectives.v1alpha1.ObjectiveService.GetStatus:input_type -> objectives.v1alpha1.GetStatusRequest
+	51, // 87: objectives.v1alpha1.ObjectiveService.BatchGetStatus:input_type -> objectives.v1alpha1.BatchGetStatusRequest
+	18, // 88: objectives.v1alpha1.ObjectiveService.GetAlerts:input_type -> objectives.v1alpha1.GetAlertsRequest
+	22, // 89: objectives.v1alpha1.ObjectiveService.GraphErrorBudget:input_type -> objectives.v1alpha1.GraphErrorBudgetRequest
+	24, // 90: objectives.v1alpha1.ObjectiveService.GraphRate:input_type -> objectives.v1alpha1.GraphRateRequest
+	26, // 91: objectives.v1alpha1.ObjectiveService.GraphErrors:input_type -> objectives.v1alpha1.GraphErrorsRequest
+	30, // 92: objectives.v1alpha1.ObjectiveService.GraphDuration:input_type -> objectives.v1alpha1.GraphDurationRequest
+	38, // 93: objectives.v1alpha1.ObjectiveService.Backtest:input_type -> objectives.v1alpha1.BacktestRequest
+	42, // 94: objectives.v1alpha1.ObjectiveService.ExportReport:input_type -> objectives.v1alpha1.ExportReportRequest
+	45, // 95: objectives.v1alpha1.ObjectiveService.GetStatusHistory:input_type -> objectives.v1alpha1.GetStatusHistoryRequest
+	49, // 96: objectives.v1alpha1.ObjectiveService.WatchStatus:input_type -> objectives.v1alpha1.WatchStatusRequest
+	50, // 97: objectives.v1alpha1.ObjectiveService.WatchAlerts:input_type -> objectives.v1alpha1.WatchAlertsRequest
+	2,  // 98: objectives.v1alpha1.ObjectiveBackendService.List:input_type -> objectives.v1alpha1.ListRequest
+	3,  // 99: objectives.v1alpha1.ObjectiveService.List:output_type -> objectives.v1alpha1.ListResponse
+	14, // 100: objectives.v1alpha1.ObjectiveService.GetStatus:output_type -> objectives.v1alpha1.GetStatusResponse
+	52, // 101: objectives.v1alpha1.ObjectiveService.BatchGetStatus:output_type -> objectives.v1alpha1.BatchGetStatusResponse
+	19, // 102: objectives.v1alpha1.ObjectiveService.GetAlerts:output_type -> objectives.v1alpha1.GetAlertsResponse
+	23, // 103: objectives.v1alpha1.ObjectiveService.GraphErrorBudget:output_type -> objectives.v1alpha1.GraphErrorBudgetResponse
+	25, // 104: objectives.v1alpha1.ObjectiveService.GraphRate:output_type -> objectives.v1alpha1.GraphRateResponse
+	27, // 105: objectives.v1alpha1.ObjectiveService.GraphErrors:output_type -> objectives.v1alpha1.GraphErrorsResponse
+	31, // 106: objectives.v1alpha1.ObjectiveService.GraphDuration:output_type -> objectives.v1alpha1.GraphDurationResponse
+	39, // 107: objectives.v1alpha1.ObjectiveService.Backtest:output_type -> objectives.v1alpha1.BacktestResponse
+	43, // 108: objectives.v1alpha1.ObjectiveService.ExportReport:output_type -> objectives.v1alpha1.ExportReportResponse
+	46, // 109: objectives.v1alpha1.ObjectiveService.GetStatusHistory:output_type -> objectives.v1alpha1.GetStatusHistoryResponse
+	14, // 110: objectives.v1alpha1.ObjectiveService.WatchStatus:output_type -> objectives.v1alpha1.GetStatusResponse
+	19, // 111: objectives.v1alpha1.ObjectiveService.WatchAlerts:output_type -> objectives.v1alpha1.GetAlertsResponse
+	3,  // 112: objectives.v1alpha1.ObjectiveBackendService.List:output_type -> objectives.v1alpha1.ListResponse
+	99, // [99:113] is the sub-list for method output_type
+	85, // [85:99] is the sub-list for method input_type
+	85, // [85:85] is the sub-list for extension type_name
+	85, // [85:85] is the sub-list for extension extendee
+	0,  // [0:85] is the sub-list for field type_name
 }
 
 func init() { file_objectives_v1alpha1_objectives_proto_init() }
@@ -2254,6 +3988,8 @@ func file_objectives_v1alpha1_objectives_proto_init() {
 		(*Indicator_Latency)(nil),
 		(*Indicator_BoolGauge)(nil),
 		(*Indicator_LatencyNative)(nil),
+		(*Indicator_Composite)(nil),
+		(*Indicator_Raw)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -2261,7 +3997,7 @@ func file_objectives_v1alpha1_objectives_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_objectives_v1alpha1_objectives_proto_rawDesc), len(file_objectives_v1alpha1_objectives_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   33,
+			NumMessages:   63,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
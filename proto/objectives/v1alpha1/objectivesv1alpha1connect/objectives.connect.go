@@ -40,6 +40,9 @@ const (
 	// ObjectiveServiceGetStatusProcedure is the fully-qualified name of the ObjectiveService's
 	// GetStatus RPC.
 	ObjectiveServiceGetStatusProcedure = "/objectives.v1alpha1.ObjectiveService/GetStatus"
+	// ObjectiveServiceBatchGetStatusProcedure is the fully-qualified name of the ObjectiveService's
+	// BatchGetStatus RPC.
+	ObjectiveServiceBatchGetStatusProcedure = "/objectives.v1alpha1.ObjectiveService/BatchGetStatus"
 	// ObjectiveServiceGetAlertsProcedure is the fully-qualified name of the ObjectiveService's
 	// GetAlerts RPC.
 	ObjectiveServiceGetAlertsProcedure = "/objectives.v1alpha1.ObjectiveService/GetAlerts"
@@ -55,6 +58,21 @@ const (
 	// ObjectiveServiceGraphDurationProcedure is the fully-qualified name of the ObjectiveService's
 	// GraphDuration RPC.
 	ObjectiveServiceGraphDurationProcedure = "/objectives.v1alpha1.ObjectiveService/GraphDuration"
+	// ObjectiveServiceBacktestProcedure is the fully-qualified name of the ObjectiveService's Backtest
+	// RPC.
+	ObjectiveServiceBacktestProcedure = "/objectives.v1alpha1.ObjectiveService/Backtest"
+	// ObjectiveServiceExportReportProcedure is the fully-qualified name of the ObjectiveService's
+	// ExportReport RPC.
+	ObjectiveServiceExportReportProcedure = "/objectives.v1alpha1.ObjectiveService/ExportReport"
+	// ObjectiveServiceGetStatusHistoryProcedure is the fully-qualified name of the ObjectiveService's
+	// GetStatusHistory RPC.
+	ObjectiveServiceGetStatusHistoryProcedure = "/objectives.v1alpha1.ObjectiveService/GetStatusHistory"
+	// ObjectiveServiceWatchStatusProcedure is the fully-qualified name of the ObjectiveService's
+	// WatchStatus RPC.
+	ObjectiveServiceWatchStatusProcedure = "/objectives.v1alpha1.ObjectiveService/WatchStatus"
+	// ObjectiveServiceWatchAlertsProcedure is the fully-qualified name of the ObjectiveService's
+	// WatchAlerts RPC.
+	ObjectiveServiceWatchAlertsProcedure = "/objectives.v1alpha1.ObjectiveService/WatchAlerts"
 	// ObjectiveBackendServiceListProcedure is the fully-qualified name of the ObjectiveBackendService's
 	// List RPC.
 	ObjectiveBackendServiceListProcedure = "/objectives.v1alpha1.ObjectiveBackendService/List"
@@ -64,11 +82,20 @@ const (
 type ObjectiveServiceClient interface {
 	List(context.Context, *connect.Request[v1alpha1.ListRequest]) (*connect.Response[v1alpha1.ListResponse], error)
 	GetStatus(context.Context, *connect.Request[v1alpha1.GetStatusRequest]) (*connect.Response[v1alpha1.GetStatusResponse], error)
+	// BatchGetStatus returns the status of all objectives matching expr, querying objectives of the same kind together.
+	BatchGetStatus(context.Context, *connect.Request[v1alpha1.BatchGetStatusRequest]) (*connect.Response[v1alpha1.BatchGetStatusResponse], error)
 	GetAlerts(context.Context, *connect.Request[v1alpha1.GetAlertsRequest]) (*connect.Response[v1alpha1.GetAlertsResponse], error)
 	GraphErrorBudget(context.Context, *connect.Request[v1alpha1.GraphErrorBudgetRequest]) (*connect.Response[v1alpha1.GraphErrorBudgetResponse], error)
 	GraphRate(context.Context, *connect.Request[v1alpha1.GraphRateRequest]) (*connect.Response[v1alpha1.GraphRateResponse], error)
 	GraphErrors(context.Context, *connect.Request[v1alpha1.GraphErrorsRequest]) (*connect.Response[v1alpha1.GraphErrorsResponse], error)
 	GraphDuration(context.Context, *connect.Request[v1alpha1.GraphDurationRequest]) (*connect.Response[v1alpha1.GraphDurationResponse], error)
+	Backtest(context.Context, *connect.Request[v1alpha1.BacktestRequest]) (*connect.Response[v1alpha1.BacktestResponse], error)
+	ExportReport(context.Context, *connect.Request[v1alpha1.ExportReportRequest]) (*connect.Response[v1alpha1.ExportReportResponse], error)
+	GetStatusHistory(context.Context, *connect.Request[v1alpha1.GetStatusHistoryRequest]) (*connect.Response[v1alpha1.GetStatusHistoryResponse], error)
+	// WatchStatus streams the status like GetStatus returns it, whenever it changes.
+	WatchStatus(context.Context, *connect.Request[v1alpha1.WatchStatusRequest]) (*connect.ServerStreamForClient[v1alpha1.GetStatusResponse], error)
+	// WatchAlerts streams the alerts like GetAlerts returns them, whenever they change.
+	WatchAlerts(context.Context, *connect.Request[v1alpha1.WatchAlertsRequest]) (*connect.ServerStreamForClient[v1alpha1.GetAlertsResponse], error)
 }
 
 // NewObjectiveServiceClient constructs a client for the objectives.v1alpha1.ObjectiveService
@@ -94,6 +121,12 @@ func NewObjectiveServiceClient(httpClient connect.HTTPClient, baseURL string, op
 			connect.WithSchema(objectiveServiceMethods.ByName("GetStatus")),
 			connect.WithClientOptions(opts...),
 		),
+		batchGetStatus: connect.NewClient[v1alpha1.BatchGetStatusRequest, v1alpha1.BatchGetStatusResponse](
+			httpClient,
+			baseURL+ObjectiveServiceBatchGetStatusProcedure,
+			connect.WithSchema(objectiveServiceMethods.ByName("BatchGetStatus")),
+			connect.WithClientOptions(opts...),
+		),
 		getAlerts: connect.NewClient[v1alpha1.GetAlertsRequest, v1alpha1.GetAlertsResponse](
 			httpClient,
 			baseURL+ObjectiveServiceGetAlertsProcedure,
@@ -124,6 +157,36 @@ func NewObjectiveServiceClient(httpClient connect.HTTPClient, baseURL string, op
 			connect.WithSchema(objectiveServiceMethods.ByName("GraphDuration")),
 			connect.WithClientOptions(opts...),
 		),
+		backtest: connect.NewClient[v1alpha1.BacktestRequest, v1alpha1.BacktestResponse](
+			httpClient,
+			baseURL+ObjectiveServiceBacktestProcedure,
+			connect.WithSchema(objectiveServiceMethods.ByName("Backtest")),
+			connect.WithClientOptions(opts...),
+		),
+		exportReport: connect.NewClient[v1alpha1.ExportReportRequest, v1alpha1.ExportReportResponse](
+			httpClient,
+			baseURL+ObjectiveServiceExportReportProcedure,
+			connect.WithSchema(objectiveServiceMethods.ByName("ExportReport")),
+			connect.WithClientOptions(opts...),
+		),
+		getStatusHistory: connect.NewClient[v1alpha1.GetStatusHistoryRequest, v1alpha1.GetStatusHistoryResponse](
+			httpClient,
+			baseURL+ObjectiveServiceGetStatusHistoryProcedure,
+			connect.WithSchema(objectiveServiceMethods.ByName("GetStatusHistory")),
+			connect.WithClientOptions(opts...),
+		),
+		watchStatus: connect.NewClient[v1alpha1.WatchStatusRequest, v1alpha1.GetStatusResponse](
+			httpClient,
+			baseURL+ObjectiveServiceWatchStatusProcedure,
+			connect.WithSchema(objectiveServiceMethods.ByName("WatchStatus")),
+			connect.WithClientOptions(opts...),
+		),
+		watchAlerts: connect.NewClient[v1alpha1.WatchAlertsRequest, v1alpha1.GetAlertsResponse](
+			httpClient,
+			baseURL+ObjectiveServiceWatchAlertsProcedure,
+			connect.WithSchema(objectiveServiceMethods.ByName("WatchAlerts")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -131,11 +194,17 @@ func NewObjectiveServiceClient(httpClient connect.HTTPClient, baseURL string, op
 type objectiveServiceClient struct {
 	list             *connect.Client[v1alpha1.ListRequest, v1alpha1.ListResponse]
 	getStatus        *connect.Client[v1alpha1.GetStatusRequest, v1alpha1.GetStatusResponse]
+	batchGetStatus   *connect.Client[v1alpha1.BatchGetStatusRequest, v1alpha1.BatchGetStatusResponse]
 	getAlerts        *connect.Client[v1alpha1.GetAlertsRequest, v1alpha1.GetAlertsResponse]
 	graphErrorBudget *connect.Client[v1alpha1.GraphErrorBudgetRequest, v1alpha1.GraphErrorBudgetResponse]
 	graphRate        *connect.Client[v1alpha1.GraphRateRequest, v1alpha1.GraphRateResponse]
 	graphErrors      *connect.Client[v1alpha1.GraphErrorsRequest, v1alpha1.GraphErrorsResponse]
 	graphDuration    *connect.Client[v1alpha1.GraphDurationRequest, v1alpha1.GraphDurationResponse]
+	backtest         *connect.Client[v1alpha1.BacktestRequest, v1alpha1.BacktestResponse]
+	exportReport     *connect.Client[v1alpha1.ExportReportRequest, v1alpha1.ExportReportResponse]
+	getStatusHistory *connect.Client[v1alpha1.GetStatusHistoryRequest, v1alpha1.GetStatusHistoryResponse]
+	watchStatus      *connect.Client[v1alpha1.WatchStatusRequest, v1alpha1.GetStatusResponse]
+	watchAlerts      *connect.Client[v1alpha1.WatchAlertsRequest, v1alpha1.GetAlertsResponse]
 }
 
 // List calls objectives.v1alpha1.ObjectiveService.List.
@@ -148,6 +217,11 @@ func (c *objectiveServiceClient) GetStatus(ctx context.Context, req *connect.Req
 	return c.getStatus.CallUnary(ctx, req)
 }
 
+// BatchGetStatus calls objectives.v1alpha1.ObjectiveService.BatchGetStatus.
+func (c *objectiveServiceClient) BatchGetStatus(ctx context.Context, req *connect.Request[v1alpha1.BatchGetStatusRequest]) (*connect.Response[v1alpha1.BatchGetStatusResponse], error) {
+	return c.batchGetStatus.CallUnary(ctx, req)
+}
+
 // GetAlerts calls objectives.v1alpha1.ObjectiveService.GetAlerts.
 func (c *objectiveServiceClient) GetAlerts(ctx context.Context, req *connect.Request[v1alpha1.GetAlertsRequest]) (*connect.Response[v1alpha1.GetAlertsResponse], error) {
 	return c.getAlerts.CallUnary(ctx, req)
@@ -173,15 +247,49 @@ func (c *objectiveServiceClient) GraphDuration(ctx context.Context, req *connect
 	return c.graphDuration.CallUnary(ctx, req)
 }
 
+// Backtest calls objectives.v1alpha1.ObjectiveService.Backtest.
+func (c *objectiveServiceClient) Backtest(ctx context.Context, req *connect.Request[v1alpha1.BacktestRequest]) (*connect.Response[v1alpha1.BacktestResponse], error) {
+	return c.backtest.CallUnary(ctx, req)
+}
+
+// ExportReport calls objectives.v1alpha1.ObjectiveService.ExportReport.
+func (c *objectiveServiceClient) ExportReport(ctx context.Context, req *connect.Request[v1alpha1.ExportReportRequest]) (*connect.Response[v1alpha1.ExportReportResponse], error) {
+	return c.exportReport.CallUnary(ctx, req)
+}
+
+// GetStatusHistory calls objectives.v1alpha1.ObjectiveService.GetStatusHistory.
+func (c *objectiveServiceClient) GetStatusHistory(ctx context.Context, req *connect.Request[v1alpha1.GetStatusHistoryRequest]) (*connect.Response[v1alpha1.GetStatusHistoryResponse], error) {
+	return c.getStatusHistory.CallUnary(ctx, req)
+}
+
+// WatchStatus calls objectives.v1alpha1.ObjectiveService.WatchStatus.
+func (c *objectiveServiceClient) WatchStatus(ctx context.Context, req *connect.Request[v1alpha1.WatchStatusRequest]) (*connect.ServerStreamForClient[v1alpha1.GetStatusResponse], error) {
+	return c.watchStatus.CallServerStream(ctx, req)
+}
+
+// WatchAlerts calls objectives.v1alpha1.ObjectiveService.WatchAlerts.
+func (c *objectiveServiceClient) WatchAlerts(ctx context.Context, req *connect.Request[v1alpha1.WatchAlertsRequest]) (*connect.ServerStreamForClient[v1alpha1.GetAlertsResponse], error) {
+	return c.watchAlerts.CallServerStream(ctx, req)
+}
+
 // ObjectiveServiceHandler is an implementation of the objectives.v1alpha1.ObjectiveService service.
 type ObjectiveServiceHandler interface {
 	List(context.Context, *connect.Request[v1alpha1.ListRequest]) (*connect.Response[v1alpha1.ListResponse], error)
 	GetStatus(context.Context, *connect.Request[v1alpha1.GetStatusRequest]) (*connect.Response[v1alpha1.GetStatusResponse], error)
+	// BatchGetStatus returns the status of all objectives matching expr, querying objectives of the same kind together.
+	BatchGetStatus(context.Context, *connect.Request[v1alpha1.BatchGetStatusRequest]) (*connect.Response[v1alpha1.BatchGetStatusResponse], error)
 	GetAlerts(context.Context, *connect.Request[v1alpha1.GetAlertsRequest]) (*connect.Response[v1alpha1.GetAlertsResponse], error)
 	GraphErrorBudget(context.Context, *connect.Request[v1alpha1.GraphErrorBudgetRequest]) (*connect.Response[v1alpha1.GraphErrorBudgetResponse], error)
 	GraphRate(context.Context, *connect.Request[v1alpha1.GraphRateRequest]) (*connect.Response[v1alpha1.GraphRateResponse], error)
 	GraphErrors(context.Context, *connect.Request[v1alpha1.GraphErrorsRequest]) (*connect.Response[v1alpha1.GraphErrorsResponse], error)
 	GraphDuration(context.Context, *connect.Request[v1alpha1.GraphDurationRequest]) (*connect.Response[v1alpha1.GraphDurationResponse], error)
+	Backtest(context.Context, *connect.Request[v1alpha1.BacktestRequest]) (*connect.Response[v1alpha1.BacktestResponse], error)
+	ExportReport(context.Context, *connect.Request[v1alpha1.ExportReportRequest]) (*connect.Response[v1alpha1.ExportReportResponse], error)
+	GetStatusHistory(context.Context, *connect.Request[v1alpha1.GetStatusHistoryRequest]) (*connect.Response[v1alpha1.GetStatusHistoryResponse], error)
+	// WatchStatus streams the status like GetStatus returns it, whenever it changes.
+	WatchStatus(context.Context, *connect.Request[v1alpha1.WatchStatusRequest], *connect.ServerStream[v1alpha1.GetStatusResponse]) error
+	// WatchAlerts streams the alerts like GetAlerts returns them, whenever they change.
+	WatchAlerts(context.Context, *connect.Request[v1alpha1.WatchAlertsRequest], *connect.ServerStream[v1alpha1.GetAlertsResponse]) error
 }
 
 // NewObjectiveServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -203,6 +311,12 @@ func NewObjectiveServiceHandler(svc ObjectiveServiceHandler, opts ...connect.Han
 		connect.WithSchema(objectiveServiceMethods.ByName("GetStatus")),
 		connect.WithHandlerOptions(opts...),
 	)
+	objectiveServiceBatchGetStatusHandler := connect.NewUnaryHandler(
+		ObjectiveServiceBatchGetStatusProcedure,
+		svc.BatchGetStatus,
+		connect.WithSchema(objectiveServiceMethods.ByName("BatchGetStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
 	objectiveServiceGetAlertsHandler := connect.NewUnaryHandler(
 		ObjectiveServiceGetAlertsProcedure,
 		svc.GetAlerts,
@@ -233,12 +347,44 @@ func NewObjectiveServiceHandler(svc ObjectiveServiceHandler, opts ...connect.Han
 		connect.WithSchema(objectiveServiceMethods.ByName("GraphDuration")),
 		connect.WithHandlerOptions(opts...),
 	)
+	objectiveServiceBacktestHandler := connect.NewUnaryHandler(
+		ObjectiveServiceBacktestProcedure,
+		svc.Backtest,
+		connect.WithSchema(objectiveServiceMethods.ByName("Backtest")),
+		connect.WithHandlerOptions(opts...),
+	)
+	objectiveServiceExportReportHandler := connect.NewUnaryHandler(
+		ObjectiveServiceExportReportProcedure,
+		svc.ExportReport,
+		connect.WithSchema(objectiveServiceMethods.ByName("ExportReport")),
+		connect.WithHandlerOptions(opts...),
+	)
+	objectiveServiceGetStatusHistoryHandler := connect.NewUnaryHandler(
+		ObjectiveServiceGetStatusHistoryProcedure,
+		svc.GetStatusHistory,
+		connect.WithSchema(objectiveServiceMethods.ByName("GetStatusHistory")),
+		connect.WithHandlerOptions(opts...),
+	)
+	objectiveServiceWatchStatusHandler := connect.NewServerStreamHandler(
+		ObjectiveServiceWatchStatusProcedure,
+		svc.WatchStatus,
+		connect.WithSchema(objectiveServiceMethods.ByName("WatchStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
+	objectiveServiceWatchAlertsHandler := connect.NewServerStreamHandler(
+		ObjectiveServiceWatchAlertsProcedure,
+		svc.WatchAlerts,
+		connect.WithSchema(objectiveServiceMethods.ByName("WatchAlerts")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/objectives.v1alpha1.ObjectiveService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case ObjectiveServiceListProcedure:
 			objectiveServiceListHandler.ServeHTTP(w, r)
 		case ObjectiveServiceGetStatusProcedure:
 			objectiveServiceGetStatusHandler.ServeHTTP(w, r)
+		case ObjectiveServiceBatchGetStatusProcedure:
+			objectiveServiceBatchGetStatusHandler.ServeHTTP(w, r)
 		case ObjectiveServiceGetAlertsProcedure:
 			objectiveServiceGetAlertsHandler.ServeHTTP(w, r)
 		case ObjectiveServiceGraphErrorBudgetProcedure:
@@ -249,6 +395,16 @@ func NewObjectiveServiceHandler(svc ObjectiveServiceHandler, opts ...connect.Han
 			objectiveServiceGraphErrorsHandler.ServeHTTP(w, r)
 		case ObjectiveServiceGraphDurationProcedure:
 			objectiveServiceGraphDurationHandler.ServeHTTP(w, r)
+		case ObjectiveServiceBacktestProcedure:
+			objectiveServiceBacktestHandler.ServeHTTP(w, r)
+		case ObjectiveServiceExportReportProcedure:
+			objectiveServiceExportReportHandler.ServeHTTP(w, r)
+		case ObjectiveServiceGetStatusHistoryProcedure:
+			objectiveServiceGetStatusHistoryHandler.ServeHTTP(w, r)
+		case ObjectiveServiceWatchStatusProcedure:
+			objectiveServiceWatchStatusHandler.ServeHTTP(w, r)
+		case ObjectiveServiceWatchAlertsProcedure:
+			objectiveServiceWatchAlertsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -266,6 +422,10 @@ func (UnimplementedObjectiveServiceHandler) GetStatus(context.Context, *connect.
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("objectives.v1alpha1.ObjectiveService.GetStatus is not implemented"))
 }
 
+func (UnimplementedObjectiveServiceHandler) BatchGetStatus(context.Context, *connect.Request[v1alpha1.BatchGetStatusRequest]) (*connect.Response[v1alpha1.BatchGetStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("objectives.v1alpha1.ObjectiveService.BatchGetStatus is not implemented"))
+}
+
 func (UnimplementedObjectiveServiceHandler) GetAlerts(context.Context, *connect.Request[v1alpha1.GetAlertsRequest]) (*connect.Response[v1alpha1.GetAlertsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("objectives.v1alpha1.ObjectiveService.GetAlerts is not implemented"))
 }
@@ -286,6 +446,26 @@ func (UnimplementedObjectiveServiceHandler) GraphDuration(context.Context, *conn
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("objectives.v1alpha1.ObjectiveService.GraphDuration is not implemented"))
 }
 
+func (UnimplementedObjectiveServiceHandler) Backtest(context.Context, *connect.Request[v1alpha1.BacktestRequest]) (*connect.Response[v1alpha1.BacktestResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("objectives.v1alpha1.ObjectiveService.Backtest is not implemented"))
+}
+
+func (UnimplementedObjectiveServiceHandler) ExportReport(context.Context, *connect.Request[v1alpha1.ExportReportRequest]) (*connect.Response[v1alpha1.ExportReportResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("objectives.v1alpha1.ObjectiveService.ExportReport is not implemented"))
+}
+
+func (UnimplementedObjectiveServiceHandler) GetStatusHistory(context.Context, *connect.Request[v1alpha1.GetStatusHistoryRequest]) (*connect.Response[v1alpha1.GetStatusHistoryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("objectives.v1alpha1.ObjectiveService.GetStatusHistory is not implemented"))
+}
+
+func (UnimplementedObjectiveServiceHandler) WatchStatus(context.Context, *connect.Request[v1alpha1.WatchStatusRequest], *connect.ServerStream[v1alpha1.GetStatusResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("objectives.v1alpha1.ObjectiveService.WatchStatus is not implemented"))
+}
+
+func (UnimplementedObjectiveServiceHandler) WatchAlerts(context.Context, *connect.Request[v1alpha1.WatchAlertsRequest], *connect.ServerStream[v1alpha1.GetAlertsResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("objectives.v1alpha1.ObjectiveService.WatchAlerts is not implemented"))
+}
+
 // ObjectiveBackendServiceClient is a client for the objectives.v1alpha1.ObjectiveBackendService
 // service.
 type ObjectiveBackendServiceClient interface {
@@ -25,6 +25,7 @@ type QueryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
 	Time          int64                  `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	Datasource    string                 `protobuf:"bytes,3,opt,name=datasource,proto3" json:"datasource,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -73,6 +74,13 @@ func (x *QueryRequest) GetTime() int64 {
 	return 0
 }
 
+func (x *QueryRequest) GetDatasource() string {
+	if x != nil {
+		return x.Datasource
+	}
+	return ""
+}
+
 type QueryResponse struct {
 	state    protoimpl.MessageState `protogen:"open.v1"`
 	Warnings []string               `protobuf:"bytes,1,rep,name=warnings,proto3" json:"warnings,omitempty"`
@@ -201,6 +209,7 @@ type QueryRangeRequest struct {
 	Start         int64                  `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
 	End           int64                  `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
 	Step          int64                  `protobuf:"varint,4,opt,name=step,proto3" json:"step,omitempty"`
+	Datasource    string                 `protobuf:"bytes,5,opt,name=datasource,proto3" json:"datasource,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -263,6 +272,13 @@ func (x *QueryRangeRequest) GetStep() int64 {
 	return 0
 }
 
+func (x *QueryRangeRequest) GetDatasource() string {
+	if x != nil {
+		return x.Datasource
+	}
+	return ""
+}
+
 type QueryRangeResponse struct {
 	state    protoimpl.MessageState `protogen:"open.v1"`
 	Warnings []string               `protobuf:"bytes,1,rep,name=warnings,proto3" json:"warnings,omitempty"`
@@ -700,22 +716,28 @@ var File_prometheus_v1_prometheus_proto protoreflect.FileDescriptor
 
 const file_prometheus_v1_prometheus_proto_rawDesc = "" +
 	"\n" +
-	"\x1eprometheus/v1/prometheus.proto\x12\rprometheus.v1\"8\n" +
+	"\x1eprometheus/v1/prometheus.proto\x12\rprometheus.v1\"X\n" +
 	"\fQueryRequest\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
-	"\x04time\x18\x02 \x01(\x03R\x04time\"\xfe\x01\n" +
+	"\x04time\x18\x02 \x01(\x03R\x04time\x12\x1e\n" +
+	"\n" +
+	"datasource\x18\x03 \x01(\tR\n" +
+	"datasource\"\xfe\x01\n" +
 	"\rQueryResponse\x12\x1a\n" +
 	"\bwarnings\x18\x01 \x03(\tR\bwarnings\x123\n" +
 	"\x06scalar\x18\x02 \x01(\v2\x19.prometheus.v1.SamplePairH\x00R\x06scalar\x12/\n" +
 	"\x06vector\x18\x03 \x01(\v2\x15.prometheus.v1.VectorH\x00R\x06vector\x12/\n" +
 	"\x06matrix\x18\x04 \x01(\v2\x15.prometheus.v1.MatrixH\x00R\x06matrix\x12/\n" +
 	"\x06string\x18\x05 \x01(\v2\x15.prometheus.v1.StringH\x00R\x06stringB\t\n" +
-	"\aoptions\"e\n" +
+	"\aoptions\"\x85\x01\n" +
 	"\x11QueryRangeRequest\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
 	"\x05start\x18\x02 \x01(\x03R\x05start\x12\x10\n" +
 	"\x03end\x18\x03 \x01(\x03R\x03end\x12\x12\n" +
-	"\x04step\x18\x04 \x01(\x03R\x04step\"\x83\x02\n" +
+	"\x04step\x18\x04 \x01(\x03R\x04step\x12\x1e\n" +
+	"\n" +
+	"datasource\x18\x05 \x01(\tR\n" +
+	"datasource\"\x83\x02\n" +
 	"\x12QueryRangeResponse\x12\x1a\n" +
 	"\bwarnings\x18\x01 \x03(\tR\bwarnings\x123\n" +
 	"\x06scalar\x18\x02 \x01(\v2\x19.prometheus.v1.SamplePairH\x00R\x06scalar\x12/\n" +
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/common/model"
+
+	"github.com/pyrra-dev/pyrra/slo"
+)
+
+// recordingRulesMode is whether graphs are queried from Pyrra's recording rules instead of the raw metrics.
+// The status and error budget are always queried from the recording rules.
+type recordingRulesMode string
+
+const (
+	recordingRulesAuto   recordingRulesMode = "auto"
+	recordingRulesAlways recordingRulesMode = "always"
+	recordingRulesNever  recordingRulesMode = "never"
+)
+
+// recordingRulesCache is how long it is cached that an objective's recording rules exist.
+const recordingRulesCache = 5 * time.Minute
+
+// useRecordingRules returns whether the objective's graphs are queried from its recording rules.
+// In auto mode an objective's recording rules are used once its shortest burn rate has been recorded.
+// Only found recording rules are cached, as the cache skips empty results. Objectives without them are
+// detected again for every graph, so that new objectives use their recording rules as soon as they're recorded.
+func (s *objectiveServer) useRecordingRules(ctx context.Context, objective slo.Objective) bool {
+	if objective.IndicatorType() == slo.Composite {
+		return false
+	}
+
+	switch s.recordingRules {
+	case recordingRulesAlways:
+		return true
+	case recordingRulesAuto:
+		query := fmt.Sprintf(`group(%s{slo=%q})`, objective.BurnrateName(objective.BurnrateWindow(0)), objective.Name())
+		value, _, err := s.promAPI.Query(contextSetPromCache(ctx, recordingRulesCache), query, time.Now())
+		if err != nil {
+			level.Warn(s.logger).Log("msg", "failed to detect recording rules", "query", query, "err", err)
+			return false
+		}
+		vector, ok := value.(model.Vector)
+		return ok && len(vector) > 0
+	default:
+		return false
+	}
+}
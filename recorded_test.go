@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/go-kit/log"
+	prometheusapiv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	objectivesv1alpha1 "github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1"
+	"github.com/pyrra-dev/pyrra/slo"
+)
+
+// recordedPrometheus answers instant queries like reportPrometheus and range queries with a single series for every query.
+type recordedPrometheus reportPrometheus
+
+func (p recordedPrometheus) Query(ctx context.Context, query string, ts time.Time, opts ...prometheusapiv1.Option) (model.Value, prometheusapiv1.Warnings, error) {
+	return reportPrometheus(p).Query(ctx, query, ts, opts...)
+}
+
+func (p recordedPrometheus) QueryRange(_ context.Context, _ string, r prometheusapiv1.Range, _ ...prometheusapiv1.Option) (model.Value, prometheusapiv1.Warnings, error) {
+	return model.Matrix{{
+		Metric: model.Metric{},
+		Values: activeSamples(r.Start, r.End, r.Step),
+	}}, nil, nil
+}
+
+func TestObjectiveServer_RecordingRules(t *testing.T) {
+	objective := batchObjective("a", "default", "api", 28*24*time.Hour)
+	objective.PerformanceOverAccuracy = true
+
+	grouped := batchObjective("b", "default", "api", 28*24*time.Hour, "handler")
+
+	detected := recordedPrometheus{
+		`group(http_requests:burnrate5m{slo="a"})`: {{Metric: model.Metric{"slo": "a"}, Value: 1}},
+		`group(http_requests:burnrate5m{slo="b"})`: {{Metric: model.Metric{"slo": "b"}, Value: 1}},
+	}
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(2 * 24 * time.Hour)
+
+	testcases := []struct {
+		name       string
+		mode       recordingRulesMode
+		prometheus recordedPrometheus
+		objective  slo.Objective
+		grouping   string
+		rate       string
+		errors     string
+	}{{
+		name:       "never",
+		mode:       recordingRulesNever,
+		prometheus: detected,
+		objective:  objective,
+		rate:       `sum by (code) (rate(http_requests_total{job="api"}[30m])) > 0`,
+		errors:     `sum by (code) (rate(http_requests_total{code=~"5..",job="api"}[30m])) / scalar(sum(rate(http_requests_total{job="api"}[30m]))) > 0`,
+	}, {
+		name:       "always",
+		mode:       recordingRulesAlways,
+		prometheus: recordedPrometheus{},
+		objective:  objective,
+		rate:       `sum by (code) (avg_over_time(http_requests:increase5m{job="api",slo="a"}[30m])) / 300 > 0`,
+		errors:     `sum(http_requests:burnrate30m{job="api",slo="a"})`,
+	}, {
+		name:       "autoDetected",
+		mode:       recordingRulesAuto,
+		prometheus: detected,
+		objective:  objective,
+		rate:       `sum by (code) (avg_over_time(http_requests:increase5m{job="api",slo="a"}[30m])) / 300 > 0`,
+		errors:     `sum(http_requests:burnrate30m{job="api",slo="a"})`,
+	}, {
+		name:       "autoMissing",
+		mode:       recordingRulesAuto,
+		prometheus: recordedPrometheus{},
+		objective:  objective,
+		rate:       `sum by (code) (rate(http_requests_total{job="api"}[30m])) > 0`,
+		errors:     `sum by (code) (rate(http_requests_total{code=~"5..",job="api"}[30m])) / scalar(sum(rate(http_requests_total{job="api"}[30m]))) > 0`,
+	}, {
+		name:       "groupingAll",
+		mode:       recordingRulesAlways,
+		prometheus: detected,
+		objective:  grouped,
+		rate:       `sum by (code) (rate(http_requests_total{job="api"}[30m])) > 0`,
+		errors:     `sum by (code) (rate(http_requests_total{code=~"5..",job="api"}[30m])) / scalar(sum(rate(http_requests_total{job="api"}[30m]))) > 0`,
+	}, {
+		name:       "groupingSingle",
+		mode:       recordingRulesAlways,
+		prometheus: detected,
+		objective:  grouped,
+		grouping:   `{handler="/api"}`,
+		rate:       `sum by (code) (rate(http_requests_total{handler="/api",job="api"}[30m])) > 0`,
+		errors:     `sum(http_requests:burnrate30m{handler="/api",job="api",slo="b"})`,
+	}}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &objectiveServer{
+				logger:         log.NewNopLogger(),
+				promAPI:        &promCache{api: tc.prometheus},
+				client:         staticObjectives{tc.objective},
+				recordingRules: tc.mode,
+			}
+
+			rate, err := s.GraphRate(context.Background(), connect.NewRequest(&objectivesv1alpha1.GraphRateRequest{
+				Grouping: tc.grouping,
+				Start:    timestamppb.New(start),
+				End:      timestamppb.New(end),
+			}))
+			require.NoError(t, err)
+			require.Equal(t, tc.rate, rate.Msg.Timeseries.Query)
+
+			errs, err := s.GraphErrors(context.Background(), connect.NewRequest(&objectivesv1alpha1.GraphErrorsRequest{
+				Grouping: tc.grouping,
+				Start:    timestamppb.New(start),
+				End:      timestamppb.New(end),
+			}))
+			require.NoError(t, err)
+			require.Equal(t, tc.errors, errs.Msg.Timeseries.Query)
+		})
+	}
+}
+
+// countingPrometheus counts the instant queries answered like reportPrometheus.
+type countingPrometheus struct {
+	reportPrometheus
+	queries int
+}
+
+func (p *countingPrometheus) Query(ctx context.Context, query string, ts time.Time, opts ...prometheusapiv1.Option) (model.Value, prometheusapiv1.Warnings, error) {
+	p.queries++
+	return p.reportPrometheus.Query(ctx, query, ts, opts...)
+}
+
+func TestObjectiveServer_RecordingRulesCache(t *testing.T) {
+	cache, err := ristretto.NewCache(&ristretto.Config[string, any]{
+		NumCounters: 100,
+		MaxCost:     1 << 20,
+		BufferItems: 64,
+	})
+	require.NoError(t, err)
+	defer cache.Close()
+
+	objective := batchObjective("a", "default", "api", 28*24*time.Hour)
+	prometheus := &countingPrometheus{reportPrometheus: reportPrometheus{}}
+	s := &objectiveServer{
+		logger:         log.NewNopLogger(),
+		promAPI:        &promCache{api: prometheus, cache: cache},
+		recordingRules: recordingRulesAuto,
+	}
+
+	// Missing recording rules aren't cached, so they're used as soon as they're recorded.
+	require.False(t, s.useRecordingRules(context.Background(), objective))
+	cache.Wait()
+	prometheus.reportPrometheus[`group(http_requests:burnrate5m{slo="a"})`] = model.Vector{{Metric: model.Metric{"slo": "a"}, Value: 1}}
+	require.True(t, s.useRecordingRules(context.Background(), objective))
+	require.Equal(t, 2, prometheus.queries)
+
+	// Found recording rules are cached.
+	cache.Wait()
+	require.True(t, s.useRecordingRules(context.Background(), objective))
+	require.Equal(t, 2, prometheus.queries)
+}
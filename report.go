@@ -0,0 +1,341 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/common/model"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	objectivesv1alpha1 "github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1"
+	"github.com/pyrra-dev/pyrra/slo"
+)
+
+// reportAlertInterval is the evaluation interval of the alerting rules.
+// Every interval with a firing alert in ALERTS stands for one interval of firing.
+const reportAlertInterval = 30 * time.Second
+
+// lastMonth returns the start and end of the last full calendar month in UTC.
+func lastMonth(now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	end := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return end.AddDate(0, -1, 0), end
+}
+
+func (s *objectiveServer) ExportReport(ctx context.Context, req *connect.Request[objectivesv1alpha1.ExportReportRequest]) (*connect.Response[objectivesv1alpha1.ExportReportResponse], error) {
+	start, end := lastMonth(time.Now())
+	switch {
+	case req.Msg.Start != nil && req.Msg.End != nil:
+		start, end = req.Msg.Start.AsTime(), req.Msg.End.AsTime()
+	case req.Msg.Start != nil || req.Msg.End != nil:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("start and end need to be set together"))
+	}
+	if !start.Before(end) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("start %s needs to be before end %s", start, end))
+	}
+
+	resp, err := s.client.List(ctx, connect.NewRequest(&objectivesv1alpha1.ListRequest{Expr: req.Msg.Expr}))
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []*objectivesv1alpha1.ReportRow
+	for _, o := range resp.Msg.Objectives {
+		objective, err := objectivesv1alpha1.ToInternal(o)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		objectiveRows, err := reportRows(ctx, s.promAPI.api, objective, start, end, s.opts)
+		if err != nil {
+			level.Warn(s.logger).Log("msg", "failed to report objective", "objective", objective.Name(), "err", err)
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("objective %s: %w", objective.Name(), err))
+		}
+		rows = append(rows, objectiveRows...)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		li, lj := reportLabels(rows[i].Labels), reportLabels(rows[j].Labels)
+		if li != lj {
+			return li < lj
+		}
+		return reportLabels(rows[i].Grouping) < reportLabels(rows[j].Grouping)
+	})
+
+	return connect.NewResponse(&objectivesv1alpha1.ExportReportResponse{
+		Start: timestamppb.New(start),
+		End:   timestamppb.New(end),
+		Rows:  rows,
+	}), nil
+}
+
+// reportRows returns a row per group of the objective.
+// The burn rate over the whole period is queried at its end from the raw series, like backtests do,
+// so that the period doesn't need to match the objective's window.
+// Groups without any requests in the period are skipped, like GetStatus does.
+func reportRows(ctx context.Context, promAPI prometheusAPI, objective slo.Objective, start, end time.Time, opts slo.GenerationOptions) ([]*objectivesv1alpha1.ReportRow, error) {
+	ctx = contextSetDatasource(ctx, objective.Datasource)
+
+	period := end.Sub(start)
+
+	query := objective.Burnrate(period, opts)
+	value, _, err := promAPI.Query(ctx, query, end)
+	if err != nil {
+		return nil, fmt.Errorf("querying burn rate: %w", err)
+	}
+	vector, ok := value.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected burn rate result %s", value.Type())
+	}
+
+	labels := objective.Labels.Map()
+
+	rows := make(map[model.Fingerprint]*objectivesv1alpha1.ReportRow, len(vector))
+	for _, sample := range vector {
+		errorRatio := float64(sample.Value)
+		if math.IsNaN(errorRatio) {
+			continue
+		}
+		rows[sample.Metric.Fingerprint()] = &objectivesv1alpha1.ReportRow{
+			Labels:         labels,
+			Grouping:       labelSetMap(sample.Metric),
+			Target:         objective.Target,
+			Availability:   1 - errorRatio,
+			BudgetConsumed: errorRatio / (1 - objective.Target),
+		}
+	}
+
+	// Only the burn rate alerts have a long window label, whatever their name.
+	// Tiers firing at the same time are counted once by counting the intervals any of them fired.
+	alertsQuery := fmt.Sprintf(`count_over_time((max by (%s) (ALERTS{alertstate="firing",long!="",slo="%s"}))[%s:%s])`,
+		strings.Join(objective.Grouping(), ", "),
+		objective.Name(),
+		model.Duration(period),
+		model.Duration(reportAlertInterval),
+	)
+	value, _, err = promAPI.Query(ctx, alertsQuery, end)
+	if err != nil {
+		return nil, fmt.Errorf("querying alerts: %w", err)
+	}
+	vector, ok = value.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected alerts result %s", value.Type())
+	}
+	for _, sample := range vector {
+		if row, ok := rows[sample.Metric.Fingerprint()]; ok {
+			row.AlertMinutes = float64(sample.Value) * reportAlertInterval.Minutes()
+		}
+	}
+
+	result := make([]*objectivesv1alpha1.ReportRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, row)
+	}
+	return result, nil
+}
+
+func labelSetMap(ls model.Metric) map[string]string {
+	m := make(map[string]string, len(ls))
+	for name, value := range ls {
+		m[string(name)] = string(value)
+	}
+	return m
+}
+
+// reportLabels formats labels like {a="b", c="d"}, sorted by their names.
+func reportLabels(m map[string]string) string {
+	return labelSet(m).String()
+}
+
+// newReportHandler serves the report as CSV, JSON or a static HTML page.
+// It takes the same expr, start and end as ExportReport, with start and end as RFC3339.
+func newReportHandler(objectives *objectiveServer, logger log.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		req := &objectivesv1alpha1.ExportReportRequest{Expr: query.Get("expr")}
+		for name, ts := range map[string]**timestamppb.Timestamp{"start": &req.Start, "end": &req.End} {
+			if query.Get(name) == "" {
+				continue
+			}
+			t, err := time.Parse(time.RFC3339, query.Get(name))
+			if err != nil {
+				http.Error(w, fmt.Sprintf("failed to parse %s: %v", name, err), http.StatusBadRequest)
+				return
+			}
+			*ts = timestamppb.New(t)
+		}
+
+		format := query.Get("format")
+		if format == "" {
+			format = "json"
+		}
+		if format != "csv" && format != "json" && format != "html" {
+			http.Error(w, fmt.Sprintf("unsupported format %q, use csv, json or html", format), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := objectives.ExportReport(r.Context(), connect.NewRequest(req))
+		if err != nil {
+			status := http.StatusInternalServerError
+			var connectErr *connect.Error
+			if errors.As(err, &connectErr) && connectErr.Code() == connect.CodeInvalidArgument {
+				status = http.StatusBadRequest
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		switch format {
+		case "csv":
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			w.Header().Set("Content-Disposition", `attachment; filename="`+reportFilename(resp.Msg)+`.csv"`)
+			err = writeReportCSV(w, resp.Msg)
+		case "json":
+			var bytes []byte
+			bytes, err = protojson.Marshal(resp.Msg)
+			if err == nil {
+				w.Header().Set("Content-Type", "application/json")
+				_, err = w.Write(bytes)
+			}
+		case "html":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			err = writeReportHTML(w, resp.Msg)
+		}
+		if err != nil {
+			level.Warn(logger).Log("msg", "failed to write report", "format", format, "err", err)
+		}
+	})
+}
+
+func reportFilename(report *objectivesv1alpha1.ExportReportResponse) string {
+	return fmt.Sprintf("slo-report-%s-%s",
+		report.GetStart().AsTime().Format("2006-01-02"),
+		report.GetEnd().AsTime().Format("2006-01-02"),
+	)
+}
+
+func writeReportCSV(w io.Writer, report *objectivesv1alpha1.ExportReportResponse) error {
+	formatFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"objective", "labels", "grouping", "start", "end", "target", "availability", "budget_consumed", "alert_minutes"}); err != nil {
+		return err
+	}
+	for _, row := range report.GetRows() {
+		labels := make(map[string]string, len(row.GetLabels()))
+		for name, value := range row.GetLabels() {
+			if name != model.MetricNameLabel {
+				labels[name] = value
+			}
+		}
+		if err := cw.Write([]string{
+			row.GetLabels()[model.MetricNameLabel],
+			reportLabels(labels),
+			reportLabels(row.GetGrouping()),
+			report.GetStart().AsTime().Format(time.RFC3339),
+			report.GetEnd().AsTime().Format(time.RFC3339),
+			formatFloat(row.GetTarget()),
+			formatFloat(row.GetAvailability()),
+			formatFloat(row.GetBudgetConsumed()),
+			formatFloat(row.GetAlertMinutes()),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"percent": func(f float64) string {
+		return strconv.FormatFloat(100*f, 'f', 3, 64) + "%"
+	},
+	"minutes": func(f float64) string {
+		return strconv.FormatFloat(f, 'f', 1, 64)
+	},
+	"labels": reportLabels,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>SLO report {{.Start}} - {{.End}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #212529; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border-bottom: 1px solid #dee2e6; padding: 0.5em; text-align: left; }
+td.number { text-align: right; font-variant-numeric: tabular-nums; }
+tr.missed td { background: #f8d7da; }
+</style>
+</head>
+<body>
+<h1>SLO report</h1>
+<p>{{.Start}} - {{.End}}</p>
+<table>
+<thead>
+<tr><th>Objective</th><th>Labels</th><th>Grouping</th><th>Target</th><th>Availability</th><th>Budget consumed</th><th>Alert minutes</th></tr>
+</thead>
+<tbody>
+{{- range .Rows}}
+<tr{{if gt .BudgetConsumed 1.0}} class="missed"{{end}}><td>{{.Name}}</td><td>{{labels .Labels}}</td><td>{{labels .Grouping}}</td><td class="number">{{percent .Target}}</td><td class="number">{{percent .Availability}}</td><td class="number">{{percent .BudgetConsumed}}</td><td class="number">{{minutes .AlertMinutes}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+</body>
+</html>
+`))
+
+func writeReportHTML(w io.Writer, report *objectivesv1alpha1.ExportReportResponse) error {
+	type row struct {
+		Name           string
+		Labels         map[string]string
+		Grouping       map[string]string
+		Target         float64
+		Availability   float64
+		BudgetConsumed float64
+		AlertMinutes   float64
+	}
+
+	rows := make([]row, 0, len(report.GetRows()))
+	for _, r := range report.GetRows() {
+		labels := make(map[string]string, len(r.GetLabels()))
+		for name, value := range r.GetLabels() {
+			if name != model.MetricNameLabel {
+				labels[name] = value
+			}
+		}
+		rows = append(rows, row{
+			Name:           r.GetLabels()[model.MetricNameLabel],
+			Labels:         labels,
+			Grouping:       r.GetGrouping(),
+			Target:         r.GetTarget(),
+			Availability:   r.GetAvailability(),
+			BudgetConsumed: r.GetBudgetConsumed(),
+			AlertMinutes:   r.GetAlertMinutes(),
+		})
+	}
+
+	return reportTemplate.Execute(w, struct {
+		Start, End string
+		Rows       []row
+	}{
+		Start: report.GetStart().AsTime().Format(time.RFC3339),
+		End:   report.GetEnd().AsTime().Format(time.RFC3339),
+		Rows:  rows,
+	})
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"github.com/go-kit/log"
+	prometheusapiv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	objectivesv1alpha1 "github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1"
+	"github.com/pyrra-dev/pyrra/slo"
+)
+
+// reportPrometheus returns the vector of an instant query and an empty vector for unknown queries.
+type reportPrometheus map[string]model.Vector
+
+func (p reportPrometheus) Query(_ context.Context, query string, _ time.Time, _ ...prometheusapiv1.Option) (model.Value, prometheusapiv1.Warnings, error) {
+	if vector, ok := p[query]; ok {
+		return vector, nil, nil
+	}
+	return model.Vector{}, nil, nil
+}
+
+func (p reportPrometheus) QueryRange(_ context.Context, query string, _ prometheusapiv1.Range, _ ...prometheusapiv1.Option) (model.Value, prometheusapiv1.Warnings, error) {
+	return nil, nil, fmt.Errorf("unexpected range query %q", query)
+}
+
+func TestLastMonth(t *testing.T) {
+	start, end := lastMonth(time.Date(2026, 1, 17, 13, 0, 0, 0, time.UTC))
+	require.Equal(t, time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), end)
+
+	start, end = lastMonth(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), end)
+}
+
+func TestReportRows(t *testing.T) {
+	objective := slo.Objective{
+		Labels: labels.FromStrings(labels.MetricName, "api", "team", "foo"),
+		Target: 0.99,
+		Window: model.Duration(28 * 24 * time.Hour),
+		Indicator: slo.Indicator{
+			Ratio: &slo.RatioIndicator{
+				Errors: slo.Metric{
+					Name: "http_requests_total",
+					LabelMatchers: []*labels.Matcher{
+						labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "http_requests_total"),
+						labels.MustNewMatcher(labels.MatchRegexp, "code", "5.."),
+					},
+				},
+				Total: slo.Metric{
+					Name: "http_requests_total",
+					LabelMatchers: []*labels.Matcher{
+						labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "http_requests_total"),
+					},
+				},
+				Grouping: []string{"handler"},
+			},
+		},
+	}
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	burnrateQuery := objective.Burnrate(end.Sub(start), slo.GenerationOptions{})
+	require.Equal(t, `sum by (handler) (rate(http_requests_total{code=~"5.."}[31d])) / sum by (handler) (rate(http_requests_total[31d]))`, burnrateQuery)
+	alertsQuery := `count_over_time((max by (handler) (ALERTS{alertstate="firing",long!="",slo="api"}))[31d:30s])`
+
+	prometheus := reportPrometheus{
+		burnrateQuery: {
+			{Metric: model.Metric{"handler": "/"}, Value: 0.002},
+			{Metric: model.Metric{"handler": "/api"}, Value: 0.02},
+			{Metric: model.Metric{"handler": "/unused"}, Value: model.SampleValue(math.NaN())},
+		},
+		alertsQuery: {
+			{Metric: model.Metric{"handler": "/api"}, Value: 90},
+			{Metric: model.Metric{"handler": "/unused"}, Value: 10},
+		},
+	}
+
+	rows, err := reportRows(context.Background(), prometheus, objective, start, end, slo.GenerationOptions{})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	byHandler := map[string]*objectivesv1alpha1.ReportRow{}
+	for _, r := range rows {
+		require.Equal(t, map[string]string{"__name__": "api", "team": "foo"}, r.Labels)
+		require.Equal(t, 0.99, r.Target)
+		byHandler[r.Grouping["handler"]] = r
+	}
+
+	require.InDelta(t, 0.998, byHandler["/"].Availability, 1e-9)
+	require.InDelta(t, 0.2, byHandler["/"].BudgetConsumed, 1e-9)
+	require.Equal(t, 0.0, byHandler["/"].AlertMinutes)
+
+	require.InDelta(t, 0.98, byHandler["/api"].Availability, 1e-9)
+	require.InDelta(t, 2.0, byHandler["/api"].BudgetConsumed, 1e-9)
+	require.Equal(t, 45.0, byHandler["/api"].AlertMinutes)
+}
+
+// enginePrometheus evaluates queries against the input series of a unit test.
+type enginePrometheus struct {
+	e *unitTestEvaluator
+}
+
+func (p enginePrometheus) Query(_ context.Context, query string, ts time.Time, _ ...prometheusapiv1.Option) (model.Value, prometheusapiv1.Warnings, error) {
+	vector, err := p.e.query(query, ts)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := make(model.Vector, 0, len(vector))
+	for _, sample := range vector {
+		metric := model.Metric{}
+		sample.Metric.Range(func(l labels.Label) { metric[model.LabelName(l.Name)] = model.LabelValue(l.Value) })
+		result = append(result, &model.Sample{Metric: metric, Value: model.SampleValue(sample.F)})
+	}
+	return result, nil, nil
+}
+
+func (p enginePrometheus) QueryRange(_ context.Context, query string, _ prometheusapiv1.Range, _ ...prometheusapiv1.Option) (model.Value, prometheusapiv1.Warnings, error) {
+	return nil, nil, fmt.Errorf("unexpected range query %q", query)
+}
+
+func TestReportRowsAlertMinutes(t *testing.T) {
+	objective := batchObjective("api", "default", "api", 28*24*time.Hour, "handler")
+
+	start := time.Unix(0, 0).UTC()
+	end := start.Add(time.Hour)
+	e, err := newUnitTestEvaluator([]unitTestSeries{
+		{Series: `http_requests_total{job="api",handler="/api"}`, Values: "0+99x120"},
+		{Series: `http_requests_total{job="api",handler="/api",code="500"}`, Values: "0+1x120"},
+		// Both tiers fire from 15m to 20m. The alerts aren't named like the objective's default.
+		{Series: `ALERTS{alertname="Burn",alertstate="firing",slo="api",handler="/api",long="1h",short="5m"}`, Values: "_x10 1x29 stale"},
+		{Series: `ALERTS{alertname="Burn",alertstate="firing",slo="api",handler="/api",long="6h",short="30m"}`, Values: "_x30 1x29 stale"},
+		// Other alerts of the objective aren't burn rate alerts.
+		{Series: `ALERTS{alertname="ErrorBudgetPolicy",alertstate="firing",slo="api",handler="/api",stage="freeze"}`, Values: "1x120"},
+		{Series: `ALERTS{alertname="Burn",alertstate="pending",slo="api",handler="/api",long="1h",short="5m"}`, Values: "1x120"},
+	}, start, reportAlertInterval)
+	require.NoError(t, err)
+
+	rows, err := reportRows(context.Background(), enginePrometheus{e: e}, objective, start, end, slo.GenerationOptions{})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, map[string]string{"handler": "/api"}, rows[0].Grouping)
+	require.InDelta(t, 0.99, rows[0].Availability, 1e-9)
+	// The first tier fired from 5m to 20m and the second one from 15m to 30m.
+	require.Equal(t, 25.0, rows[0].AlertMinutes)
+}
+
+func TestExportReportInvalidPeriod(t *testing.T) {
+	s := &objectiveServer{logger: log.NewNopLogger()}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for name, req := range map[string]*objectivesv1alpha1.ExportReportRequest{
+		"start only":   {Start: timestamppb.New(start)},
+		"end only":     {End: timestamppb.New(start)},
+		"end at start": {Start: timestamppb.New(start), End: timestamppb.New(start)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := s.ExportReport(context.Background(), connect.NewRequest(req))
+			require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+		})
+	}
+}
+
+func TestReportHandlerBadRequest(t *testing.T) {
+	h := newReportHandler(&objectiveServer{logger: log.NewNopLogger()}, log.NewNopLogger())
+
+	for name, query := range map[string]string{
+		"format":       "?format=xml",
+		"start":        "?start=yesterday&end=2026-02-01T00:00:00Z",
+		"end at start": "?start=2026-02-01T00:00:00Z&end=2026-02-01T00:00:00Z",
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/report"+query, nil))
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+var testReport = &objectivesv1alpha1.ExportReportResponse{
+	Start: timestamppb.New(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+	End:   timestamppb.New(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)),
+	Rows: []*objectivesv1alpha1.ReportRow{{
+		Labels:         map[string]string{"__name__": "api", "team": "foo"},
+		Grouping:       map[string]string{"handler": "/api"},
+		Target:         0.99,
+		Availability:   0.98,
+		BudgetConsumed: 2,
+		AlertMinutes:   45,
+	}},
+}
+
+func TestWriteReportCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeReportCSV(&buf, testReport))
+	require.Equal(t, `objective,labels,grouping,start,end,target,availability,budget_consumed,alert_minutes
+api,"{team=""foo""}","{handler=""/api""}",2026-01-01T00:00:00Z,2026-02-01T00:00:00Z,0.99,0.98,2,45
+`, buf.String())
+}
+
+func TestWriteReportHTML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeReportHTML(&buf, testReport))
+	require.Contains(t, buf.String(), `<tr class="missed"><td>api</td><td>{team=&#34;foo&#34;}</td><td>{handler=&#34;/api&#34;}</td><td class="number">99.000%</td><td class="number">98.000%</td><td class="number">200.000%</td><td class="number">45.0</td></tr>`)
+}
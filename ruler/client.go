@@ -0,0 +1,173 @@
+// Package ruler provides a client to provision rule groups via the ruler APIs of Mimir and Cortex,
+// and of other rulers with a compatible API.
+package ruler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	promconfig "github.com/prometheus/common/config"
+	"github.com/prometheus/prometheus/model/rulefmt"
+)
+
+// TenantHeaderName is the header Mimir and Cortex read the tenant of requests from.
+const TenantHeaderName = "X-Scope-OrgID"
+
+// The kinds of rulers. They only differ in the paths of their APIs.
+const (
+	KindMimir   = "mimir"
+	KindCortex  = "cortex"
+	KindGeneric = "generic"
+)
+
+// Client provisions rule groups to a ruler.
+type Client interface {
+	// Ready checks if the ruler is ready to serve traffic.
+	Ready(ctx context.Context) error
+	// SetRuleGroup creates or updates a rule group.
+	SetRuleGroup(ctx context.Context, namespace string, ruleGroup rulefmt.RuleGroup) error
+	// DeleteRuleGroup deletes a rule group of a namespace.
+	DeleteRuleGroup(ctx context.Context, namespace, ruleGroup string) error
+	// DeleteNamespace deletes all the rule groups in a namespace (including the namespace itself).
+	DeleteNamespace(ctx context.Context, namespace string) error
+}
+
+// HTTPClient is a simple client for the ruler API Mimir and Cortex share.
+type HTTPClient struct {
+	client       *http.Client
+	address      *url.URL
+	rulesPath    string
+	readyPath    string
+	tenantHeader string
+	tenantID     string
+}
+
+// Config is used to configure the client.
+type Config struct {
+	Kind    string
+	Address string
+	// RulesPath is the path of the rules API. Defaults to the path of the kind's API and is required for generic rulers.
+	RulesPath string
+	// ReadyPath is the path to check if the ruler is ready. Defaults to the path of the kind's API.
+	// Generic rulers without it are always ready.
+	ReadyPath string
+	// PrometheusPrefix is the prefix for the Prometheus API in Mimir, which the rules API is served under.
+	PrometheusPrefix string
+	// DeploymentMode is the Mimir deployment mode, standalone or distributed.
+	DeploymentMode string
+
+	// TenantHeader is the header to send the TenantID in. Defaults to TenantHeaderName.
+	TenantHeader      string
+	TenantID          string
+	BasicAuthUsername string
+	BasicAuthPassword string
+	BearerTokenFile   string
+}
+
+// NewClient creates a new client with the given configuration.
+func NewClient(config Config) (*HTTPClient, error) {
+	addr, err := url.Parse(config.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	rulesPath, readyPath := config.RulesPath, config.ReadyPath
+	switch config.Kind {
+	case KindMimir, "":
+		prefix := config.PrometheusPrefix
+		if prefix == "" {
+			prefix = "prometheus"
+		}
+		if rulesPath == "" {
+			rulesPath = "/" + strings.Trim(prefix, "/") + "/config/v1/rules"
+		}
+		if readyPath == "" {
+			readyPath = "/ready"
+			if config.DeploymentMode == "distributed" {
+				readyPath = "/api/v1/status/buildinfo"
+			}
+		}
+	case KindCortex:
+		if rulesPath == "" {
+			rulesPath = "/api/v1/rules"
+		}
+		if readyPath == "" {
+			readyPath = "/ready"
+		}
+	case KindGeneric:
+		if rulesPath == "" {
+			return nil, fmt.Errorf("the rules path of generic rulers must be set")
+		}
+	default:
+		return nil, fmt.Errorf("unknown kind of ruler %q", config.Kind)
+	}
+
+	clientConfig := promconfig.HTTPClientConfig{}
+	if config.BasicAuthUsername != "" && config.BasicAuthPassword != "" {
+		clientConfig.BasicAuth = &promconfig.BasicAuth{
+			Username: config.BasicAuthUsername,
+			Password: promconfig.Secret(config.BasicAuthPassword),
+		}
+	}
+	if config.BearerTokenFile != "" {
+		// The file is read for every request, so that rotated tokens are used.
+		clientConfig.Authorization = &promconfig.Authorization{CredentialsFile: config.BearerTokenFile}
+	}
+	if err := clientConfig.Validate(); err != nil {
+		return nil, err
+	}
+	httpClient, err := promconfig.NewClientFromConfig(clientConfig, "ruler")
+	if err != nil {
+		return nil, err
+	}
+
+	tenantHeader := config.TenantHeader
+	if tenantHeader == "" {
+		tenantHeader = TenantHeaderName
+	}
+
+	return &HTTPClient{
+		client:       httpClient,
+		address:      addr,
+		rulesPath:    rulesPath,
+		readyPath:    readyPath,
+		tenantHeader: tenantHeader,
+		tenantID:     config.TenantID,
+	}, nil
+}
+
+// Ready checks if the ruler is ready to serve traffic.
+func (c *HTTPClient) Ready(ctx context.Context) error {
+	if c.readyPath == "" {
+		return nil
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, c.address.JoinPath(c.readyPath), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("ruler not ready, unexpected status code: %d, expected %d", resp.StatusCode, http.StatusOK)
+	}
+	return nil
+}
+
+func (c *HTTPClient) do(ctx context.Context, method string, path *url.URL, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, path.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/yaml")
+	}
+	if c.tenantID != "" {
+		req.Header.Set(c.tenantHeader, c.tenantID)
+	}
+	return c.client.Do(req)
+}
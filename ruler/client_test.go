@@ -0,0 +1,171 @@
+package ruler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/rulefmt"
+	"github.com/stretchr/testify/require"
+)
+
+type request struct {
+	method, path, tenant, authorization, body string
+}
+
+func server(t *testing.T, status int) (*httptest.Server, *[]request) {
+	t.Helper()
+	var requests []request
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, request{
+			method:        r.Method,
+			path:          r.URL.Path,
+			tenant:        r.Header.Get("X-Tenant"),
+			authorization: r.Header.Get("Authorization"),
+			body:          string(body),
+		})
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.Close)
+	return s, &requests
+}
+
+func TestNewClient(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		config    Config
+		rulesPath string
+		readyPath string
+		err       string
+	}{{
+		name:      "mimir",
+		config:    Config{Kind: KindMimir},
+		rulesPath: "/prometheus/config/v1/rules",
+		readyPath: "/ready",
+	}, {
+		name:      "mimir distributed",
+		config:    Config{PrometheusPrefix: "/api/prom/", DeploymentMode: "distributed"},
+		rulesPath: "/api/prom/config/v1/rules",
+		readyPath: "/api/v1/status/buildinfo",
+	}, {
+		name:      "cortex",
+		config:    Config{Kind: KindCortex},
+		rulesPath: "/api/v1/rules",
+		readyPath: "/ready",
+	}, {
+		name:      "cortex with rules path",
+		config:    Config{Kind: KindCortex, RulesPath: "/rules"},
+		rulesPath: "/rules",
+		readyPath: "/ready",
+	}, {
+		name:      "generic",
+		config:    Config{Kind: KindGeneric, RulesPath: "/api/v1/rules"},
+		rulesPath: "/api/v1/rules",
+	}, {
+		name:   "generic without rules path",
+		config: Config{Kind: KindGeneric},
+		err:    "the rules path of generic rulers must be set",
+	}, {
+		name:   "loki",
+		config: Config{Kind: "loki"},
+		err:    `unknown kind of ruler "loki"`,
+	}, {
+		name:   "unknown",
+		config: Config{Kind: "thanos"},
+		err:    `unknown kind of ruler "thanos"`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := NewClient(tc.config)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.rulesPath, c.rulesPath)
+			require.Equal(t, tc.readyPath, c.readyPath)
+		})
+	}
+}
+
+func TestHTTPClient(t *testing.T) {
+	s, requests := server(t, http.StatusAccepted)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret"), 0o600))
+
+	c, err := NewClient(Config{
+		Kind:            KindCortex,
+		Address:         s.URL,
+		TenantHeader:    "X-Tenant",
+		TenantID:        "team-a",
+		BearerTokenFile: tokenFile,
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, c.SetRuleGroup(ctx, "api", rulefmt.RuleGroup{Name: "api"}))
+	require.NoError(t, c.DeleteRuleGroup(ctx, "api", "api"))
+	require.NoError(t, c.DeleteNamespace(ctx, "api"))
+
+	require.Equal(t, []request{{
+		method:        http.MethodPost,
+		path:          "/api/v1/rules/api",
+		tenant:        "team-a",
+		authorization: "Bearer secret",
+		body:          "name: api\nrules: []\n",
+	}, {
+		method:        http.MethodDelete,
+		path:          "/api/v1/rules/api/api",
+		tenant:        "team-a",
+		authorization: "Bearer secret",
+	}, {
+		method:        http.MethodDelete,
+		path:          "/api/v1/rules/api",
+		tenant:        "team-a",
+		authorization: "Bearer secret",
+	}}, *requests)
+}
+
+func TestHTTPClientBasicAuth(t *testing.T) {
+	s, requests := server(t, http.StatusOK)
+
+	c, err := NewClient(Config{
+		Kind:              KindGeneric,
+		Address:           s.URL,
+		RulesPath:         "/api/v1/rules",
+		BasicAuthUsername: "pyrra",
+		BasicAuthPassword: "secret",
+	})
+	require.NoError(t, err)
+	require.NoError(t, c.Ready(context.Background()))
+	require.NoError(t, c.SetRuleGroup(context.Background(), "api", rulefmt.RuleGroup{Name: "api"}))
+
+	// Generic rulers without a ready path aren't asked if they're ready.
+	require.Len(t, *requests, 1)
+	r := (*requests)[0]
+	require.Equal(t, "/api/v1/rules/api", r.path)
+	require.Equal(t, "Basic cHlycmE6c2VjcmV0", r.authorization)
+	require.Empty(t, r.tenant)
+}
+
+func TestHTTPClientErrors(t *testing.T) {
+	s, _ := server(t, http.StatusBadRequest)
+
+	c, err := NewClient(Config{Kind: KindCortex, Address: s.URL})
+	require.NoError(t, err)
+	require.EqualError(t, c.Ready(context.Background()), "ruler not ready, unexpected status code: 400, expected 200")
+	require.EqualError(t, c.SetRuleGroup(context.Background(), "api", rulefmt.RuleGroup{Name: "api"}), "unexpected status code: 400")
+
+	// Deleting rules that are gone already succeeds.
+	s, _ = server(t, http.StatusNotFound)
+	c, err = NewClient(Config{Kind: KindCortex, Address: s.URL})
+	require.NoError(t, err)
+	require.NoError(t, c.DeleteRuleGroup(context.Background(), "api", "api"))
+	require.NoError(t, c.DeleteNamespace(context.Background(), "api"))
+}
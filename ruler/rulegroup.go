@@ -0,0 +1,69 @@
+package ruler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/prometheus/prometheus/model/rulefmt"
+	"gopkg.in/yaml.v3"
+)
+
+// SetRuleGroup creates or updates a rule group.
+func (c *HTTPClient) SetRuleGroup(ctx context.Context, namespace string, ruleGroup rulefmt.RuleGroup) error {
+	body, err := yaml.Marshal(ruleGroup)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, c.address.JoinPath(c.rulesPath, namespace), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkStatus(resp)
+}
+
+// DeleteRuleGroup deletes a rule group of a namespace.
+func (c *HTTPClient) DeleteRuleGroup(ctx context.Context, namespace, ruleGroup string) error {
+	resp, err := c.do(ctx, http.MethodDelete, c.address.JoinPath(c.rulesPath, namespace, ruleGroup), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil // The rule group is gone already.
+	}
+	return checkStatus(resp)
+}
+
+// DeleteNamespace deletes all the rule groups in a namespace (including the namespace itself).
+func (c *HTTPClient) DeleteNamespace(ctx context.Context, namespace string) error {
+	resp, err := c.do(ctx, http.MethodDelete, c.address.JoinPath(c.rulesPath, namespace), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil // The namespace is gone already.
+	}
+	return checkStatus(resp)
+}
+
+// checkStatus returns an error unless the ruler accepted the request.
+// Mimir and Cortex respond with 202 Accepted, other rulers might respond with any successful status.
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	if body = bytes.TrimSpace(body); len(body) > 0 {
+		return fmt.Errorf("unexpected status code: %d: %s", resp.StatusCode, body)
+	}
+	return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+}
@@ -0,0 +1,102 @@
+package slo
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/promql/parser"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const defaultAlertnameBudgetPolicy = "ErrorBudgetPolicy"
+
+// BudgetPolicyStage is a stage of an error budget policy.
+// An objective enters the stage once its remaining error budget drops to Remaining.
+type BudgetPolicyStage struct {
+	// Name of the stage, like freeze-deploys.
+	Name string
+	// Remaining error budget from 0 to 1 the stage starts at.
+	Remaining float64
+	Severity  Severity
+	// Labels are added to the stage's alert to route it in Alertmanager.
+	Labels map[string]string
+}
+
+// BudgetPolicyStage returns the stage of the error budget policy the remaining error budget is in.
+// It returns false if the remaining error budget is above all stages.
+func (o Objective) BudgetPolicyStage(remaining float64) (BudgetPolicyStage, bool) {
+	var (
+		stage BudgetPolicyStage
+		found bool
+	)
+	for _, s := range o.BudgetPolicy {
+		if remaining <= s.Remaining && (!found || s.Remaining < stage.Remaining) {
+			stage = s
+			found = true
+		}
+	}
+	return stage, found
+}
+
+// BudgetPolicyRules returns a RuleGroup with an alert for every stage of the error budget policy.
+// Only the alert of the stage the objective is currently in fires,
+// so that Alertmanager can route each stage differently.
+func (o Objective) BudgetPolicyRules(opts GenerationOptions) (monitoringv1.RuleGroup, error) {
+	sloName := o.Labels.Get(model.MetricNameLabel)
+
+	if o.Calendar != nil {
+		return monitoringv1.RuleGroup{}, fmt.Errorf("error budget policies don't support calendar windows")
+	}
+
+	budget := o.QueryErrorBudget(opts)
+	if budget == "" {
+		return monitoringv1.RuleGroup{}, fmt.Errorf("objective %s has no error budget query", sloName)
+	}
+
+	stages := make([]BudgetPolicyStage, len(o.BudgetPolicy))
+	copy(stages, o.BudgetPolicy)
+	sort.Slice(stages, func(i, j int) bool {
+		return stages[i].Remaining > stages[j].Remaining
+	})
+
+	rules := make([]monitoringv1.Rule, 0, len(stages))
+	for i, s := range stages {
+		query := fmt.Sprintf("(%s) <= %s", budget, strconv.FormatFloat(s.Remaining, 'f', -1, 64))
+		if i+1 < len(stages) {
+			query = fmt.Sprintf("%s > %s", query, strconv.FormatFloat(stages[i+1].Remaining, 'f', -1, 64))
+		}
+		expr, err := parser.ParseExpr(query)
+		if err != nil {
+			return monitoringv1.RuleGroup{}, err
+		}
+
+		alertLabels := o.commonRuleLabels(sloName)
+		for k, v := range s.Labels {
+			alertLabels[k] = v
+		}
+		alertLabels["stage"] = s.Name
+		alertLabels["severity"] = string(s.Severity)
+		if s.Severity == "" {
+			alertLabels["severity"] = string(warning)
+			if s.Remaining <= 0 {
+				alertLabels["severity"] = string(critical)
+			}
+		}
+
+		rules = append(rules, monitoringv1.Rule{
+			Alert:       defaultAlertnameBudgetPolicy,
+			Expr:        intstr.FromString(expr.String()),
+			Labels:      alertLabels,
+			Annotations: o.commonRuleAnnotations(opts.ExternalURL),
+		})
+	}
+
+	return monitoringv1.RuleGroup{
+		Name:     sloName + "-budget-policy",
+		Interval: monitoringDuration(o.increaseInterval().String()),
+		Rules:    rules,
+	}, nil
+}
@@ -0,0 +1,63 @@
+package slo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func objectiveBudgetPolicy() Objective {
+	o := objectiveHTTPRatio()
+	o.BudgetPolicy = []BudgetPolicyStage{
+		{Name: "exhausted", Remaining: 0},
+		{Name: "review", Remaining: 0.5},
+		{Name: "freeze-deploys", Remaining: 0.25, Severity: critical, Labels: map[string]string{"action": "freeze"}},
+	}
+	return o
+}
+
+func TestObjective_BudgetPolicyStage(t *testing.T) {
+	o := objectiveBudgetPolicy()
+
+	_, ok := o.BudgetPolicyStage(0.8)
+	require.False(t, ok)
+
+	for remaining, name := range map[float64]string{
+		0.5:  "review",
+		0.3:  "review",
+		0.25: "freeze-deploys",
+		0.01: "freeze-deploys",
+		0:    "exhausted",
+		-0.7: "exhausted",
+	} {
+		stage, ok := o.BudgetPolicyStage(remaining)
+		require.True(t, ok)
+		require.Equal(t, name, stage.Name, "remaining: %v", remaining)
+	}
+}
+
+func TestObjective_BudgetPolicyRules(t *testing.T) {
+	o := objectiveBudgetPolicy()
+
+	group, err := o.BudgetPolicyRules(GenerationOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "monitoring-http-errors-budget-policy", group.Name)
+	require.Len(t, group.Rules, 3)
+
+	budget := `((1 - 0.99) - (sum(http_requests:increase4w{code=~"5..",job="thanos-receive-default",slo="monitoring-http-errors"} or vector(0)) / sum(http_requests:increase4w{job="thanos-receive-default",slo="monitoring-http-errors"}))) / (1 - 0.99)`
+	require.Equal(t, "ErrorBudgetPolicy", group.Rules[0].Alert)
+	require.Equal(t, "("+budget+") <= 0.5 > 0.25", group.Rules[0].Expr.String())
+	require.Equal(t, map[string]string{"slo": "monitoring-http-errors", "stage": "review", "severity": "warning"}, group.Rules[0].Labels)
+	require.Equal(t, "("+budget+") <= 0.25 > 0", group.Rules[1].Expr.String())
+	require.Equal(t, map[string]string{"slo": "monitoring-http-errors", "stage": "freeze-deploys", "severity": "critical", "action": "freeze"}, group.Rules[1].Labels)
+	require.Equal(t, "("+budget+") <= 0", group.Rules[2].Expr.String())
+	require.Equal(t, map[string]string{"slo": "monitoring-http-errors", "stage": "exhausted", "severity": "critical"}, group.Rules[2].Labels)
+
+	// The stages of the objective aren't reordered.
+	require.Equal(t, "exhausted", o.BudgetPolicy[0].Name)
+
+	o.Calendar = &Calendar{Period: CalendarMonth, Location: time.UTC}
+	_, err = o.BudgetPolicyRules(GenerationOptions{})
+	require.EqualError(t, err, "error budget policies don't support calendar windows")
+}
@@ -0,0 +1,252 @@
+package slo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // Calendar periods need time zones even if the host doesn't ship them.
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+// CalendarPeriod is the length of a calendar aligned window.
+type CalendarPeriod string
+
+const (
+	CalendarWeek    CalendarPeriod = "week"
+	CalendarMonth   CalendarPeriod = "month"
+	CalendarQuarter CalendarPeriod = "quarter"
+)
+
+// Calendar aligns an objective's window to calendar periods instead of a rolling duration.
+// The error budget resets at the start of every period.
+type Calendar struct {
+	Period   CalendarPeriod
+	Location *time.Location
+}
+
+// NewCalendar returns a Calendar for the period in the given IANA time zone.
+// An empty time zone defaults to UTC.
+func NewCalendar(period, timeZone string) (*Calendar, error) {
+	switch CalendarPeriod(period) {
+	case CalendarWeek, CalendarMonth, CalendarQuarter:
+	default:
+		return nil, fmt.Errorf("calendar period must be one of week, month or quarter, but got %q", period)
+	}
+
+	location := time.UTC
+	if timeZone != "" {
+		var err error
+		location, err = time.LoadLocation(timeZone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load calendar time zone: %w", err)
+		}
+	}
+
+	return &Calendar{Period: CalendarPeriod(period), Location: location}, nil
+}
+
+// Window returns the nominal duration of a period.
+// It's used wherever a fixed duration is needed, like the multi burn rate alert windows.
+func (c Calendar) Window() model.Duration {
+	day := 24 * time.Hour
+	switch c.Period {
+	case CalendarWeek:
+		return model.Duration(7 * day)
+	case CalendarQuarter:
+		return model.Duration(90 * day)
+	default:
+		return model.Duration(30 * day)
+	}
+}
+
+// Start returns the start of the period t falls into.
+// Weeks start on Monday.
+func (c Calendar) Start(t time.Time) time.Time {
+	t = t.In(c.location())
+	year, month, day := t.Date()
+
+	switch c.Period {
+	case CalendarWeek:
+		monday := time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+		return monday.AddDate(0, 0, -((int(monday.Weekday()) + 6) % 7))
+	case CalendarQuarter:
+		return time.Date(year, ((month-1)/3)*3+1, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// End returns the start of the period following the one t falls into.
+func (c Calendar) End(t time.Time) time.Time {
+	start := c.Start(t)
+
+	switch c.Period {
+	case CalendarWeek:
+		return start.AddDate(0, 0, 7)
+	case CalendarQuarter:
+		return start.AddDate(0, 3, 0)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
+
+func (c Calendar) location() *time.Location {
+	if c.Location == nil {
+		return time.UTC
+	}
+	return c.Location
+}
+
+// QueryCalendar rewrites a query for the rolling window, like the ones returned by
+// QueryTotal, QueryErrors and QueryErrorBudget, to only take the calendar period
+// into account that the evaluation time falls into.
+// The 5m increase recording rules are summed up since the start of the period,
+// so that range queries between start and end reset at every period boundary.
+// Queries of objectives without a Calendar are returned unchanged.
+func (o Objective) QueryCalendar(query string, start, end time.Time) (string, error) {
+	if o.Calendar == nil {
+		return query, nil
+	}
+
+	names := o.calendarMetrics()
+
+	type period struct {
+		query      string
+		start, end time.Time
+	}
+
+	var periods []period
+	for periodStart := o.Calendar.Start(start); !periodStart.After(end); periodStart = o.Calendar.End(periodStart) {
+		periodEnd := o.Calendar.End(periodStart)
+		rangeEnd := periodEnd
+		if rangeEnd.After(end) {
+			rangeEnd = end
+		}
+
+		subqueryRange := rangeEnd.Sub(periodStart).Truncate(time.Minute) + time.Minute
+		if subqueryRange < 5*time.Minute {
+			subqueryRange = 5 * time.Minute
+		}
+
+		expr, err := parser.ParseExpr(query)
+		if err != nil {
+			return "", err
+		}
+
+		startSeconds := strconv.FormatInt(periodStart.Unix(), 10)
+		expr, err = rewriteSelectors(expr, func(vs *parser.VectorSelector) (parser.Expr, error) {
+			short, ok := names[vs.Name]
+			if !ok {
+				return vs, nil
+			}
+			vs.Name = short
+			for _, m := range vs.LabelMatchers {
+				if m.Name == model.MetricNameLabel {
+					m.Value = short
+				}
+			}
+
+			// Only sum up the increases after the period has started.
+			return parser.ParseExpr(fmt.Sprintf(
+				`sum_over_time((%s and on () (vector(time()) > %s))[%s:5m])`,
+				vs.String(), startSeconds, model.Duration(subqueryRange),
+			))
+		})
+		if err != nil {
+			return "", err
+		}
+
+		periods = append(periods, period{
+			query: expr.String(),
+			start: periodStart,
+			end:   periodEnd,
+		})
+	}
+
+	if len(periods) == 1 {
+		return periods[0].query, nil
+	}
+
+	// Select the period the evaluation time falls into.
+	selected := make([]string, 0, len(periods))
+	for _, p := range periods {
+		selected = append(selected, fmt.Sprintf(
+			`(%s) and on () (vector(time()) >= %d < %d)`,
+			p.query, p.start.Unix(), p.end.Unix(),
+		))
+	}
+
+	expr, err := parser.ParseExpr(strings.Join(selected, " or "))
+	if err != nil {
+		return "", err
+	}
+	return expr.String(), nil
+}
+
+// calendarMetrics maps the recording rules for the rolling window
+// to the 5m recording rules calendar periods are summed up from.
+func (o Objective) calendarMetrics() map[string]string {
+	short := model.Duration(5 * time.Minute)
+
+	switch o.IndicatorType() {
+	case Ratio:
+		return map[string]string{
+			increaseName(o.Indicator.Ratio.Total.Name, o.Window):  increaseName(o.Indicator.Ratio.Total.Name, short),
+			increaseName(o.Indicator.Ratio.Errors.Name, o.Window): increaseName(o.Indicator.Ratio.Errors.Name, short),
+		}
+	case Latency:
+		return map[string]string{
+			increaseName(o.Indicator.Latency.Total.Name, o.Window):   increaseName(o.Indicator.Latency.Total.Name, short),
+			increaseName(o.Indicator.Latency.Success.Name, o.Window): increaseName(o.Indicator.Latency.Success.Name, short),
+		}
+	case LatencyNative:
+		return map[string]string{
+			increaseName(o.Indicator.LatencyNative.Total.Name, o.Window): increaseName(o.Indicator.LatencyNative.Total.Name, short),
+		}
+	case BoolGauge:
+		return map[string]string{
+			countName(o.Indicator.BoolGauge.Name, o.Window): countName(o.Indicator.BoolGauge.Name, short),
+			sumName(o.Indicator.BoolGauge.Name, o.Window):   sumName(o.Indicator.BoolGauge.Name, short),
+		}
+	case Raw:
+		return map[string]string{
+			increaseName(rawTotalMetric, o.Window): increaseName(rawTotalMetric, short),
+			increaseName(rawGoodMetric, o.Window):  increaseName(rawGoodMetric, short),
+		}
+	default:
+		return nil
+	}
+}
+
+// rewriteSelectors walks the expression and replaces every vector selector with the returned expression.
+func rewriteSelectors(node parser.Expr, fn func(*parser.VectorSelector) (parser.Expr, error)) (parser.Expr, error) {
+	var err error
+	switch n := node.(type) {
+	case *parser.VectorSelector:
+		return fn(n)
+	case *parser.AggregateExpr:
+		n.Expr, err = rewriteSelectors(n.Expr, fn)
+	case *parser.BinaryExpr:
+		if n.LHS, err = rewriteSelectors(n.LHS, fn); err != nil {
+			return nil, err
+		}
+		n.RHS, err = rewriteSelectors(n.RHS, fn)
+	case *parser.ParenExpr:
+		n.Expr, err = rewriteSelectors(n.Expr, fn)
+	case *parser.UnaryExpr:
+		n.Expr, err = rewriteSelectors(n.Expr, fn)
+	case *parser.Call:
+		for i, arg := range n.Args {
+			if n.Args[i], err = rewriteSelectors(arg, fn); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
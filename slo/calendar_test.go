@@ -0,0 +1,120 @@
+package slo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendar_StartEnd(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	ts := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC) // Saturday
+
+	testcases := []struct {
+		name     string
+		calendar Calendar
+		start    time.Time
+		end      time.Time
+	}{{
+		name:     "week",
+		calendar: Calendar{Period: CalendarWeek},
+		start:    time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC),
+		end:      time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:     "month",
+		calendar: Calendar{Period: CalendarMonth, Location: time.UTC},
+		start:    time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		end:      time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:     "quarter",
+		calendar: Calendar{Period: CalendarQuarter, Location: time.UTC},
+		start:    time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		end:      time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:     "month-berlin",
+		calendar: Calendar{Period: CalendarMonth, Location: berlin},
+		start:    time.Date(2026, 10, 1, 0, 0, 0, 0, berlin),
+		end:      time.Date(2026, 11, 1, 0, 0, 0, 0, berlin),
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.True(t, tc.start.Equal(tc.calendar.Start(ts)), "start: %s", tc.calendar.Start(ts))
+			require.True(t, tc.end.Equal(tc.calendar.End(ts)), "end: %s", tc.calendar.End(ts))
+		})
+	}
+}
+
+func TestNewCalendar(t *testing.T) {
+	c, err := NewCalendar("quarter", "")
+	require.NoError(t, err)
+	require.Equal(t, &Calendar{Period: CalendarQuarter, Location: time.UTC}, c)
+	require.Equal(t, model.Duration(90*24*time.Hour), c.Window())
+
+	_, err = NewCalendar("year", "")
+	require.EqualError(t, err, `calendar period must be one of week, month or quarter, but got "year"`)
+
+	_, err = NewCalendar("month", "Mars/Olympus")
+	require.EqualError(t, err, "failed to load calendar time zone: unknown time zone Mars/Olympus")
+}
+
+func TestObjective_QueryCalendar(t *testing.T) {
+	o := objectiveHTTPRatio()
+	o.Calendar = &Calendar{Period: CalendarMonth, Location: time.UTC}
+	o.Window = o.Calendar.Window()
+
+	ts := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	query, err := o.QueryCalendar(o.QueryTotal(o.Window, GenerationOptions{}), ts, ts)
+	require.NoError(t, err)
+	require.Equal(t, `sum(sum_over_time((http_requests:increase5m{job="thanos-receive-default",slo="monitoring-http-errors"} and on () (vector(time()) > 1790812800))[16d12h1m:5m]))`, query)
+
+	query, err = o.QueryCalendar(o.QueryErrors(o.Window, GenerationOptions{}), ts, ts)
+	require.NoError(t, err)
+	require.Equal(t, `sum(sum_over_time((http_requests:increase5m{code=~"5..",job="thanos-receive-default",slo="monitoring-http-errors"} and on () (vector(time()) > 1790812800))[16d12h1m:5m]))`, query)
+
+	// The range crosses the start of October, so the error budget of September and October are combined.
+	query, err = o.QueryCalendar(o.QueryTotal(o.Window, GenerationOptions{}), ts.AddDate(0, 0, -20), ts)
+	require.NoError(t, err)
+	require.Equal(t, `(sum(sum_over_time((http_requests:increase5m{job="thanos-receive-default",slo="monitoring-http-errors"} and on () (vector(time()) > 1788220800))[30d1m:5m]))) and on () (vector(time()) >= 1788220800 < 1790812800) or (sum(sum_over_time((http_requests:increase5m{job="thanos-receive-default",slo="monitoring-http-errors"} and on () (vector(time()) > 1790812800))[16d12h1m:5m]))) and on () (vector(time()) >= 1790812800 < 1793491200)`, query)
+
+	o.Calendar = nil
+	query, err = o.QueryCalendar(o.QueryTotal(o.Window, GenerationOptions{}), ts, ts)
+	require.NoError(t, err)
+	require.Equal(t, `sum(http_requests:increase30d{job="thanos-receive-default",slo="monitoring-http-errors"})`, query)
+}
+
+func TestObjective_CalendarIncreaseRules(t *testing.T) {
+	o := objectiveHTTPRatio()
+	o.Calendar = &Calendar{Period: CalendarWeek, Location: time.UTC}
+	o.Window = o.Calendar.Window()
+
+	group, err := o.IncreaseRules(GenerationOptions{})
+	require.NoError(t, err)
+
+	var records []string
+	for _, r := range group.Rules {
+		if r.Record != "" {
+			records = append(records, r.Record)
+		}
+	}
+	// The 5m increases are needed to sum up the calendar periods at query time.
+	require.Equal(t, []string{
+		"http_requests:increase5m",
+		"http_requests:increase1w",
+	}, records)
+
+	o = objectiveHTTPNativeLatency()
+	o.Calendar = &Calendar{Period: CalendarWeek, Location: time.UTC}
+	o.Window = o.Calendar.Window()
+
+	group, err = o.IncreaseRules(GenerationOptions{})
+	require.NoError(t, err)
+	require.Len(t, group.Rules, 4)
+	require.Equal(t, "http_request_duration_seconds:increase1w", group.Rules[0].Record)
+	require.Equal(t, "http_request_duration_seconds:increase5m", group.Rules[2].Record)
+	require.Equal(t, `histogram_count(sum(increase(http_request_duration_seconds{code=~"2..",job="metrics-service-thanos-receive-default"}[5m])))`, group.Rules[2].Expr.String())
+}
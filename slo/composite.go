@@ -0,0 +1,191 @@
+package slo
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+// compositeMetric is the metric name of the recording rules of composite objectives.
+// They don't have a metric of their own, the slo label tells them apart.
+const compositeMetric = "pyrra_composite"
+
+// CompositeModel is how the components of a composite objective are combined.
+type CompositeModel string
+
+const (
+	// CompositeWeighted averages the error ratios of the components by their weights.
+	CompositeWeighted CompositeModel = "weighted"
+	// CompositeMultiplicative multiplies the availabilities of the components,
+	// as requests have to succeed in every one of them, like in a chain of services.
+	CompositeMultiplicative CompositeModel = "multiplicative"
+)
+
+// CompositeIndicator combines the indicators of other objectives into one.
+type CompositeIndicator struct {
+	Model      CompositeModel
+	Components []CompositeComponent
+}
+
+// CompositeComponent selects objectives by their labels to be part of a composite objective.
+type CompositeComponent struct {
+	Selector []*labels.Matcher
+	Weight   float64
+
+	// Objectives the Selector matched, set by ResolveComposite.
+	Objectives []Objective
+}
+
+// ResolveComposite returns a copy of the objective with the components of its
+// composite indicator resolved to the matching objectives.
+// Objectives that aren't composite are returned unchanged.
+func (o Objective) ResolveComposite(objectives []Objective) (Objective, error) {
+	if o.Indicator.Composite == nil {
+		return o, nil
+	}
+
+	components := make([]CompositeComponent, 0, len(o.Indicator.Composite.Components))
+	for i, c := range o.Indicator.Composite.Components {
+		var matching []Objective
+		for _, candidate := range objectives {
+			if labels.Equal(candidate.Labels, o.Labels) || !candidate.matches(c.Selector) {
+				continue
+			}
+			if candidate.Indicator.Composite != nil {
+				return Objective{}, fmt.Errorf("composite component %d selects %s which is a composite objective itself", i, candidate.Name())
+			}
+			if grouping := candidate.Grouping(); len(grouping) > 0 {
+				return Objective{}, fmt.Errorf("composite component %d selects %s which is grouped by %s", i, candidate.Name(), strings.Join(grouping, ","))
+			}
+			if candidate.IndicatorType() == Unknown {
+				continue
+			}
+			matching = append(matching, candidate)
+		}
+		if len(matching) == 0 {
+			return Objective{}, fmt.Errorf("composite component %d selector %s matches no objectives", i, selectorString(c.Selector))
+		}
+
+		sort.Slice(matching, func(i, j int) bool {
+			return labels.Compare(matching[i].Labels, matching[j].Labels) < 0
+		})
+
+		components = append(components, CompositeComponent{
+			Selector:   c.Selector,
+			Weight:     c.Weight,
+			Objectives: matching,
+		})
+	}
+
+	o.Indicator.Composite = &CompositeIndicator{
+		Model:      o.Indicator.Composite.Model,
+		Components: components,
+	}
+	return o, nil
+}
+
+// matches returns whether the objective's labels match all matchers.
+// Like for the API, propagated labels match without their prefix too.
+func (o Objective) matches(ms []*labels.Matcher) bool {
+	for _, m := range ms {
+		v := o.Labels.Get(m.Name)
+		if v == "" {
+			v = o.Labels.Get(PropagationLabelsPrefix + m.Name)
+		}
+		if !m.Matches(v) {
+			return false
+		}
+	}
+	return true
+}
+
+type weightedObjective struct {
+	objective Objective
+	weight    float64
+}
+
+// compositeObjectives returns all resolved objectives of the composite indicator with their weight.
+func (o Objective) compositeObjectives() []weightedObjective {
+	var objectives []weightedObjective
+	for _, c := range o.Indicator.Composite.Components {
+		weight := c.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		for _, obj := range c.Objectives {
+			objectives = append(objectives, weightedObjective{objective: obj, weight: weight})
+		}
+	}
+	return objectives
+}
+
+// compositeBurnrate combines the error ratios of the components over the timerange.
+func (o Objective) compositeBurnrate(timerange time.Duration, opts GenerationOptions) string {
+	objectives := o.compositeObjectives()
+	if len(objectives) == 0 {
+		return ""
+	}
+
+	var query string
+	switch o.Indicator.Composite.Model {
+	case CompositeMultiplicative:
+		availabilities := make([]string, 0, len(objectives))
+		for _, c := range objectives {
+			availabilities = append(availabilities, fmt.Sprintf("(1 - (%s))", c.objective.Burnrate(timerange, opts)))
+		}
+		query = fmt.Sprintf("1 - (%s)", strings.Join(availabilities, " * "))
+	default:
+		var total float64
+		ratios := make([]string, 0, len(objectives))
+		for _, c := range objectives {
+			total += c.weight
+			ratios = append(ratios, fmt.Sprintf("%s * (%s)",
+				strconv.FormatFloat(c.weight, 'f', -1, 64),
+				c.objective.Burnrate(timerange, opts),
+			))
+		}
+		query = fmt.Sprintf("(%s) / %s", strings.Join(ratios, " + "), strconv.FormatFloat(total, 'f', -1, 64))
+	}
+
+	expr, err := parser.ParseExpr(query)
+	if err != nil {
+		return err.Error()
+	}
+	return expr.String()
+}
+
+// compositeRequestRange returns the request rates of all components,
+// each with the slo label of the objective it belongs to.
+func (o Objective) compositeRequestRange(timerange time.Duration, opts GenerationOptions) string {
+	objectives := o.compositeObjectives()
+	if len(objectives) == 0 {
+		return ""
+	}
+
+	queries := make([]string, 0, len(objectives))
+	for _, c := range objectives {
+		queries = append(queries, fmt.Sprintf(`label_replace(%s, "slo", "%s", "", "")`,
+			c.objective.RequestRange(timerange, opts),
+			c.objective.Name(),
+		))
+	}
+
+	expr, err := parser.ParseExpr(strings.Join(queries, " or "))
+	if err != nil {
+		return err.Error()
+	}
+	return expr.String()
+}
+
+func selectorString(ms []*labels.Matcher) string {
+	matchers := make([]string, 0, len(ms))
+	for _, m := range ms {
+		matchers = append(matchers, m.String())
+	}
+	return "{" + strings.Join(matchers, ",") + "}"
+}
@@ -0,0 +1,142 @@
+package slo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+)
+
+func objectiveComposite(compositeModel CompositeModel) Objective {
+	return Objective{
+		Labels: labels.FromStrings(model.MetricNameLabel, "checkout"),
+		Target: 0.99,
+		Window: model.Duration(28 * 24 * time.Hour),
+		Alerting: Alerting{
+			Burnrates: true,
+			Absent:    true,
+		},
+		Indicator: Indicator{
+			Composite: &CompositeIndicator{
+				Model: compositeModel,
+				Components: []CompositeComponent{{
+					Selector: []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, model.MetricNameLabel, "monitoring-http-errors")},
+					Weight:   3,
+				}, {
+					Selector: []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, model.MetricNameLabel, "monitoring-http-latency")},
+					Weight:   1,
+				}},
+			},
+		},
+	}
+}
+
+func resolvedComposite(t *testing.T, compositeModel CompositeModel) Objective {
+	o, err := objectiveComposite(compositeModel).ResolveComposite([]Objective{
+		objectiveHTTPRatio(),
+		objectiveHTTPLatency(),
+		objectiveGRPCRatio(),
+	})
+	require.NoError(t, err)
+	return o
+}
+
+func TestObjective_ResolveComposite(t *testing.T) {
+	composite := objectiveComposite(CompositeWeighted)
+	require.Equal(t, Composite, composite.IndicatorType())
+
+	o, err := composite.ResolveComposite([]Objective{
+		objectiveHTTPRatio(),
+		objectiveHTTPLatency(),
+		objectiveGRPCRatio(),
+		composite, // Composite objectives don't select themselves.
+	})
+	require.NoError(t, err)
+	require.Len(t, o.Indicator.Composite.Components, 2)
+	require.Len(t, o.Indicator.Composite.Components[0].Objectives, 1)
+	require.Equal(t, "monitoring-http-errors", o.Indicator.Composite.Components[0].Objectives[0].Name())
+	require.Equal(t, "monitoring-http-latency", o.Indicator.Composite.Components[1].Objectives[0].Name())
+	// The original objective isn't modified.
+	require.Nil(t, composite.Indicator.Composite.Components[0].Objectives)
+
+	_, err = composite.ResolveComposite([]Objective{objectiveHTTPRatio()})
+	require.EqualError(t, err, `composite component 1 selector {__name__="monitoring-http-latency"} matches no objectives`)
+
+	_, err = composite.ResolveComposite([]Objective{objectiveHTTPRatioGrouping(), objectiveHTTPLatency()})
+	require.EqualError(t, err, "composite component 0 selects monitoring-http-errors which is grouped by job,handler")
+
+	other := objectiveComposite(CompositeWeighted)
+	other.Labels = labels.FromStrings(model.MetricNameLabel, "monitoring-http-latency")
+	_, err = composite.ResolveComposite([]Objective{objectiveHTTPRatio(), other})
+	require.EqualError(t, err, "composite component 1 selects monitoring-http-latency which is a composite objective itself")
+
+	o, err = objectiveHTTPRatio().ResolveComposite(nil)
+	require.NoError(t, err)
+	require.Equal(t, objectiveHTTPRatio(), o)
+}
+
+func TestObjective_CompositeBurnrate(t *testing.T) {
+	o := resolvedComposite(t, CompositeWeighted)
+	require.Equal(t, "pyrra_composite:burnrate5m", o.BurnrateName(5*time.Minute))
+	require.Equal(t,
+		`(3 * (sum(rate(http_requests_total{code=~"5..",job="thanos-receive-default"}[5m])) / sum(rate(http_requests_total{job="thanos-receive-default"}[5m]))) + 1 * ((sum(rate(http_request_duration_seconds_count{code=~"2..",job="metrics-service-thanos-receive-default"}[5m])) - sum(rate(http_request_duration_seconds_bucket{code=~"2..",job="metrics-service-thanos-receive-default",le="1"}[5m]))) / sum(rate(http_request_duration_seconds_count{code=~"2..",job="metrics-service-thanos-receive-default"}[5m])))) / 4`,
+		o.Burnrate(5*time.Minute, GenerationOptions{}),
+	)
+
+	o = resolvedComposite(t, CompositeMultiplicative)
+	require.Equal(t,
+		`1 - ((1 - (sum(rate(http_requests_total{code=~"5..",job="thanos-receive-default"}[5m])) / sum(rate(http_requests_total{job="thanos-receive-default"}[5m])))) * (1 - ((sum(rate(http_request_duration_seconds_count{code=~"2..",job="metrics-service-thanos-receive-default"}[5m])) - sum(rate(http_request_duration_seconds_bucket{code=~"2..",job="metrics-service-thanos-receive-default",le="1"}[5m]))) / sum(rate(http_request_duration_seconds_count{code=~"2..",job="metrics-service-thanos-receive-default"}[5m])))))`,
+		o.Burnrate(5*time.Minute, GenerationOptions{}),
+	)
+	require.Equal(t, o.Burnrate(5*time.Minute, GenerationOptions{}), o.ErrorsRange(5*time.Minute, GenerationOptions{}))
+
+	query, err := o.QueryBurnrate(time.Hour, nil)
+	require.NoError(t, err)
+	require.Equal(t, `sum(pyrra_composite:burnrate1h{slo="checkout"})`, query)
+	require.Equal(t, `((1 - 0.99) - sum(pyrra_composite:burnrate4w{slo="checkout"})) / (1 - 0.99)`, o.QueryErrorBudget(GenerationOptions{}))
+	require.Equal(t,
+		`label_replace(sum by (code) (rate(http_requests_total{job="thanos-receive-default"}[5m])) > 0, "slo", "monitoring-http-errors", "", "") or label_replace(sum(rate(http_request_duration_seconds_count{code=~"2..",job="metrics-service-thanos-receive-default"}[5m])), "slo", "monitoring-http-latency", "", "")`,
+		o.RequestRange(5*time.Minute, GenerationOptions{}),
+	)
+	require.Equal(t, "", o.QueryTotal(o.Window, GenerationOptions{}))
+}
+
+func TestObjective_CompositeRules(t *testing.T) {
+	o := resolvedComposite(t, CompositeWeighted)
+
+	increases, err := o.IncreaseRules(GenerationOptions{})
+	require.NoError(t, err)
+	require.Len(t, increases.Rules, 1)
+	require.Equal(t, "pyrra_composite:burnrate4w", increases.Rules[0].Record)
+	require.Equal(t, o.Burnrate(28*24*time.Hour, GenerationOptions{}), increases.Rules[0].Expr.String())
+
+	burnrates, err := o.Burnrates(GenerationOptions{})
+	require.NoError(t, err)
+	require.Len(t, burnrates.Rules, 11)
+	require.Equal(t, "pyrra_composite:burnrate5m", burnrates.Rules[0].Record)
+	require.Equal(t, map[string]string{"slo": "checkout"}, burnrates.Rules[0].Labels)
+	require.Equal(t, "ErrorBudgetBurn", burnrates.Rules[7].Alert)
+	require.Equal(t, `pyrra_composite:burnrate5m{slo="checkout"} > (14 * (1-0.99)) and pyrra_composite:burnrate1h{slo="checkout"} > (14 * (1-0.99))`, burnrates.Rules[7].Expr.String())
+	require.Equal(t, map[string]string{"slo": "checkout", "short": "5m", "long": "1h", "severity": "critical", "exhaustion": "2d"}, burnrates.Rules[7].Labels)
+
+	// Fractional factors of burn rate policies aren't rounded.
+	policy := o
+	policy.BurnRatePolicy = []Window{{Severity: "ticket", Long: 6 * time.Hour, Short: 30 * time.Minute, Factor: 1.5}}
+	burnrates, err = policy.Burnrates(GenerationOptions{})
+	require.NoError(t, err)
+	require.Len(t, burnrates.Rules, 3)
+	require.Equal(t, `pyrra_composite:burnrate30m{slo="checkout"} > (1.5 * (1-0.99)) and pyrra_composite:burnrate6h{slo="checkout"} > (1.5 * (1-0.99))`, burnrates.Rules[2].Expr.String())
+
+	generic, err := o.GenericRules(GenerationOptions{})
+	require.NoError(t, err)
+	require.Len(t, generic.Rules, 3)
+	require.Equal(t, "pyrra_availability", generic.Rules[2].Record)
+	require.Equal(t, `1 - pyrra_composite:burnrate4w{slo="checkout"}`, generic.Rules[2].Expr.String())
+
+	_, err = objectiveComposite(CompositeWeighted).Burnrates(GenerationOptions{})
+	require.EqualError(t, err, "composite objective checkout has no resolved components")
+	_, err = objectiveComposite(CompositeWeighted).IncreaseRules(GenerationOptions{})
+	require.EqualError(t, err, "composite objective checkout has no resolved components")
+}
@@ -47,6 +47,10 @@ func (o Objective) QueryTotal(window model.Duration, opts GenerationOptions) str
 		metric = countName(o.Indicator.BoolGauge.Name, window)
 		matchers = cloneMatchers(o.Indicator.BoolGauge.LabelMatchers)
 		grouping = slices.Clone(o.Indicator.BoolGauge.Grouping)
+	case Raw:
+		metric = increaseName(rawTotalMetric, window)
+		matchers = []*labels.Matcher{{Type: labels.MatchEqual, Name: model.MetricNameLabel, Value: metric}}
+		grouping = slices.Clone(o.Indicator.Raw.Grouping)
 	default:
 		return ""
 	}
@@ -229,6 +233,23 @@ func (o Objective) QueryErrors(window model.Duration, opts GenerationOptions) st
 			grouping:      o.Indicator.BoolGauge.Grouping,
 		}.replace(expr)
 
+		return expr.String()
+	case Raw:
+		expr, err := parser.ParseExpr(`sum by (grouping) (metric{matchers="total"}) - sum by (grouping) (errorMetric{matchers="errors"})`)
+		if err != nil {
+			return ""
+		}
+
+		metric := increaseName(rawTotalMetric, window)
+		errorMetric := increaseName(rawGoodMetric, window)
+		objectiveReplacer{
+			metric:        metric,
+			matchers:      o.rawMatchers(metric),
+			errorMetric:   errorMetric,
+			errorMatchers: o.rawMatchers(errorMetric),
+			grouping:      o.Indicator.Raw.Grouping,
+		}.replace(expr)
+
 		return expr.String()
 	default:
 		return ""
@@ -428,6 +449,53 @@ func (o Objective) QueryErrorBudget(opts GenerationOptions) string {
 			target:        o.Target,
 		}.replace(expr)
 
+		return expr.String()
+	case Raw:
+		expr, err := parser.ParseExpr(`
+(
+  (1 - 0.696969)
+  -
+  (
+    1 -
+    sum(errorMetric{matchers="errors"} or vector(0))
+    /
+    sum(metric{matchers="total"})
+  )
+)
+/
+(1 - 0.696969)
+`)
+		if err != nil {
+			return ""
+		}
+
+		metric := increaseName(rawTotalMetric, o.Window)
+		errorMetric := increaseName(rawGoodMetric, o.Window)
+		objectiveReplacer{
+			metric:        metric,
+			matchers:      o.rawMatchers(metric),
+			errorMetric:   errorMetric,
+			errorMatchers: o.rawMatchers(errorMetric),
+			target:        o.Target,
+		}.replace(expr)
+
+		return expr.String()
+	case Composite:
+		expr, err := parser.ParseExpr(`((1 - 0.696969) - sum(metric{})) / (1 - 0.696969)`)
+		if err != nil {
+			return ""
+		}
+
+		metric := o.BurnrateName(time.Duration(o.Window))
+		objectiveReplacer{
+			metric: metric,
+			matchers: []*labels.Matcher{
+				{Type: labels.MatchEqual, Name: model.MetricNameLabel, Value: metric},
+				{Type: labels.MatchEqual, Name: "slo", Value: o.Name()},
+			},
+			target: o.Target,
+		}.replace(expr)
+
 		return expr.String()
 	default:
 		return ""
@@ -512,6 +580,8 @@ func (o Objective) QueryBurnrate(timerange time.Duration, groupingMatchers []*la
 				}
 			}
 		}
+	case Composite, Raw:
+		metric = o.BurnrateName(timerange)
 	}
 
 	if metric == "" {
@@ -702,12 +772,51 @@ func (o Objective) RequestRange(timerange time.Duration, opts GenerationOptions)
 			window:   timerange,
 		}.replace(expr)
 
+		return expr.String()
+	case Composite:
+		return o.compositeRequestRange(timerange, opts)
+	case Raw:
+		expr, err := rawExpr(o.Indicator.Raw.Total, "rate", timerange, nil)
+		if err != nil {
+			return err.Error()
+		}
 		return expr.String()
 	default:
 		return ""
 	}
 }
 
+// RecordedRequestRange returns a query like RequestRange that reads the 5m increase recording rules instead of the raw metric.
+// Only ratio objectives with PerformanceOverAccuracy or a calendar window record these, for all others it returns false.
+func (o Objective) RecordedRequestRange(timerange time.Duration) (string, bool) {
+	if o.IndicatorType() != Ratio || !o.shortIncreases() {
+		return "", false
+	}
+
+	expr, err := parser.ParseExpr(`sum by (group) (avg_over_time(metric{}[1s])) / 300 > 0`)
+	if err != nil {
+		return "", false
+	}
+
+	metric := increaseName(o.Indicator.Ratio.Total.Name, model.Duration(5*time.Minute))
+	matchers := append(
+		o.buildSubqueryMatchers(o.Indicator.Ratio.Total.LabelMatchers, metric),
+		&labels.Matcher{Type: labels.MatchEqual, Name: "slo", Value: o.Name()},
+	)
+
+	objectiveReplacer{
+		metric:   metric,
+		matchers: matchers,
+		grouping: groupingLabels(
+			o.Indicator.Ratio.Errors.LabelMatchers,
+			o.Indicator.Ratio.Total.LabelMatchers,
+		),
+		window: timerange,
+	}.replace(expr)
+
+	return expr.String(), true
+}
+
 func (o Objective) ErrorsRange(timerange time.Duration, opts GenerationOptions) string {
 	switch o.IndicatorType() {
 	case Ratio:
@@ -797,6 +906,10 @@ func (o Objective) ErrorsRange(timerange time.Duration, opts GenerationOptions)
 		}.replace(expr)
 
 		return expr.String()
+	case Composite:
+		return o.compositeBurnrate(timerange, opts)
+	case Raw:
+		return o.rawBurnrate(timerange, nil)
 	default:
 		return ""
 	}
@@ -845,6 +845,48 @@ func TestObjective_RequestRange(t *testing.T) {
 	}
 }
 
+func TestObjective_RecordedRequestRange(t *testing.T) {
+	performance := func(o Objective) Objective {
+		o.PerformanceOverAccuracy = true
+		return o
+	}
+
+	testcases := []struct {
+		name      string
+		objective Objective
+		timerange time.Duration
+		expected  string
+		ok        bool
+	}{{
+		name:      "http-ratio",
+		objective: objectiveHTTPRatio(),
+		timerange: 6 * time.Hour,
+	}, {
+		name:      "http-ratio-performance",
+		objective: performance(objectiveHTTPRatio()),
+		timerange: 6 * time.Hour,
+		expected:  `sum by (code) (avg_over_time(http_requests:increase5m{job="thanos-receive-default",slo="monitoring-http-errors"}[6h])) / 300 > 0`,
+		ok:        true,
+	}, {
+		name:      "http-ratio-grouping-regex-performance",
+		objective: performance(objectiveHTTPRatioGroupingRegex()),
+		timerange: 6 * time.Hour,
+		expected:  `sum by (code) (avg_over_time(http_requests:increase5m{handler=~"/api.*",job="thanos-receive-default",slo="monitoring-http-errors"}[6h])) / 300 > 0`,
+		ok:        true,
+	}, {
+		name:      "http-latency-performance",
+		objective: performance(objectiveHTTPLatency()),
+		timerange: 2 * time.Hour,
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, ok := tc.objective.RecordedRequestRange(tc.timerange)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.expected, query)
+		})
+	}
+}
+
 func TestObjective_ErrorsRange(t *testing.T) {
 	testcases := []struct {
 		name      string
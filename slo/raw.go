@@ -0,0 +1,186 @@
+package slo
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+const (
+	// rawTotalMetric and rawGoodMetric are the metric names of the recording rules of raw objectives.
+	// As there is no single metric to name them after, the slo label tells them apart.
+	rawTotalMetric = "pyrra_raw_total"
+	rawGoodMetric  = "pyrra_raw_good"
+)
+
+// RawIndicator is an indicator with freeform PromQL expressions for the good and total events.
+// The expressions select counters, like `sum by (job) (label_replace(metric, ...))`,
+// and every selector is wrapped with rate or increase when generating the rules.
+type RawIndicator struct {
+	Good     string
+	Total    string
+	Grouping []string
+}
+
+// NewRawIndicator returns a RawIndicator after validating the expressions.
+// Every label of the grouping has to be kept by both expressions.
+func NewRawIndicator(good, total string, grouping []string) (*RawIndicator, error) {
+	for _, e := range []struct {
+		name  string
+		query string
+	}{{name: "good", query: good}, {name: "total", query: total}} {
+		if e.query == "" {
+			return nil, fmt.Errorf("raw %s expression must be set", e.name)
+		}
+
+		expr, err := parser.ParseExpr(e.query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse raw %s expression: %w", e.name, err)
+		}
+		if expr.Type() != parser.ValueTypeVector {
+			return nil, fmt.Errorf("raw %s expression must return an instant vector, but got %s", e.name, expr.Type())
+		}
+
+		var ranged bool
+		parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+			switch node.(type) {
+			case *parser.MatrixSelector, *parser.SubqueryExpr:
+				ranged = true
+			}
+			return nil
+		})
+		if ranged {
+			return nil, fmt.Errorf("raw %s expression must select counters without a range, rate and increase are added by Pyrra", e.name)
+		}
+
+		for _, g := range grouping {
+			if !keepsLabel(expr, g) {
+				return nil, fmt.Errorf("raw grouping label %q is not kept by the %s expression", g, e.name)
+			}
+		}
+	}
+
+	return &RawIndicator{Good: good, Total: total, Grouping: grouping}, nil
+}
+
+// keepsLabel returns whether the series returned by the expression can still have the label.
+func keepsLabel(expr parser.Expr, name string) bool {
+	switch e := expr.(type) {
+	case *parser.VectorSelector:
+		return name != model.MetricNameLabel
+	case *parser.ParenExpr:
+		return keepsLabel(e.Expr, name)
+	case *parser.AggregateExpr:
+		switch e.Op {
+		case parser.TOPK, parser.BOTTOMK, parser.LIMITK, parser.LIMIT_RATIO:
+			return keepsLabel(e.Expr, name)
+		}
+		if e.Without {
+			return !slices.Contains(e.Grouping, name) && keepsLabel(e.Expr, name)
+		}
+		return slices.Contains(e.Grouping, name) && keepsLabel(e.Expr, name)
+	case *parser.Call:
+		switch e.Func.Name {
+		case "label_replace", "label_join":
+			if dst, ok := e.Args[1].(*parser.StringLiteral); ok && dst.Val == name {
+				return true
+			}
+			return keepsLabel(e.Args[0], name)
+		}
+		for _, arg := range e.Args {
+			if arg.Type() == parser.ValueTypeVector {
+				return keepsLabel(arg, name)
+			}
+		}
+		return false
+	case *parser.BinaryExpr:
+		lhs := e.LHS.Type() == parser.ValueTypeVector
+		rhs := e.RHS.Type() == parser.ValueTypeVector
+		if !lhs || !rhs {
+			return (lhs && keepsLabel(e.LHS, name)) || (rhs && keepsLabel(e.RHS, name))
+		}
+
+		switch e.Op {
+		case parser.LOR:
+			return keepsLabel(e.LHS, name) || keepsLabel(e.RHS, name)
+		case parser.LAND, parser.LUNLESS:
+			return keepsLabel(e.LHS, name)
+		}
+
+		vm := e.VectorMatching
+		if vm == nil {
+			return keepsLabel(e.LHS, name)
+		}
+		switch vm.Card {
+		case parser.CardManyToOne:
+			return keepsLabel(e.LHS, name) || (slices.Contains(vm.Include, name) && keepsLabel(e.RHS, name))
+		case parser.CardOneToMany:
+			return keepsLabel(e.RHS, name) || (slices.Contains(vm.Include, name) && keepsLabel(e.LHS, name))
+		}
+		if vm.On {
+			return slices.Contains(vm.MatchingLabels, name) && keepsLabel(e.LHS, name)
+		}
+		return !slices.Contains(vm.MatchingLabels, name) && keepsLabel(e.LHS, name)
+	default:
+		return false
+	}
+}
+
+func (o Objective) rawGrouping() []string {
+	grouping := slices.Clone(o.Indicator.Raw.Grouping)
+	sort.Strings(grouping)
+	return grouping
+}
+
+// rawExpr wraps every selector of the expression with the function over the timerange,
+// like increase(metric[4w]), and sums the result by the grouping.
+func rawExpr(query, function string, timerange time.Duration, grouping []string) (parser.Expr, error) {
+	expr, err := parser.ParseExpr(query)
+	if err != nil {
+		return nil, err
+	}
+
+	expr, err = rewriteSelectors(expr, func(vs *parser.VectorSelector) (parser.Expr, error) {
+		return parser.ParseExpr(fmt.Sprintf("%s(%s[%s])", function, vs.String(), model.Duration(timerange)))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &parser.AggregateExpr{
+		Op:       parser.SUM,
+		Expr:     expr,
+		Grouping: grouping,
+	}, nil
+}
+
+// rawBurnrate returns the error ratio of the raw expressions over the timerange.
+func (o Objective) rawBurnrate(timerange time.Duration, grouping []string) string {
+	total, err := rawExpr(o.Indicator.Raw.Total, "rate", timerange, grouping)
+	if err != nil {
+		return err.Error()
+	}
+	good, err := rawExpr(o.Indicator.Raw.Good, "rate", timerange, grouping)
+	if err != nil {
+		return err.Error()
+	}
+
+	expr, err := parser.ParseExpr(fmt.Sprintf("(%s - %s) / %s", total, good, total))
+	if err != nil {
+		return err.Error()
+	}
+	return expr.String()
+}
+
+// rawMatchers select the recording rule of the raw objective.
+func (o Objective) rawMatchers(metric string) []*labels.Matcher {
+	return []*labels.Matcher{
+		{Type: labels.MatchEqual, Name: model.MetricNameLabel, Value: metric},
+		{Type: labels.MatchEqual, Name: "slo", Value: o.Name()},
+	}
+}
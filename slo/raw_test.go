@@ -0,0 +1,139 @@
+package slo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+)
+
+func objectiveRaw() Objective {
+	return Objective{
+		Labels: labels.FromStrings(model.MetricNameLabel, "api-raw"),
+		Target: 0.99,
+		Window: model.Duration(28 * 24 * time.Hour),
+		Alerting: Alerting{
+			Burnrates: true,
+			Absent:    true,
+		},
+		Indicator: Indicator{
+			Raw: &RawIndicator{
+				Good:     `sum by (handler) (label_replace(http_requests_total{code!~"5.."}, "handler", "$1", "path", "(.*)")) or grpc_requests_total{code="OK"}`,
+				Total:    `sum by (handler) (label_replace(http_requests_total, "handler", "$1", "path", "(.*)")) or grpc_requests_total`,
+				Grouping: []string{"handler"},
+			},
+		},
+	}
+}
+
+func TestNewRawIndicator(t *testing.T) {
+	r, err := NewRawIndicator(objectiveRaw().Indicator.Raw.Good, objectiveRaw().Indicator.Raw.Total, []string{"handler"})
+	require.NoError(t, err)
+	require.Equal(t, objectiveRaw().Indicator.Raw, r)
+
+	testcases := []struct {
+		name     string
+		good     string
+		total    string
+		grouping []string
+		err      string
+	}{{
+		name:  "missing",
+		total: `http_requests_total`,
+		err:   "raw good expression must be set",
+	}, {
+		name:  "invalid",
+		good:  `http_requests_total{`,
+		total: `http_requests_total`,
+		err:   "failed to parse raw good expression: 1:21: parse error: unexpected end of input inside braces",
+	}, {
+		name:  "scalar",
+		good:  `http_requests_total`,
+		total: `1`,
+		err:   "raw total expression must return an instant vector, but got scalar",
+	}, {
+		name:  "range",
+		good:  `sum(rate(http_requests_total{code!~"5.."}[5m]))`,
+		total: `http_requests_total`,
+		err:   "raw good expression must select counters without a range, rate and increase are added by Pyrra",
+	}, {
+		name:     "grouping-dropped",
+		good:     `sum by (job) (http_requests_total{code!~"5.."})`,
+		total:    `sum by (job, handler) (http_requests_total)`,
+		grouping: []string{"handler"},
+		err:      `raw grouping label "handler" is not kept by the good expression`,
+	}, {
+		name:     "grouping-without",
+		good:     `sum without (handler) (http_requests_total{code!~"5.."})`,
+		total:    `http_requests_total`,
+		grouping: []string{"handler"},
+		err:      `raw grouping label "handler" is not kept by the good expression`,
+	}, {
+		name:     "grouping-on",
+		good:     `http_requests_total * on (job) group_left (team) up`,
+		total:    `http_requests_total / on (job) up`,
+		grouping: []string{"team"},
+		err:      `raw grouping label "team" is not kept by the total expression`,
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewRawIndicator(tc.good, tc.total, tc.grouping)
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestObjective_RawRules(t *testing.T) {
+	o := objectiveRaw()
+	require.Equal(t, Raw, o.IndicatorType())
+	require.Equal(t, []string{"handler"}, o.Grouping())
+
+	increases, err := o.IncreaseRules(GenerationOptions{})
+	require.NoError(t, err)
+	require.Len(t, increases.Rules, 3)
+	require.Equal(t, "pyrra_raw:increase4w", increases.Rules[0].Record)
+	require.Equal(t, `sum by (handler) (sum by (handler) (label_replace(increase(http_requests_total[4w]), "handler", "$1", "path", "(.*)")) or increase(grpc_requests_total[4w]))`, increases.Rules[0].Expr.String())
+	require.Equal(t, map[string]string{"slo": "api-raw"}, increases.Rules[0].Labels)
+	require.Equal(t, "pyrra_raw_good:increase4w", increases.Rules[1].Record)
+	require.Equal(t, `sum by (handler) (sum by (handler) (label_replace(increase(http_requests_total{code!~"5.."}[4w]), "handler", "$1", "path", "(.*)")) or increase(grpc_requests_total{code="OK"}[4w]))`, increases.Rules[1].Expr.String())
+	require.Equal(t, "SLOMetricAbsent", increases.Rules[2].Alert)
+	require.Equal(t, `absent(sum by (handler) (label_replace(http_requests_total, "handler", "$1", "path", "(.*)")) or grpc_requests_total) == 1`, increases.Rules[2].Expr.String())
+
+	burnrates, err := o.Burnrates(GenerationOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "pyrra_raw:burnrate5m", burnrates.Rules[0].Record)
+	require.Equal(t, `(sum by (handler) (sum by (handler) (label_replace(rate(http_requests_total[5m]), "handler", "$1", "path", "(.*)")) or rate(grpc_requests_total[5m])) - sum by (handler) (sum by (handler) (label_replace(rate(http_requests_total{code!~"5.."}[5m]), "handler", "$1", "path", "(.*)")) or rate(grpc_requests_total{code="OK"}[5m]))) / sum by (handler) (sum by (handler) (label_replace(rate(http_requests_total[5m]), "handler", "$1", "path", "(.*)")) or rate(grpc_requests_total[5m]))`, burnrates.Rules[0].Expr.String())
+	require.Equal(t, "ErrorBudgetBurn", burnrates.Rules[7].Alert)
+	require.Equal(t, `pyrra_raw:burnrate5m{slo="api-raw"} > (14 * (1-0.99)) and pyrra_raw:burnrate1h{slo="api-raw"} > (14 * (1-0.99))`, burnrates.Rules[7].Expr.String())
+
+	_, err = o.GenericRules(GenerationOptions{})
+	require.Equal(t, ErrGroupingUnsupported, err)
+
+	o.PerformanceOverAccuracy = true
+	short, long, err := o.SplitIncreaseRules(GenerationOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "pyrra_raw:increase5m", short.Rules[0].Record)
+	require.Equal(t, "pyrra_raw:increase4w", long.Rules[0].Record)
+	require.Equal(t, `sum by (handler) (sum_over_time(pyrra_raw:increase5m{slo="api-raw"}[4w:5m]))`, long.Rules[0].Expr.String())
+}
+
+func TestObjective_RawQueries(t *testing.T) {
+	o := objectiveRaw()
+
+	require.Equal(t, `sum by (handler) (pyrra_raw:increase4w{slo="api-raw"})`, o.QueryTotal(o.Window, GenerationOptions{}))
+	require.Equal(t, `sum by (handler) (pyrra_raw:increase4w{slo="api-raw"}) - sum by (handler) (pyrra_raw_good:increase4w{slo="api-raw"})`, o.QueryErrors(o.Window, GenerationOptions{}))
+	require.Equal(t, `((1 - 0.99) - (1 - sum(pyrra_raw_good:increase4w{slo="api-raw"} or vector(0)) / sum(pyrra_raw:increase4w{slo="api-raw"}))) / (1 - 0.99)`, o.QueryErrorBudget(GenerationOptions{}))
+
+	query, err := o.QueryBurnrate(time.Hour, nil)
+	require.NoError(t, err)
+	require.Equal(t, `sum(pyrra_raw:burnrate1h{slo="api-raw"})`, query)
+
+	require.Equal(t, `sum(sum by (handler) (label_replace(rate(http_requests_total[5m]), "handler", "$1", "path", "(.*)")) or rate(grpc_requests_total[5m]))`, o.RequestRange(5*time.Minute, GenerationOptions{}))
+
+	o.Indicator.Raw.Grouping = nil
+	generic, err := o.GenericRules(GenerationOptions{})
+	require.NoError(t, err)
+	require.Equal(t, `sum(pyrra_raw_good:increase4w{slo="api-raw"} or vector(0)) / sum(pyrra_raw:increase4w{slo="api-raw"})`, generic.Rules[2].Expr.String())
+}
@@ -30,7 +30,7 @@ type MultiBurnRateAlert struct {
 }
 
 func (o Objective) Alerts() ([]MultiBurnRateAlert, error) {
-	ws := Windows(time.Duration(o.Window))
+	ws := o.Windows()
 
 	mbras := make([]MultiBurnRateAlert, len(ws))
 	for i, w := range ws {
@@ -61,7 +61,7 @@ func (o Objective) Burnrates(opts GenerationOptions) (monitoringv1.RuleGroup, er
 	sloName := o.Labels.Get(model.MetricNameLabel)
 	externalURL := opts.ExternalURL
 
-	ws := Windows(time.Duration(o.Window))
+	ws := o.Windows()
 	burnrates := burnratesFromWindows(ws)
 	rules := make([]monitoringv1.Rule, 0, len(burnrates))
 
@@ -129,6 +129,8 @@ func (o Objective) Burnrates(opts GenerationOptions) (monitoringv1.RuleGroup, er
 				}
 			}
 
+			alertAnnotations = o.addAlertingLabels(w, alertLabels, alertAnnotations)
+
 			// Propagate useful SLO information to alerts' labels
 			alertLabels["short"] = model.Duration(w.Short).String()
 			alertLabels["long"] = model.Duration(w.Long).String()
@@ -138,14 +140,14 @@ func (o Objective) Burnrates(opts GenerationOptions) (monitoringv1.RuleGroup, er
 			r := monitoringv1.Rule{
 				Alert: o.AlertName(),
 				// TODO: Use expr replacer
-				Expr: intstr.FromString(fmt.Sprintf("%s{%s} > (%.f * (1-%s)) and %s{%s} > (%.f * (1-%s))",
+				Expr: intstr.FromString(fmt.Sprintf("%s{%s} > (%s * (1-%s)) and %s{%s} > (%s * (1-%s))",
 					o.BurnrateName(w.Short),
 					alertMatchersString,
-					w.Factor,
+					strconv.FormatFloat(w.Factor, 'f', -1, 64),
 					strconv.FormatFloat(o.Target, 'f', -1, 64),
 					o.BurnrateName(w.Long),
 					alertMatchersString,
-					w.Factor,
+					strconv.FormatFloat(w.Factor, 'f', -1, 64),
 					strconv.FormatFloat(o.Target, 'f', -1, 64),
 				)),
 				For:         monitoringDuration(w.For.String()),
@@ -217,6 +219,8 @@ func (o Objective) Burnrates(opts GenerationOptions) (monitoringv1.RuleGroup, er
 				}
 			}
 
+			alertAnnotations = o.addAlertingLabels(w, alertLabels, alertAnnotations)
+
 			// Propagate useful SLO information to alerts' labels
 			alertLabels["short"] = model.Duration(w.Short).String()
 			alertLabels["long"] = model.Duration(w.Long).String()
@@ -226,14 +230,14 @@ func (o Objective) Burnrates(opts GenerationOptions) (monitoringv1.RuleGroup, er
 			r := monitoringv1.Rule{
 				Alert: o.AlertName(),
 				// TODO: Use expr replacer
-				Expr: intstr.FromString(fmt.Sprintf("%s{%s} > (%.f * (1-%s)) and %s{%s} > (%.f * (1-%s))",
+				Expr: intstr.FromString(fmt.Sprintf("%s{%s} > (%s * (1-%s)) and %s{%s} > (%s * (1-%s))",
 					o.BurnrateName(w.Short),
 					alertMatchersString,
-					w.Factor,
+					strconv.FormatFloat(w.Factor, 'f', -1, 64),
 					strconv.FormatFloat(o.Target, 'f', -1, 64),
 					o.BurnrateName(w.Long),
 					alertMatchersString,
-					w.Factor,
+					strconv.FormatFloat(w.Factor, 'f', -1, 64),
 					strconv.FormatFloat(o.Target, 'f', -1, 64),
 				)),
 				For:         monitoringDuration(model.Duration(w.For).String()),
@@ -305,6 +309,8 @@ func (o Objective) Burnrates(opts GenerationOptions) (monitoringv1.RuleGroup, er
 				}
 			}
 
+			alertAnnotations = o.addAlertingLabels(w, alertLabels, alertAnnotations)
+
 			// Propagate useful SLO information to alerts' labels
 			alertLabels["short"] = model.Duration(w.Short).String()
 			alertLabels["long"] = model.Duration(w.Long).String()
@@ -314,14 +320,14 @@ func (o Objective) Burnrates(opts GenerationOptions) (monitoringv1.RuleGroup, er
 			r := monitoringv1.Rule{
 				Alert: o.AlertName(),
 				// TODO: Use expr replacer
-				Expr: intstr.FromString(fmt.Sprintf("%s{%s} > (%.f * (1-%s)) and %s{%s} > (%.f * (1-%s))",
+				Expr: intstr.FromString(fmt.Sprintf("%s{%s} > (%s * (1-%s)) and %s{%s} > (%s * (1-%s))",
 					o.BurnrateName(w.Short),
 					alertMatchersString,
-					w.Factor,
+					strconv.FormatFloat(w.Factor, 'f', -1, 64),
 					strconv.FormatFloat(o.Target, 'f', -1, 64),
 					o.BurnrateName(w.Long),
 					alertMatchersString,
-					w.Factor,
+					strconv.FormatFloat(w.Factor, 'f', -1, 64),
 					strconv.FormatFloat(o.Target, 'f', -1, 64),
 				)),
 				For:         monitoringDuration(model.Duration(w.For).String()),
@@ -393,6 +399,8 @@ func (o Objective) Burnrates(opts GenerationOptions) (monitoringv1.RuleGroup, er
 				}
 			}
 
+			alertAnnotations = o.addAlertingLabels(w, alertLabels, alertAnnotations)
+
 			// Propagate useful SLO information to alerts' labels
 			alertLabels["short"] = model.Duration(w.Short).String()
 			alertLabels["long"] = model.Duration(w.Long).String()
@@ -402,14 +410,14 @@ func (o Objective) Burnrates(opts GenerationOptions) (monitoringv1.RuleGroup, er
 			r := monitoringv1.Rule{
 				Alert: o.AlertName(),
 				// TODO: Use expr replacer
-				Expr: intstr.FromString(fmt.Sprintf("%s{%s} > (%.f * (1-%s)) and %s{%s} > (%.f * (1-%s))",
+				Expr: intstr.FromString(fmt.Sprintf("%s{%s} > (%s * (1-%s)) and %s{%s} > (%s * (1-%s))",
 					o.BurnrateName(w.Short),
 					alertMatchersString,
-					w.Factor,
+					strconv.FormatFloat(w.Factor, 'f', -1, 64),
 					strconv.FormatFloat(o.Target, 'f', -1, 64),
 					o.BurnrateName(w.Long),
 					alertMatchersString,
-					w.Factor,
+					strconv.FormatFloat(w.Factor, 'f', -1, 64),
 					strconv.FormatFloat(o.Target, 'f', -1, 64),
 				)),
 				For:         monitoringDuration(model.Duration(w.For).String()),
@@ -418,6 +426,61 @@ func (o Objective) Burnrates(opts GenerationOptions) (monitoringv1.RuleGroup, er
 			}
 			rules = append(rules, r)
 		}
+	case Composite, Raw:
+		if o.IndicatorType() == Composite && len(o.compositeObjectives()) == 0 {
+			return monitoringv1.RuleGroup{}, fmt.Errorf("composite objective %s has no resolved components", sloName)
+		}
+
+		ruleLabels := o.commonRuleLabels(sloName)
+
+		for _, br := range burnrates {
+			rules = append(rules, monitoringv1.Rule{
+				Record: o.BurnrateName(br),
+				Expr:   intstr.FromString(o.Burnrate(br, opts)),
+				Labels: ruleLabels,
+			})
+		}
+
+		if o.Alerting.Disabled || !o.Alerting.Burnrates {
+			return monitoringv1.RuleGroup{
+				Name:     sloName,
+				Interval: monitoringDuration("30s"), // TODO: Increase or decrease based on availability target
+				Rules:    rules,
+			}, nil
+		}
+
+		// Composite and raw objectives have no metric matchers, the slo label is all there is.
+		alertMatchersString := fmt.Sprintf(`slo="%s"`, sloName)
+
+		for i, w := range ws {
+			alertLabels := o.commonRuleLabels(sloName)
+			alertAnnotations := o.commonRuleAnnotations(externalURL)
+
+			alertAnnotations = o.addAlertingLabels(w, alertLabels, alertAnnotations)
+
+			// Propagate useful SLO information to alerts' labels
+			alertLabels["short"] = model.Duration(w.Short).String()
+			alertLabels["long"] = model.Duration(w.Long).String()
+			alertLabels["severity"] = o.alertSeverityLabel(i, w)
+			alertLabels["exhaustion"] = o.Exhausts(w.Factor).String()
+
+			rules = append(rules, monitoringv1.Rule{
+				Alert: o.AlertName(),
+				Expr: intstr.FromString(fmt.Sprintf("%s{%s} > (%s * (1-%s)) and %s{%s} > (%s * (1-%s))",
+					o.BurnrateName(w.Short),
+					alertMatchersString,
+					strconv.FormatFloat(w.Factor, 'f', -1, 64),
+					strconv.FormatFloat(o.Target, 'f', -1, 64),
+					o.BurnrateName(w.Long),
+					alertMatchersString,
+					strconv.FormatFloat(w.Factor, 'f', -1, 64),
+					strconv.FormatFloat(o.Target, 'f', -1, 64),
+				)),
+				For:         monitoringDuration(model.Duration(w.For).String()),
+				Labels:      alertLabels,
+				Annotations: alertAnnotations,
+			})
+		}
 	}
 
 	// We only get here if alerting was not disabled
@@ -440,6 +503,10 @@ func (o Objective) BurnrateName(rate time.Duration) string {
 		metric = o.Indicator.LatencyNative.Total.Name
 	case BoolGauge:
 		metric = o.Indicator.BoolGauge.Name
+	case Composite:
+		metric = compositeMetric
+	case Raw:
+		metric = rawTotalMetric
 	}
 
 	metric = strings.TrimSuffix(metric, "_total")
@@ -448,6 +515,21 @@ func (o Objective) BurnrateName(rate time.Duration) string {
 	return fmt.Sprintf("%s:burnrate%s", metric, model.Duration(rate))
 }
 
+// BurnrateWindow returns the shortest recorded burn rate that is at least as long as the timerange,
+// or the longest recorded burn rate if the timerange is longer than all of them.
+func (o Objective) BurnrateWindow(timerange time.Duration) time.Duration {
+	burnrates := burnratesFromWindows(o.Windows())
+	for _, br := range burnrates {
+		if br >= timerange {
+			return br
+		}
+	}
+	if len(burnrates) == 0 {
+		return timerange
+	}
+	return burnrates[len(burnrates)-1]
+}
+
 func (o Objective) Burnrate(timerange time.Duration, opts GenerationOptions) string {
 	switch o.IndicatorType() {
 	case Ratio:
@@ -573,6 +655,10 @@ func (o Objective) Burnrate(timerange time.Duration, opts GenerationOptions) str
 		}.replace(expr)
 
 		return expr.String()
+	case Composite:
+		return o.compositeBurnrate(timerange, opts)
+	case Raw:
+		return o.rawBurnrate(timerange, o.rawGrouping())
 	default:
 		return ""
 	}
@@ -707,6 +793,12 @@ func (o Objective) increaseInterval() model.Duration {
 	return model.Duration(240 * time.Second) // 8w+
 }
 
+// shortIncreases returns whether the 5m increase recording rules are generated.
+// They are needed for PerformanceOverAccuracy and to sum up calendar periods at query time.
+func (o Objective) shortIncreases() bool {
+	return o.PerformanceOverAccuracy || o.Calendar != nil
+}
+
 // IncreaseRules returns a single RuleGroup with all increase rules.
 func (o Objective) IncreaseRules(opts GenerationOptions) (monitoringv1.RuleGroup, error) {
 	sloName := o.Labels.Get(model.MetricNameLabel)
@@ -729,7 +821,8 @@ func (o Objective) IncreaseRules(opts GenerationOptions) (monitoringv1.RuleGroup
 //   - short: 5m increase recording rules and absent alerts (run on Prometheus)
 //   - long: subquery rules over the full window (run on Thanos)
 //
-// When PerformanceOverAccuracy is false, short is empty and long contains all rules.
+// When PerformanceOverAccuracy is false, short is empty and long contains all rules,
+// unless the objective has a Calendar which needs the 5m increase recording rules too.
 func (o Objective) SplitIncreaseRules(opts GenerationOptions) (short, long monitoringv1.RuleGroup, err error) {
 	sloName := o.Labels.Get(model.MetricNameLabel)
 
@@ -770,6 +863,11 @@ func (o Objective) splitIncreaseRulesForType(sloName string, opts GenerationOpti
 		return nil, rules, err
 	case BoolGauge:
 		return o.increaseRuleBoolGauge(sloName)
+	case Composite:
+		rules, err := o.increaseRuleComposite(sloName, opts)
+		return nil, rules, err
+	case Raw:
+		return o.increaseRulesRaw(sloName)
 	}
 	return nil, nil, nil
 }
@@ -812,7 +910,7 @@ func (o Objective) increaseRulesRatio(sloName string) (shortRules, longRules []m
 		return nil, nil, err
 	}
 
-	if o.PerformanceOverAccuracy {
+	if o.shortIncreases() {
 		objectiveReplacer{
 			metric:   o.Indicator.Ratio.Total.Name,
 			matchers: o.Indicator.Ratio.Total.LabelMatchers,
@@ -888,7 +986,7 @@ func (o Objective) increaseRulesRatio(sloName string) (shortRules, longRules []m
 			Labels:      alertLabels,
 			Annotations: o.commonRuleAnnotations(""),
 		}
-		if o.PerformanceOverAccuracy {
+		if o.shortIncreases() {
 			shortRules = append(shortRules, absentRule)
 		} else {
 			longRules = append(longRules, absentRule)
@@ -901,7 +999,7 @@ func (o Objective) increaseRulesRatio(sloName string) (shortRules, longRules []m
 			return nil, nil, err
 		}
 
-		if o.PerformanceOverAccuracy {
+		if o.shortIncreases() {
 			// Short rule: increase(errors[5m])
 			objectiveReplacer{
 				metric:   o.Indicator.Ratio.Errors.Name,
@@ -969,7 +1067,7 @@ func (o Objective) increaseRulesRatio(sloName string) (shortRules, longRules []m
 				Labels:      alertLabels,
 				Annotations: o.commonRuleAnnotations(""),
 			}
-			if o.PerformanceOverAccuracy {
+			if o.shortIncreases() {
 				shortRules = append(shortRules, absentRule)
 			} else {
 				longRules = append(longRules, absentRule)
@@ -1044,7 +1142,7 @@ func (o Objective) increaseRuleLatency(sloName string, opts GenerationOptions) (
 	}
 
 	window := time.Duration(o.Window)
-	if o.PerformanceOverAccuracy {
+	if o.shortIncreases() {
 		window = 5 * time.Minute
 	}
 
@@ -1086,7 +1184,7 @@ func (o Objective) increaseRuleLatency(sloName string, opts GenerationOptions) (
 		Labels: ruleLabelsLe,
 	}
 
-	if o.PerformanceOverAccuracy {
+	if o.shortIncreases() {
 		shortRules = append(shortRules, totalRule, successRule)
 
 		// Long rule: sum_over_time for total
@@ -1190,7 +1288,7 @@ func (o Objective) increaseRuleLatency(sloName string, opts GenerationOptions) (
 			Annotations: o.commonRuleAnnotations(""),
 		}
 
-		if o.PerformanceOverAccuracy {
+		if o.shortIncreases() {
 			shortRules = append(shortRules, absentTotalRule, absentSuccessRule)
 		} else {
 			longRules = append(longRules, absentTotalRule, absentSuccessRule)
@@ -1210,46 +1308,54 @@ func (o Objective) increaseRuleLatencyNative(sloName string) ([]monitoringv1.Rul
 		}
 	}
 
-	expr, err := parser.ParseExpr(`histogram_count(sum by (grouping) (increase(metric{matchers="total"}[1s])))`)
-	if err != nil {
-		return rules, err
+	latencySeconds := time.Duration(o.Indicator.LatencyNative.Latency).Seconds()
+	latencyRuleLabels := maps.Clone(ruleLabels)
+	latencyRuleLabels["le"] = fmt.Sprintf("%g", latencySeconds)
+
+	windows := []model.Duration{o.Window}
+	if o.Calendar != nil {
+		// Calendar periods are summed up from the 5m increases at query time.
+		windows = append(windows, model.Duration(5*time.Minute))
 	}
 
-	objectiveReplacer{
-		metric:   o.Indicator.LatencyNative.Total.Name,
-		matchers: slices.Clone(o.Indicator.LatencyNative.Total.LabelMatchers),
-		grouping: slices.Clone(o.Indicator.LatencyNative.Grouping),
-		window:   time.Duration(o.Window),
-	}.replace(expr)
+	for _, window := range windows {
+		expr, err := parser.ParseExpr(`histogram_count(sum by (grouping) (increase(metric{matchers="total"}[1s])))`)
+		if err != nil {
+			return rules, err
+		}
 
-	rules = append(rules, monitoringv1.Rule{
-		Record: increaseName(o.Indicator.LatencyNative.Total.Name, o.Window),
-		Expr:   intstr.FromString(expr.String()),
-		Labels: ruleLabels,
-	})
+		objectiveReplacer{
+			metric:   o.Indicator.LatencyNative.Total.Name,
+			matchers: slices.Clone(o.Indicator.LatencyNative.Total.LabelMatchers),
+			grouping: slices.Clone(o.Indicator.LatencyNative.Grouping),
+			window:   time.Duration(window),
+		}.replace(expr)
 
-	expr, err = parser.ParseExpr(`histogram_fraction(0, 0.696969, sum by (grouping) (increase(metric{matchers="total"}[1s]))) * histogram_count(sum by (grouping) (increase(metric{matchers="total"}[1s])))`)
-	if err != nil {
-		return rules, err
-	}
+		rules = append(rules, monitoringv1.Rule{
+			Record: increaseName(o.Indicator.LatencyNative.Total.Name, window),
+			Expr:   intstr.FromString(expr.String()),
+			Labels: ruleLabels,
+		})
 
-	latencySeconds := time.Duration(o.Indicator.LatencyNative.Latency).Seconds()
-	objectiveReplacer{
-		metric:   o.Indicator.LatencyNative.Total.Name,
-		matchers: slices.Clone(o.Indicator.LatencyNative.Total.LabelMatchers),
-		grouping: slices.Clone(o.Indicator.LatencyNative.Grouping),
-		window:   time.Duration(o.Window),
-		target:   latencySeconds,
-	}.replace(expr)
+		expr, err = parser.ParseExpr(`histogram_fraction(0, 0.696969, sum by (grouping) (increase(metric{matchers="total"}[1s]))) * histogram_count(sum by (grouping) (increase(metric{matchers="total"}[1s])))`)
+		if err != nil {
+			return rules, err
+		}
 
-	ruleLabels = maps.Clone(ruleLabels)
-	ruleLabels["le"] = fmt.Sprintf("%g", latencySeconds)
+		objectiveReplacer{
+			metric:   o.Indicator.LatencyNative.Total.Name,
+			matchers: slices.Clone(o.Indicator.LatencyNative.Total.LabelMatchers),
+			grouping: slices.Clone(o.Indicator.LatencyNative.Grouping),
+			window:   time.Duration(window),
+			target:   latencySeconds,
+		}.replace(expr)
 
-	rules = append(rules, monitoringv1.Rule{
-		Record: increaseName(o.Indicator.LatencyNative.Total.Name, o.Window),
-		Expr:   intstr.FromString(expr.String()),
-		Labels: ruleLabels,
-	})
+		rules = append(rules, monitoringv1.Rule{
+			Record: increaseName(o.Indicator.LatencyNative.Total.Name, window),
+			Expr:   intstr.FromString(expr.String()),
+			Labels: latencyRuleLabels,
+		})
+	}
 
 	return rules, nil
 }
@@ -1295,7 +1401,7 @@ func (o Objective) increaseRuleBoolGauge(sloName string) (shortRules, longRules
 		return nil, nil, err
 	}
 
-	if o.PerformanceOverAccuracy {
+	if o.shortIncreases() {
 		// Short rules: 5m window count_over_time + sum_over_time
 		objectiveReplacer{
 			metric:   o.Indicator.BoolGauge.Name,
@@ -1413,7 +1519,107 @@ func (o Objective) increaseRuleBoolGauge(sloName string) (shortRules, longRules
 			Labels:      alertLabels,
 			Annotations: o.commonRuleAnnotations(""),
 		}
-		if o.PerformanceOverAccuracy {
+		if o.shortIncreases() {
+			shortRules = append(shortRules, absentRule)
+		} else {
+			longRules = append(longRules, absentRule)
+		}
+	}
+
+	return shortRules, longRules, nil
+}
+
+// increaseRuleComposite records the combined error ratio over the whole window.
+// There is nothing to increase for composite objectives, the components' metrics
+// are absent-alerted by their own objectives already.
+func (o Objective) increaseRuleComposite(sloName string, opts GenerationOptions) ([]monitoringv1.Rule, error) {
+	if len(o.compositeObjectives()) == 0 {
+		return nil, fmt.Errorf("composite objective %s has no resolved components", sloName)
+	}
+
+	return []monitoringv1.Rule{{
+		Record: o.BurnrateName(time.Duration(o.Window)),
+		Expr:   intstr.FromString(o.Burnrate(time.Duration(o.Window), opts)),
+		Labels: o.commonRuleLabels(sloName),
+	}}, nil
+}
+
+// increaseRulesRaw records the increases of the raw total and good expressions over the window.
+func (o Objective) increaseRulesRaw(sloName string) (shortRules, longRules []monitoringv1.Rule, err error) {
+	ruleLabels := o.commonRuleLabels(sloName)
+	grouping := o.rawGrouping()
+
+	for _, e := range []struct {
+		metric string
+		query  string
+	}{
+		{metric: rawTotalMetric, query: o.Indicator.Raw.Total},
+		{metric: rawGoodMetric, query: o.Indicator.Raw.Good},
+	} {
+		if !o.shortIncreases() {
+			expr, err := rawExpr(e.query, "increase", time.Duration(o.Window), grouping)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			longRules = append(longRules, monitoringv1.Rule{
+				Record: increaseName(e.metric, o.Window),
+				Expr:   intstr.FromString(expr.String()),
+				Labels: ruleLabels,
+			})
+			continue
+		}
+
+		// Short rule: increase(expr[5m])
+		expr, err := rawExpr(e.query, "increase", 5*time.Minute, grouping)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		subqueryName := increaseName(e.metric, model.Duration(5*time.Minute))
+		shortRules = append(shortRules, monitoringv1.Rule{
+			Record: subqueryName,
+			Expr:   intstr.FromString(expr.String()),
+			Labels: ruleLabels,
+		})
+
+		// Long rule: sum_over_time(pyrra_raw:increase5m[window:5m])
+		subExpr, err := o.increaseSubqueryExpr()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		objectiveReplacer{
+			metric:   subqueryName,
+			matchers: o.rawMatchers(subqueryName),
+			grouping: grouping,
+			window:   time.Duration(o.Window),
+		}.replace(subExpr)
+
+		longRules = append(longRules, monitoringv1.Rule{
+			Record: increaseName(e.metric, o.Window),
+			Expr:   intstr.FromString(subExpr.String()),
+			Labels: ruleLabels,
+		})
+	}
+
+	if o.Alerting.Absent {
+		expr, err := parser.ParseExpr(o.Indicator.Raw.Total)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		alertLabels := o.commonRuleLabels(sloName)
+		alertLabels["severity"] = o.alertSeverityLabelAbsent()
+
+		absentRule := monitoringv1.Rule{
+			Alert:       o.AlertNameAbsent(),
+			Expr:        intstr.FromString(fmt.Sprintf("absent(%s) == 1", expr)),
+			For:         monitoringDuration(o.AbsentDuration().String()),
+			Labels:      alertLabels,
+			Annotations: o.commonRuleAnnotations(""),
+		}
+		if o.shortIncreases() {
 			shortRules = append(shortRules, absentRule)
 		} else {
 			longRules = append(longRules, absentRule)
@@ -1423,19 +1629,22 @@ func (o Objective) increaseRuleBoolGauge(sloName string) (shortRules, longRules
 	return shortRules, longRules, nil
 }
 
-type severity string
+type Severity string
 
 const (
-	critical severity = "critical"
-	warning  severity = "warning"
+	critical Severity = "critical"
+	warning  Severity = "warning"
 )
 
 type Window struct {
-	Severity severity
+	Severity Severity
 	For      time.Duration
 	Long     time.Duration
 	Short    time.Duration
 	Factor   float64
+	// Labels and Annotations are added to the alert of this window only.
+	Labels      map[string]string
+	Annotations map[string]string
 }
 
 func Windows(sloWindow time.Duration) []Window {
@@ -1871,6 +2080,71 @@ func (o Objective) GenericRules(opts GenerationOptions) (monitoringv1.RuleGroup,
 				Labels: ruleLabels,
 			})
 		}
+	case Composite:
+		// Composite objectives have no requests of their own, only the availability is recorded.
+		expr, err := parser.ParseExpr(`1 - metric{}`)
+		if err != nil {
+			return monitoringv1.RuleGroup{}, err
+		}
+
+		metric := o.BurnrateName(time.Duration(o.Window))
+		objectiveReplacer{
+			metric: metric,
+			matchers: []*labels.Matcher{
+				{Type: labels.MatchEqual, Name: model.MetricNameLabel, Value: metric},
+				{Type: labels.MatchEqual, Name: "slo", Value: o.Name()},
+			},
+		}.replace(expr)
+
+		rules = append(rules, monitoringv1.Rule{
+			Record: "pyrra_availability",
+			Expr:   intstr.FromString(expr.String()),
+			Labels: ruleLabels,
+		})
+	case Raw:
+		if len(o.Indicator.Raw.Grouping) > 0 {
+			return monitoringv1.RuleGroup{}, ErrGroupingUnsupported
+		}
+
+		availability, err := parser.ParseExpr(`sum(errorMetric{matchers="errors"} or vector(0)) / sum(metric{matchers="total"})`)
+		if err != nil {
+			return monitoringv1.RuleGroup{}, err
+		}
+
+		totalIncreaseName := increaseName(rawTotalMetric, o.Window)
+		goodIncreaseName := increaseName(rawGoodMetric, o.Window)
+		objectiveReplacer{
+			metric:        totalIncreaseName,
+			matchers:      o.rawMatchers(totalIncreaseName),
+			errorMetric:   goodIncreaseName,
+			errorMatchers: o.rawMatchers(goodIncreaseName),
+		}.replace(availability)
+
+		rules = append(rules, monitoringv1.Rule{
+			Record: "pyrra_availability",
+			Expr:   intstr.FromString(availability.String()),
+			Labels: ruleLabels,
+		})
+
+		total, err := rawExpr(o.Indicator.Raw.Total, "rate", 5*time.Minute, nil)
+		if err != nil {
+			return monitoringv1.RuleGroup{}, err
+		}
+		good, err := rawExpr(o.Indicator.Raw.Good, "rate", 5*time.Minute, nil)
+		if err != nil {
+			return monitoringv1.RuleGroup{}, err
+		}
+
+		rules = append(rules, monitoringv1.Rule{
+			Record: "pyrra_requests:rate5m",
+			Expr:   intstr.FromString(total.String()),
+			Labels: ruleLabels,
+		})
+		rules = append(rules, monitoringv1.Rule{
+			Record: "pyrra_errors:rate5m",
+			Expr:   intstr.FromString(fmt.Sprintf("%s - %s", total, good)),
+			Labels: ruleLabels,
+		})
 	}
 
 	return monitoringv1.RuleGroup{
@@ -1887,7 +2161,12 @@ func monitoringDuration(d string) *monitoringv1.Duration {
 
 // alertSeverityLabel returns the severity label for the given window index.
 // If the severity is not set, it returns the severity from the default window.
+// Windows of a BurnRatePolicy always carry their own severity.
 func (o Objective) alertSeverityLabel(windowIndex int, w Window) string {
+	if len(o.BurnRatePolicy) > 0 {
+		return string(w.Severity)
+	}
+
 	var v string
 	switch windowIndex {
 	case 0:
@@ -1912,3 +2191,22 @@ func (o Objective) alertSeverityLabelAbsent() string {
 	}
 	return string(critical)
 }
+
+// addAlertingLabels adds the labels and annotations of the alerting config and the window to a burn rate alert.
+// The labels Pyrra sets afterward, like severity, can't be overridden.
+// It returns the annotations, as they're nil if the objective has none.
+func (o Objective) addAlertingLabels(w Window, alertLabels, alertAnnotations map[string]string) map[string]string {
+	maps.Copy(alertLabels, o.Alerting.Labels)
+	maps.Copy(alertLabels, w.Labels)
+
+	for _, annotations := range []map[string]string{o.Alerting.Annotations, w.Annotations} {
+		if len(annotations) == 0 {
+			continue
+		}
+		if alertAnnotations == nil {
+			alertAnnotations = make(map[string]string, len(annotations))
+		}
+		maps.Copy(alertAnnotations, annotations)
+	}
+	return alertAnnotations
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -1398,6 +1399,15 @@ func TestObjective_BurnrateNames(t *testing.T) {
 	}
 }
 
+func TestObjective_BurnrateWindow(t *testing.T) {
+	o := objectiveHTTPRatio()
+	require.Equal(t, 5*time.Minute, o.BurnrateWindow(0))
+	require.Equal(t, 30*time.Minute, o.BurnrateWindow(30*time.Minute))
+	require.Equal(t, time.Hour, o.BurnrateWindow(31*time.Minute))
+	require.Equal(t, 4*24*time.Hour, o.BurnrateWindow(3*24*time.Hour))
+	require.Equal(t, 4*24*time.Hour, o.BurnrateWindow(28*24*time.Hour))
+}
+
 func TestObjective_IncreaseRules(t *testing.T) {
 	testcases := []struct {
 		name  string
@@ -2043,6 +2053,121 @@ func Test_windows(t *testing.T) {
 	}, ws[3])
 }
 
+func TestObjective_BurnRatePolicy(t *testing.T) {
+	o := objectiveHTTPRatio()
+	o.BurnRatePolicy = []Window{{
+		Severity: "page",
+		For:      5 * time.Minute,
+		Long:     3 * time.Hour,
+		Short:    15 * time.Minute,
+		Factor:   10,
+	}, {
+		Severity: "ticket",
+		For:      time.Hour,
+		Long:     3 * 24 * time.Hour,
+		Short:    3 * time.Hour,
+		Factor:   1.5,
+	}}
+
+	require.Equal(t, o.BurnRatePolicy, o.Windows())
+
+	w, ok := o.HasWindows(model.Duration(15*time.Minute), model.Duration(3*time.Hour))
+	require.True(t, ok)
+	require.Equal(t, o.BurnRatePolicy[0], w)
+	_, ok = o.HasWindows(model.Duration(5*time.Minute), model.Duration(time.Hour))
+	require.False(t, ok)
+
+	alerts, err := o.Alerts()
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+	require.Equal(t, "page", alerts[0].Severity)
+	require.Equal(t, 15*time.Minute, alerts[0].Short)
+	require.Equal(t, 3*time.Hour, alerts[0].Long)
+	require.Equal(t, "ticket", alerts[1].Severity)
+	require.Equal(t, 1.5, alerts[1].Factor)
+
+	group, err := o.Burnrates(GenerationOptions{})
+	require.NoError(t, err)
+	require.Len(t, group.Rules, 5) // 3 unique burn rates and 2 alerts
+	require.Equal(t, "http_requests:burnrate15m", group.Rules[0].Record)
+	require.Equal(t, "http_requests:burnrate3h", group.Rules[1].Record)
+	require.Equal(t, "http_requests:burnrate3d", group.Rules[2].Record)
+	require.Equal(t, monitoringv1.Rule{
+		Alert:  "ErrorBudgetBurn",
+		For:    monitoringDuration("5m0s"),
+		Expr:   intstr.FromString(`http_requests:burnrate15m{job="thanos-receive-default",slo="monitoring-http-errors"} > (10 * (1-0.99)) and http_requests:burnrate3h{job="thanos-receive-default",slo="monitoring-http-errors"} > (10 * (1-0.99))`),
+		Labels: map[string]string{"severity": "page", "job": "thanos-receive-default", "long": "3h", "slo": "monitoring-http-errors", "short": "15m", "exhaustion": "2d19h12m"},
+	}, group.Rules[3])
+	// Fractional factors aren't rounded.
+	require.Equal(t, monitoringv1.Rule{
+		Alert:  "ErrorBudgetBurn",
+		For:    monitoringDuration("1h0m0s"),
+		Expr:   intstr.FromString(`http_requests:burnrate3h{job="thanos-receive-default",slo="monitoring-http-errors"} > (1.5 * (1-0.99)) and http_requests:burnrate3d{job="thanos-receive-default",slo="monitoring-http-errors"} > (1.5 * (1-0.99))`),
+		Labels: map[string]string{"severity": "ticket", "job": "thanos-receive-default", "long": "3d", "slo": "monitoring-http-errors", "short": "3h", "exhaustion": "18d16h"},
+	}, group.Rules[4])
+
+	// The absent alert is derived from the most urgent window of the policy.
+	require.Equal(t, model.Duration(23*time.Minute), o.AbsentDuration())
+
+	// Custom severities per tier are only used for the default windows.
+	o.Alerting.Severities.FastBurn = "urgent"
+	alerts, err = o.Alerts()
+	require.NoError(t, err)
+	require.Equal(t, "page", alerts[0].Severity)
+}
+
+func TestObjective_BurnratesAlertingLabels(t *testing.T) {
+	o := objectiveHTTPRatio()
+	o.Alerting.Labels = map[string]string{"team": "observability", "severity": "none"}
+	o.Alerting.Annotations = map[string]string{"runbook_url": "https://example.com/runbook"}
+	o.BurnRatePolicy = []Window{{
+		Severity:    "page",
+		Long:        time.Hour,
+		Short:       5 * time.Minute,
+		Factor:      14.4,
+		Labels:      map[string]string{"team": "sre", "routing": "pager"},
+		Annotations: map[string]string{"summary": "High error budget burn"},
+	}, {
+		Severity: "ticket",
+		Long:     24 * time.Hour,
+		Short:    2 * time.Hour,
+		Factor:   3,
+	}}
+
+	group, err := o.Burnrates(GenerationOptions{})
+	require.NoError(t, err)
+	require.Len(t, group.Rules, 6)
+
+	// The window's labels take precedence over alerting's and Pyrra's labels over both.
+	require.Equal(t, map[string]string{
+		"exhaustion": "1d22h40m",
+		"job":        "thanos-receive-default",
+		"long":       "1h",
+		"routing":    "pager",
+		"severity":   "page",
+		"short":      "5m",
+		"slo":        "monitoring-http-errors",
+		"team":       "sre",
+	}, group.Rules[4].Labels)
+	require.Contains(t, group.Rules[4].Expr.String(), "> (14.4 * (1-0.99))")
+	require.Equal(t, map[string]string{
+		"runbook_url": "https://example.com/runbook",
+		"summary":     "High error budget burn",
+	}, group.Rules[4].Annotations)
+
+	require.Equal(t, "observability", group.Rules[5].Labels["team"])
+	require.Equal(t, "ticket", group.Rules[5].Labels["severity"])
+	require.Equal(t, map[string]string{"runbook_url": "https://example.com/runbook"}, group.Rules[5].Annotations)
+
+	// Without custom labels and annotations the alerts have none either.
+	o = objectiveHTTPRatio()
+	group, err = o.Burnrates(GenerationOptions{})
+	require.NoError(t, err)
+	for _, r := range group.Rules {
+		require.Nil(t, r.Annotations)
+	}
+}
+
 func TestObjective_GrafanaRules(t *testing.T) {
 	testcases := []struct {
 		name  string
@@ -24,9 +24,25 @@ type Objective struct {
 	Window      model.Duration
 	Config      string
 
+	// Calendar aligns the Window to calendar periods if set.
+	// The Window is the nominal duration of a period then.
+	Calendar *Calendar
+
 	PerformanceOverAccuracy bool
 	RuleOutput              RuleOutput
 
+	// Datasource is the name of the Prometheus to query the objective's metrics from.
+	// The default Prometheus is queried if empty.
+	Datasource string
+
+	// BurnRatePolicy replaces the default multi burn rate windows if set.
+	// Windows are ordered from the most to the least urgent.
+	BurnRatePolicy []Window
+
+	// BudgetPolicy are the stages of the error budget policy, alerted on
+	// once the remaining error budget drops to their thresholds.
+	BudgetPolicy []BudgetPolicyStage
+
 	Alerting  Alerting
 	Indicator Indicator
 }
@@ -41,12 +57,17 @@ func (o Objective) Name() string {
 	return name
 }
 
+// Windows returns the multi burn rate windows used for alerting.
+// A configured BurnRatePolicy takes precedence over the default windows derived from the objective's window.
 func (o Objective) Windows() []Window {
+	if len(o.BurnRatePolicy) > 0 {
+		return o.BurnRatePolicy
+	}
 	return Windows(time.Duration(o.Window))
 }
 
 func (o Objective) HasWindows(short, long model.Duration) (Window, bool) {
-	for _, w := range Windows(time.Duration(o.Window)) {
+	for _, w := range o.Windows() {
 		if w.Short == time.Duration(short) && w.Long == time.Duration(long) {
 			return w, true
 		}
@@ -82,6 +103,8 @@ const (
 	Latency       IndicatorType = iota
 	LatencyNative IndicatorType = iota
 	BoolGauge     IndicatorType = iota
+	Composite     IndicatorType = iota
+	Raw           IndicatorType = iota
 )
 
 func (o Objective) IndicatorType() IndicatorType {
@@ -97,6 +120,12 @@ func (o Objective) IndicatorType() IndicatorType {
 	if o.Indicator.BoolGauge != nil && o.Indicator.BoolGauge.Name != "" {
 		return BoolGauge
 	}
+	if o.Indicator.Composite != nil && len(o.Indicator.Composite.Components) > 0 {
+		return Composite
+	}
+	if o.Indicator.Raw != nil && o.Indicator.Raw.Total != "" {
+		return Raw
+	}
 	return Unknown
 }
 
@@ -110,6 +139,8 @@ func (o Objective) Grouping() []string {
 		return o.Indicator.LatencyNative.Grouping
 	case BoolGauge:
 		return o.Indicator.BoolGauge.Grouping
+	case Raw:
+		return o.Indicator.Raw.Grouping
 	default:
 		return nil
 	}
@@ -136,6 +167,8 @@ type Indicator struct {
 	Latency       *LatencyIndicator
 	LatencyNative *LatencyNativeIndicator
 	BoolGauge     *BoolGaugeIndicator
+	Composite     *CompositeIndicator
+	Raw           *RawIndicator
 }
 
 type RatioIndicator struct {
@@ -168,6 +201,9 @@ type Alerting struct {
 	Name       string
 	AbsentName string
 	Severities AlertingSeverities
+	// Labels and Annotations are added to all burn rate alerts.
+	Labels      map[string]string
+	Annotations map[string]string
 }
 
 type AlertingSeverities struct {
@@ -0,0 +1,336 @@
+package sloth
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/promql/parser"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pyrra-dev/pyrra/kubernetes/api/v1alpha1"
+	"github.com/pyrra-dev/pyrra/slo"
+)
+
+// DefaultWindow is the SLO period Sloth uses by default.
+const DefaultWindow = model.Duration(30 * 24 * time.Hour)
+
+// windowTemplate matches Sloth's template for the range of rates in queries.
+var windowTemplate = regexp.MustCompile(`{{\s*\.window\s*}}`)
+
+// windowPlaceholder replaces the window template, so that queries can be parsed.
+// It's an odd duration, so that it's not mistaken for a fixed range of a query.
+const windowPlaceholder = 1337 * time.Second
+
+// burnRateTier is one of the multi window, multi burn rate alerts Sloth generates.
+type burnRateTier struct {
+	long, short time.Duration
+	// budgetPercent of the error budget that's burned within the long window when the alert fires.
+	budgetPercent float64
+}
+
+// pageTiers and ticketTiers are Sloth's alert windows. They're the same for every SLO period.
+var (
+	pageTiers = []burnRateTier{
+		{long: time.Hour, short: 5 * time.Minute, budgetPercent: 2},
+		{long: 6 * time.Hour, short: 30 * time.Minute, budgetPercent: 5},
+	}
+	ticketTiers = []burnRateTier{
+		{long: 24 * time.Hour, short: 2 * time.Hour, budgetPercent: 10},
+		{long: 3 * 24 * time.Hour, short: 6 * time.Hour, budgetPercent: 10},
+	}
+)
+
+// factor returns the burn rate at which the tier burns its percentage of the error budget within the long window.
+func (t burnRateTier) factor(window model.Duration) float64 {
+	return t.budgetPercent * time.Duration(window).Hours() / (100 * t.long.Hours())
+}
+
+// ServiceLevelObjectives converts every SLO of the spec with the window as the SLO period.
+// The objectives are validated like the Kubernetes webhook validates them.
+func (s Spec) ServiceLevelObjectives(window model.Duration) ([]v1alpha1.ServiceLevelObjective, error) {
+	objectives := make([]v1alpha1.ServiceLevelObjective, 0, len(s.SLOs))
+	for _, sloSpec := range s.SLOs {
+		o, err := s.serviceLevelObjective(sloSpec, window)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert SLO %q of service %q: %w", sloSpec.Name, s.Service, err)
+		}
+		if _, err := o.ValidateCreate(context.Background(), &o); err != nil {
+			return nil, fmt.Errorf("SLO %q of service %q converts to an invalid objective: %w", sloSpec.Name, s.Service, err)
+		}
+		objectives = append(objectives, o)
+	}
+	return objectives, nil
+}
+
+func (s Spec) serviceLevelObjective(sloSpec SLO, window model.Duration) (v1alpha1.ServiceLevelObjective, error) {
+	o := v1alpha1.ServiceLevelObjective{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.GroupVersion.String(),
+			Kind:       "ServiceLevelObjective",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.Service + "-" + sloSpec.Name,
+			Namespace: s.Namespace,
+		},
+		Spec: v1alpha1.ServiceLevelObjectiveSpec{
+			Description: sloSpec.Description,
+			Target:      strconv.FormatFloat(sloSpec.Objective, 'f', -1, 64),
+			Window:      window.String(),
+		},
+	}
+
+	// Sloth adds the labels to all rules, which Pyrra does for labels with its prefix.
+	for _, l := range []map[string]string{s.Labels, sloSpec.Labels} {
+		for name, value := range l {
+			if o.Labels == nil {
+				o.Labels = map[string]string{}
+			}
+			o.Labels[slo.PropagationLabelsPrefix+name] = value
+		}
+	}
+
+	indicator, err := indicator(sloSpec.SLI)
+	if err != nil {
+		return o, err
+	}
+	o.Spec.ServiceLevelIndicator = indicator
+	alerting(&o, sloSpec.Alerting, window)
+
+	return o, nil
+}
+
+func indicator(sli SLI) (v1alpha1.ServiceLevelIndicator, error) {
+	if sli.Raw != nil {
+		return v1alpha1.ServiceLevelIndicator{}, fmt.Errorf("raw SLIs with an error ratio query can't be converted, Pyrra needs the queries of the errors and total events")
+	}
+	if sli.Plugin != nil {
+		return v1alpha1.ServiceLevelIndicator{}, fmt.Errorf("SLI plugin %q can't be converted, replace it with the events queries it generates", sli.Plugin.ID)
+	}
+	if sli.Events == nil {
+		return v1alpha1.ServiceLevelIndicator{}, fmt.Errorf("the SLI needs events")
+	}
+
+	errorsExpr, err := parseQuery(sli.Events.ErrorQuery)
+	if err != nil {
+		return v1alpha1.ServiceLevelIndicator{}, fmt.Errorf("error query: %w", err)
+	}
+	totalExpr, err := parseQuery(sli.Events.TotalQuery)
+	if err != nil {
+		return v1alpha1.ServiceLevelIndicator{}, fmt.Errorf("total query: %w", err)
+	}
+
+	// sum(rate(errors[{{.window}}])) / sum(rate(total[{{.window}}])) is exactly what Pyrra's ratio indicator queries.
+	errorsSelector, errorsOK := summedRate(errorsExpr)
+	totalSelector, totalOK := summedRate(totalExpr)
+	if errorsOK && totalOK {
+		return v1alpha1.ServiceLevelIndicator{Ratio: &v1alpha1.RatioIndicator{
+			Errors: v1alpha1.Query{Metric: errorsSelector.String()},
+			Total:  v1alpha1.Query{Metric: totalSelector.String()},
+		}}, nil
+	}
+
+	// Other queries become a raw indicator. Pyrra adds the rates to its expressions again.
+	errorsRaw, err := stripRates(errorsExpr)
+	if err != nil {
+		return v1alpha1.ServiceLevelIndicator{}, fmt.Errorf("error query: %w", err)
+	}
+	totalRaw, err := stripRates(totalExpr)
+	if err != nil {
+		return v1alpha1.ServiceLevelIndicator{}, fmt.Errorf("total query: %w", err)
+	}
+
+	good, err := parser.ParseExpr(fmt.Sprintf("(%s) - (%s)", totalRaw, errorsRaw))
+	if err != nil {
+		return v1alpha1.ServiceLevelIndicator{}, fmt.Errorf("failed to build good query: %w", err)
+	}
+	return v1alpha1.ServiceLevelIndicator{Raw: &v1alpha1.RawIndicator{
+		Good:  good.String(),
+		Total: totalRaw.String(),
+	}}, nil
+}
+
+// parseQuery parses a Sloth query with the window template replaced by a placeholder.
+func parseQuery(query string) (parser.Expr, error) {
+	if query == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+	query = windowTemplate.ReplaceAllString(query, model.Duration(windowPlaceholder).String())
+	if strings.Contains(query, "{{") {
+		return nil, fmt.Errorf("only the {{.window}} template is supported")
+	}
+	expr, err := parser.ParseExpr(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse: %w", err)
+	}
+	return expr, nil
+}
+
+// summedRate returns the selector of queries like sum(rate(metric{...}[{{.window}}])).
+func summedRate(expr parser.Expr) (*parser.VectorSelector, bool) {
+	sum, ok := expr.(*parser.AggregateExpr)
+	if !ok || sum.Op != parser.SUM || len(sum.Grouping) > 0 || sum.Without {
+		return nil, false
+	}
+	vs, ok := windowRate(sum.Expr)
+	if !ok || vs.OriginalOffset != 0 || vs.Timestamp != nil || vs.StartOrEnd != 0 {
+		return nil, false
+	}
+	return vs, true
+}
+
+// windowRate returns the selector of rate or increase over the window.
+func windowRate(expr parser.Expr) (*parser.VectorSelector, bool) {
+	call, ok := expr.(*parser.Call)
+	if !ok || (call.Func.Name != "rate" && call.Func.Name != "increase") || len(call.Args) != 1 {
+		return nil, false
+	}
+	ms, ok := call.Args[0].(*parser.MatrixSelector)
+	if !ok || ms.Range != windowPlaceholder {
+		return nil, false
+	}
+	vs, ok := ms.VectorSelector.(*parser.VectorSelector)
+	return vs, ok
+}
+
+// stripRates replaces the rates and increases over the window with their selectors.
+// Every selector needs to be in one, as Pyrra adds them to all selectors of raw indicators.
+func stripRates(expr parser.Expr) (parser.Expr, error) {
+	switch e := expr.(type) {
+	case *parser.NumberLiteral, *parser.StringLiteral:
+		return e, nil
+	case *parser.ParenExpr:
+		inner, err := stripRates(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return &parser.ParenExpr{Expr: inner}, nil
+	case *parser.UnaryExpr:
+		inner, err := stripRates(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return &parser.UnaryExpr{Op: e.Op, Expr: inner}, nil
+	case *parser.AggregateExpr:
+		inner, err := stripRates(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		stripped := *e
+		stripped.Expr = inner
+		return &stripped, nil
+	case *parser.BinaryExpr:
+		lhs, err := stripRates(e.LHS)
+		if err != nil {
+			return nil, err
+		}
+		rhs, err := stripRates(e.RHS)
+		if err != nil {
+			return nil, err
+		}
+		stripped := *e
+		stripped.LHS, stripped.RHS = lhs, rhs
+		return &stripped, nil
+	case *parser.Call:
+		if vs, ok := windowRate(e); ok {
+			return vs, nil
+		}
+		for _, arg := range e.Args {
+			if _, ok := arg.(*parser.MatrixSelector); ok {
+				return nil, fmt.Errorf("%s can't be converted, only rate and increase over {{.window}} are supported", queryString(e))
+			}
+		}
+		stripped := *e
+		stripped.Args = make(parser.Expressions, 0, len(e.Args))
+		for _, arg := range e.Args {
+			a, err := stripRates(arg)
+			if err != nil {
+				return nil, err
+			}
+			stripped.Args = append(stripped.Args, a)
+		}
+		return &stripped, nil
+	case *parser.VectorSelector:
+		return nil, fmt.Errorf("%s needs to be in a rate or increase over {{.window}}", queryString(e))
+	default:
+		return nil, fmt.Errorf("%s can't be converted, only rate and increase over {{.window}} are supported", queryString(e))
+	}
+}
+
+// queryString formats the expression with the window template instead of its placeholder.
+func queryString(expr parser.Expr) string {
+	return strings.ReplaceAll(expr.String(), model.Duration(windowPlaceholder).String(), "{{.window}}")
+}
+
+func alerting(o *v1alpha1.ServiceLevelObjective, a Alerting, window model.Duration) {
+	o.Spec.Alerting.Name = a.Name
+
+	// Pyrra sets the severity label itself, Sloth's is the default severity of both alerts.
+	labels, severity := withoutSeverity(a.Labels)
+	o.Spec.Alerting.Labels = labels
+	o.Spec.Alerting.Annotations = a.Annotations
+
+	var windows []v1alpha1.BurnRateWindow
+	for _, alert := range []struct {
+		alert           Alert
+		tiers           []burnRateTier
+		defaultSeverity string
+	}{
+		{alert: a.PageAlert, tiers: pageTiers, defaultSeverity: "critical"},
+		{alert: a.TicketAlert, tiers: ticketTiers, defaultSeverity: "warning"},
+	} {
+		if alert.alert.Disable {
+			continue
+		}
+
+		labels, alertSeverity := withoutSeverity(alert.alert.Labels)
+		switch {
+		case alertSeverity != "":
+		case severity != "":
+			alertSeverity = severity
+		default:
+			alertSeverity = alert.defaultSeverity
+		}
+
+		for _, t := range alert.tiers {
+			windows = append(windows, v1alpha1.BurnRateWindow{
+				Short:       model.Duration(t.short).String(),
+				Long:        model.Duration(t.long).String(),
+				Factor:      strconv.FormatFloat(t.factor(window), 'f', -1, 64),
+				Severity:    alertSeverity,
+				Labels:      labels,
+				Annotations: alert.alert.Annotations,
+			})
+		}
+	}
+
+	if len(windows) == 0 {
+		burnrates := false
+		o.Spec.Alerting.Burnrates = &burnrates
+		return
+	}
+	o.Spec.BurnRatePolicy = &v1alpha1.BurnRatePolicy{Windows: windows}
+}
+
+// withoutSeverity returns a copy of the labels without the severity label and the severity.
+func withoutSeverity(labels map[string]string) (map[string]string, string) {
+	var (
+		without  map[string]string
+		severity string
+	)
+	for name, value := range labels {
+		if name == "severity" {
+			severity = value
+			continue
+		}
+		if without == nil {
+			without = make(map[string]string, len(labels))
+		}
+		without[name] = value
+	}
+	return without, severity
+}
@@ -0,0 +1,276 @@
+package sloth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pyrra-dev/pyrra/kubernetes/api/v1alpha1"
+	"github.com/pyrra-dev/pyrra/slo"
+)
+
+func TestSpec_ServiceLevelObjectives(t *testing.T) {
+	specs, err := Parse([]byte(`
+version: prometheus/v1
+service: myservice
+labels:
+  owner: myteam
+slos:
+  - name: requests-availability
+    objective: 99.9
+    description: Common SLO based on availability for HTTP request responses.
+    labels:
+      category: availability
+    sli:
+      events:
+        error_query: sum(rate(http_request_duration_seconds_count{job="myservice",code=~"(5..|429)"}[{{.window}}]))
+        total_query: sum(rate(http_request_duration_seconds_count{job="myservice"}[{{ .window }}]))
+    alerting:
+      name: MyServiceHighErrorRate
+      labels:
+        category: availability
+      annotations:
+        summary: High error rate on 'myservice' requests responses
+      page_alert:
+        labels:
+          severity: pageteam
+          routing_key: myteam
+      ticket_alert:
+        labels:
+          severity: slack
+          slack_channel: '#alerts-myteam'
+`))
+	require.NoError(t, err)
+	require.Len(t, specs, 1)
+
+	objectives, err := specs[0].ServiceLevelObjectives(DefaultWindow)
+	require.NoError(t, err)
+	require.Len(t, objectives, 1)
+
+	o := objectives[0]
+	require.Equal(t, "ServiceLevelObjective", o.Kind)
+	require.Equal(t, "myservice-requests-availability", o.Name)
+	require.Equal(t, map[string]string{
+		"pyrra.dev/owner":    "myteam",
+		"pyrra.dev/category": "availability",
+	}, o.Labels)
+	require.Equal(t, "99.9", o.Spec.Target)
+	require.Equal(t, "30d", o.Spec.Window)
+	require.Equal(t, "Common SLO based on availability for HTTP request responses.", o.Spec.Description)
+	require.Equal(t, &v1alpha1.RatioIndicator{
+		Errors: v1alpha1.Query{Metric: `http_request_duration_seconds_count{code=~"(5..|429)",job="myservice"}`},
+		Total:  v1alpha1.Query{Metric: `http_request_duration_seconds_count{job="myservice"}`},
+	}, o.Spec.ServiceLevelIndicator.Ratio)
+
+	require.Equal(t, "MyServiceHighErrorRate", o.Spec.Alerting.Name)
+	require.Equal(t, map[string]string{"category": "availability"}, o.Spec.Alerting.Labels)
+	require.Equal(t, map[string]string{"summary": "High error rate on 'myservice' requests responses"}, o.Spec.Alerting.Annotations)
+	require.Nil(t, o.Spec.Alerting.Burnrates)
+
+	pageLabels := map[string]string{"routing_key": "myteam"}
+	ticketLabels := map[string]string{"slack_channel": "#alerts-myteam"}
+	require.Equal(t, &v1alpha1.BurnRatePolicy{Windows: []v1alpha1.BurnRateWindow{
+		{Short: "5m", Long: "1h", Factor: "14.4", Severity: "pageteam", Labels: pageLabels},
+		{Short: "30m", Long: "6h", Factor: "6", Severity: "pageteam", Labels: pageLabels},
+		{Short: "2h", Long: "1d", Factor: "3", Severity: "slack", Labels: ticketLabels},
+		{Short: "6h", Long: "3d", Factor: "1", Severity: "slack", Labels: ticketLabels},
+	}}, o.Spec.BurnRatePolicy)
+
+	// The objective generates the same alerts Sloth does.
+	internal, err := o.Internal()
+	require.NoError(t, err)
+	group, err := internal.Burnrates(slo.GenerationOptions{})
+	require.NoError(t, err)
+	alert := group.Rules[len(group.Rules)-4]
+	require.Equal(t, "MyServiceHighErrorRate", alert.Alert)
+	require.Equal(t, "pageteam", alert.Labels["severity"])
+	require.Equal(t, "myteam", alert.Labels["routing_key"])
+	require.Equal(t, "availability", alert.Labels["category"])
+	require.Equal(t, "High error rate on 'myservice' requests responses", alert.Annotations["summary"])
+}
+
+func TestSpec_ServiceLevelObjectivesRaw(t *testing.T) {
+	spec := Spec{
+		Version: Version,
+		Service: "api",
+		SLOs: []SLO{{
+			Name:      "errors",
+			Objective: 99,
+			SLI: SLI{Events: &SLIEvents{
+				ErrorQuery: `sum(rate(http_requests_total{job="api",code=~"5.."}[{{.window}}])) + sum(increase(grpc_server_handled_total{job="api",grpc_code!="OK"}[{{.window}}]))`,
+				TotalQuery: `sum(rate(http_requests_total{job="api"}[{{.window}}])) + sum(increase(grpc_server_handled_total{job="api"}[{{.window}}]))`,
+			}},
+		}},
+	}
+
+	objectives, err := spec.ServiceLevelObjectives(model.Duration(28 * 24 * time.Hour))
+	require.NoError(t, err)
+	require.Len(t, objectives, 1)
+
+	o := objectives[0]
+	require.Nil(t, o.Spec.ServiceLevelIndicator.Ratio)
+	require.Equal(t, &v1alpha1.RawIndicator{
+		Good:  `(sum(http_requests_total{job="api"}) + sum(grpc_server_handled_total{job="api"})) - (sum(http_requests_total{code=~"5..",job="api"}) + sum(grpc_server_handled_total{grpc_code!="OK",job="api"}))`,
+		Total: `sum(http_requests_total{job="api"}) + sum(grpc_server_handled_total{job="api"})`,
+	}, o.Spec.ServiceLevelIndicator.Raw)
+
+	// The factors burn the same share of the error budget for other SLO periods.
+	require.Equal(t, "4w", o.Spec.Window)
+	require.Equal(t, "13.44", o.Spec.BurnRatePolicy.Windows[0].Factor)
+	require.Equal(t, "critical", o.Spec.BurnRatePolicy.Windows[0].Severity)
+	require.Equal(t, "warning", o.Spec.BurnRatePolicy.Windows[3].Severity)
+}
+
+func TestSpec_ServiceLevelObjectivesAlerting(t *testing.T) {
+	spec := func() Spec {
+		return Spec{
+			Version: Version,
+			Service: "api",
+			SLOs: []SLO{{
+				Name:      "errors",
+				Objective: 99.5,
+				SLI: SLI{Events: &SLIEvents{
+					ErrorQuery: `sum(rate(http_requests_total{job="api",code=~"5.."}[{{.window}}]))`,
+					TotalQuery: `sum(rate(http_requests_total{job="api"}[{{.window}}]))`,
+				}},
+			}},
+		}
+	}
+
+	s := spec()
+	s.SLOs[0].Alerting = Alerting{
+		Labels:      map[string]string{"severity": "low"},
+		TicketAlert: Alert{Disable: true},
+	}
+	objectives, err := s.ServiceLevelObjectives(DefaultWindow)
+	require.NoError(t, err)
+	require.Nil(t, objectives[0].Spec.Alerting.Labels)
+	require.Len(t, objectives[0].Spec.BurnRatePolicy.Windows, 2)
+	require.Equal(t, "low", objectives[0].Spec.BurnRatePolicy.Windows[0].Severity)
+
+	s = spec()
+	s.SLOs[0].Alerting = Alerting{
+		PageAlert:   Alert{Disable: true},
+		TicketAlert: Alert{Disable: true},
+	}
+	objectives, err = s.ServiceLevelObjectives(DefaultWindow)
+	require.NoError(t, err)
+	require.Nil(t, objectives[0].Spec.BurnRatePolicy)
+	require.False(t, *objectives[0].Spec.Alerting.Burnrates)
+
+	s = spec()
+	s.SLOs[0].Alerting.PageAlert.Labels = map[string]string{"slo": "api"}
+	_, err = s.ServiceLevelObjectives(DefaultWindow)
+	require.EqualError(t, err, `SLO "errors" of service "api" converts to an invalid objective: burnRatePolicy window 0 label "slo" is set by Pyrra`)
+}
+
+func TestSpec_ServiceLevelObjectivesUnsupported(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		sli  SLI
+		err  string
+	}{{
+		name: "raw",
+		sli:  SLI{Raw: &SLIRaw{ErrorRatioQuery: `1 - sum(rate(up[{{.window}}]))`}},
+		err:  "raw SLIs with an error ratio query can't be converted, Pyrra needs the queries of the errors and total events",
+	}, {
+		name: "plugin",
+		sli:  SLI{Plugin: &SLIPlugin{ID: "sloth-common/kubernetes/apiserver/availability"}},
+		err:  `SLI plugin "sloth-common/kubernetes/apiserver/availability" can't be converted, replace it with the events queries it generates`,
+	}, {
+		name: "fixed range",
+		sli: SLI{Events: &SLIEvents{
+			ErrorQuery: `sum(rate(errors_total[5m]))`,
+			TotalQuery: `sum(rate(requests_total[{{.window}}]))`,
+		}},
+		err: "error query: rate(errors_total[5m]) can't be converted, only rate and increase over {{.window}} are supported",
+	}, {
+		name: "irate",
+		sli: SLI{Events: &SLIEvents{
+			ErrorQuery: `sum(irate(errors_total[{{.window}}]))`,
+			TotalQuery: `sum(rate(requests_total[{{.window}}]))`,
+		}},
+		err: "error query: irate(errors_total[{{.window}}]) can't be converted, only rate and increase over {{.window}} are supported",
+	}, {
+		name: "selector without rate",
+		sli: SLI{Events: &SLIEvents{
+			ErrorQuery: `sum(rate(errors_total[{{.window}}]))`,
+			TotalQuery: `sum(rate(requests_total[{{.window}}]) * on() group_left() up)`,
+		}},
+		err: "total query: up needs to be in a rate or increase over {{.window}}",
+	}, {
+		name: "template",
+		sli: SLI{Events: &SLIEvents{
+			ErrorQuery: `sum(rate(errors_total{job="{{.service}}"}[{{.window}}]))`,
+			TotalQuery: `sum(rate(requests_total[{{.window}}]))`,
+		}},
+		err: "error query: only the {{.window}} template is supported",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := Spec{Version: Version, Service: "api", SLOs: []SLO{{Name: "errors", Objective: 99, SLI: tc.sli}}}
+			_, err := spec.ServiceLevelObjectives(DefaultWindow)
+			require.EqualError(t, err, `failed to convert SLO "errors" of service "api": `+tc.err)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	specs, err := Parse([]byte(`
+apiVersion: sloth.slok.dev/v1
+kind: PrometheusServiceLevel
+metadata:
+  name: api
+  namespace: monitoring
+spec:
+  service: api
+  slos:
+    - name: errors
+      objective: 99
+      sli:
+        events:
+          errorQuery: sum(rate(http_requests_total{job="api",code=~"5.."}[{{.window}}]))
+          totalQuery: sum(rate(http_requests_total{job="api"}[{{.window}}]))
+      alerting:
+        pageAlert:
+          labels:
+            severity: page
+        ticketAlert:
+          disable: true
+---
+version: prometheus/v1
+service: web
+slos: []
+`))
+	require.NoError(t, err)
+	require.Len(t, specs, 2)
+	require.Equal(t, Spec{
+		Version:   Version,
+		Service:   "api",
+		Namespace: "monitoring",
+		SLOs: []SLO{{
+			Name:      "errors",
+			Objective: 99,
+			SLI: SLI{Events: &SLIEvents{
+				ErrorQuery: `sum(rate(http_requests_total{job="api",code=~"5.."}[{{.window}}]))`,
+				TotalQuery: `sum(rate(http_requests_total{job="api"}[{{.window}}]))`,
+			}},
+			Alerting: Alerting{
+				PageAlert:   Alert{Labels: map[string]string{"severity": "page"}},
+				TicketAlert: Alert{Disable: true},
+			},
+		}},
+	}, specs[0])
+	require.Equal(t, "web", specs[1].Service)
+
+	objectives, err := specs[0].ServiceLevelObjectives(DefaultWindow)
+	require.NoError(t, err)
+	require.Equal(t, "monitoring", objectives[0].Namespace)
+
+	_, err = Parse([]byte("version: prometheus/v1\nservice: api\nslos:\n  - name: errors\n    objectiv: 99\n"))
+	require.EqualError(t, err, `failed to unmarshal document 0: error unmarshaling JSON: while decoding JSON: json: unknown field "objectiv"`)
+
+	_, err = Parse([]byte("apiVersion: pyrra.dev/v1alpha1\nkind: ServiceLevelObjective\n"))
+	require.EqualError(t, err, "document 0: unsupported document, only version prometheus/v1 and sloth.slok.dev/v1 PrometheusServiceLevel are supported")
+}
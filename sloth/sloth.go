@@ -0,0 +1,208 @@
+// Package sloth converts Sloth SLO specs to Pyrra's ServiceLevelObjectives, to migrate from Sloth.
+// See https://sloth.dev for the specification.
+package sloth
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// Version is the version of Sloth's own spec format.
+const Version = "prometheus/v1"
+
+// APIVersion and KindPrometheusServiceLevel are the Kubernetes resource of Sloth's operator.
+const (
+	APIVersion                 = "sloth.slok.dev/v1"
+	KindPrometheusServiceLevel = "PrometheusServiceLevel"
+)
+
+// Spec is a Sloth prometheus/v1 spec of a service and its SLOs.
+type Spec struct {
+	Version string            `json:"version"`
+	Service string            `json:"service"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	SLOs    []SLO             `json:"slos"`
+
+	// Namespace of the PrometheusServiceLevel the spec was read from.
+	// It's empty for prometheus/v1 specs.
+	Namespace string `json:"-"`
+}
+
+type SLO struct {
+	Name        string            `json:"name"`
+	Objective   float64           `json:"objective"`
+	Description string            `json:"description,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	SLI         SLI               `json:"sli"`
+	Alerting    Alerting          `json:"alerting"`
+}
+
+type SLI struct {
+	Events *SLIEvents `json:"events,omitempty"`
+	Raw    *SLIRaw    `json:"raw,omitempty"`
+	Plugin *SLIPlugin `json:"plugin,omitempty"`
+}
+
+// SLIEvents are queries of the errors and total events.
+// They use the {{.window}} template for the range of their rates.
+type SLIEvents struct {
+	ErrorQuery string `json:"error_query"`
+	TotalQuery string `json:"total_query"`
+}
+
+type SLIRaw struct {
+	ErrorRatioQuery string `json:"error_ratio_query"`
+}
+
+type SLIPlugin struct {
+	ID      string            `json:"id"`
+	Options map[string]string `json:"options,omitempty"`
+}
+
+type Alerting struct {
+	Name        string            `json:"name,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	PageAlert   Alert             `json:"page_alert,omitempty"`
+	TicketAlert Alert             `json:"ticket_alert,omitempty"`
+}
+
+// Alert configures Sloth's page or ticket alert.
+// Its keys are the same for both formats.
+type Alert struct {
+	Disable     bool              `json:"disable,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// prometheusServiceLevel is the Kubernetes resource of Sloth's operator.
+// Its spec is the same as the prometheus/v1 one, but with camel case keys.
+type prometheusServiceLevel struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name        string            `json:"name"`
+		Namespace   string            `json:"namespace,omitempty"`
+		Labels      map[string]string `json:"labels,omitempty"`
+		Annotations map[string]string `json:"annotations,omitempty"`
+	} `json:"metadata"`
+	Spec struct {
+		Service string            `json:"service"`
+		Labels  map[string]string `json:"labels,omitempty"`
+		SLOs    []struct {
+			Name        string            `json:"name"`
+			Objective   float64           `json:"objective"`
+			Description string            `json:"description,omitempty"`
+			Labels      map[string]string `json:"labels,omitempty"`
+			SLI         struct {
+				Events *struct {
+					ErrorQuery string `json:"errorQuery"`
+					TotalQuery string `json:"totalQuery"`
+				} `json:"events,omitempty"`
+				Raw *struct {
+					ErrorRatioQuery string `json:"errorRatioQuery"`
+				} `json:"raw,omitempty"`
+				Plugin *SLIPlugin `json:"plugin,omitempty"`
+			} `json:"sli"`
+			Alerting struct {
+				Name        string            `json:"name,omitempty"`
+				Labels      map[string]string `json:"labels,omitempty"`
+				Annotations map[string]string `json:"annotations,omitempty"`
+				PageAlert   Alert             `json:"pageAlert,omitempty"`
+				TicketAlert Alert             `json:"ticketAlert,omitempty"`
+			} `json:"alerting"`
+		} `json:"slos"`
+	} `json:"spec"`
+	// Status is ignored, so that resources can be read from the output of kubectl.
+	Status map[string]any `json:"status,omitempty"`
+}
+
+func (p prometheusServiceLevel) spec() Spec {
+	spec := Spec{
+		Version:   Version,
+		Service:   p.Spec.Service,
+		Labels:    p.Spec.Labels,
+		Namespace: p.Metadata.Namespace,
+	}
+	for _, s := range p.Spec.SLOs {
+		slo := SLO{
+			Name:        s.Name,
+			Objective:   s.Objective,
+			Description: s.Description,
+			Labels:      s.Labels,
+			SLI: SLI{
+				Plugin: s.SLI.Plugin,
+			},
+			Alerting: Alerting{
+				Name:        s.Alerting.Name,
+				Labels:      s.Alerting.Labels,
+				Annotations: s.Alerting.Annotations,
+				PageAlert:   s.Alerting.PageAlert,
+				TicketAlert: s.Alerting.TicketAlert,
+			},
+		}
+		if s.SLI.Events != nil {
+			slo.SLI.Events = &SLIEvents{ErrorQuery: s.SLI.Events.ErrorQuery, TotalQuery: s.SLI.Events.TotalQuery}
+		}
+		if s.SLI.Raw != nil {
+			slo.SLI.Raw = &SLIRaw{ErrorRatioQuery: s.SLI.Raw.ErrorRatioQuery}
+		}
+		spec.SLOs = append(spec.SLOs, slo)
+	}
+	return spec
+}
+
+// Parse reads all Sloth specs of multi document YAML.
+// Documents are either prometheus/v1 specs or PrometheusServiceLevel resources.
+// Unknown fields are errors, so that typos don't go unnoticed.
+func Parse(data []byte) ([]Spec, error) {
+	var specs []Spec
+
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for i := 0; ; i++ {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read document %d: %w", i, err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		var header struct {
+			Version    string `json:"version"`
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+		}
+		if err := yaml.Unmarshal(doc, &header); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal document %d: %w", i, err)
+		}
+
+		switch {
+		case header.Version == Version:
+			var spec Spec
+			if err := yaml.UnmarshalStrict(doc, &spec); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal document %d: %w", i, err)
+			}
+			specs = append(specs, spec)
+		case header.APIVersion == APIVersion && header.Kind == KindPrometheusServiceLevel:
+			var resource prometheusServiceLevel
+			if err := yaml.UnmarshalStrict(doc, &resource); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal document %d: %w", i, err)
+			}
+			specs = append(specs, resource.spec())
+		default:
+			return nil, fmt.Errorf("document %d: unsupported document, only version %s and %s %s are supported", i, Version, APIVersion, KindPrometheusServiceLevel)
+		}
+	}
+
+	return specs, nil
+}
@@ -73,6 +73,7 @@ const AlertsTable = ({
     to / 1000,
     step(from, to),
     {enabled: objective.labels.__name__ !== ''},
+    objective.datasource,
   )
   const {
     labels: alertsLabels,
@@ -216,6 +217,7 @@ const AlertsTable = ({
                       <BurnrateGraph
                         client={promClient}
                         alert={a}
+                        datasource={objective.datasource}
                         threshold={a.factor * (1 - objective.target)}
                         from={from}
                         to={to}
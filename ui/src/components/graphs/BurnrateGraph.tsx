@@ -18,6 +18,7 @@ import GraphTooltip from './GraphTooltip'
 interface BurnrateGraphProps {
   client: Client<typeof PrometheusService>
   alert: Alert
+  datasource: string
   threshold: number
   from: number
   to: number
@@ -29,6 +30,7 @@ interface BurnrateGraphProps {
 const BurnrateGraph = ({
   client,
   alert,
+  datasource,
   threshold,
   from,
   to,
@@ -59,6 +61,7 @@ const BurnrateGraph = ({
     to / 1000,
     step(from, to),
     {enabled: alert.short?.query !== undefined},
+    datasource,
   )
 
   const {response: longResponse, status: longStatus} = usePrometheusQueryRange(
@@ -69,6 +72,7 @@ const BurnrateGraph = ({
     to / 1000,
     step(from, to),
     {enabled: alert.long?.query !== undefined},
+    datasource,
   )
 
   const {tooltipRef, initHook, setCursorHook} = useGraphTooltip(150)
@@ -18,6 +18,7 @@ import {buildExternalHRef, externalName} from '../../external'
 interface ErrorBudgetGraphProps {
   client: Client<typeof PrometheusService>
   query: string
+  datasource: string
   from: number
   to: number
   uPlotCursor: uPlot.Cursor
@@ -28,6 +29,7 @@ interface ErrorBudgetGraphProps {
 const ErrorBudgetGraph = ({
   client,
   query,
+  datasource,
   from,
   to,
   uPlotCursor,
@@ -62,6 +64,8 @@ const ErrorBudgetGraph = ({
     to / 1000,
     // convert to seconds and then we want 1000 samples
     (to - from) / 1000 / 1000,
+    undefined,
+    datasource,
   )
 
   let samples: AlignedData = []
@@ -21,6 +21,7 @@ interface ErrorsGraphProps {
   client: Client<typeof PrometheusService>
   type: ObjectiveType
   query: string
+  datasource: string
   from: number
   to: number
   uPlotCursor: uPlot.Cursor
@@ -32,6 +33,7 @@ const ErrorsGraph = ({
   client,
   type,
   query,
+  datasource,
   from,
   to,
   uPlotCursor,
@@ -59,6 +61,8 @@ const ErrorsGraph = ({
     from / 1000,
     to / 1000,
     step(from, to),
+    undefined,
+    datasource,
   )
 
   const {tooltipRef, initHook, setCursorHook} = useGraphTooltip(150)
@@ -20,6 +20,7 @@ import GraphTooltip from './GraphTooltip'
 interface RequestsGraphProps {
   client: Client<typeof PrometheusService>
   query: string
+  datasource: string
   from: number
   to: number
   uPlotCursor: uPlot.Cursor
@@ -31,6 +32,7 @@ interface RequestsGraphProps {
 const RequestsGraph = ({
   client,
   query,
+  datasource,
   from,
   to,
   uPlotCursor,
@@ -59,6 +61,8 @@ const RequestsGraph = ({
     from / 1000,
     to / 1000,
     step(from, to),
+    undefined,
+    datasource,
   )
 
   const {tooltipRef, initHook, setCursorHook} = useGraphTooltip(150)
@@ -93,6 +93,7 @@ const Detail = () => {
     objective?.queries?.countTotal ?? '',
     to / 1000,
     {enabled: objectiveStatus === 'success' && objective?.queries?.countTotal !== undefined},
+    objective?.datasource,
   )
 
   const {response: errorResponse, status: errorStatus} = usePrometheusQuery(
@@ -100,6 +101,7 @@ const Detail = () => {
     objective?.queries?.countErrors ?? '',
     to / 1000,
     {enabled: objectiveStatus === 'success' && objective?.queries?.countTotal !== undefined},
+    objective?.datasource,
   )
 
   const updateTimeRange = useCallback(
@@ -329,6 +331,7 @@ const Detail = () => {
                 <ErrorBudgetGraph
                   client={promClient}
                   query={objective.queries.graphErrorBudget}
+                  datasource={objective.datasource}
                   from={from}
                   to={to}
                   uPlotCursor={uPlotCursor}
@@ -346,6 +349,7 @@ const Detail = () => {
                 <RequestsGraph
                   client={promClient}
                   query={replaceInterval(objective.queries.graphRequests, from, to)}
+                  datasource={objective.datasource}
                   from={from}
                   to={to}
                   uPlotCursor={uPlotCursor}
@@ -363,6 +367,7 @@ const Detail = () => {
                   client={promClient}
                   type={objectiveType}
                   query={replaceInterval(objective.queries.graphErrors, from, to)}
+                  datasource={objective.datasource}
                   from={from}
                   to={to}
                   uPlotCursor={uPlotCursor}
@@ -676,6 +676,12 @@ const List = () => {
           <div>
             <h3 className="mb-8">Service Level Objectives</h3>
           </div>
+          {objectiveResponse?.warnings.map((warning) => (
+            <Alert key={warning} className="mb-4">
+              <AlertTitle>Some objectives could not be listed</AlertTitle>
+              <AlertDescription>{warning}</AlertDescription>
+            </Alert>
+          ))}
         </div>
         <div className="flex flex-wrap items-center gap-y-2">
           <div className="my-2 w-full md:w-1/2 lg:w-1/3">
@@ -15,12 +15,13 @@ export const usePrometheusQuery = (
   query: string,
   time: number,
   options?: QueryOptions,
+  datasource = '',
 ): PrometheusQueryResponse => {
   time = Math.floor(time)
   const {data, error, status} = useConnectQuery<QueryResponse>({
-    key: ['query', query, time],
+    key: ['query', datasource, query, time],
     func: async () => {
-      return await client.query({query, time: BigInt(time)})
+      return await client.query({query, time: BigInt(time), datasource})
     },
     options,
   })
@@ -41,18 +42,20 @@ export const usePrometheusQueryRange = (
   end: number,
   step: number,
   options?: QueryOptions,
+  datasource = '',
 ): PrometheusQueryRangeResponse => {
   start = Math.floor(start)
   end = Math.floor(end)
   step = Math.floor(step) !== 0 ? Math.floor(step) : 1
   const {data, error, status} = useConnectQuery<QueryRangeResponse>({
-    key: ['queryRange', query, start / 10, end / 10, step],
+    key: ['queryRange', datasource, query, start / 10, end / 10, step],
     func: async () => {
       return await client.queryRange({
         query,
         start: BigInt(start),
         end: BigInt(end),
         step: BigInt(step),
+        datasource,
       })
     },
     options,
@@ -24,6 +24,34 @@ export declare type ListRequest = Message<"objectives.v1alpha1.ListRequest"> & {
    * @generated from field: string grouping = 2;
    */
   grouping: string;
+
+  /**
+   * page_size is the maximum number of objectives to return. All objectives are returned if 0.
+   *
+   * @generated from field: int32 page_size = 3;
+   */
+  pageSize: number;
+
+  /**
+   * page_token is the next_page_token of the previous page.
+   *
+   * @generated from field: string page_token = 4;
+   */
+  pageToken: string;
+
+  /**
+   * order_by is one of name, namespace, target or window. A - prefix sorts descending. The backend's order is kept if empty.
+   *
+   * @generated from field: string order_by = 5;
+   */
+  orderBy: string;
+
+  /**
+   * search only returns objectives with the search in their name or description, ignoring case.
+   *
+   * @generated from field: string search = 6;
+   */
+  search: string;
 };
 
 /**
@@ -40,6 +68,27 @@ export declare type ListResponse = Message<"objectives.v1alpha1.ListResponse"> &
    * @generated from field: repeated objectives.v1alpha1.Objective objectives = 1;
    */
   objectives: Objective[];
+
+  /**
+   * next_page_token is the page_token of the next page. It's empty on the last page.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+
+  /**
+   * total_size is the number of objectives on all pages.
+   *
+   * @generated from field: int32 total_size = 3;
+   */
+  totalSize: number;
+
+  /**
+   * warnings explain objectives missing from the response, like the ones of backends that failed to list them.
+   *
+   * @generated from field: repeated string warnings = 4;
+   */
+  warnings: string[];
 };
 
 /**
@@ -86,6 +135,31 @@ export declare type Objective = Message<"objectives.v1alpha1.Objective"> & {
    * @generated from field: objectives.v1alpha1.Queries queries = 7;
    */
   queries?: Queries | undefined;
+
+  /**
+   * @generated from field: repeated objectives.v1alpha1.BurnRateWindow burn_rate_policy = 8;
+   */
+  burnRatePolicy: BurnRateWindow[];
+
+  /**
+   * @generated from field: objectives.v1alpha1.Calendar calendar = 9;
+   */
+  calendar?: Calendar | undefined;
+
+  /**
+   * @generated from field: repeated objectives.v1alpha1.BudgetPolicyStage budget_policy = 10;
+   */
+  budgetPolicy: BudgetPolicyStage[];
+
+  /**
+   * @generated from field: bool performance_over_accuracy = 11;
+   */
+  performanceOverAccuracy: boolean;
+
+  /**
+   * @generated from field: string datasource = 12;
+   */
+  datasource: string;
 };
 
 /**
@@ -125,6 +199,18 @@ export declare type Indicator = Message<"objectives.v1alpha1.Indicator"> & {
      */
     value: LatencyNative;
     case: "latencyNative";
+  } | {
+    /**
+     * @generated from field: objectives.v1alpha1.Composite composite = 5;
+     */
+    value: Composite;
+    case: "composite";
+  } | {
+    /**
+     * @generated from field: objectives.v1alpha1.Raw raw = 6;
+     */
+    value: Raw;
+    case: "raw";
   } | { case: undefined; value?: undefined };
 };
 
@@ -466,6 +552,11 @@ export declare type Budget = Message<"objectives.v1alpha1.Budget"> & {
    * @generated from field: double max = 3;
    */
   max: number;
+
+  /**
+   * @generated from field: string policy_stage = 4;
+   */
+  policyStage: string;
 };
 
 /**
@@ -848,6 +939,620 @@ export declare type GraphDurationResponse = Message<"objectives.v1alpha1.GraphDu
  */
 export declare const GraphDurationResponseSchema: GenMessage<GraphDurationResponse>;
 
+/**
+ * @generated from message objectives.v1alpha1.BurnRateWindow
+ */
+export declare type BurnRateWindow = Message<"objectives.v1alpha1.BurnRateWindow"> & {
+  /**
+   * @generated from field: string severity = 1;
+   */
+  severity: string;
+
+  /**
+   * @generated from field: google.protobuf.Duration for = 2;
+   */
+  for?: Duration | undefined;
+
+  /**
+   * @generated from field: double factor = 3;
+   */
+  factor: number;
+
+  /**
+   * @generated from field: google.protobuf.Duration short = 4;
+   */
+  short?: Duration | undefined;
+
+  /**
+   * @generated from field: google.protobuf.Duration long = 5;
+   */
+  long?: Duration | undefined;
+
+  /**
+   * @generated from field: map<string, string> labels = 6;
+   */
+  labels: { [key: string]: string };
+
+  /**
+   * @generated from field: map<string, string> annotations = 7;
+   */
+  annotations: { [key: string]: string };
+};
+
+/**
+ * Describes the message objectives.v1alpha1.BurnRateWindow.
+ * Use `create(BurnRateWindowSchema)` to create a new message.
+ */
+export declare const BurnRateWindowSchema: GenMessage<BurnRateWindow>;
+
+/**
+ * @generated from message objectives.v1alpha1.Calendar
+ */
+export declare type Calendar = Message<"objectives.v1alpha1.Calendar"> & {
+  /**
+   * @generated from field: string period = 1;
+   */
+  period: string;
+
+  /**
+   * @generated from field: string time_zone = 2;
+   */
+  timeZone: string;
+};
+
+/**
+ * Describes the message objectives.v1alpha1.Calendar.
+ * Use `create(CalendarSchema)` to create a new message.
+ */
+export declare const CalendarSchema: GenMessage<Calendar>;
+
+/**
+ * @generated from message objectives.v1alpha1.Composite
+ */
+export declare type Composite = Message<"objectives.v1alpha1.Composite"> & {
+  /**
+   * @generated from field: string model = 1;
+   */
+  model: string;
+
+  /**
+   * @generated from field: repeated objectives.v1alpha1.CompositeComponent components = 2;
+   */
+  components: CompositeComponent[];
+};
+
+/**
+ * Describes the message objectives.v1alpha1.Composite.
+ * Use `create(CompositeSchema)` to create a new message.
+ */
+export declare const CompositeSchema: GenMessage<Composite>;
+
+/**
+ * @generated from message objectives.v1alpha1.CompositeComponent
+ */
+export declare type CompositeComponent = Message<"objectives.v1alpha1.CompositeComponent"> & {
+  /**
+   * @generated from field: string selector = 1;
+   */
+  selector: string;
+
+  /**
+   * @generated from field: double weight = 2;
+   */
+  weight: number;
+
+  /**
+   * @generated from field: repeated objectives.v1alpha1.Objective objectives = 3;
+   */
+  objectives: Objective[];
+};
+
+/**
+ * Describes the message objectives.v1alpha1.CompositeComponent.
+ * Use `create(CompositeComponentSchema)` to create a new message.
+ */
+export declare const CompositeComponentSchema: GenMessage<CompositeComponent>;
+
+/**
+ * @generated from message objectives.v1alpha1.Raw
+ */
+export declare type Raw = Message<"objectives.v1alpha1.Raw"> & {
+  /**
+   * @generated from field: string good = 1;
+   */
+  good: string;
+
+  /**
+   * @generated from field: string total = 2;
+   */
+  total: string;
+
+  /**
+   * @generated from field: repeated string grouping = 3;
+   */
+  grouping: string[];
+};
+
+/**
+ * Describes the message objectives.v1alpha1.Raw.
+ * Use `create(RawSchema)` to create a new message.
+ */
+export declare const RawSchema: GenMessage<Raw>;
+
+/**
+ * @generated from message objectives.v1alpha1.BudgetPolicyStage
+ */
+export declare type BudgetPolicyStage = Message<"objectives.v1alpha1.BudgetPolicyStage"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: double remaining = 2;
+   */
+  remaining: number;
+
+  /**
+   * @generated from field: string severity = 3;
+   */
+  severity: string;
+
+  /**
+   * @generated from field: map<string, string> labels = 4;
+   */
+  labels: { [key: string]: string };
+};
+
+/**
+ * Describes the message objectives.v1alpha1.BudgetPolicyStage.
+ * Use `create(BudgetPolicyStageSchema)` to create a new message.
+ */
+export declare const BudgetPolicyStageSchema: GenMessage<BudgetPolicyStage>;
+
+/**
+ * @generated from message objectives.v1alpha1.BacktestRequest
+ */
+export declare type BacktestRequest = Message<"objectives.v1alpha1.BacktestRequest"> & {
+  /**
+   * @generated from field: string expr = 1;
+   */
+  expr: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp start = 2;
+   */
+  start?: Timestamp | undefined;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp end = 3;
+   */
+  end?: Timestamp | undefined;
+
+  /**
+   * @generated from field: google.protobuf.Duration step = 4;
+   */
+  step?: Duration | undefined;
+
+  /**
+   * objective is backtested instead of looking up expr, to tune objectives before they're deployed.
+   *
+   * @generated from field: objectives.v1alpha1.Objective objective = 5;
+   */
+  objective?: Objective | undefined;
+};
+
+/**
+ * Describes the message objectives.v1alpha1.BacktestRequest.
+ * Use `create(BacktestRequestSchema)` to create a new message.
+ */
+export declare const BacktestRequestSchema: GenMessage<BacktestRequest>;
+
+/**
+ * @generated from message objectives.v1alpha1.BacktestResponse
+ */
+export declare type BacktestResponse = Message<"objectives.v1alpha1.BacktestResponse"> & {
+  /**
+   * @generated from field: repeated objectives.v1alpha1.BacktestAlert alerts = 1;
+   */
+  alerts: BacktestAlert[];
+};
+
+/**
+ * Describes the message objectives.v1alpha1.BacktestResponse.
+ * Use `create(BacktestResponseSchema)` to create a new message.
+ */
+export declare const BacktestResponseSchema: GenMessage<BacktestResponse>;
+
+/**
+ * @generated from message objectives.v1alpha1.BacktestAlert
+ */
+export declare type BacktestAlert = Message<"objectives.v1alpha1.BacktestAlert"> & {
+  /**
+   * @generated from field: objectives.v1alpha1.BurnRateWindow window = 1;
+   */
+  window?: BurnRateWindow | undefined;
+
+  /**
+   * @generated from field: string query = 2;
+   */
+  query: string;
+
+  /**
+   * @generated from field: repeated objectives.v1alpha1.BacktestInterval intervals = 3;
+   */
+  intervals: BacktestInterval[];
+};
+
+/**
+ * Describes the message objectives.v1alpha1.BacktestAlert.
+ * Use `create(BacktestAlertSchema)` to create a new message.
+ */
+export declare const BacktestAlertSchema: GenMessage<BacktestAlert>;
+
+/**
+ * @generated from message objectives.v1alpha1.BacktestInterval
+ */
+export declare type BacktestInterval = Message<"objectives.v1alpha1.BacktestInterval"> & {
+  /**
+   * @generated from field: map<string, string> labels = 1;
+   */
+  labels: { [key: string]: string };
+
+  /**
+   * @generated from field: objectives.v1alpha1.Alert.State state = 2;
+   */
+  state: Alert_State;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp start = 3;
+   */
+  start?: Timestamp | undefined;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp end = 4;
+   */
+  end?: Timestamp | undefined;
+};
+
+/**
+ * Describes the message objectives.v1alpha1.BacktestInterval.
+ * Use `create(BacktestIntervalSchema)` to create a new message.
+ */
+export declare const BacktestIntervalSchema: GenMessage<BacktestInterval>;
+
+/**
+ * @generated from message objectives.v1alpha1.ExportReportRequest
+ */
+export declare type ExportReportRequest = Message<"objectives.v1alpha1.ExportReportRequest"> & {
+  /**
+   * expr selects the objectives of the report. All objectives are reported if empty.
+   *
+   * @generated from field: string expr = 1;
+   */
+  expr: string;
+
+  /**
+   * start and end of the reported period. They default to the last full calendar month.
+   *
+   * @generated from field: google.protobuf.Timestamp start = 2;
+   */
+  start?: Timestamp | undefined;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp end = 3;
+   */
+  end?: Timestamp | undefined;
+};
+
+/**
+ * Describes the message objectives.v1alpha1.ExportReportRequest.
+ * Use `create(ExportReportRequestSchema)` to create a new message.
+ */
+export declare const ExportReportRequestSchema: GenMessage<ExportReportRequest>;
+
+/**
+ * @generated from message objectives.v1alpha1.ExportReportResponse
+ */
+export declare type ExportReportResponse = Message<"objectives.v1alpha1.ExportReportResponse"> & {
+  /**
+   * @generated from field: google.protobuf.Timestamp start = 1;
+   */
+  start?: Timestamp | undefined;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp end = 2;
+   */
+  end?: Timestamp | undefined;
+
+  /**
+   * @generated from field: repeated objectives.v1alpha1.ReportRow rows = 3;
+   */
+  rows: ReportRow[];
+};
+
+/**
+ * Describes the message objectives.v1alpha1.ExportReportResponse.
+ * Use `create(ExportReportResponseSchema)` to create a new message.
+ */
+export declare const ExportReportResponseSchema: GenMessage<ExportReportResponse>;
+
+/**
+ * ReportRow is the report of an objective, or of one group of an objective with grouping.
+ *
+ * @generated from message objectives.v1alpha1.ReportRow
+ */
+export declare type ReportRow = Message<"objectives.v1alpha1.ReportRow"> & {
+  /**
+   * @generated from field: map<string, string> labels = 1;
+   */
+  labels: { [key: string]: string };
+
+  /**
+   * @generated from field: map<string, string> grouping = 2;
+   */
+  grouping: { [key: string]: string };
+
+  /**
+   * @generated from field: double target = 3;
+   */
+  target: number;
+
+  /**
+   * @generated from field: double availability = 4;
+   */
+  availability: number;
+
+  /**
+   * budget_consumed is the share of the period's error budget the errors consumed. It's above 1 once the objective is missed.
+   *
+   * @generated from field: double budget_consumed = 5;
+   */
+  budgetConsumed: number;
+
+  /**
+   * alert_minutes is how long any of the burn rate alerts fired in the period.
+   *
+   * @generated from field: double alert_minutes = 6;
+   */
+  alertMinutes: number;
+};
+
+/**
+ * Describes the message objectives.v1alpha1.ReportRow.
+ * Use `create(ReportRowSchema)` to create a new message.
+ */
+export declare const ReportRowSchema: GenMessage<ReportRow>;
+
+/**
+ * @generated from message objectives.v1alpha1.GetStatusHistoryRequest
+ */
+export declare type GetStatusHistoryRequest = Message<"objectives.v1alpha1.GetStatusHistoryRequest"> & {
+  /**
+   * @generated from field: string expr = 1;
+   */
+  expr: string;
+
+  /**
+   * @generated from field: string grouping = 2;
+   */
+  grouping: string;
+
+  /**
+   * time is the end of the latest period and defaults to now.
+   * The periods of calendar objectives end with the last full period before time.
+   *
+   * @generated from field: google.protobuf.Timestamp time = 3;
+   */
+  time?: Timestamp | undefined;
+
+  /**
+   * periods is the number of windows before time. It defaults to 12.
+   *
+   * @generated from field: uint32 periods = 4;
+   */
+  periods: number;
+};
+
+/**
+ * Describes the message objectives.v1alpha1.GetStatusHistoryRequest.
+ * Use `create(GetStatusHistoryRequestSchema)` to create a new message.
+ */
+export declare const GetStatusHistoryRequestSchema: GenMessage<GetStatusHistoryRequest>;
+
+/**
+ * @generated from message objectives.v1alpha1.GetStatusHistoryResponse
+ */
+export declare type GetStatusHistoryResponse = Message<"objectives.v1alpha1.GetStatusHistoryResponse"> & {
+  /**
+   * @generated from field: repeated objectives.v1alpha1.ObjectiveStatusHistory history = 1;
+   */
+  history: ObjectiveStatusHistory[];
+};
+
+/**
+ * Describes the message objectives.v1alpha1.GetStatusHistoryResponse.
+ * Use `create(GetStatusHistoryResponseSchema)` to create a new message.
+ */
+export declare const GetStatusHistoryResponseSchema: GenMessage<GetStatusHistoryResponse>;
+
+/**
+ * ObjectiveStatusHistory is the status of an objective, or of one group of an objective with grouping, in past periods.
+ *
+ * @generated from message objectives.v1alpha1.ObjectiveStatusHistory
+ */
+export declare type ObjectiveStatusHistory = Message<"objectives.v1alpha1.ObjectiveStatusHistory"> & {
+  /**
+   * @generated from field: map<string, string> labels = 1;
+   */
+  labels: { [key: string]: string };
+
+  /**
+   * periods are sorted by time, the latest last. Periods without any requests are left out.
+   *
+   * @generated from field: repeated objectives.v1alpha1.PeriodStatus periods = 2;
+   */
+  periods: PeriodStatus[];
+};
+
+/**
+ * Describes the message objectives.v1alpha1.ObjectiveStatusHistory.
+ * Use `create(ObjectiveStatusHistorySchema)` to create a new message.
+ */
+export declare const ObjectiveStatusHistorySchema: GenMessage<ObjectiveStatusHistory>;
+
+/**
+ * @generated from message objectives.v1alpha1.PeriodStatus
+ */
+export declare type PeriodStatus = Message<"objectives.v1alpha1.PeriodStatus"> & {
+  /**
+   * @generated from field: google.protobuf.Timestamp start = 1;
+   */
+  start?: Timestamp | undefined;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp end = 2;
+   */
+  end?: Timestamp | undefined;
+
+  /**
+   * @generated from field: objectives.v1alpha1.Availability availability = 3;
+   */
+  availability?: Availability | undefined;
+
+  /**
+   * @generated from field: objectives.v1alpha1.Budget budget = 4;
+   */
+  budget?: Budget | undefined;
+
+  /**
+   * met is whether the availability reached the objective's target in the period.
+   *
+   * @generated from field: bool met = 5;
+   */
+  met: boolean;
+};
+
+/**
+ * Describes the message objectives.v1alpha1.PeriodStatus.
+ * Use `create(PeriodStatusSchema)` to create a new message.
+ */
+export declare const PeriodStatusSchema: GenMessage<PeriodStatus>;
+
+/**
+ * @generated from message objectives.v1alpha1.WatchStatusRequest
+ */
+export declare type WatchStatusRequest = Message<"objectives.v1alpha1.WatchStatusRequest"> & {
+  /**
+   * @generated from field: string expr = 1;
+   */
+  expr: string;
+
+  /**
+   * @generated from field: string grouping = 2;
+   */
+  grouping: string;
+};
+
+/**
+ * Describes the message objectives.v1alpha1.WatchStatusRequest.
+ * Use `create(WatchStatusRequestSchema)` to create a new message.
+ */
+export declare const WatchStatusRequestSchema: GenMessage<WatchStatusRequest>;
+
+/**
+ * @generated from message objectives.v1alpha1.WatchAlertsRequest
+ */
+export declare type WatchAlertsRequest = Message<"objectives.v1alpha1.WatchAlertsRequest"> & {
+  /**
+   * @generated from field: string expr = 1;
+   */
+  expr: string;
+
+  /**
+   * @generated from field: string grouping = 2;
+   */
+  grouping: string;
+
+  /**
+   * @generated from field: bool inactive = 3;
+   */
+  inactive: boolean;
+
+  /**
+   * @generated from field: bool current = 4;
+   */
+  current: boolean;
+};
+
+/**
+ * Describes the message objectives.v1alpha1.WatchAlertsRequest.
+ * Use `create(WatchAlertsRequestSchema)` to create a new message.
+ */
+export declare const WatchAlertsRequestSchema: GenMessage<WatchAlertsRequest>;
+
+/**
+ * @generated from message objectives.v1alpha1.BatchGetStatusRequest
+ */
+export declare type BatchGetStatusRequest = Message<"objectives.v1alpha1.BatchGetStatusRequest"> & {
+  /**
+   * expr selects the objectives. All objectives are returned if empty.
+   *
+   * @generated from field: string expr = 1;
+   */
+  expr: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp time = 2;
+   */
+  time?: Timestamp | undefined;
+};
+
+/**
+ * Describes the message objectives.v1alpha1.BatchGetStatusRequest.
+ * Use `create(BatchGetStatusRequestSchema)` to create a new message.
+ */
+export declare const BatchGetStatusRequestSchema: GenMessage<BatchGetStatusRequest>;
+
+/**
+ * @generated from message objectives.v1alpha1.BatchGetStatusResponse
+ */
+export declare type BatchGetStatusResponse = Message<"objectives.v1alpha1.BatchGetStatusResponse"> & {
+  /**
+   * @generated from field: repeated objectives.v1alpha1.BatchStatus objectives = 1;
+   */
+  objectives: BatchStatus[];
+};
+
+/**
+ * Describes the message objectives.v1alpha1.BatchGetStatusResponse.
+ * Use `create(BatchGetStatusResponseSchema)` to create a new message.
+ */
+export declare const BatchGetStatusResponseSchema: GenMessage<BatchGetStatusResponse>;
+
+/**
+ * BatchStatus is the status of an objective, like GetStatus returns it.
+ *
+ * @generated from message objectives.v1alpha1.BatchStatus
+ */
+export declare type BatchStatus = Message<"objectives.v1alpha1.BatchStatus"> & {
+  /**
+   * @generated from field: map<string, string> labels = 1;
+   */
+  labels: { [key: string]: string };
+
+  /**
+   * @generated from field: repeated objectives.v1alpha1.ObjectiveStatus status = 2;
+   */
+  status: ObjectiveStatus[];
+};
+
+/**
+ * Describes the message objectives.v1alpha1.BatchStatus.
+ * Use `create(BatchStatusSchema)` to create a new message.
+ */
+export declare const BatchStatusSchema: GenMessage<BatchStatus>;
+
 /**
  * @generated from service objectives.v1alpha1.ObjectiveService
  */
@@ -868,6 +1573,16 @@ export declare const ObjectiveService: GenService<{
     input: typeof GetStatusRequestSchema;
     output: typeof GetStatusResponseSchema;
   },
+  /**
+   * BatchGetStatus returns the status of all objectives matching expr, querying objectives of the same kind together.
+   *
+   * @generated from rpc objectives.v1alpha1.ObjectiveService.BatchGetStatus
+   */
+  batchGetStatus: {
+    methodKind: "unary";
+    input: typeof BatchGetStatusRequestSchema;
+    output: typeof BatchGetStatusResponseSchema;
+  },
   /**
    * @generated from rpc objectives.v1alpha1.ObjectiveService.GetAlerts
    */
@@ -908,6 +1623,50 @@ export declare const ObjectiveService: GenService<{
     input: typeof GraphDurationRequestSchema;
     output: typeof GraphDurationResponseSchema;
   },
+  /**
+   * @generated from rpc objectives.v1alpha1.ObjectiveService.Backtest
+   */
+  backtest: {
+    methodKind: "unary";
+    input: typeof BacktestRequestSchema;
+    output: typeof BacktestResponseSchema;
+  },
+  /**
+   * @generated from rpc objectives.v1alpha1.ObjectiveService.ExportReport
+   */
+  exportReport: {
+    methodKind: "unary";
+    input: typeof ExportReportRequestSchema;
+    output: typeof ExportReportResponseSchema;
+  },
+  /**
+   * @generated from rpc objectives.v1alpha1.ObjectiveService.GetStatusHistory
+   */
+  getStatusHistory: {
+    methodKind: "unary";
+    input: typeof GetStatusHistoryRequestSchema;
+    output: typeof GetStatusHistoryResponseSchema;
+  },
+  /**
+   * WatchStatus streams the status like GetStatus returns it, whenever it changes.
+   *
+   * @generated from rpc objectives.v1alpha1.ObjectiveService.WatchStatus
+   */
+  watchStatus: {
+    methodKind: "server_streaming";
+    input: typeof WatchStatusRequestSchema;
+    output: typeof GetStatusResponseSchema;
+  },
+  /**
+   * WatchAlerts streams the alerts like GetAlerts returns them, whenever they change.
+   *
+   * @generated from rpc objectives.v1alpha1.ObjectiveService.WatchAlerts
+   */
+  watchAlerts: {
+    methodKind: "server_streaming";
+    input: typeof WatchAlertsRequestSchema;
+    output: typeof GetAlertsResponseSchema;
+  },
 }>;
 
 /**
@@ -9,7 +9,7 @@ import { file_google_protobuf_duration, file_google_protobuf_timestamp } from "@
  * Describes the file objectives/v1alpha1/objectives.proto.
  */
 export const file_objectives_v1alpha1_objectives = /*@__PURE__*/
-  fileDesc("CiRvYmplY3RpdmVzL3YxYWxwaGExL29iamVjdGl2ZXMucHJvdG8SE29iamVjdGl2ZXMudjFhbHBoYTEiLQoLTGlzdFJlcXVlc3QSDAoEZXhwchgBIAEoCRIQCghncm91cGluZxgCIAEoCSJCCgxMaXN0UmVzcG9uc2USMgoKb2JqZWN0aXZlcxgBIAMoCzIeLm9iamVjdGl2ZXMudjFhbHBoYTEuT2JqZWN0aXZlIrgCCglPYmplY3RpdmUSOgoGbGFiZWxzGAEgAygLMioub2JqZWN0aXZlcy52MWFscGhhMS5PYmplY3RpdmUuTGFiZWxzRW50cnkSDgoGdGFyZ2V0GAIgASgBEikKBndpbmRvdxgDIAEoCzIZLmdvb2dsZS5wcm90b2J1Zi5EdXJhdGlvbhITCgtkZXNjcmlwdGlvbhgEIAEoCRIxCglpbmRpY2F0b3IYBSABKAsyHi5vYmplY3RpdmVzLnYxYWxwaGExLkluZGljYXRvchIOCgZjb25maWcYBiABKAkSLQoHcXVlcmllcxgHIAEoCzIcLm9iamVjdGl2ZXMudjFhbHBoYTEuUXVlcmllcxotCgtMYWJlbHNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIucBCglJbmRpY2F0b3ISKwoFcmF0aW8YASABKAsyGi5vYmplY3RpdmVzLnYxYWxwaGExLlJhdGlvSAASLwoHbGF0ZW5jeRgCIAEoCzIcLm9iamVjdGl2ZXMudjFhbHBoYTEuTGF0ZW5jeUgAEjMKCWJvb2xHYXVnZRgDIAEoCzIeLm9iamVjdGl2ZXMudjFhbHBoYTEuQm9vbEdhdWdlSAASPAoObGF0ZW5jeV9uYXRpdmUYBCABKAsyIi5vYmplY3RpdmVzLnYxYWxwaGExLkxhdGVuY3lOYXRpdmVIAEIJCgdvcHRpb25zInAKBVJhdGlvEikKBXRvdGFsGAEgASgLMhoub2JqZWN0aXZlcy52MWFscGhhMS5RdWVyeRIqCgZlcnJvcnMYAiABKAsyGi5vYmplY3RpdmVzLnYxYWxwaGExLlF1ZXJ5EhAKCGdyb3VwaW5nGAMgAygJInMKB0xhdGVuY3kSKQoFdG90YWwYASABKAsyGi5vYmplY3RpdmVzLnYxYWxwaGExLlF1ZXJ5EisKB3N1Y2Nlc3MYAiABKAsyGi5vYmplY3RpdmVzLnYxYWxwaGExLlF1ZXJ5EhAKCGdyb3VwaW5nGAMgAygJIl0KDUxhdGVuY3lOYXRpdmUSKQoFdG90YWwYASABKAsyGi5vYmplY3RpdmVzLnYxYWxwaGExLlF1ZXJ5Eg8KB2xhdGVuY3kYAiABKAkSEAoIZ3JvdXBpbmcYAyADKAkiTAoJQm9vbEdhdWdlEi0KCWJvb2xHYXVnZRgBIAEoCzIaLm9iamVjdGl2ZXMudjFhbHBoYTEuUXVlcnkSEAoIZ3JvdXBpbmcYAyADKAkiWgoFUXVlcnkSDgoGbWV0cmljGAEgASgJEgwKBG5hbWUYAiABKAkSMwoIbWF0Y2hlcnMYAyADKAsyIS5vYmplY3RpdmVzLnYxYWxwaGExLkxhYmVsTWF0Y2hlciJ4CgdRdWVyaWVzEhIKCmNvdW50VG90YWwYASABKAkSEwoLY291bnRFcnJvcnMYAiABKAkSGAoQZ3JhcGhFcnJvckJ1ZGdldBgDIAEoCRIVCg1ncmFwaFJlcXVlc3RzGAQgASgJEhMKC2dyYXBoRXJyb3JzGAUgASgJIosBCgxMYWJlbE1hdGNoZXISNAoEdHlwZRgBIAEoDjImLm9iamVjdGl2ZXMudjFhbHBoYTEuTGFiZWxNYXRjaGVyLlR5cGUSDAoEbmFtZRgCIAEoCRINCgV2YWx1ZRgDIAEoCSIoCgRUeXBlEgYKAkVREAASBwoDTkVREAESBgoCUkUQAhIHCgNOUkUQAyJcChBHZXRTdGF0dXNSZXF1ZXN0EgwKBGV4cHIYASABKAkSEAoIZ3JvdXBpbmcYAiABKAkSKAoEdGltZRgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiSQoRR2V0U3RhdHVzUmVzcG9uc2USNAoGc3RhdHVzGAEgAygLMiQub2JqZWN0aXZlcy52MWFscGhhMS5PYmplY3RpdmVTdGF0dXMi6AEKD09iamVjdGl2ZVN0YXR1cxJACgZsYWJlbHMYASADKAsyMC5vYmplY3RpdmVzLnYxYWxwaGExLk9iamVjdGl2ZVN0YXR1cy5MYWJlbHNFbnRyeRI3CgxhdmFpbGFiaWxpdHkYAiABKAsyIS5vYmplY3RpdmVzLnYxYWxwaGExLkF2YWlsYWJpbGl0eRIrCgZidWRnZXQYAyABKAsyGy5vYmplY3RpdmVzLnYxYWxwaGExLkJ1ZGdldBotCgtMYWJlbHNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIkEKDEF2YWlsYWJpbGl0eRISCgpwZXJjZW50YWdlGAEgASgBEg0KBXRvdGFsGAIgASgBEg4KBmVycm9ycxgDIAEoASI3CgZCdWRnZXQSDQoFdG90YWwYASABKAESEQoJcmVtYWluaW5nGAIgASgBEgsKA21heBgDIAEoASJVChBHZXRBbGVydHNSZXF1ZXN0EgwKBGV4cHIYASABKAkSEAoIZ3JvdXBpbmcYAiABKAkSEAoIaW5hY3RpdmUYAyABKAgSDwoHY3VycmVudBgEIAEoCCI/ChFHZXRBbGVydHNSZXNwb25zZRIqCgZhbGVydHMYASADKAsyGi5vYmplY3RpdmVzLnYxYWxwaGExLkFsZXJ0IvQCCgVBbGVydBI2CgZsYWJlbHMYASADKAsyJi5vYmplY3RpdmVzLnYxYWxwaGExLkFsZXJ0LkxhYmVsc0VudHJ5EhAKCHNldmVyaXR5GAIgASgJEiYKA2ZvchgDIAEoCzIZLmdvb2dsZS5wcm90b2J1Zi5EdXJhdGlvbhIOCgZmYWN0b3IYBCABKAESLwoFc3RhdGUYBSABKA4yIC5vYmplY3RpdmVzLnYxYWxwaGExLkFsZXJ0LlN0YXRlEiwKBXNob3J0GAYgASgLMh0ub2JqZWN0aXZlcy52MWFscGhhMS5CdXJucmF0ZRIrCgRsb25nGAcgASgLMh0ub2JqZWN0aXZlcy52MWFscGhhMS5CdXJucmF0ZRotCgtMYWJlbHNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIi4KBVN0YXRlEgwKCGluYWN0aXZlEAASCwoHcGVuZGluZxABEgoKBmZpcmluZxACIlUKCEJ1cm5yYXRlEikKBndpbmRvdxgBIAEoCzIZLmdvb2dsZS5wcm90b2J1Zi5EdXJhdGlvbhIPCgdjdXJyZW50GAIgASgBEg0KBXF1ZXJ5GAMgASgJIo0BChdHcmFwaEVycm9yQnVkZ2V0UmVxdWVzdBIMCgRleHByGAEgASgJEhAKCGdyb3VwaW5nGAIgASgJEikKBXN0YXJ0GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBInCgNlbmQYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIk8KGEdyYXBoRXJyb3JCdWRnZXRSZXNwb25zZRIzCgp0aW1lc2VyaWVzGAEgASgLMh8ub2JqZWN0aXZlcy52MWFscGhhMS5UaW1lc2VyaWVzIoYBChBHcmFwaFJhdGVSZXF1ZXN0EgwKBGV4cHIYASABKAkSEAoIZ3JvdXBpbmcYAiABKAkSKQoFc3RhcnQYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEicKA2VuZBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiSAoRR3JhcGhSYXRlUmVzcG9uc2USMwoKdGltZXNlcmllcxgBIAEoCzIfLm9iamVjdGl2ZXMudjFhbHBoYTEuVGltZXNlcmllcyKIAQoSR3JhcGhFcnJvcnNSZXF1ZXN0EgwKBGV4cHIYASABKAkSEAoIZ3JvdXBpbmcYAiABKAkSKQoFc3RhcnQYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEicKA2VuZBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiSgoTR3JhcGhFcnJvcnNSZXNwb25zZRIzCgp0aW1lc2VyaWVzGAEgASgLMh8ub2JqZWN0aXZlcy52MWFscGhhMS5UaW1lc2VyaWVzIlgKClRpbWVzZXJpZXMSDgoGbGFiZWxzGAEgAygJEg0KBXF1ZXJ5GAIgASgJEisKBnNlcmllcxgDIAMoCzIbLm9iamVjdGl2ZXMudjFhbHBoYTEuU2VyaWVzIhgKBlNlcmllcxIOCgZ2YWx1ZXMYASADKAEiigEKFEdyYXBoRHVyYXRpb25SZXF1ZXN0EgwKBGV4cHIYASABKAkSEAoIZ3JvdXBpbmcYAiABKAkSKQoFc3RhcnQYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEicKA2VuZBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiTAoVR3JhcGhEdXJhdGlvblJlc3BvbnNlEjMKCnRpbWVzZXJpZXMYASADKAsyHy5vYmplY3RpdmVzLnYxYWxwaGExLlRpbWVzZXJpZXMyvAUKEE9iamVjdGl2ZVNlcnZpY2USTQoETGlzdBIgLm9iamVjdGl2ZXMudjFhbHBoYTEuTGlzdFJlcXVlc3QaIS5vYmplY3RpdmVzLnYxYWxwaGExLkxpc3RSZXNwb25zZSIAElwKCUdldFN0YXR1cxIlLm9iamVjdGl2ZXMudjFhbHBoYTEuR2V0U3RhdHVzUmVxdWVzdBomLm9iamVjdGl2ZXMudjFhbHBoYTEuR2V0U3RhdHVzUmVzcG9uc2UiABJcCglHZXRBbGVydHMSJS5vYmplY3RpdmVzLnYxYWxwaGExLkdldEFsZXJ0c1JlcXVlc3QaJi5vYmplY3RpdmVzLnYxYWxwaGExLkdldEFsZXJ0c1Jlc3BvbnNlIgAScQoQR3JhcGhFcnJvckJ1ZGdldBIsLm9iamVjdGl2ZXMudjFhbHBoYTEuR3JhcGhFcnJvckJ1ZGdldFJlcXVlc3QaLS5vYmplY3RpdmVzLnYxYWxwaGExLkdyYXBoRXJyb3JCdWRnZXRSZXNwb25zZSIAElwKCUdyYXBoUmF0ZRIlLm9iamVjdGl2ZXMudjFhbHBoYTEuR3JhcGhSYXRlUmVxdWVzdBomLm9iamVjdGl2ZXMudjFhbHBoYTEuR3JhcGhSYXRlUmVzcG9uc2UiABJiCgtHcmFwaEVycm9ycxInLm9iamVjdGl2ZXMudjFhbHBoYTEuR3JhcGhFcnJvcnNSZXF1ZXN0Gigub2JqZWN0aXZlcy52MWFscGhhMS5HcmFwaEVycm9yc1Jlc3BvbnNlIgASaAoNR3JhcGhEdXJhdGlvbhIpLm9iamVjdGl2ZXMudjFhbHBoYTEuR3JhcGhEdXJhdGlvblJlcXVlc3QaKi5vYmplY3RpdmVzLnYxYWxwaGExLkdyYXBoRHVyYXRpb25SZXNwb25zZSIAMmgKF09iamVjdGl2ZUJhY2tlbmRTZXJ2aWNlEk0KBExpc3QSIC5vYmplY3RpdmVzLnYxYWxwaGExLkxpc3RSZXF1ZXN0GiEub2JqZWN0aXZlcy52MWFscGhhMS5MaXN0UmVzcG9uc2UiAEJJWkdnaXRodWIuY29tL3B5cnJhLWRldi9weXJyYS9wcm90by9vYmplY3RpdmVzL3YxYWxwaGExO29iamVjdGl2ZXN2MWFscGhhMWIGcHJvdG8z", [file_google_protobuf_duration, file_google_protobuf_timestamp]);
+  fileDesc("CiRvYmplY3RpdmVzL3YxYWxwaGExL29iamVjdGl2ZXMucHJvdG8SE29iamVjdGl2ZXMudjFhbHBoYTEidgoLTGlzdFJlcXVlc3QSDAoEZXhwchgBIAEoCRIQCghncm91cGluZxgCIAEoCRIRCglwYWdlX3NpemUYAyABKAUSEgoKcGFnZV90b2tlbhgEIAEoCRIQCghvcmRlcl9ieRgFIAEoCRIOCgZzZWFyY2gYBiABKAkigQEKDExpc3RSZXNwb25zZRIyCgpvYmplY3RpdmVzGAEgAygLMh4ub2JqZWN0aXZlcy52MWFscGhhMS5PYmplY3RpdmUSFwoPbmV4dF9wYWdlX3Rva2VuGAIgASgJEhIKCnRvdGFsX3NpemUYAyABKAUSEAoId2FybmluZ3MYBCADKAkingQKCU9iamVjdGl2ZRI6CgZsYWJlbHMYASADKAsyKi5vYmplY3RpdmVzLnYxYWxwaGExLk9iamVjdGl2ZS5MYWJlbHNFbnRyeRIOCgZ0YXJnZXQYAiABKAESKQoGd2luZG93GAMgASgLMhkuZ29vZ2xlLnByb3RvYnVmLkR1cmF0aW9uEhMKC2Rlc2NyaXB0aW9uGAQgASgJEjEKCWluZGljYXRvchgFIAEoCzIeLm9iamVjdGl2ZXMudjFhbHBoYTEuSW5kaWNhdG9yEg4KBmNvbmZpZxgGIAEoCRItCgdxdWVyaWVzGAcgASgLMhwub2JqZWN0aXZlcy52MWFscGhhMS5RdWVyaWVzEj0KEGJ1cm5fcmF0ZV9wb2xpY3kYCCADKAsyIy5vYmplY3RpdmVzLnYxYWxwaGExLkJ1cm5SYXRlV2luZG93Ei8KCGNhbGVuZGFyGAkgASgLMh0ub2JqZWN0aXZlcy52MWFscGhhMS5DYWxlbmRhchI9Cg1idWRnZXRfcG9saWN5GAogAygLMiYub2JqZWN0aXZlcy52MWFscGhhMS5CdWRnZXRQb2xpY3lTdGFnZRIhChlwZXJmb3JtYW5jZV9vdmVyX2FjY3VyYWN5GAsgASgIEhIKCmRhdGFzb3VyY2UYDCABKAkaLQoLTGFiZWxzRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASLFAgoJSW5kaWNhdG9yEisKBXJhdGlvGAEgASgLMhoub2JqZWN0aXZlcy52MWFscGhhMS5SYXRpb0gAEi8KB2xhdGVuY3kYAiABKAsyHC5vYmplY3RpdmVzLnYxYWxwaGExLkxhdGVuY3lIABIzCglib29sR2F1Z2UYAyABKAsyHi5vYmplY3RpdmVzLnYxYWxwaGExLkJvb2xHYXVnZUgAEjwKDmxhdGVuY3lfbmF0aXZlGAQgASgLMiIub2JqZWN0aXZlcy52MWFscGhhMS5MYXRlbmN5TmF0aXZlSAASMwoJY29tcG9zaXRlGAUgASgLMh4ub2JqZWN0aXZlcy52MWFscGhhMS5Db21wb3NpdGVIABInCgNyYXcYBiABKAsyGC5vYmplY3RpdmVzLnYxYWxwaGExLlJhd0gAQgkKB29wdGlvbnMicAoFUmF0aW8SKQoFdG90YWwYASABKAsyGi5vYmplY3RpdmVzLnYxYWxwaGExLlF1ZXJ5EioKBmVycm9ycxgCIAEoCzIaLm9iamVjdGl2ZXMudjFhbHBoYTEuUXVlcnkSEAoIZ3JvdXBpbmcYAyADKAkicwoHTGF0ZW5jeRIpCgV0b3RhbBgBIAEoCzIaLm9iamVjdGl2ZXMudjFhbHBoYTEuUXVlcnkSKwoHc3VjY2VzcxgCIAEoCzIaLm9iamVjdGl2ZXMudjFhbHBoYTEuUXVlcnkSEAoIZ3JvdXBpbmcYAyADKAkiXQoNTGF0ZW5jeU5hdGl2ZRIpCgV0b3RhbBgBIAEoCzIaLm9iamVjdGl2ZXMudjFhbHBoYTEuUXVlcnkSDwoHbGF0ZW5jeRgCIAEoCRIQCghncm91cGluZxgDIAMoCSJMCglCb29sR2F1Z2USLQoJYm9vbEdhdWdlGAEgASgLMhoub2JqZWN0aXZlcy52MWFscGhhMS5RdWVyeRIQCghncm91cGluZxgDIAMoCSJaCgVRdWVyeRIOCgZtZXRyaWMYASABKAkSDAoEbmFtZRgCIAEoCRIzCghtYXRjaGVycxgDIAMoCzIhLm9iamVjdGl2ZXMudjFhbHBoYTEuTGFiZWxNYXRjaGVyIngKB1F1ZXJpZXMSEgoKY291bnRUb3RhbBgBIAEoCRITCgtjb3VudEVycm9ycxgCIAEoCRIYChBncmFwaEVycm9yQnVkZ2V0GAMgASgJEhUKDWdyYXBoUmVxdWVzdHMYBCABKAkSEwoLZ3JhcGhFcnJvcnMYBSABKAkiiwEKDExhYmVsTWF0Y2hlchI0CgR0eXBlGAEgASgOMiYub2JqZWN0aXZlcy52MWFscGhhMS5MYWJlbE1hdGNoZXIuVHlwZRIMCgRuYW1lGAIgASgJEg0KBXZhbHVlGAMgASgJIigKBFR5cGUSBgoCRVEQABIHCgNORVEQARIGCgJSRRACEgcKA05SRRADIlwKEEdldFN0YXR1c1JlcXVlc3QSDAoEZXhwchgBIAEoCRIQCghncm91cGluZxgCIAEoCRIoCgR0aW1lGAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCJJChFHZXRTdGF0dXNSZXNwb25zZRI0CgZzdGF0dXMYASADKAsyJC5vYmplY3RpdmVzLnYxYWxwaGExLk9iamVjdGl2ZVN0YXR1cyLoAQoPT2JqZWN0aXZlU3RhdHVzEkAKBmxhYmVscxgBIAMoCzIwLm9iamVjdGl2ZXMudjFhbHBoYTEuT2JqZWN0aXZlU3RhdHVzLkxhYmVsc0VudHJ5EjcKDGF2YWlsYWJpbGl0eRgCIAEoCzIhLm9iamVjdGl2ZXMudjFhbHBoYTEuQXZhaWxhYmlsaXR5EisKBmJ1ZGdldBgDIAEoCzIbLm9iamVjdGl2ZXMudjFhbHBoYTEuQnVkZ2V0Gi0KC0xhYmVsc0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEiQQoMQXZhaWxhYmlsaXR5EhIKCnBlcmNlbnRhZ2UYASABKAESDQoFdG90YWwYAiABKAESDgoGZXJyb3JzGAMgASgBIk0KBkJ1ZGdldBINCgV0b3RhbBgBIAEoARIRCglyZW1haW5pbmcYAiABKAESCwoDbWF4GAMgASgBEhQKDHBvbGljeV9zdGFnZRgEIAEoCSJVChBHZXRBbGVydHNSZXF1ZXN0EgwKBGV4cHIYASABKAkSEAoIZ3JvdXBpbmcYAiABKAkSEAoIaW5hY3RpdmUYAyABKAgSDwoHY3VycmVudBgEIAEoCCI/ChFHZXRBbGVydHNSZXNwb25zZRIqCgZhbGVydHMYASADKAsyGi5vYmplY3RpdmVzLnYxYWxwaGExLkFsZXJ0IvQCCgVBbGVydBI2CgZsYWJlbHMYASADKAsyJi5vYmplY3RpdmVzLnYxYWxwaGExLkFsZXJ0LkxhYmVsc0VudHJ5EhAKCHNldmVyaXR5GAIgASgJEiYKA2ZvchgDIAEoCzIZLmdvb2dsZS5wcm90b2J1Zi5EdXJhdGlvbhIOCgZmYWN0b3IYBCABKAESLwoFc3RhdGUYBSABKA4yIC5vYmplY3RpdmVzLnYxYWxwaGExLkFsZXJ0LlN0YXRlEiwKBXNob3J0GAYgASgLMh0ub2JqZWN0aXZlcy52MWFscGhhMS5CdXJucmF0ZRIrCgRsb25nGAcgASgLMh0ub2JqZWN0aXZlcy52MWFscGhhMS5CdXJucmF0ZRotCgtMYWJlbHNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIi4KBVN0YXRlEgwKCGluYWN0aXZlEAASCwoHcGVuZGluZxABEgoKBmZpcmluZxACIlUKCEJ1cm5yYXRlEikKBndpbmRvdxgBIAEoCzIZLmdvb2dsZS5wcm90b2J1Zi5EdXJhdGlvbhIPCgdjdXJyZW50GAIgASgBEg0KBXF1ZXJ5GAMgASgJIo0BChdHcmFwaEVycm9yQnVkZ2V0UmVxdWVzdBIMCgRleHByGAEgASgJEhAKCGdyb3VwaW5nGAIgASgJEikKBXN0YXJ0GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBInCgNlbmQYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIk8KGEdyYXBoRXJyb3JCdWRnZXRSZXNwb25zZRIzCgp0aW1lc2VyaWVzGAEgASgLMh8ub2JqZWN0aXZlcy52MWFscGhhMS5UaW1lc2VyaWVzIoYBChBHcmFwaFJhdGVSZXF1ZXN0EgwKBGV4cHIYASABKAkSEAoIZ3JvdXBpbmcYAiABKAkSKQoFc3RhcnQYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEicKA2VuZBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiSAoRR3JhcGhSYXRlUmVzcG9uc2USMwoKdGltZXNlcmllcxgBIAEoCzIfLm9iamVjdGl2ZXMudjFhbHBoYTEuVGltZXNlcmllcyKIAQoSR3JhcGhFcnJvcnNSZXF1ZXN0EgwKBGV4cHIYASABKAkSEAoIZ3JvdXBpbmcYAiABKAkSKQoFc3RhcnQYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEicKA2VuZBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiSgoTR3JhcGhFcnJvcnNSZXNwb25zZRIzCgp0aW1lc2VyaWVzGAEgASgLMh8ub2JqZWN0aXZlcy52MWFscGhhMS5UaW1lc2VyaWVzIlgKClRpbWVzZXJpZXMSDgoGbGFiZWxzGAEgAygJEg0KBXF1ZXJ5GAIgASgJEisKBnNlcmllcxgDIAMoCzIbLm9iamVjdGl2ZXMudjFhbHBoYTEuU2VyaWVzIhgKBlNlcmllcxIOCgZ2YWx1ZXMYASADKAEiigEKFEdyYXBoRHVyYXRpb25SZXF1ZXN0EgwKBGV4cHIYASABKAkSEAoIZ3JvdXBpbmcYAiABKAkSKQoFc3RhcnQYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEicKA2VuZBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiTAoVR3JhcGhEdXJhdGlvblJlc3BvbnNlEjMKCnRpbWVzZXJpZXMYASADKAsyHy5vYmplY3RpdmVzLnYxYWxwaGExLlRpbWVzZXJpZXMinAMKDkJ1cm5SYXRlV2luZG93EhAKCHNldmVyaXR5GAEgASgJEiYKA2ZvchgCIAEoCzIZLmdvb2dsZS5wcm90b2J1Zi5EdXJhdGlvbhIOCgZmYWN0b3IYAyABKAESKAoFc2hvcnQYBCABKAsyGS5nb29nbGUucHJvdG9idWYuRHVyYXRpb24SJwoEbG9uZxgFIAEoCzIZLmdvb2dsZS5wcm90b2J1Zi5EdXJhdGlvbhI/CgZsYWJlbHMYBiADKAsyLy5vYmplY3RpdmVzLnYxYWxwaGExLkJ1cm5SYXRlV2luZG93LkxhYmVsc0VudHJ5EkkKC2Fubm90YXRpb25zGAcgAygLMjQub2JqZWN0aXZlcy52MWFscGhhMS5CdXJuUmF0ZVdpbmRvdy5Bbm5vdGF0aW9uc0VudHJ5Gi0KC0xhYmVsc0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEaMgoQQW5ub3RhdGlvbnNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIi0KCENhbGVuZGFyEg4KBnBlcmlvZBgBIAEoCRIRCgl0aW1lX3pvbmUYAiABKAkiVwoJQ29tcG9zaXRlEg0KBW1vZGVsGAEgASgJEjsKCmNvbXBvbmVudHMYAiADKAsyJy5vYmplY3RpdmVzLnYxYWxwaGExLkNvbXBvc2l0ZUNvbXBvbmVudCJqChJDb21wb3NpdGVDb21wb25lbnQSEAoIc2VsZWN0b3IYASABKAkSDgoGd2VpZ2h0GAIgASgBEjIKCm9iamVjdGl2ZXMYAyADKAsyHi5vYmplY3RpdmVzLnYxYWxwaGExLk9iamVjdGl2ZSI0CgNSYXcSDAoEZ29vZBgBIAEoCRINCgV0b3RhbBgCIAEoCRIQCghncm91cGluZxgDIAMoCSK5AQoRQnVkZ2V0UG9saWN5U3RhZ2USDAoEbmFtZRgBIAEoCRIRCglyZW1haW5pbmcYAiABKAESEAoIc2V2ZXJpdHkYAyABKAkSQgoGbGFiZWxzGAQgAygLMjIub2JqZWN0aXZlcy52MWFscGhhMS5CdWRnZXRQb2xpY3lTdGFnZS5MYWJlbHNFbnRyeRotCgtMYWJlbHNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIs8BCg9CYWNrdGVzdFJlcXVlc3QSDAoEZXhwchgBIAEoCRIpCgVzdGFydBgCIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASJwoDZW5kGAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBInCgRzdGVwGAQgASgLMhkuZ29vZ2xlLnByb3RvYnVmLkR1cmF0aW9uEjEKCW9iamVjdGl2ZRgFIAEoCzIeLm9iamVjdGl2ZXMudjFhbHBoYTEuT2JqZWN0aXZlIkYKEEJhY2t0ZXN0UmVzcG9uc2USMgoGYWxlcnRzGAEgAygLMiIub2JqZWN0aXZlcy52MWFscGhhMS5CYWNrdGVzdEFsZXJ0Io0BCg1CYWNrdGVzdEFsZXJ0EjMKBndpbmRvdxgBIAEoCzIjLm9iamVjdGl2ZXMudjFhbHBoYTEuQnVyblJhdGVXaW5kb3cSDQoFcXVlcnkYAiABKAkSOAoJaW50ZXJ2YWxzGAMgAygLMiUub2JqZWN0aXZlcy52MWFscGhhMS5CYWNrdGVzdEludGVydmFsIokCChBCYWNrdGVzdEludGVydmFsEkEKBmxhYmVscxgBIAMoCzIxLm9iamVjdGl2ZXMudjFhbHBoYTEuQmFja3Rlc3RJbnRlcnZhbC5MYWJlbHNFbnRyeRIvCgVzdGF0ZRgCIAEoDjIgLm9iamVjdGl2ZXMudjFhbHBoYTEuQWxlcnQuU3RhdGUSKQoFc3RhcnQYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEicKA2VuZBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAaLQoLTGFiZWxzRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASJ3ChNFeHBvcnRSZXBvcnRSZXF1ZXN0EgwKBGV4cHIYASABKAkSKQoFc3RhcnQYAiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEicKA2VuZBgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAimAEKFEV4cG9ydFJlcG9ydFJlc3BvbnNlEikKBXN0YXJ0GAEgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBInCgNlbmQYAiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEiwKBHJvd3MYAyADKAsyHi5vYmplY3RpdmVzLnYxYWxwaGExLlJlcG9ydFJvdyK9AgoJUmVwb3J0Um93EjoKBmxhYmVscxgBIAMoCzIqLm9iamVjdGl2ZXMudjFhbHBoYTEuUmVwb3J0Um93LkxhYmVsc0VudHJ5Ej4KCGdyb3VwaW5nGAIgAygLMiwub2JqZWN0aXZlcy52MWFscGhhMS5SZXBvcnRSb3cuR3JvdXBpbmdFbnRyeRIOCgZ0YXJnZXQYAyABKAESFAoMYXZhaWxhYmlsaXR5GAQgASgBEhcKD2J1ZGdldF9jb25zdW1lZBgFIAEoARIVCg1hbGVydF9taW51dGVzGAYgASgBGi0KC0xhYmVsc0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEaLwoNR3JvdXBpbmdFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBInQKF0dldFN0YXR1c0hpc3RvcnlSZXF1ZXN0EgwKBGV4cHIYASABKAkSEAoIZ3JvdXBpbmcYAiABKAkSKAoEdGltZRgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASDwoHcGVyaW9kcxgEIAEoDSJYChhHZXRTdGF0dXNIaXN0b3J5UmVzcG9uc2USPAoHaGlzdG9yeRgBIAMoCzIrLm9iamVjdGl2ZXMudjFhbHBoYTEuT2JqZWN0aXZlU3RhdHVzSGlzdG9yeSLEAQoWT2JqZWN0aXZlU3RhdHVzSGlzdG9yeRJHCgZsYWJlbHMYASADKAsyNy5vYmplY3RpdmVzLnYxYWxwaGExLk9iamVjdGl2ZVN0YXR1c0hpc3RvcnkuTGFiZWxzRW50cnkSMgoHcGVyaW9kcxgCIAMoCzIhLm9iamVjdGl2ZXMudjFhbHBoYTEuUGVyaW9kU3RhdHVzGi0KC0xhYmVsc0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEi1QEKDFBlcmlvZFN0YXR1cxIpCgVzdGFydBgBIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASJwoDZW5kGAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBI3CgxhdmFpbGFiaWxpdHkYAyABKAsyIS5vYmplY3RpdmVzLnYxYWxwaGExLkF2YWlsYWJpbGl0eRIrCgZidWRnZXQYBCABKAsyGy5vYmplY3RpdmVzLnYxYWxwaGExLkJ1ZGdldBILCgNtZXQYBSABKAgiNAoSV2F0Y2hTdGF0dXNSZXF1ZXN0EgwKBGV4cHIYASABKAkSEAoIZ3JvdXBpbmcYAiABKAkiVwoSV2F0Y2hBbGVydHNSZXF1ZXN0EgwKBGV4cHIYASABKAkSEAoIZ3JvdXBpbmcYAiABKAkSEAoIaW5hY3RpdmUYAyABKAgSDwoHY3VycmVudBgEIAEoCCJPChVCYXRjaEdldFN0YXR1c1JlcXVlc3QSDAoEZXhwchgBIAEoCRIoCgR0aW1lGAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCJOChZCYXRjaEdldFN0YXR1c1Jlc3BvbnNlEjQKCm9iamVjdGl2ZXMYASADKAsyIC5vYmplY3RpdmVzLnYxYWxwaGExLkJhdGNoU3RhdHVzIrABCgtCYXRjaFN0YXR1cxI8CgZsYWJlbHMYASADKAsyLC5vYmplY3RpdmVzLnYxYWxwaGExLkJhdGNoU3RhdHVzLkxhYmVsc0VudHJ5EjQKBnN0YXR1cxgCIAMoCzIkLm9iamVjdGl2ZXMudjFhbHBoYTEuT2JqZWN0aXZlU3RhdHVzGi0KC0xhYmVsc0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEypgoKEE9iamVjdGl2ZVNlcnZpY2USTQoETGlzdBIgLm9iamVjdGl2ZXMudjFhbHBoYTEuTGlzdFJlcXVlc3QaIS5vYmplY3RpdmVzLnYxYWxwaGExLkxpc3RSZXNwb25zZSIAElwKCUdldFN0YXR1cxIlLm9iamVjdGl2ZXMudjFhbHBoYTEuR2V0U3RhdHVzUmVxdWVzdBomLm9iamVjdGl2ZXMudjFhbHBoYTEuR2V0U3RhdHVzUmVzcG9uc2UiABJrCg5CYXRjaEdldFN0YXR1cxIqLm9iamVjdGl2ZXMudjFhbHBoYTEuQmF0Y2hHZXRTdGF0dXNSZXF1ZXN0Gisub2JqZWN0aXZlcy52MWFscGhhMS5CYXRjaEdldFN0YXR1c1Jlc3BvbnNlIgASXAoJR2V0QWxlcnRzEiUub2JqZWN0aXZlcy52MWFscGhhMS5HZXRBbGVydHNSZXF1ZXN0GiYub2JqZWN0aXZlcy52MWFscGhhMS5HZXRBbGVydHNSZXNwb25zZSIAEnEKEEdyYXBoRXJyb3JCdWRnZXQSLC5vYmplY3RpdmVzLnYxYWxwaGExLkdyYXBoRXJyb3JCdWRnZXRSZXF1ZXN0Gi0ub2JqZWN0aXZlcy52MWFscGhhMS5HcmFwaEVycm9yQnVkZ2V0UmVzcG9uc2UiABJcCglHcmFwaFJhdGUSJS5vYmplY3RpdmVzLnYxYWxwaGExLkdyYXBoUmF0ZVJlcXVlc3QaJi5vYmplY3RpdmVzLnYxYWxwaGExLkdyYXBoUmF0ZVJlc3BvbnNlIgASYgoLR3JhcGhFcnJvcnMSJy5vYmplY3RpdmVzLnYxYWxwaGExLkdyYXBoRXJyb3JzUmVxdWVzdBooLm9iamVjdGl2ZXMudjFhbHBoYTEuR3JhcGhFcnJvcnNSZXNwb25zZSIAEmgKDUdyYXBoRHVyYXRpb24SKS5vYmplY3RpdmVzLnYxYWxwaGExLkdyYXBoRHVyYXRpb25SZXF1ZXN0Gioub2JqZWN0aXZlcy52MWFscGhhMS5HcmFwaER1cmF0aW9uUmVzcG9uc2UiABJZCghCYWNrdGVzdBIkLm9iamVjdGl2ZXMudjFhbHBoYTEuQmFja3Rlc3RSZXF1ZXN0GiUub2JqZWN0aXZlcy52MWFscGhhMS5CYWNrdGVzdFJlc3BvbnNlIgASZQoMRXhwb3J0UmVwb3J0Eigub2JqZWN0aXZlcy52MWFscGhhMS5FeHBvcnRSZXBvcnRSZXF1ZXN0Gikub2JqZWN0aXZlcy52MWFscGhhMS5FeHBvcnRSZXBvcnRSZXNwb25zZSIAEnEKEEdldFN0YXR1c0hpc3RvcnkSLC5vYmplY3RpdmVzLnYxYWxwaGExLkdldFN0YXR1c0hpc3RvcnlSZXF1ZXN0Gi0ub2JqZWN0aXZlcy52MWFscGhhMS5HZXRTdGF0dXNIaXN0b3J5UmVzcG9uc2UiABJiCgtXYXRjaFN0YXR1cxInLm9iamVjdGl2ZXMudjFhbHBoYTEuV2F0Y2hTdGF0dXNSZXF1ZXN0GiYub2JqZWN0aXZlcy52MWFscGhhMS5HZXRTdGF0dXNSZXNwb25zZSIAMAESYgoLV2F0Y2hBbGVydHMSJy5vYmplY3RpdmVzLnYxYWxwaGExLldhdGNoQWxlcnRzUmVxdWVzdBomLm9iamVjdGl2ZXMudjFhbHBoYTEuR2V0QWxlcnRzUmVzcG9uc2UiADABMmgKF09iamVjdGl2ZUJhY2tlbmRTZXJ2aWNlEk0KBExpc3QSIC5vYmplY3RpdmVzLnYxYWxwaGExLkxpc3RSZXF1ZXN0GiEub2JqZWN0aXZlcy52MWFscGhhMS5MaXN0UmVzcG9uc2UiAEJJWkdnaXRodWIuY29tL3B5cnJhLWRldi9weXJyYS9wcm90by9vYmplY3RpdmVzL3YxYWxwaGExO29iamVjdGl2ZXN2MWFscGhhMWIGcHJvdG8z", [file_google_protobuf_duration, file_google_protobuf_timestamp]);
 
 /**
  * Describes the message objectives.v1alpha1.ListRequest.
@@ -245,6 +245,160 @@ export const GraphDurationRequestSchema = /*@__PURE__*/
 export const GraphDurationResponseSchema = /*@__PURE__*/
   messageDesc(file_objectives_v1alpha1_objectives, 29);
 
+/**
+ * Describes the message objectives.v1alpha1.BurnRateWindow.
+ * Use `create(BurnRateWindowSchema)` to create a new message.
+ */
+export const BurnRateWindowSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 30);
+
+/**
+ * Describes the message objectives.v1alpha1.Calendar.
+ * Use `create(CalendarSchema)` to create a new message.
+ */
+export const CalendarSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 31);
+
+/**
+ * Describes the message objectives.v1alpha1.Composite.
+ * Use `create(CompositeSchema)` to create a new message.
+ */
+export const CompositeSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 32);
+
+/**
+ * Describes the message objectives.v1alpha1.CompositeComponent.
+ * Use `create(CompositeComponentSchema)` to create a new message.
+ */
+export const CompositeComponentSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 33);
+
+/**
+ * Describes the message objectives.v1alpha1.Raw.
+ * Use `create(RawSchema)` to create a new message.
+ */
+export const RawSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 34);
+
+/**
+ * Describes the message objectives.v1alpha1.BudgetPolicyStage.
+ * Use `create(BudgetPolicyStageSchema)` to create a new message.
+ */
+export const BudgetPolicyStageSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 35);
+
+/**
+ * Describes the message objectives.v1alpha1.BacktestRequest.
+ * Use `create(BacktestRequestSchema)` to create a new message.
+ */
+export const BacktestRequestSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 36);
+
+/**
+ * Describes the message objectives.v1alpha1.BacktestResponse.
+ * Use `create(BacktestResponseSchema)` to create a new message.
+ */
+export const BacktestResponseSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 37);
+
+/**
+ * Describes the message objectives.v1alpha1.BacktestAlert.
+ * Use `create(BacktestAlertSchema)` to create a new message.
+ */
+export const BacktestAlertSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 38);
+
+/**
+ * Describes the message objectives.v1alpha1.BacktestInterval.
+ * Use `create(BacktestIntervalSchema)` to create a new message.
+ */
+export const BacktestIntervalSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 39);
+
+/**
+ * Describes the message objectives.v1alpha1.ExportReportRequest.
+ * Use `create(ExportReportRequestSchema)` to create a new message.
+ */
+export const ExportReportRequestSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 40);
+
+/**
+ * Describes the message objectives.v1alpha1.ExportReportResponse.
+ * Use `create(ExportReportResponseSchema)` to create a new message.
+ */
+export const ExportReportResponseSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 41);
+
+/**
+ * Describes the message objectives.v1alpha1.ReportRow.
+ * Use `create(ReportRowSchema)` to create a new message.
+ */
+export const ReportRowSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 42);
+
+/**
+ * Describes the message objectives.v1alpha1.GetStatusHistoryRequest.
+ * Use `create(GetStatusHistoryRequestSchema)` to create a new message.
+ */
+export const GetStatusHistoryRequestSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 43);
+
+/**
+ * Describes the message objectives.v1alpha1.GetStatusHistoryResponse.
+ * Use `create(GetStatusHistoryResponseSchema)` to create a new message.
+ */
+export const GetStatusHistoryResponseSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 44);
+
+/**
+ * Describes the message objectives.v1alpha1.ObjectiveStatusHistory.
+ * Use `create(ObjectiveStatusHistorySchema)` to create a new message.
+ */
+export const ObjectiveStatusHistorySchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 45);
+
+/**
+ * Describes the message objectives.v1alpha1.PeriodStatus.
+ * Use `create(PeriodStatusSchema)` to create a new message.
+ */
+export const PeriodStatusSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 46);
+
+/**
+ * Describes the message objectives.v1alpha1.WatchStatusRequest.
+ * Use `create(WatchStatusRequestSchema)` to create a new message.
+ */
+export const WatchStatusRequestSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 47);
+
+/**
+ * Describes the message objectives.v1alpha1.WatchAlertsRequest.
+ * Use `create(WatchAlertsRequestSchema)` to create a new message.
+ */
+export const WatchAlertsRequestSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 48);
+
+/**
+ * Describes the message objectives.v1alpha1.BatchGetStatusRequest.
+ * Use `create(BatchGetStatusRequestSchema)` to create a new message.
+ */
+export const BatchGetStatusRequestSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 49);
+
+/**
+ * Describes the message objectives.v1alpha1.BatchGetStatusResponse.
+ * Use `create(BatchGetStatusResponseSchema)` to create a new message.
+ */
+export const BatchGetStatusResponseSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 50);
+
+/**
+ * Describes the message objectives.v1alpha1.BatchStatus.
+ * Use `create(BatchStatusSchema)` to create a new message.
+ */
+export const BatchStatusSchema = /*@__PURE__*/
+  messageDesc(file_objectives_v1alpha1_objectives, 51);
+
 /**
  * @generated from service objectives.v1alpha1.ObjectiveService
  */
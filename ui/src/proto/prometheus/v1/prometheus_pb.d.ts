@@ -23,6 +23,11 @@ export declare type QueryRequest = Message<"prometheus.v1.QueryRequest"> & {
    * @generated from field: int64 time = 2;
    */
   time: bigint;
+
+  /**
+   * @generated from field: string datasource = 3;
+   */
+  datasource: string;
 };
 
 /**
@@ -99,6 +104,11 @@ export declare type QueryRangeRequest = Message<"prometheus.v1.QueryRangeRequest
    * @generated from field: int64 step = 4;
    */
   step: bigint;
+
+  /**
+   * @generated from field: string datasource = 5;
+   */
+  datasource: string;
 };
 
 /**
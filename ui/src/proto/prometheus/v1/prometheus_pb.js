@@ -8,7 +8,7 @@ import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2
  * Describes the file prometheus/v1/prometheus.proto.
  */
 export const file_prometheus_v1_prometheus = /*@__PURE__*/
-  fileDesc("Ch5wcm9tZXRoZXVzL3YxL3Byb21ldGhldXMucHJvdG8SDXByb21ldGhldXMudjEiKwoMUXVlcnlSZXF1ZXN0Eg0KBXF1ZXJ5GAEgASgJEgwKBHRpbWUYAiABKAMi1AEKDVF1ZXJ5UmVzcG9uc2USEAoId2FybmluZ3MYASADKAkSKwoGc2NhbGFyGAIgASgLMhkucHJvbWV0aGV1cy52MS5TYW1wbGVQYWlySAASJwoGdmVjdG9yGAMgASgLMhUucHJvbWV0aGV1cy52MS5WZWN0b3JIABInCgZtYXRyaXgYBCABKAsyFS5wcm9tZXRoZXVzLnYxLk1hdHJpeEgAEicKBnN0cmluZxgFIAEoCzIVLnByb21ldGhldXMudjEuU3RyaW5nSABCCQoHb3B0aW9ucyJMChFRdWVyeVJhbmdlUmVxdWVzdBINCgVxdWVyeRgBIAEoCRINCgVzdGFydBgCIAEoAxILCgNlbmQYAyABKAMSDAoEc3RlcBgEIAEoAyLZAQoSUXVlcnlSYW5nZVJlc3BvbnNlEhAKCHdhcm5pbmdzGAEgAygJEisKBnNjYWxhchgCIAEoCzIZLnByb21ldGhldXMudjEuU2FtcGxlUGFpckgAEicKBnZlY3RvchgDIAEoCzIVLnByb21ldGhldXMudjEuVmVjdG9ySAASJwoGbWF0cml4GAQgASgLMhUucHJvbWV0aGV1cy52MS5NYXRyaXhIABInCgZzdHJpbmcYBSABKAsyFS5wcm9tZXRoZXVzLnYxLlN0cmluZ0gAQgkKB29wdGlvbnMiMAoGVmVjdG9yEiYKB3NhbXBsZXMYASADKAsyFS5wcm9tZXRoZXVzLnYxLlNhbXBsZSKHAQoGU2FtcGxlEgwKBHRpbWUYASABKAMSDQoFdmFsdWUYAiABKAESMQoGbWV0cmljGAMgAygLMiEucHJvbWV0aGV1cy52MS5TYW1wbGUuTWV0cmljRW50cnkaLQoLTWV0cmljRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASI2CgZNYXRyaXgSLAoHc2FtcGxlcxgBIAMoCzIbLnByb21ldGhldXMudjEuU2FtcGxlU3RyZWFtIqEBCgxTYW1wbGVTdHJlYW0SKQoGdmFsdWVzGAEgAygLMhkucHJvbWV0aGV1cy52MS5TYW1wbGVQYWlyEjcKBm1ldHJpYxgCIAMoCzInLnByb21ldGhldXMudjEuU2FtcGxlU3RyZWFtLk1ldHJpY0VudHJ5Gi0KC01ldHJpY0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEiKQoKU2FtcGxlUGFpchIMCgR0aW1lGAEgASgDEg0KBXZhbHVlGAIgASgBIiUKBlN0cmluZxIMCgR0aW1lGAEgASgDEg0KBXZhbHVlGAIgASgJMq4BChFQcm9tZXRoZXVzU2VydmljZRJECgVRdWVyeRIbLnByb21ldGhldXMudjEuUXVlcnlSZXF1ZXN0GhwucHJvbWV0aGV1cy52MS5RdWVyeVJlc3BvbnNlIgASUwoKUXVlcnlSYW5nZRIgLnByb21ldGhldXMudjEuUXVlcnlSYW5nZVJlcXVlc3QaIS5wcm9tZXRoZXVzLnYxLlF1ZXJ5UmFuZ2VSZXNwb25zZSIAQj1aO2dpdGh1Yi5jb20vcHlycmEtZGV2L3B5cnJhL3Byb3RvL3Byb21ldGhldXMvdjE7cHJvbWV0aGV1c3YxYgZwcm90bzM");
+  fileDesc("Ch5wcm9tZXRoZXVzL3YxL3Byb21ldGhldXMucHJvdG8SDXByb21ldGhldXMudjEiPwoMUXVlcnlSZXF1ZXN0Eg0KBXF1ZXJ5GAEgASgJEgwKBHRpbWUYAiABKAMSEgoKZGF0YXNvdXJjZRgDIAEoCSLUAQoNUXVlcnlSZXNwb25zZRIQCgh3YXJuaW5ncxgBIAMoCRIrCgZzY2FsYXIYAiABKAsyGS5wcm9tZXRoZXVzLnYxLlNhbXBsZVBhaXJIABInCgZ2ZWN0b3IYAyABKAsyFS5wcm9tZXRoZXVzLnYxLlZlY3RvckgAEicKBm1hdHJpeBgEIAEoCzIVLnByb21ldGhldXMudjEuTWF0cml4SAASJwoGc3RyaW5nGAUgASgLMhUucHJvbWV0aGV1cy52MS5TdHJpbmdIAEIJCgdvcHRpb25zImAKEVF1ZXJ5UmFuZ2VSZXF1ZXN0Eg0KBXF1ZXJ5GAEgASgJEg0KBXN0YXJ0GAIgASgDEgsKA2VuZBgDIAEoAxIMCgRzdGVwGAQgASgDEhIKCmRhdGFzb3VyY2UYBSABKAki2QEKElF1ZXJ5UmFuZ2VSZXNwb25zZRIQCgh3YXJuaW5ncxgBIAMoCRIrCgZzY2FsYXIYAiABKAsyGS5wcm9tZXRoZXVzLnYxLlNhbXBsZVBhaXJIABInCgZ2ZWN0b3IYAyABKAsyFS5wcm9tZXRoZXVzLnYxLlZlY3RvckgAEicKBm1hdHJpeBgEIAEoCzIVLnByb21ldGhldXMudjEuTWF0cml4SAASJwoGc3RyaW5nGAUgASgLMhUucHJvbWV0aGV1cy52MS5TdHJpbmdIAEIJCgdvcHRpb25zIjAKBlZlY3RvchImCgdzYW1wbGVzGAEgAygLMhUucHJvbWV0aGV1cy52MS5TYW1wbGUihwEKBlNhbXBsZRIMCgR0aW1lGAEgASgDEg0KBXZhbHVlGAIgASgBEjEKBm1ldHJpYxgDIAMoCzIhLnByb21ldGhldXMudjEuU2FtcGxlLk1ldHJpY0VudHJ5Gi0KC01ldHJpY0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEiNgoGTWF0cml4EiwKB3NhbXBsZXMYASADKAsyGy5wcm9tZXRoZXVzLnYxLlNhbXBsZVN0cmVhbSKhAQoMU2FtcGxlU3RyZWFtEikKBnZhbHVlcxgBIAMoCzIZLnByb21ldGhldXMudjEuU2FtcGxlUGFpchI3CgZtZXRyaWMYAiADKAsyJy5wcm9tZXRoZXVzLnYxLlNhbXBsZVN0cmVhbS5NZXRyaWNFbnRyeRotCgtNZXRyaWNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIikKClNhbXBsZVBhaXISDAoEdGltZRgBIAEoAxINCgV2YWx1ZRgCIAEoASIlCgZTdHJpbmcSDAoEdGltZRgBIAEoAxINCgV2YWx1ZRgCIAEoCTKuAQoRUHJvbWV0aGV1c1NlcnZpY2USRAoFUXVlcnkSGy5wcm9tZXRoZXVzLnYxLlF1ZXJ5UmVxdWVzdBocLnByb21ldGhldXMudjEuUXVlcnlSZXNwb25zZSIAElMKClF1ZXJ5UmFuZ2USIC5wcm9tZXRoZXVzLnYxLlF1ZXJ5UmFuZ2VSZXF1ZXN0GiEucHJvbWV0aGV1cy52MS5RdWVyeVJhbmdlUmVzcG9uc2UiAEI9WjtnaXRodWIuY29tL3B5cnJhLWRldi9weXJyYS9wcm90by9wcm9tZXRoZXVzL3YxO3Byb21ldGhldXN2MWIGcHJvdG8z");
 
 /**
  * Describes the message prometheus.v1.QueryRequest.
@@ -0,0 +1,619 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/histogram"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb/chunkenc"
+	"github.com/prometheus/prometheus/tsdb/chunks"
+	"github.com/prometheus/prometheus/util/annotations"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pyrra-dev/pyrra/slo"
+)
+
+// isTestFile returns true for unit test files of objectives.
+// They are kept next to the objectives' config files and need to be skipped when reading those.
+func isTestFile(file string) bool {
+	name := strings.TrimSuffix(strings.TrimSuffix(file, ".yaml"), ".yml")
+	return name != file && strings.HasSuffix(name, "_test")
+}
+
+// unitTestFile contains unit tests for objectives, similar to promtool's rule unit tests.
+type unitTestFile struct {
+	// Objectives are the config files of the objectives to test, relative to the unit test file.
+	Objectives []string `json:"objectives"`
+	// Interval is the interval of the input series and the interval rules are evaluated at. Defaults to 1m.
+	Interval model.Duration `json:"interval,omitempty"`
+	Tests    []unitTest     `json:"tests"`
+}
+
+type unitTest struct {
+	Name         string                `json:"name,omitempty"`
+	InputSeries  []unitTestSeries      `json:"inputSeries"`
+	Expectations []unitTestExpectation `json:"expectations"`
+}
+
+// unitTestSeries is a series in promtool's expanding notation, like 0+10x100.
+type unitTestSeries struct {
+	Series string `json:"series"`
+	Values string `json:"values"`
+}
+
+type unitTestExpectation struct {
+	// EvalTime is the time since the start of the input series to check the expectation at.
+	EvalTime model.Duration `json:"evalTime"`
+	// Objective is the name of the objective, like the slo label of its rules.
+	// It's only needed if the unit test file tests more than one objective.
+	Objective string `json:"objective,omitempty"`
+	// Availability over the window in percent, compared with as many decimals as given.
+	Availability string `json:"availability,omitempty"`
+	// ErrorBudget is the remaining error budget in percent, compared with as many decimals as given.
+	ErrorBudget string `json:"errorBudget,omitempty"`
+	// Alerts are the alerts that are firing. Alerts aren't checked if unset,
+	// while an empty list expects no alerts to fire.
+	Alerts []unitTestAlert `json:"alerts,omitempty"`
+}
+
+type unitTestAlert struct {
+	Alertname string `json:"alertname"`
+	// Labels only need to contain the labels of the alert to check.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+func cmdTest(logger log.Logger, files []string) int {
+	failed := false
+	for _, file := range files {
+		errs := runUnitTestFile(file)
+		if len(errs) == 0 {
+			fmt.Printf("%s: SUCCESS\n", file)
+			continue
+		}
+
+		failed = true
+		fmt.Printf("%s: FAILED\n", file)
+		for _, err := range errs {
+			fmt.Printf("  %v\n", err)
+		}
+	}
+	if failed {
+		level.Error(logger).Log("msg", "unit tests failed")
+		return 1
+	}
+	return 0
+}
+
+func runUnitTestFile(file string) []error {
+	bytes, err := os.ReadFile(file)
+	if err != nil {
+		return []error{fmt.Errorf("failed to read file %q: %w", file, err)}
+	}
+
+	var utf unitTestFile
+	if err := yaml.UnmarshalStrict(bytes, &utf); err != nil {
+		return []error{fmt.Errorf("failed to unmarshal unit test file %q: %w", file, err)}
+	}
+	if utf.Interval == 0 {
+		utf.Interval = model.Duration(time.Minute)
+	}
+
+	objectives := make([]slo.Objective, 0, len(utf.Objectives))
+	for _, f := range utf.Objectives {
+		if !filepath.IsAbs(f) {
+			f = filepath.Join(filepath.Dir(file), f)
+		}
+		fileObjectives, err := objectivesFromFile(f)
+		if err != nil {
+			return []error{err}
+		}
+		for _, o := range fileObjectives {
+			objectives = append(objectives, o.objective)
+		}
+	}
+	if len(objectives) == 0 {
+		return []error{fmt.Errorf("unit test file %q has no objectives to test", file)}
+	}
+
+	var errs []error
+	for i, test := range utf.Tests {
+		name := test.Name
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+		for _, err := range test.run(objectives, time.Duration(utf.Interval)) {
+			errs = append(errs, fmt.Errorf("test %s: %w", name, err))
+		}
+	}
+	return errs
+}
+
+// run loads the input series and evaluates the objectives' rules at every interval,
+// checking the expectations at their evaluation time.
+func (t unitTest) run(objectives []slo.Objective, interval time.Duration) []error {
+	start := time.Unix(0, 0).UTC()
+
+	e, err := newUnitTestEvaluator(t.InputSeries, start, interval)
+	if err != nil {
+		return []error{err}
+	}
+
+	byName := make(map[string]slo.Objective, len(objectives))
+	for _, objective := range objectives {
+		objective, err := objective.ResolveComposite(objectives)
+		if err != nil {
+			return []error{err}
+		}
+		byName[objective.Name()] = objective
+
+		groups, err := unitTestRuleGroups(objective)
+		if err != nil {
+			return []error{fmt.Errorf("objective %s: %w", objective.Name(), err)}
+		}
+		if err := e.add(groups); err != nil {
+			return []error{fmt.Errorf("objective %s: %w", objective.Name(), err)}
+		}
+	}
+
+	expectations := make([]unitTestExpectation, len(t.Expectations))
+	copy(expectations, t.Expectations)
+	sort.SliceStable(expectations, func(i, j int) bool {
+		return expectations[i].EvalTime < expectations[j].EvalTime
+	})
+	if len(expectations) == 0 {
+		return nil
+	}
+
+	var (
+		errs []error
+		end  = start.Add(time.Duration(expectations[len(expectations)-1].EvalTime))
+		next = 0
+	)
+	for ts := start; !ts.After(end); ts = ts.Add(interval) {
+		if err := e.eval(ts); err != nil {
+			return append(errs, fmt.Errorf("at %s: %w", model.Duration(ts.Sub(start)), err))
+		}
+
+		// Expectations are checked at the last evaluation before or at their time.
+		for next < len(expectations) && start.Add(time.Duration(expectations[next].EvalTime)).Before(ts.Add(interval)) {
+			exp := expectations[next]
+			next++
+
+			objective, err := exp.objective(byName)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			for _, err := range exp.check(e, objective, ts) {
+				errs = append(errs, fmt.Errorf("%s at %s: %w", objective.Name(), exp.EvalTime, err))
+			}
+		}
+	}
+	return errs
+}
+
+// unitTestRuleGroups returns the rules Pyrra generates for an objective, excluding the generic rules.
+func unitTestRuleGroups(objective slo.Objective) ([]monitoringv1.RuleGroup, error) {
+	opts := slo.GenerationOptions{}
+
+	increases, err := objective.IncreaseRules(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get increase rules: %w", err)
+	}
+	burnrates, err := objective.Burnrates(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get burn rate rules: %w", err)
+	}
+	groups := []monitoringv1.RuleGroup{increases, burnrates}
+
+	if len(objective.BudgetPolicy) > 0 {
+		policy, err := objective.BudgetPolicyRules(opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get error budget policy rules: %w", err)
+		}
+		groups = append(groups, policy)
+	}
+	return groups, nil
+}
+
+// newUnitTestEvaluator returns an evaluator with the input series loaded, their first sample at start.
+func newUnitTestEvaluator(series []unitTestSeries, start time.Time, interval time.Duration) (*unitTestEvaluator, error) {
+	storage := &unitTestStorage{series: map[uint64]*unitTestStorageSeries{}}
+	for _, s := range series {
+		lset, values, err := parser.ParseSeriesDesc(s.Series + " " + s.Values)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse input series %s: %w", s.Series, err)
+		}
+		for i, v := range values {
+			if v.Omitted {
+				continue
+			}
+			storage.append(lset, unitTestSample{
+				t:  start.Add(time.Duration(i) * interval).UnixMilli(),
+				f:  v.Value,
+				fh: v.Histogram,
+			})
+		}
+	}
+
+	engine := promql.NewEngine(promql.EngineOpts{
+		MaxSamples:           50_000_000,
+		Timeout:              time.Minute,
+		EnableAtModifier:     true,
+		EnableNegativeOffset: true,
+		NoStepSubqueryIntervalFn: func(int64) int64 {
+			return interval.Milliseconds()
+		},
+	})
+	return &unitTestEvaluator{engine: engine, storage: storage}, nil
+}
+
+// unitTestEvaluator evaluates rules like Prometheus does, but all at the same interval.
+// Recorded samples are appended to the storage, so that later rules can query them.
+type unitTestEvaluator struct {
+	engine  *promql.Engine
+	storage *unitTestStorage
+	rules   []*unitTestRule
+}
+
+type unitTestRule struct {
+	record string
+	alert  string
+	expr   string
+	hold   time.Duration
+	labels labels.Labels
+
+	// active alerts by the hash of their labels.
+	active map[uint64]*unitTestActiveAlert
+}
+
+type unitTestActiveAlert struct {
+	labels   labels.Labels
+	activeAt time.Time
+}
+
+func (e *unitTestEvaluator) add(groups []monitoringv1.RuleGroup) error {
+	for _, g := range groups {
+		for _, r := range g.Rules {
+			rule := &unitTestRule{
+				record: r.Record,
+				alert:  r.Alert,
+				expr:   r.Expr.String(),
+				labels: labels.FromMap(r.Labels),
+				active: map[uint64]*unitTestActiveAlert{},
+			}
+			if r.For != nil {
+				hold, err := model.ParseDuration(string(*r.For))
+				if err != nil {
+					return fmt.Errorf("failed to parse for duration of %s: %w", r.Alert, err)
+				}
+				rule.hold = time.Duration(hold)
+			}
+			e.rules = append(e.rules, rule)
+		}
+	}
+	return nil
+}
+
+func (e *unitTestEvaluator) query(query string, ts time.Time) (promql.Vector, error) {
+	ctx := context.Background()
+	q, err := e.engine.NewInstantQuery(ctx, e.storage, nil, query, ts)
+	if err != nil {
+		return nil, err
+	}
+	defer q.Close()
+
+	res := q.Exec(ctx)
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	return res.Vector()
+}
+
+func (e *unitTestEvaluator) eval(ts time.Time) error {
+	for _, r := range e.rules {
+		vector, err := e.query(r.expr, ts)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate %s%s: %w", r.record, r.alert, err)
+		}
+
+		if r.record != "" {
+			for _, sample := range vector {
+				b := labels.NewBuilder(sample.Metric)
+				r.labels.Range(func(l labels.Label) { b.Set(l.Name, l.Value) })
+				b.Set(labels.MetricName, r.record)
+				e.storage.append(b.Labels(), unitTestSample{t: ts.UnixMilli(), f: sample.F, fh: sample.H})
+			}
+			continue
+		}
+
+		active := make(map[uint64]*unitTestActiveAlert, len(vector))
+		for _, sample := range vector {
+			b := labels.NewBuilder(sample.Metric)
+			b.Del(labels.MetricName)
+			r.labels.Range(func(l labels.Label) { b.Set(l.Name, l.Value) })
+			b.Set(labels.AlertName, r.alert)
+			lset := b.Labels()
+
+			alert, ok := r.active[lset.Hash()]
+			if !ok {
+				alert = &unitTestActiveAlert{labels: lset, activeAt: ts}
+			}
+			active[lset.Hash()] = alert
+		}
+		r.active = active
+	}
+	return nil
+}
+
+// firing returns the labels of all alerts that have been active for at least their rule's for duration.
+func (e *unitTestEvaluator) firing(ts time.Time) []labels.Labels {
+	var firing []labels.Labels
+	for _, r := range e.rules {
+		for _, alert := range r.active {
+			if ts.Sub(alert.activeAt) >= r.hold {
+				firing = append(firing, alert.labels)
+			}
+		}
+	}
+	sort.Slice(firing, func(i, j int) bool {
+		return labels.Compare(firing[i], firing[j]) < 0
+	})
+	return firing
+}
+
+func (exp unitTestExpectation) objective(objectives map[string]slo.Objective) (slo.Objective, error) {
+	if exp.Objective != "" {
+		objective, ok := objectives[exp.Objective]
+		if !ok {
+			return slo.Objective{}, fmt.Errorf("objective %q at %s not found", exp.Objective, exp.EvalTime)
+		}
+		return objective, nil
+	}
+	if len(objectives) > 1 {
+		return slo.Objective{}, fmt.Errorf("expectation at %s needs an objective as there's more than one", exp.EvalTime)
+	}
+	for _, objective := range objectives {
+		return objective, nil
+	}
+	return slo.Objective{}, fmt.Errorf("no objectives")
+}
+
+func (exp unitTestExpectation) check(e *unitTestEvaluator, objective slo.Objective, ts time.Time) []error {
+	var errs []error
+
+	if exp.Availability != "" || exp.ErrorBudget != "" {
+		vector, err := e.query(objective.QueryErrorBudget(slo.GenerationOptions{}), ts)
+		if err != nil {
+			return []error{err}
+		}
+		if len(vector) == 0 {
+			return []error{fmt.Errorf("error budget query returned no result")}
+		}
+		budget := vector[0].F
+		availability := 1 - (1-objective.Target)*(1-budget)
+
+		if err := comparePercent("availability", exp.Availability, availability); err != nil {
+			errs = append(errs, err)
+		}
+		if err := comparePercent("error budget", exp.ErrorBudget, budget); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if exp.Alerts != nil {
+		var firing []labels.Labels
+		for _, lset := range e.firing(ts) {
+			if lset.Get("slo") == objective.Name() {
+				firing = append(firing, lset)
+			}
+		}
+		if err := compareAlerts(exp.Alerts, firing); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// comparePercent compares the value with the expected percentage, rounded to as many decimals as the expectation has.
+func comparePercent(name, expected string, value float64) error {
+	if expected == "" {
+		return nil
+	}
+	want, err := strconv.ParseFloat(expected, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse expected %s %q: %w", name, expected, err)
+	}
+
+	decimals := 0
+	if i := strings.IndexByte(expected, '.'); i >= 0 {
+		decimals = len(expected) - i - 1
+	}
+	got := strconv.FormatFloat(100*value, 'f', decimals, 64)
+	if math.IsNaN(value) || got != strconv.FormatFloat(want, 'f', decimals, 64) {
+		return fmt.Errorf("expected %s %s%%, got %s%%", name, expected, got)
+	}
+	return nil
+}
+
+// compareAlerts checks that every expected alert matches exactly one of the firing alerts and that no other alerts are firing.
+func compareAlerts(expected []unitTestAlert, firing []labels.Labels) error {
+	unmatched := make([]labels.Labels, len(firing))
+	copy(unmatched, firing)
+
+	for _, e := range expected {
+		found := -1
+		for i, lset := range unmatched {
+			if lset.Get(labels.AlertName) != e.Alertname {
+				continue
+			}
+			match := true
+			for k, v := range e.Labels {
+				if lset.Get(k) != v {
+					match = false
+					break
+				}
+			}
+			if match {
+				found = i
+				break
+			}
+		}
+		if found < 0 {
+			return alertsMismatch(expected, firing)
+		}
+		unmatched = append(unmatched[:found], unmatched[found+1:]...)
+	}
+	if len(unmatched) > 0 {
+		return alertsMismatch(expected, firing)
+	}
+	return nil
+}
+
+func alertsMismatch(expected []unitTestAlert, firing []labels.Labels) error {
+	want := make([]string, 0, len(expected))
+	for _, a := range expected {
+		lset := labels.FromMap(a.Labels)
+		want = append(want, a.Alertname+lset.String())
+	}
+	got := make([]string, 0, len(firing))
+	for _, lset := range firing {
+		got = append(got, lset.String())
+	}
+	return fmt.Errorf("expected alerts [%s], got [%s]", strings.Join(want, ", "), strings.Join(got, ", "))
+}
+
+// unitTestStorage is a minimal in-memory storage for the input series and the samples recorded by rules.
+type unitTestStorage struct {
+	series map[uint64]*unitTestStorageSeries
+}
+
+type unitTestStorageSeries struct {
+	labels  labels.Labels
+	samples []chunks.Sample
+}
+
+func (s *unitTestStorage) append(lset labels.Labels, sample unitTestSample) {
+	series, ok := s.series[lset.Hash()]
+	if !ok {
+		series = &unitTestStorageSeries{labels: lset}
+		s.series[lset.Hash()] = series
+	}
+	series.samples = append(series.samples, sample)
+}
+
+func (s *unitTestStorage) Querier(_, _ int64) (storage.Querier, error) {
+	return s, nil
+}
+
+func (s *unitTestStorage) Select(_ context.Context, _ bool, _ *storage.SelectHints, matchers ...*labels.Matcher) storage.SeriesSet {
+	var selected []storage.Series
+	for _, series := range s.series {
+		if matchLabels(series.labels, matchers) {
+			selected = append(selected, storage.NewListSeries(series.labels, series.samples))
+		}
+	}
+	// Series are always sorted, as the engine needs sorted series for some operations.
+	sort.Slice(selected, func(i, j int) bool {
+		return labels.Compare(selected[i].Labels(), selected[j].Labels()) < 0
+	})
+	return &unitTestSeriesSet{series: selected, i: -1}
+}
+
+func (s *unitTestStorage) LabelValues(_ context.Context, name string, _ *storage.LabelHints, matchers ...*labels.Matcher) ([]string, annotations.Annotations, error) {
+	values := map[string]struct{}{}
+	for _, series := range s.series {
+		if v := series.labels.Get(name); v != "" && matchLabels(series.labels, matchers) {
+			values[v] = struct{}{}
+		}
+	}
+	result := make([]string, 0, len(values))
+	for v := range values {
+		result = append(result, v)
+	}
+	sort.Strings(result)
+	return result, nil, nil
+}
+
+func (s *unitTestStorage) LabelNames(_ context.Context, _ *storage.LabelHints, matchers ...*labels.Matcher) ([]string, annotations.Annotations, error) {
+	names := map[string]struct{}{}
+	for _, series := range s.series {
+		if matchLabels(series.labels, matchers) {
+			series.labels.Range(func(l labels.Label) { names[l.Name] = struct{}{} })
+		}
+	}
+	result := make([]string, 0, len(names))
+	for n := range names {
+		result = append(result, n)
+	}
+	sort.Strings(result)
+	return result, nil, nil
+}
+
+func (s *unitTestStorage) Close() error { return nil }
+
+func matchLabels(lset labels.Labels, matchers []*labels.Matcher) bool {
+	for _, m := range matchers {
+		if !m.Matches(lset.Get(m.Name)) {
+			return false
+		}
+	}
+	return true
+}
+
+type unitTestSeriesSet struct {
+	series []storage.Series
+	i      int
+}
+
+func (s *unitTestSeriesSet) Next() bool {
+	s.i++
+	return s.i < len(s.series)
+}
+
+func (s *unitTestSeriesSet) At() storage.Series                { return s.series[s.i] }
+func (s *unitTestSeriesSet) Err() error                        { return nil }
+func (s *unitTestSeriesSet) Warnings() annotations.Annotations { return nil }
+
+// unitTestSample is a float or native histogram sample.
+type unitTestSample struct {
+	t  int64
+	f  float64
+	fh *histogram.FloatHistogram
+}
+
+func (s unitTestSample) T() int64                      { return s.t }
+func (s unitTestSample) ST() int64                     { return 0 }
+func (s unitTestSample) F() float64                    { return s.f }
+func (s unitTestSample) H() *histogram.Histogram       { return nil }
+func (s unitTestSample) FH() *histogram.FloatHistogram { return s.fh }
+
+func (s unitTestSample) Type() chunkenc.ValueType {
+	if s.fh != nil {
+		return chunkenc.ValFloatHistogram
+	}
+	return chunkenc.ValFloat
+}
+
+func (s unitTestSample) Copy() chunks.Sample {
+	if s.fh != nil {
+		s.fh = s.fh.Copy()
+	}
+	return s
+}
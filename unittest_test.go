@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb/chunkenc"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestIsTestFile(t *testing.T) {
+	require.True(t, isTestFile("examples/pyrra-filesystem-errors_test.yaml"))
+	require.True(t, isTestFile("api_test.yml"))
+	require.False(t, isTestFile("examples/pyrra-filesystem-errors.yaml"))
+	require.False(t, isTestFile("api_test.json"))
+}
+
+func TestRunUnitTestFile(t *testing.T) {
+	require.Empty(t, runUnitTestFile("examples/pyrra-filesystem-errors_test.yaml"))
+
+	objective, err := filepath.Abs("examples/pyrra-filesystem-errors.yaml")
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "failing_test.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+objectives:
+  - `+objective+`
+tests:
+  - name: errors
+    inputSeries:
+      - series: pyrra_filesystem_reconciles_total
+        values: 0+60x60
+      - series: pyrra_filesystem_reconciles_errors_total
+        values: 0+6x60
+    expectations:
+      - evalTime: 1h
+        availability: "99.5"
+        errorBudget: "-900"
+        alerts: []
+`), 0o644))
+
+	errs := runUnitTestFile(file)
+	require.Len(t, errs, 2)
+	require.EqualError(t, errs[0], `test errors: pyrra-filesystem-errors at 1h: expected availability 99.5%, got 90.0%`)
+	require.ErrorContains(t, errs[1], `test errors: pyrra-filesystem-errors at 1h: expected alerts [], got [{alertname="ErrorBudgetBurn", exhaustion="1d"`)
+}
+
+func TestComparePercent(t *testing.T) {
+	require.NoError(t, comparePercent("availability", "", 0.5))
+	require.NoError(t, comparePercent("availability", "99", 0.9904))
+	require.NoError(t, comparePercent("availability", "99.04", 0.99041))
+	require.EqualError(t, comparePercent("availability", "99.1", 0.9904), "expected availability 99.1%, got 99.0%")
+	require.EqualError(t, comparePercent("availability", "foo", 0.9904), `failed to parse expected availability "foo": strconv.ParseFloat: parsing "foo": invalid syntax`)
+}
+
+func TestUnitTestEvaluator(t *testing.T) {
+	hold := monitoringv1.Duration("2m")
+
+	for _, tc := range []struct {
+		name   string
+		series []unitTestSeries
+		rules  []monitoringv1.Rule
+		// firing are the alerts firing after each evaluation.
+		firing [][]string
+		// query is run after each evaluation, values are its results.
+		query  string
+		values [][]string
+	}{{
+		name:   "for holds alerts pending",
+		series: []unitTestSeries{{Series: `up{job="api"}`, Values: "1 0 0 0 0"}},
+		rules:  []monitoringv1.Rule{{Alert: "Down", Expr: intstr.FromString("up == 0"), For: &hold}},
+		firing: [][]string{nil, nil, nil, {`{alertname="Down", job="api"}`}, {`{alertname="Down", job="api"}`}},
+	}, {
+		name:   "alerts resolve and are pending again",
+		series: []unitTestSeries{{Series: `up{job="api"}`, Values: "0 0 0 1 0 0 0"}},
+		rules:  []monitoringv1.Rule{{Alert: "Down", Expr: intstr.FromString("up == 0"), For: &hold, Labels: map[string]string{"severity": "critical"}}},
+		firing: [][]string{nil, nil, {`{alertname="Down", job="api", severity="critical"}`}, nil, nil, nil, {`{alertname="Down", job="api", severity="critical"}`}},
+	}, {
+		name:   "alerts without for fire right away",
+		series: []unitTestSeries{{Series: `up{job="api"}`, Values: "0 1"}},
+		rules:  []monitoringv1.Rule{{Alert: "Down", Expr: intstr.FromString("up == 0")}},
+		firing: [][]string{{`{alertname="Down", job="api"}`}, nil},
+	}, {
+		name:   "recorded series are chained",
+		series: []unitTestSeries{{Series: `requests_total{job="api"}`, Values: "0+60x4"}},
+		rules: []monitoringv1.Rule{
+			{Record: "job:requests:irate2m", Expr: intstr.FromString("irate(requests_total[2m])"), Labels: map[string]string{"env": "prod"}},
+			{Record: "env:requests:irate2m", Expr: intstr.FromString("sum by (env) (job:requests:irate2m)")},
+			{Alert: "Traffic", Expr: intstr.FromString("env:requests:irate2m > 0")},
+		},
+		firing: [][]string{nil, {`{alertname="Traffic", env="prod"}`}, {`{alertname="Traffic", env="prod"}`}},
+		query:  "env:requests:irate2m",
+		values: [][]string{nil, {`{__name__="env:requests:irate2m", env="prod"} => 1`}, {`{__name__="env:requests:irate2m", env="prod"} => 1`}},
+	}, {
+		name:   "native histograms are recorded",
+		series: []unitTestSeries{{Series: `latency_seconds{job="api"}`, Values: "{{schema:0 sum:5 count:4 buckets:[1 2 1]}}x2"}},
+		rules: []monitoringv1.Rule{
+			{Record: "job:latency_seconds", Expr: intstr.FromString("latency_seconds")},
+		},
+		query:  "histogram_count(job:latency_seconds)",
+		values: [][]string{{`{job="api"} => 4`}, {`{job="api"} => 4`}, {`{job="api"} => 4`}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Unix(0, 0).UTC()
+			e, err := newUnitTestEvaluator(tc.series, start, time.Minute)
+			require.NoError(t, err)
+			require.NoError(t, e.add([]monitoringv1.RuleGroup{{Name: "test", Rules: tc.rules}}))
+
+			steps := max(len(tc.firing), len(tc.values))
+			for i := range steps {
+				ts := start.Add(time.Duration(i) * time.Minute)
+				require.NoError(t, e.eval(ts))
+
+				if tc.firing != nil {
+					var firing []string
+					for _, lset := range e.firing(ts) {
+						firing = append(firing, lset.String())
+					}
+					require.Equal(t, tc.firing[i], firing, "firing at %dm", i)
+				}
+				if tc.query != "" {
+					vector, err := e.query(tc.query, ts)
+					require.NoError(t, err)
+					var values []string
+					for _, sample := range vector {
+						values = append(values, sample.Metric.String()+" => "+strconv.FormatFloat(sample.F, 'f', -1, 64))
+					}
+					require.Equal(t, tc.values[i], values, "%s at %dm", tc.query, i)
+				}
+			}
+		})
+	}
+}
+
+func TestUnitTestStorageIgnoresHints(t *testing.T) {
+	s := &unitTestStorage{series: map[uint64]*unitTestStorageSeries{}}
+	lset := labels.FromStrings(labels.MetricName, "up", "job", "api")
+	for i := range int64(3) {
+		s.append(lset, unitTestSample{t: i * 60_000, f: 1})
+	}
+	s.append(labels.FromStrings(labels.MetricName, "up", "job", "web"), unitTestSample{t: 0, f: 0})
+
+	// The engine only asks for the samples it needs, but it filters them itself, so all samples are returned.
+	q, err := s.Querier(60_000, 60_000)
+	require.NoError(t, err)
+	set := q.Select(context.Background(), false, &storage.SelectHints{Start: 60_000, End: 60_000}, labels.MustNewMatcher(labels.MatchEqual, "job", "api"))
+	require.True(t, set.Next())
+	require.Equal(t, lset, set.At().Labels())
+
+	var timestamps []int64
+	it := set.At().Iterator(nil)
+	for it.Next() != chunkenc.ValNone {
+		timestamps = append(timestamps, it.AtT())
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []int64{0, 60_000, 120_000}, timestamps)
+	require.False(t, set.Next())
+}
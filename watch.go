@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/prometheus/promql/parser"
+	"google.golang.org/protobuf/proto"
+
+	objectivesv1alpha1 "github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1"
+)
+
+// watchUpdate is the latest result of a watched evaluation.
+type watchUpdate struct {
+	msg proto.Message
+	err error
+}
+
+// watcher evaluates every distinct watched request in a single loop and pushes changed results to all of its subscribers.
+// Subscribers of the same request share the loop and therefore the queries against Prometheus.
+type watcher struct {
+	logger   log.Logger
+	interval time.Duration
+
+	mu     sync.Mutex
+	topics map[string]*watchTopic
+}
+
+type watchTopic struct {
+	subscribers map[chan watchUpdate]struct{}
+	latest      proto.Message
+	cancel      context.CancelFunc
+}
+
+func newWatcher(logger log.Logger, interval time.Duration) *watcher {
+	return &watcher{
+		logger:   logger,
+		interval: interval,
+		topics:   map[string]*watchTopic{},
+	}
+}
+
+// subscribe returns the updates of the evaluation identified by key and a function to unsubscribe.
+// The evaluation loop starts with the first subscriber and stops once the last one unsubscribed.
+// New subscribers of a running loop get its latest result right away.
+func (w *watcher) subscribe(key string, evaluate func(context.Context) (proto.Message, error)) (<-chan watchUpdate, func()) {
+	updates := make(chan watchUpdate, 1)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	topic, ok := w.topics[key]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		topic = &watchTopic{
+			subscribers: map[chan watchUpdate]struct{}{},
+			cancel:      cancel,
+		}
+		w.topics[key] = topic
+		go w.run(ctx, key, topic, evaluate)
+	}
+	topic.subscribers[updates] = struct{}{}
+	if topic.latest != nil {
+		updates <- watchUpdate{msg: topic.latest}
+	}
+
+	return updates, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		delete(topic.subscribers, updates)
+		if len(topic.subscribers) == 0 {
+			topic.cancel()
+			delete(w.topics, key)
+		}
+	}
+}
+
+func (w *watcher) run(ctx context.Context, key string, topic *watchTopic, evaluate func(context.Context) (proto.Message, error)) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		msg, err := evaluate(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		w.mu.Lock()
+		switch {
+		case err != nil && topic.latest == nil:
+			// Nothing was ever evaluated, so the request itself is probably invalid.
+			w.publish(topic, watchUpdate{err: err})
+		case err != nil:
+			level.Warn(w.logger).Log("msg", "failed to evaluate watched request, keeping the latest result", "key", key, "err", err)
+		case topic.latest == nil || !proto.Equal(topic.latest, msg):
+			topic.latest = msg
+			w.publish(topic, watchUpdate{msg: msg})
+		}
+		w.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publish replaces any update a subscriber hasn't received yet, so slow subscribers only skip intermediate results.
+// It needs to be called with the watcher's lock held.
+func (w *watcher) publish(topic *watchTopic, update watchUpdate) {
+	for updates := range topic.subscribers {
+		select {
+		case <-updates:
+		default:
+		}
+		updates <- update
+	}
+}
+
+// watch sends every update to the stream until the client disconnects.
+func watch[T any](ctx context.Context, w *watcher, key string, evaluate func(context.Context) (*T, error), stream *connect.ServerStream[T]) error {
+	updates, unsubscribe := w.subscribe(key, func(ctx context.Context) (proto.Message, error) {
+		msg, err := evaluate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return any(msg).(proto.Message), nil
+	})
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case update := <-updates:
+			if update.err != nil {
+				return update.err
+			}
+			if err := stream.Send(any(update.msg).(*T)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *objectiveServer) WatchStatus(ctx context.Context, req *connect.Request[objectivesv1alpha1.WatchStatusRequest], stream *connect.ServerStream[objectivesv1alpha1.GetStatusResponse]) error {
+	if err := validateWatchRequest(req.Msg.Expr, req.Msg.Grouping); err != nil {
+		return err
+	}
+
+	// Callers share loops with the callers that may see the same objectives.
+	id := contextGetIdentity(ctx)
+	key := fmt.Sprintf("status;%s;%s;%s", req.Msg.Expr, req.Msg.Grouping, id.key())
+	return watch(ctx, s.watcher, key, func(ctx context.Context) (*objectivesv1alpha1.GetStatusResponse, error) {
+		resp, err := s.GetStatus(contextSetIdentity(ctx, id), connect.NewRequest(&objectivesv1alpha1.GetStatusRequest{
+			Expr:     req.Msg.Expr,
+			Grouping: req.Msg.Grouping,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}, stream)
+}
+
+func (s *objectiveServer) WatchAlerts(ctx context.Context, req *connect.Request[objectivesv1alpha1.WatchAlertsRequest], stream *connect.ServerStream[objectivesv1alpha1.GetAlertsResponse]) error {
+	if err := validateWatchRequest(req.Msg.Expr, req.Msg.Grouping); err != nil {
+		return err
+	}
+
+	id := contextGetIdentity(ctx)
+	key := fmt.Sprintf("alerts;%s;%s;%t;%t;%s", req.Msg.Expr, req.Msg.Grouping, req.Msg.Inactive, req.Msg.Current, id.key())
+	return watch(ctx, s.watcher, key, func(ctx context.Context) (*objectivesv1alpha1.GetAlertsResponse, error) {
+		resp, err := s.GetAlerts(contextSetIdentity(ctx, id), connect.NewRequest(&objectivesv1alpha1.GetAlertsRequest{
+			Expr:     req.Msg.Expr,
+			Grouping: req.Msg.Grouping,
+			Inactive: req.Msg.Inactive,
+			Current:  req.Msg.Current,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}, stream)
+}
+
+// validateWatchRequest rejects selectors that can't be parsed before a loop is started for them.
+func validateWatchRequest(expr, grouping string) error {
+	for name, selector := range map[string]string{"expr": expr, "grouping": grouping} {
+		if selector == "" || selector == "{}" {
+			continue
+		}
+		if _, err := parser.ParseMetricSelector(selector); err != nil {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("failed to parse %s: %w", name, err))
+		}
+	}
+	return nil
+}
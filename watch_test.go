@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/go-kit/log"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	objectivesv1alpha1 "github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1"
+	"github.com/pyrra-dev/pyrra/proto/objectives/v1alpha1/objectivesv1alpha1connect"
+	"github.com/pyrra-dev/pyrra/slo"
+)
+
+func receive(t *testing.T, updates <-chan watchUpdate) watchUpdate {
+	t.Helper()
+	select {
+	case update := <-updates:
+		return update
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for update")
+		return watchUpdate{}
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	w := newWatcher(log.NewNopLogger(), 10*time.Millisecond)
+
+	var evaluations atomic.Int64
+	evaluate := func(context.Context) (proto.Message, error) {
+		// The value changes every third evaluation only.
+		return wrapperspb.Int64(evaluations.Add(1) / 3), nil
+	}
+
+	first, unsubscribeFirst := w.subscribe("key", evaluate)
+	require.Equal(t, int64(0), receive(t, first).msg.(*wrapperspb.Int64Value).Value)
+
+	second, unsubscribeSecond := w.subscribe("key", evaluate)
+	// The latest result is sent to new subscribers right away.
+	require.Equal(t, int64(0), receive(t, second).msg.(*wrapperspb.Int64Value).Value)
+
+	// Unchanged results aren't pushed again.
+	require.Equal(t, int64(1), receive(t, first).msg.(*wrapperspb.Int64Value).Value)
+	require.Equal(t, int64(1), receive(t, second).msg.(*wrapperspb.Int64Value).Value)
+	require.GreaterOrEqual(t, evaluations.Load(), int64(3))
+
+	unsubscribeFirst()
+	unsubscribeSecond()
+
+	w.mu.Lock()
+	require.Empty(t, w.topics)
+	w.mu.Unlock()
+
+	// The loop stops with the last subscriber.
+	stopped := evaluations.Load()
+	time.Sleep(50 * time.Millisecond)
+	require.LessOrEqual(t, evaluations.Load(), stopped+1)
+}
+
+func TestWatcherError(t *testing.T) {
+	w := newWatcher(log.NewNopLogger(), 10*time.Millisecond)
+
+	var evaluations atomic.Int64
+	updates, unsubscribe := w.subscribe("key", func(context.Context) (proto.Message, error) {
+		if evaluations.Add(1) > 1 {
+			return nil, errors.New("prometheus down")
+		}
+		return wrapperspb.Int64(1), nil
+	})
+	defer unsubscribe()
+
+	require.NoError(t, receive(t, updates).err)
+	for evaluations.Load() < 3 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	// Failing evaluations keep the latest result.
+	select {
+	case update := <-updates:
+		t.Fatalf("unexpected update: %v", update)
+	default:
+	}
+
+	failing, unsubscribeFailing := w.subscribe("failing", func(context.Context) (proto.Message, error) {
+		return nil, errors.New("invalid")
+	})
+	defer unsubscribeFailing()
+	require.EqualError(t, receive(t, failing).err, "invalid")
+}
+
+func TestWatchStatus(t *testing.T) {
+	objective := slo.Objective{
+		Labels: labels.FromStrings(labels.MetricName, "api"),
+		Target: 0.99,
+		Window: model.Duration(28 * 24 * time.Hour),
+		Indicator: slo.Indicator{
+			Ratio: &slo.RatioIndicator{
+				Errors: slo.Metric{
+					Name: "http_requests_total",
+					LabelMatchers: []*labels.Matcher{
+						labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "http_requests_total"),
+						labels.MustNewMatcher(labels.MatchRegexp, "code", "5.."),
+					},
+				},
+				Total: slo.Metric{
+					Name: "http_requests_total",
+					LabelMatchers: []*labels.Matcher{
+						labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "http_requests_total"),
+					},
+				},
+			},
+		},
+	}
+
+	s := &objectiveServer{
+		logger: log.NewNopLogger(),
+		promAPI: &promCache{api: reportPrometheus{
+			objective.QueryTotal(objective.Window, slo.GenerationOptions{}):  {{Metric: model.Metric{}, Value: 1000}},
+			objective.QueryErrors(objective.Window, slo.GenerationOptions{}): {{Metric: model.Metric{}, Value: 1}},
+		}},
+		client:  staticObjectives{objective},
+		watcher: newWatcher(log.NewNopLogger(), time.Minute),
+	}
+	// GetStatus caches its queries.
+	cache, err := ristretto.NewCache(&ristretto.Config[string, any]{NumCounters: 1e3, MaxCost: 1 << 20, BufferItems: 64})
+	require.NoError(t, err)
+	t.Cleanup(cache.Close)
+	s.promAPI.cache = cache
+
+	_, handler := objectivesv1alpha1connect.NewObjectiveServiceHandler(s)
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	client := objectivesv1alpha1connect.NewObjectiveServiceClient(srv.Client(), srv.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.WatchStatus(ctx, connect.NewRequest(&objectivesv1alpha1.WatchStatusRequest{Expr: `{__name__="api"}`}))
+	require.NoError(t, err)
+	require.True(t, stream.Receive(), stream.Err())
+	require.Len(t, stream.Msg().Status, 1)
+	require.InDelta(t, 0.999, stream.Msg().Status[0].Availability.Percentage, 1e-9)
+	cancel()
+	require.NoError(t, stream.Close())
+
+	stream, err = client.WatchStatus(context.Background(), connect.NewRequest(&objectivesv1alpha1.WatchStatusRequest{Grouping: `{handler=`}))
+	require.NoError(t, err)
+	require.False(t, stream.Receive())
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(stream.Err()))
+}